@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/notifications": {
+        "/api/assets": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all unread notifications for the authenticated manager",
+                "description": "List the assets by name, optionally only those installed on a site",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,21 +39,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "assets"
+                ],
+                "summary": "List assets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only the assets of this site",
+                        "name": "site_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get unread notifications",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/sword-challenge_internal_models.Notification"
+                                "$ref": "#/definitions/sword-challenge_internal_models.Asset"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -61,8 +69,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -89,16 +97,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/notifications/{id}/read": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark a specific notification as read",
+                "description": "Add an asset to a site (managers only). The serial number is unique among the assets of the model",
                 "consumes": [
                     "application/json"
                 ],
@@ -106,21 +112,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "assets"
                 ],
-                "summary": "Mark notification as read",
+                "summary": "Create an asset",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Asset",
+                        "name": "asset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AssetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Asset"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -149,8 +160,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -170,31 +190,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tasks": {
+        "/api/assets/labels": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all tasks for the authenticated user (if technician) or all tasks (if manager)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "A PDF sheet of labels (A4, 3 x 7 labels of 63.5 x 38.1 mm) for the assets of a site, or every asset (managers only). Each label has the QR code, name, serial number, model and site of the asset",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "tasks"
+                    "assets"
+                ],
+                "summary": "Print asset labels",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only the assets of this site",
+                        "name": "site_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all tasks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -207,6 +238,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -216,6 +256,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -226,14 +275,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/assets/resolve": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new task for the authenticated technician",
+                "description": "The asset of a scanned label, with its site and open tasks, to pick a task or start a new one on the asset. Technicians only get their own tasks",
                 "consumes": [
                     "application/json"
                 ],
@@ -241,25 +292,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "assets"
                 ],
-                "summary": "Create a new task",
+                "summary": "Resolve a scanned label",
                 "parameters": [
                     {
-                        "description": "Task Information",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.CreateTaskRequest"
-                        }
+                        "type": "string",
+                        "description": "Token of the label's deep link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            "$ref": "#/definitions/sword-challenge_internal_models.AssetScan"
                         }
                     },
                     "400": {
@@ -280,8 +329,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -301,14 +350,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tasks/{id}": {
+        "/api/assets/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a task by its ID",
+                "description": "Get an asset by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -316,13 +365,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "assets"
                 ],
-                "summary": "Get a specific task",
+                "summary": "Get an asset",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Asset ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -332,7 +381,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            "$ref": "#/definitions/sword-challenge_internal_models.Asset"
                         }
                     },
                     "400": {
@@ -353,15 +402,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -388,7 +428,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing task",
+                "description": "Change an asset or move it to another site (managers only). The tasks performed on it keep their site",
                 "consumes": [
                     "application/json"
                 ],
@@ -396,24 +436,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "assets"
                 ],
-                "summary": "Update a task",
+                "summary": "Update an asset",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Asset ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Task Information",
-                        "name": "task",
+                        "description": "Asset",
+                        "name": "asset",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.UpdateTaskRequest"
+                            "$ref": "#/definitions/internal_controllers.AssetRequest"
                         }
                     }
                 ],
@@ -421,7 +461,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            "$ref": "#/definitions/sword-challenge_internal_models.Asset"
                         }
                     },
                     "400": {
@@ -460,6 +500,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -477,7 +535,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a task by its ID",
+                "description": "Remove an asset (managers only). Its maintenance history stays readable",
                 "consumes": [
                     "application/json"
                 ],
@@ -485,13 +543,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "assets"
                 ],
-                "summary": "Delete a task",
+                "summary": "Delete an asset",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Asset ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -548,122 +606,9846 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_controllers.CreateTaskRequest": {
-            "type": "object",
-            "required": [
-                "performed_at",
-                "summary",
-                "title"
-            ],
-            "properties": {
-                "performed_at": {
-                    "type": "string",
-                    "example": "2024-03-20T14:30:00Z"
-                },
-                "summary": {
-                    "type": "string",
-                    "example": "Replaced filters and recharged coolant"
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Fix air conditioning"
+        },
+        "/api/assets/{id}/label": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A QR code of the asset's deep link, with a signed token, as a PNG or an SVG (managers only)",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Get an asset label",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 256,
+                        "description": "Width and height of the PNG in pixels (64-1024)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "internal_controllers.UpdateTaskRequest": {
-            "type": "object",
-            "required": [
-                "performed_at",
-                "summary",
-                "title"
-            ],
-            "properties": {
-                "performed_at": {
+        "/api/assets/{id}/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The tasks performed on an asset, most recent first. Technicians only see their own tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Asset maintenance history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/customers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the customers by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "List customers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Customer"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a customer (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Create a customer",
+                "parameters": [
+                    {
+                        "description": "Customer",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/customers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a customer by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get a customer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the name and contact details of a customer (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Update a customer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Customer",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a customer once its sites are deleted (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Delete a customer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the authenticated user's notifications, newest first, with cursor pagination. Managers see shared notifications and those addressed to them; technicians only those addressed to them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "enum": [
+                            "unread",
+                            "read",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "unread",
+                        "description": "Filter by read status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by task ID",
+                        "name": "task_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "next_cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.NotificationPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a notification by its ID, read or unread",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get a specific notification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{id}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a specific notification as read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the parts catalog with the stock of each part, by SKU",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "List parts",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only parts at or below their low stock threshold",
+                        "name": "low_stock",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Part"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a part to the catalog (managers only). It starts with no stock; restock it to receive parts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "Create a part",
+                "parameters": [
+                    {
+                        "description": "Part",
+                        "name": "part",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.PartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Part"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a part of the catalog with its stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "Get a part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Part ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Part"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the SKU, name, unit or low stock threshold of a part (managers only). The stock only changes through stock movements",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "Update a part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Part ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Part",
+                        "name": "part",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.PartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Part"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a part from the catalog (managers only). Its ledger and the materials already recorded on tasks are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "Delete a part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Part ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parts/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The ledger of a part, newest first, with cursor pagination (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "List stock movements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Part ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "next_cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.StockMovementPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parts/{id}/stock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restock a part or correct its stock after a count (managers only). The movement is recorded in the ledger; the stock never goes below zero",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parts"
+                ],
+                "summary": "Move stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Part ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock movement",
+                        "name": "movement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.StockMovementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Part"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/queue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the open tasks nobody has claimed yet: urgent first, then by due date (tasks without one last) and age. Tasks are assigned when they are created, so every technician has their own queue; technicians only see theirs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queue"
+                ],
+                "summary": "Get the work queue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of tasks (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/queue/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Claim the first task of the technician's own work queue (Technician only); there is no shared pool of unassigned tasks. Concurrent claims never get the same task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queue"
+                ],
+                "summary": "Claim the next task",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version of the task"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/queue/{id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give up a claim, putting the task back in the technician's work queue (Technician only, for tasks they claimed)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queue"
+                ],
+                "summary": "Release a claimed task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/recurring-tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List recurring task definitions. Technicians only see the ones assigned to them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-tasks"
+                ],
+                "summary": "List recurring tasks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.RecurringTask"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule a task that recurs following an iCalendar RRULE (Manager only). The scheduler creates each occurrence ahead of time as an open task assigned to the technician",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-tasks"
+                ],
+                "summary": "Create a recurring task",
+                "parameters": [
+                    {
+                        "description": "Recurring task",
+                        "name": "recurringTask",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.RecurringTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.RecurringTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/recurring-tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a recurring task definition. Technicians can only get the ones assigned to them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-tasks"
+                ],
+                "summary": "Get a recurring task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.RecurringTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a recurring task (Manager only). Tasks already created are kept; the new schedule applies to the occurrences that have no task yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-tasks"
+                ],
+                "summary": "Update a recurring task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recurring task",
+                        "name": "recurringTask",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.RecurringTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.RecurringTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a recurring task (Manager only). The tasks it created are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-tasks"
+                ],
+                "summary": "Delete a recurring task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/recurring-tasks/{id}/occurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the next occurrences of a recurring task, with the task already created for each one the scheduler has reached",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-tasks"
+                ],
+                "summary": "List upcoming occurrences",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of occurrences (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.RecurringOccurrence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the sites by name, optionally only those of a customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "List sites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only the sites of this customer",
+                        "name": "customer_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Site"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a site to a customer (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Create a site",
+                "parameters": [
+                    {
+                        "description": "Site",
+                        "name": "site",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.SiteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sites/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a site by ID with its address and coordinates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Get a site",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the name, address, coordinates or geofence radius of a site (managers only). A site cannot move to another customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Update a site",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Site",
+                        "name": "site",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.SiteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a site once its assets are deleted (managers only). Tasks performed there keep the link",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sites"
+                ],
+                "summary": "Delete a site",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sla/policies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the SLA policies giving the tasks of a tag a resolution time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sla"
+                ],
+                "summary": "List SLA policies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.SLAPolicy"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give the tasks of a tag a resolution time (Manager only). Open tasks created with the tag from then on get a due date; a task with several tags follows the strictest policy",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sla"
+                ],
+                "summary": "Create an SLA policy",
+                "parameters": [
+                    {
+                        "description": "SLA policy",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.SLAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.SLAPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sla/policies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an SLA policy by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sla"
+                ],
+                "summary": "Get an SLA policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.SLAPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change an SLA policy (Manager only). Tasks keep the due date the policy already gave them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sla"
+                ],
+                "summary": "Update an SLA policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SLA policy",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.SLAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.SLAPolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an SLA policy (Manager only). Tasks keep the due date it gave them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sla"
+                ],
+                "summary": "Delete an SLA policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sla/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count the tasks due in a period that met or missed their due date, overall and per technician. Open tasks past their due date count as missed. Technicians only get their own figures",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sla"
+                ],
+                "summary": "SLA compliance report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (ISO 8601 format)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive (ISO 8601 format)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.SLAReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the managed tag vocabulary, grouped by category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Tag"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a tag to the managed vocabulary (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a tag",
+                "parameters": [
+                    {
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/counts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Number of active tasks carrying each tag, for dashboards. Technicians only count their own tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Count tasks per tag",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TagCount"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename, recategorize or describe a tag (managers only); tagged tasks follow the new name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a tag from the vocabulary and from every task (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/task-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current revision of every template, by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List task templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplate"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a template for a routine job (Manager only). Placeholders such as {{site}} in the title pattern are filled when a task is created; {{date}} defaults to the performed_at date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a task template",
+                "parameters": [
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TaskTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/task-templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current revision of a template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a task template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a new revision of a template (Manager only). Tasks created earlier keep pointing at the revision they were created from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Update a task template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TaskTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a template from the list (Manager only). Its revisions remain available to the tasks created from it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete a task template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/task-templates/{id}/revisions/{revision}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a past or current revision of a template, e.g. the one a task was created from. Revisions of deleted templates remain available",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a task template revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all tasks for the authenticated user (if technician) or all tasks (if manager), optionally only those with some tags or SLA status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get all tasks",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag names; repeat the parameter for several tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether tasks need any or all of the tags",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "on_track",
+                            "at_risk",
+                            "breached",
+                            "met",
+                            "missed"
+                        ],
+                        "type": "string",
+                        "description": "Only tasks with this SLA status",
+                        "name": "sla",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "inside",
+                            "outside",
+                            "unverified",
+                            "flagged"
+                        ],
+                        "type": "string",
+                        "description": "Only tasks whose location has this geofence outcome, or flagged for those outside the geofence not reviewed yet",
+                        "name": "geofence",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new task for the authenticated technician. A task recorded with the device location outside the geofence of its site is flagged and its technician's manager notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Create a new task",
+                "parameters": [
+                    {
+                        "description": "Task Information",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/from-template/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a task from the current revision of a template (Technician only). The title is rendered from the title pattern with the given variables; summary, tags and status (default open) can be overridden. The template checklist is copied to the task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Create a task from a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Task fields",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateTaskFromTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over task titles and summaries, ranked by relevance. Technicians only get their own tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Search tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text (max 200 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of results (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskSearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the tasks in the trash, most recently deleted first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "List deleted tasks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a task by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get a specific task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version of the task"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing task. It can only be completed once every required checklist item is done and the tasks it depends on are completed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag returned by GET /api/tasks/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Task Information",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.UpdateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a task to the trash; it can be restored until the trash retention window expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON Merge Patch (RFC 7386) to a task. Only title, summary, performed_at, status, priority, tags, due_at, site_id and asset_id can be patched; omitted fields keep their value. Tags, due_at, site_id and asset_id can be removed with null",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Partially update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag returned by GET /api/tasks/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change, e.g. {\\",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the files attached to a task, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "List task attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskAttachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a photo or document to a task. The type is detected from the content and must be allowed; the size is limited",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Upload a task attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskAttachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/attachments/{attachmentId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the content of an attachment",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download a task attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "SHA-256 of the content"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an attachment; only its uploader or a manager can delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete a task attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/attachments/{attachmentId}/thumbnails/{size}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a JPEG preview of an image attachment, without metadata. Sizes fit the longest side in 160 (small), 480 (medium) or 1024 (large) pixels. Images are processed in the background after upload; until then this returns 404.",
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get an attachment thumbnail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "small",
+                            "medium",
+                            "large"
+                        ],
+                        "type": "string",
+                        "description": "Thumbnail size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/checklist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the checklist of a task in order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "List checklist items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskChecklistItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an open item to the checklist of a task (max 100 items). Completed tasks cannot get required items.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Add a checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checklist item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateChecklistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskChecklistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/checklist/{itemId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change an item, tick it off or move it. Marking it done records who completed it and when. Required items of completed tasks cannot be reopened.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Update a checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checklist item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.UpdateChecklistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskChecklistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from the checklist of a task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Delete a checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the comments on a task, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List task comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskComment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a comment to a task. Users mentioned as @email who can see the task are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskComment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/comments/{commentId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the text of a comment; only its author can edit it. Users mentioned for the first time are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Edit a task comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskComment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a comment; only its author or a manager can delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a task comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/dependencies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the tasks a task depends on, directly or not, and the tasks depending on it, with the dependencies between them. Technicians get no titles for other technicians' tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dependencies"
+                ],
+                "summary": "Get the dependency graph of a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskDependencyGraph"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make a task wait for another one: it cannot be completed before its prerequisite. Links closing a cycle are rejected, as are open prerequisites of a completed task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dependencies"
+                ],
+                "summary": "Add a dependency",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Prerequisite",
+                        "name": "dependency",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateTaskDependencyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskDependencyGraph"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/dependencies/{dependsOnId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a task waiting for a prerequisite. When it was the last one, the technician of the open task is notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dependencies"
+                ],
+                "summary": "Remove a dependency",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Prerequisite task ID",
+                        "name": "dependsOnId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/location/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a task recorded outside the geofence of its site as reviewed, which takes it off the flagged tasks (managers only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Review a task location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/materials": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the parts used on a task, in the order they were recorded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "materials"
+                ],
+                "summary": "List task materials",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskMaterial"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record parts used on a task and take them out of stock. Managers are notified when the part reaches its low stock threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "materials"
+                ],
+                "summary": "Record a material",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Material",
+                        "name": "material",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TaskMaterialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskMaterial"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/materials/{materialId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a material recorded by mistake and put its quantity back in stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "materials"
+                ],
+                "summary": "Remove a material",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Material ID",
+                        "name": "materialId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a task from the trash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Restore a deleted task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every stored version of a task, oldest first, with who wrote it and when",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "List task revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/sword-challenge_internal_models.TaskRevision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Show the fields that changed between two revisions of a task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Diff two task revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Older revision number",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Newer revision number",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskRevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/time-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the time entries of a task, oldest first, with the total time spent on it. Running timers count up to now",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time-entries"
+                ],
+                "summary": "List time entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskTimeEntries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record time spent on one of your tasks. Entries cannot be in the future nor overlap your other entries; without ended_at a timer starts at started_at",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time-entries"
+                ],
+                "summary": "Record a time entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time entry",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateTimeEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TimeEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/time-entries/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start tracking time on one of your tasks now. You can only run one timer at a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time-entries"
+                ],
+                "summary": "Start a timer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Timer",
+                        "name": "timer",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.StartTimerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TimeEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/time-entries/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop your running timer on a task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time-entries"
+                ],
+                "summary": "Stop the timer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TimeEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tasks/{id}/time-entries/{entryId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a time entry. Technicians can only delete their own entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time-entries"
+                ],
+                "summary": "Delete a time entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time entry ID",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/time-entries/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total the time spent in a period per technician and per task. Entries crossing the bounds only count for their part inside the period, and running timers count up to now. Technicians only get their own figures",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time-entries"
+                ],
+                "summary": "Time tracking report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (ISO 8601 format)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive (ISO 8601 format)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Restrict the report to a technician (managers only)",
+                        "name": "technician_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TimeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_controllers.AssetRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "serial_number",
+                "site_id"
+            ],
+            "properties": {
+                "installed_on": {
+                    "description": "InstalledOn is a date (YYYY-MM-DD), omitted when unknown",
+                    "type": "string",
+                    "example": "2019-06-01"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "Carrier 30RB-160"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Rooftop chiller 1"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "CH-2019-0042"
+                },
+                "site_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_controllers.CommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "@sarah.j@company.com was the filter replaced too?"
+                }
+            }
+        },
+        "internal_controllers.CreateChecklistItemRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Use MERV 13 filters"
+                },
+                "position": {
+                    "description": "Position in the checklist, starting at 1; omit to append the item",
+                    "type": "integer",
+                    "example": 1
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Replace air filters"
+                }
+            }
+        },
+        "internal_controllers.CreateTaskDependencyRequest": {
+            "type": "object",
+            "required": [
+                "depends_on_id"
+            ],
+            "properties": {
+                "depends_on_id": {
+                    "description": "ID of the task that has to be completed first",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_controllers.CreateTaskFromTemplateRequest": {
+            "type": "object",
+            "required": [
+                "performed_at"
+            ],
+            "properties": {
+                "performed_at": {
+                    "description": "When the task was performed (ISO 8601 format)",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "priority": {
+                    "description": "low, normal (default), high or urgent",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "status": {
+                    "description": "open (default) while work is pending, completed once done",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed"
+                    ],
+                    "example": "open"
+                },
+                "summary": {
+                    "description": "Overrides the template summary",
+                    "type": "string",
+                    "example": "Replaced the air filters and checked the airflow."
+                },
+                "tags": {
+                    "description": "Overrides the template tags; send [] for none",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "title": {
+                    "description": "Overrides the title rendered from the title pattern",
+                    "type": "string",
+                    "example": "Replace HVAC filters - Lisbon office"
+                },
+                "variables": {
+                    "description": "Values of the title pattern placeholders",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_controllers.CreateTaskRequest": {
+            "type": "object",
+            "required": [
+                "performed_at",
+                "summary",
+                "title"
+            ],
+            "properties": {
+                "accuracy": {
+                    "type": "number",
+                    "example": 12
+                },
+                "asset_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "due_at": {
+                    "description": "When the task has to be completed; defaults to the strictest SLA policy\nof its tags when it is created open",
+                    "type": "string",
+                    "example": "2024-03-21T14:30:00Z"
+                },
+                "latitude": {
+                    "description": "Where the technician's device is, checked against the geofence of the\nsite; Accuracy is in meters",
+                    "type": "number",
+                    "example": 38.7199
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": -9.1451
+                },
+                "performed_at": {
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "priority": {
+                    "description": "defaults to normal",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "site_id": {
+                    "description": "SiteID defaults to the site of the asset",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "defaults to completed",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed"
+                    ],
+                    "example": "completed"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Replaced filters and recharged coolant"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fix air conditioning"
+                }
+            }
+        },
+        "internal_controllers.CreateTimeEntryRequest": {
+            "type": "object",
+            "required": [
+                "started_at"
+            ],
+            "properties": {
+                "ended_at": {
+                    "description": "EndedAt closes the entry; omit it to start a timer at started_at",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Replaced the compressor"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-03-20T13:00:00Z"
+                }
+            }
+        },
+        "internal_controllers.CustomerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "facilities@northwind.example"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Northwind Offices"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+351 210 000 000"
+                }
+            }
+        },
+        "internal_controllers.PartRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "sku",
+                "unit"
+            ],
+            "properties": {
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "name": {
+                    "type": "string",
+                    "example": "MERV 13 air filter"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "FLT-MERV13"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "pcs"
+                }
+            }
+        },
+        "internal_controllers.RecurringTaskRequest": {
+            "type": "object",
+            "required": [
+                "rrule",
+                "starts_at",
+                "technician_id",
+                "title"
+            ],
+            "properties": {
+                "rrule": {
+                    "description": "iCalendar recurrence rule (RFC 5545); BYHOUR, BYMINUTE, BYSECOND, BYYEARDAY and BYWEEKNO are not supported",
+                    "type": "string",
+                    "example": "FREQ=MONTHLY;BYDAY=1MO"
+                },
+                "starts_at": {
+                    "description": "First occurrence (ISO 8601 format); later occurrences keep its time of day",
+                    "type": "string",
+                    "example": "2024-04-01T09:00:00+01:00"
+                },
+                "summary": {
+                    "description": "Summary of the generated tasks; required unless a template is given",
+                    "type": "string",
+                    "maxLength": 2500,
+                    "example": "Inspect the rooftop units and replace the filters."
+                },
+                "technician_id": {
+                    "description": "The technician the generated tasks are assigned to",
+                    "type": "integer",
+                    "example": 2
+                },
+                "template_id": {
+                    "description": "Template giving the generated tasks their checklist and tags",
+                    "type": "integer",
+                    "example": 1
+                },
+                "timezone": {
+                    "description": "IANA time zone the rule is expanded in (default UTC)",
+                    "type": "string",
+                    "example": "Europe/Lisbon"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Monthly HVAC inspection"
+                }
+            }
+        },
+        "internal_controllers.SLAPolicyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "resolution_minutes",
+                "tag"
+            ],
+            "properties": {
+                "at_risk_minutes": {
+                    "description": "Minutes before the due date when the task is at risk",
+                    "type": "integer",
+                    "example": 240
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Corrective maintenance"
+                },
+                "resolution_minutes": {
+                    "description": "Minutes from creation until the task is due",
+                    "type": "integer",
+                    "example": 1440
+                },
+                "tag": {
+                    "description": "Tag whose tasks follow the policy",
+                    "type": "string",
+                    "example": "corrective"
+                }
+            }
+        },
+        "internal_controllers.SiteRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Avenida da Liberdade 110"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Lisboa"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "PT"
+                },
+                "customer_id": {
+                    "description": "CustomerID is ignored on updates; a site cannot move to another customer",
+                    "type": "integer",
+                    "example": 1
+                },
+                "geofence_radius": {
+                    "description": "GeofenceRadius in meters defaults to 200 on creation and is kept when\nomitted on updates",
+                    "type": "integer",
+                    "example": 200
+                },
+                "latitude": {
+                    "description": "Latitude and Longitude are set together, or omitted when unknown",
+                    "type": "number",
+                    "example": 38.7197
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": -9.1453
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lisbon headquarters"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "1250-146"
+                }
+            }
+        },
+        "internal_controllers.StartTimerRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "On site"
+                }
+            }
+        },
+        "internal_controllers.StockMovementRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "PO 2024-117"
+                },
+                "quantity": {
+                    "description": "Quantity is added to the stock; adjustments can be negative",
+                    "type": "integer",
+                    "example": 10
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "restock",
+                        "adjustment"
+                    ],
+                    "example": "restock"
+                }
+            }
+        },
+        "internal_controllers.TagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "discipline"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Heating, ventilation and air conditioning"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "hvac"
+                }
+            }
+        },
+        "internal_controllers.TaskMaterialRequest": {
+            "type": "object",
+            "required": [
+                "part_id",
+                "quantity"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Rooftop unit 2"
+                },
+                "part_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "internal_controllers.TaskTemplateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "title_pattern"
+            ],
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplateChecklistItem"
+                    }
+                },
+                "estimated_minutes": {
+                    "description": "Expected duration in minutes, 0 when unknown (max 10080)",
+                    "type": "integer",
+                    "example": 45
+                },
+                "name": {
+                    "type": "string",
+                    "example": "HVAC filter replacement"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Replaced the air filters and checked the airflow."
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "title_pattern": {
+                    "type": "string",
+                    "example": "Replace HVAC filters - {{site}}"
+                }
+            }
+        },
+        "internal_controllers.UpdateChecklistItemRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Filters were clogged"
+                },
+                "position": {
+                    "description": "Position in the checklist, starting at 1; omit to keep the item in place",
+                    "type": "integer",
+                    "example": 1
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Replace air filters"
+                }
+            }
+        },
+        "internal_controllers.UpdateTaskRequest": {
+            "type": "object",
+            "required": [
+                "performed_at",
+                "summary",
+                "title"
+            ],
+            "properties": {
+                "accuracy": {
+                    "type": "number",
+                    "example": 12
+                },
+                "asset_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "due_at": {
+                    "description": "DueAt replaces the task's due date; omit it to keep the current one",
+                    "type": "string",
+                    "example": "2024-03-21T14:30:00Z"
+                },
+                "latitude": {
+                    "description": "Latitude, Longitude and Accuracy replace the task's location; omit them\nto keep the current one",
+                    "type": "number",
+                    "example": 38.7199
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": -9.1451
+                },
+                "performed_at": {
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "priority": {
+                    "description": "Priority is kept when omitted",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "site_id": {
+                    "description": "SiteID and AssetID are kept when both are omitted, and the asset when\nonly the current site is given",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "Status can only become completed once every required checklist item is\ndone; omit it to keep the current status",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed"
+                    ],
+                    "example": "completed"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Replaced filters and recharged coolant"
+                },
+                "tags": {
+                    "description": "Tags replace the task's tags; omit them to keep the current ones",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fix air conditioning"
+                }
+            }
+        },
+        "sword-challenge_internal_models.Asset": {
+            "description": "An asset installed on a site",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description When the asset was added",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the asset",
+                    "type": "integer",
+                    "example": 1
+                },
+                "installed_on": {
+                    "description": "@Description Install date (YYYY-MM-DD), absent when unknown",
+                    "type": "string",
+                    "example": "2019-06-01"
+                },
+                "model": {
+                    "description": "@Description Manufacturer model, may be empty",
+                    "type": "string",
+                    "example": "Carrier 30RB-160"
+                },
+                "name": {
+                    "description": "@Description Name of the asset",
+                    "type": "string",
+                    "example": "Rooftop chiller 1"
+                },
+                "serial_number": {
+                    "description": "@Description Serial number, unique among the assets of the model",
+                    "type": "string",
+                    "example": "CH-2019-0042"
+                },
+                "site_id": {
+                    "description": "@Description The site the asset is installed on",
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "description": "@Description When the asset was last changed",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.AssetScan": {
+            "description": "A scanned asset label",
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "description": "@Description The asset the label is stuck on",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Asset"
+                        }
+                    ]
+                },
+                "open_tasks": {
+                    "description": "@Description The open tasks on the asset, most recent first; technicians only get their own",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                    }
+                },
+                "site": {
+                    "description": "@Description The site the asset is installed on",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Site"
+                        }
+                    ]
+                }
+            }
+        },
+        "sword-challenge_internal_models.ChecklistProgress": {
+            "description": "Checklist completion of a task",
+            "type": "object",
+            "properties": {
+                "done": {
+                    "description": "@Description Number of items done",
+                    "type": "integer",
+                    "example": 3
+                },
+                "percent": {
+                    "description": "@Description Share of items done, 0-100; 0 when the task has no checklist",
+                    "type": "integer",
+                    "example": 75
+                },
+                "required_open": {
+                    "description": "@Description Number of required items still open; the task cannot be completed until it is 0",
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "description": "@Description Number of checklist items",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "sword-challenge_internal_models.Customer": {
+            "description": "A customer",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description When the customer was added",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "email": {
+                    "description": "@Description Contact email, may be empty",
+                    "type": "string",
+                    "example": "facilities@northwind.example"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the customer",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "@Description Name of the customer",
+                    "type": "string",
+                    "example": "Northwind Offices"
+                },
+                "phone": {
+                    "description": "@Description Contact phone, may be empty",
+                    "type": "string",
+                    "example": "+351 210 000 000"
+                },
+                "updated_at": {
+                    "description": "@Description When the customer was last changed",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.FieldChange": {
+            "description": "A changed field between two revisions",
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "@Description The value in the newer revision",
+                    "type": "string",
+                    "example": "Replaced filters and recharged coolant"
+                },
+                "before": {
+                    "description": "@Description The value in the older revision",
+                    "type": "string",
+                    "example": "Replaced filters"
+                },
+                "field": {
+                    "description": "@Description The name of the field",
+                    "type": "string",
+                    "example": "summary"
+                }
+            }
+        },
+        "sword-challenge_internal_models.Notification": {
+            "description": "Notification information",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description When the notification was created",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the notification",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_read": {
+                    "description": "@Description Whether the notification has been read",
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "description": "@Description The notification message, rendered in the reader's locale",
+                    "type": "string",
+                    "example": "The tech John Doe performed the task on 2024-03-20 14:30:00"
+                },
+                "params": {
+                    "description": "@Description The parameters used to render the message",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "recipient_id": {
+                    "description": "@Description The user this notification is for; absent when it is shared by all managers",
+                    "type": "integer",
+                    "example": 2
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task this notification is about",
+                    "type": "integer",
+                    "example": 1
+                },
+                "template_key": {
+                    "description": "@Description The template used to render the message",
+                    "type": "string",
+                    "example": "task_performed"
+                }
+            }
+        },
+        "sword-challenge_internal_models.NotificationPage": {
+            "description": "A page of notifications",
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "@Description Cursor to request the next page, absent on the last page",
+                    "type": "integer",
+                    "example": 42
+                },
+                "notifications": {
+                    "description": "@Description The notifications of this page, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.Notification"
+                    }
+                },
+                "unread_count": {
+                    "description": "@Description Total number of unread notifications",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "sword-challenge_internal_models.Part": {
+            "description": "A part of the catalog",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description When the part was added",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the part",
+                    "type": "integer",
+                    "example": 1
+                },
+                "low_stock": {
+                    "description": "@Description Whether the stock is at or below the threshold",
+                    "type": "boolean",
+                    "example": false
+                },
+                "low_stock_threshold": {
+                    "description": "@Description Managers are alerted when a task brings the stock down to this quantity",
+                    "type": "integer",
+                    "example": 6
+                },
+                "name": {
+                    "description": "@Description Name of the part",
+                    "type": "string",
+                    "example": "MERV 13 air filter"
+                },
+                "sku": {
+                    "description": "@Description Stock keeping unit, unique among the parts in the catalog",
+                    "type": "string",
+                    "example": "FLT-MERV13"
+                },
+                "stock": {
+                    "description": "@Description Quantity in stock",
+                    "type": "integer",
+                    "example": 24
+                },
+                "unit": {
+                    "description": "@Description Unit the quantities are counted in, e.g. pcs, m or g",
+                    "type": "string",
+                    "example": "pcs"
+                },
+                "updated_at": {
+                    "description": "@Description When the part was last changed",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.RecurringOccurrence": {
+            "description": "An upcoming occurrence of a recurring task",
+            "type": "object",
+            "properties": {
+                "scheduled_for": {
+                    "description": "@Description When the occurrence is scheduled",
+                    "type": "string",
+                    "example": "2024-04-01T09:00:00+01:00"
+                },
+                "task_id": {
+                    "description": "@Description The task created for the occurrence, absent until the scheduler reaches it",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "sword-challenge_internal_models.RecurringTask": {
+            "description": "A recurring task definition",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description When the recurring task was created",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "created_by": {
+                    "description": "@Description The ID of the manager who created the recurring task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "generated_until": {
+                    "description": "@Description Occurrences up to this time have been created as tasks",
+                    "type": "string",
+                    "example": "2024-04-15T09:00:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the recurring task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "description": "@Description iCalendar recurrence rule (RFC 5545)",
+                    "type": "string",
+                    "example": "FREQ=MONTHLY;BYDAY=1MO"
+                },
+                "starts_at": {
+                    "description": "@Description First occurrence; later occurrences keep its time of day",
+                    "type": "string",
+                    "example": "2024-04-01T09:00:00+01:00"
+                },
+                "summary": {
+                    "description": "@Description Summary of the generated tasks, in Markdown; the template summary when empty",
+                    "type": "string",
+                    "example": "Inspect the rooftop units and replace the filters."
+                },
+                "technician_id": {
+                    "description": "@Description The ID of the technician the generated tasks are assigned to",
+                    "type": "integer",
+                    "example": 2
+                },
+                "template_id": {
+                    "description": "@Description Template giving the generated tasks their checklist and tags",
+                    "type": "integer",
+                    "example": 1
+                },
+                "timezone": {
+                    "description": "@Description IANA time zone the rule is expanded in",
+                    "type": "string",
+                    "example": "Europe/Lisbon"
+                },
+                "title": {
+                    "description": "@Description Title of the generated tasks",
+                    "type": "string",
+                    "example": "Monthly HVAC inspection"
+                },
+                "updated_at": {
+                    "description": "@Description When the recurring task was last updated",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.SLACompliance": {
+            "description": "SLA compliance of the tasks due in a period",
+            "type": "object",
+            "properties": {
+                "compliance_percent": {
+                    "description": "@Description Percentage of met over met and missed tasks, absent when none is decided yet",
+                    "type": "number",
+                    "example": 89.47
+                },
+                "met": {
+                    "description": "@Description Tasks completed by their due date",
+                    "type": "integer",
+                    "example": 17
+                },
+                "missed": {
+                    "description": "@Description Tasks completed late or still open past their due date",
+                    "type": "integer",
+                    "example": 2
+                },
+                "pending": {
+                    "description": "@Description Open tasks not due yet",
+                    "type": "integer",
+                    "example": 1
+                },
+                "technician_id": {
+                    "description": "@Description The technician, absent in the overall figures",
+                    "type": "integer",
+                    "example": 2
+                },
+                "technician_name": {
+                    "description": "@Description Name of the technician, absent in the overall figures",
+                    "type": "string",
+                    "example": "Sarah Johnson"
+                },
+                "total": {
+                    "description": "@Description Tasks due in the period",
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "sword-challenge_internal_models.SLAPolicy": {
+            "description": "An SLA policy",
+            "type": "object",
+            "properties": {
+                "at_risk_minutes": {
+                    "description": "@Description Minutes before the due date when the task is at risk",
+                    "type": "integer",
+                    "example": 240
+                },
+                "created_at": {
+                    "description": "@Description When the policy was created",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the policy",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "@Description Name of the policy",
+                    "type": "string",
+                    "example": "Corrective maintenance"
+                },
+                "resolution_minutes": {
+                    "description": "@Description Minutes from creation until the task is due",
+                    "type": "integer",
+                    "example": 1440
+                },
+                "tag": {
+                    "description": "@Description The tag whose tasks follow the policy",
+                    "type": "string",
+                    "example": "corrective"
+                },
+                "updated_at": {
+                    "description": "@Description When the policy was last updated",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.SLAReport": {
+            "description": "SLA compliance report",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "@Description Start of the period (inclusive)",
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "overall": {
+                    "description": "@Description Figures of every technician in the report together",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.SLACompliance"
+                        }
+                    ]
+                },
+                "technicians": {
+                    "description": "@Description Figures per technician",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.SLACompliance"
+                    }
+                },
+                "to": {
+                    "description": "@Description End of the period (exclusive)",
+                    "type": "string",
+                    "example": "2024-04-01T00:00:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.Site": {
+            "description": "A customer site",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "@Description Street address",
+                    "type": "string",
+                    "example": "Avenida da Liberdade 110"
+                },
+                "city": {
+                    "description": "@Description City, may be empty",
+                    "type": "string",
+                    "example": "Lisboa"
+                },
+                "country": {
+                    "description": "@Description ISO 3166-1 alpha-2 country code, may be empty",
+                    "type": "string",
+                    "example": "PT"
+                },
+                "created_at": {
+                    "description": "@Description When the site was added",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "customer_id": {
+                    "description": "@Description The customer the site belongs to",
+                    "type": "integer",
+                    "example": 1
+                },
+                "geofence_radius": {
+                    "description": "@Description Radius in meters around the coordinates within which tasks are recorded on site",
+                    "type": "integer",
+                    "example": 200
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the site",
+                    "type": "integer",
+                    "example": 1
+                },
+                "latitude": {
+                    "description": "@Description Latitude in decimal degrees, absent when the site has no coordinates",
+                    "type": "number",
+                    "example": 38.7197
+                },
+                "longitude": {
+                    "description": "@Description Longitude in decimal degrees, absent when the site has no coordinates",
+                    "type": "number",
+                    "example": -9.1453
+                },
+                "name": {
+                    "description": "@Description Name of the site",
+                    "type": "string",
+                    "example": "Lisbon headquarters"
+                },
+                "postal_code": {
+                    "description": "@Description Postal code, may be empty",
+                    "type": "string",
+                    "example": "1250-146"
+                },
+                "updated_at": {
+                    "description": "@Description When the site was last changed",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.StockMovement": {
+            "description": "A stock ledger entry",
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "@Description Stock after the movement",
+                    "type": "integer",
+                    "example": 22
+                },
+                "created_at": {
+                    "description": "@Description When the stock moved",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "created_by": {
+                    "description": "@Description The ID of the user who moved the stock, absent once deleted",
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the movement",
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "description": "@Description Optional remark, e.g. a purchase order or why stock was adjusted",
+                    "type": "string",
+                    "example": "PO 2024-117"
+                },
+                "part_id": {
+                    "description": "@Description The ID of the part",
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "@Description Change of the stock, negative when parts left it",
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason": {
+                    "description": "@Description Why the stock changed: restock, consumption, return or adjustment",
+                    "type": "string",
+                    "example": "consumption"
+                },
+                "task_id": {
+                    "description": "@Description The task the part was used on or returned from, absent otherwise",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "sword-challenge_internal_models.StockMovementPage": {
+            "description": "A page of stock movements",
+            "type": "object",
+            "properties": {
+                "movements": {
+                    "description": "@Description The movements of this page, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.StockMovement"
+                    }
+                },
+                "next_cursor": {
+                    "description": "@Description Cursor to request the next page, absent on the last page",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "sword-challenge_internal_models.Tag": {
+            "description": "A tag that classifies tasks",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "@Description The category grouping related tags, empty when uncategorized",
+                    "type": "string",
+                    "example": "discipline"
+                },
+                "created_at": {
+                    "description": "@Description When the tag was created",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "description": {
+                    "description": "@Description What the tag is used for",
+                    "type": "string",
+                    "example": "Heating, ventilation and air conditioning"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the tag",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "@Description The tag name, unique, lowercase",
+                    "type": "string",
+                    "example": "hvac"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TagCount": {
+            "description": "Number of tasks with a tag",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "@Description The category of the tag",
+                    "type": "string",
+                    "example": "discipline"
+                },
+                "name": {
+                    "description": "@Description The tag name",
+                    "type": "string",
+                    "example": "hvac"
+                },
+                "tag_id": {
+                    "description": "@Description The ID of the tag",
+                    "type": "integer",
+                    "example": 1
+                },
+                "task_count": {
+                    "description": "@Description Number of active tasks with the tag",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "sword-challenge_internal_models.Task": {
+            "description": "Task information",
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "description": "@Description The asset of the site the task was performed on",
+                    "type": "integer",
+                    "example": 1
+                },
+                "at_risk_at": {
+                    "description": "@Description When the task becomes at risk of missing its due date",
+                    "type": "string",
+                    "example": "2024-03-21T10:30:00Z"
+                },
+                "blocked_by": {
+                    "description": "@Description The prerequisites that are not completed yet; the task cannot be completed before them",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        5
+                    ]
+                },
+                "checklist": {
+                    "description": "@Description Completion of the task checklist",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.ChecklistProgress"
+                        }
+                    ]
+                },
+                "claimed_at": {
+                    "description": "@Description When the task was claimed from the work queue",
+                    "type": "string",
+                    "example": "2024-03-21T08:00:00Z"
+                },
+                "claimed_by": {
+                    "description": "@Description The technician who claimed the task from the work queue",
+                    "type": "integer",
+                    "example": 2
+                },
+                "completed_at": {
+                    "description": "@Description When the task was completed",
+                    "type": "string",
+                    "example": "2024-03-21T09:00:00Z"
+                },
+                "created_at": {
+                    "description": "@Description When the task was created",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "deleted_at": {
+                    "description": "@Description When the task was moved to the trash, absent for active tasks",
+                    "type": "string",
+                    "example": "2024-03-21T09:00:00Z"
+                },
+                "due_at": {
+                    "description": "@Description When the task has to be completed, absent when it has no due date",
+                    "type": "string",
+                    "example": "2024-03-21T14:30:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "location": {
+                    "description": "@Description Where the technician recorded the task, checked against the geofence of the site",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskLocation"
+                        }
+                    ]
+                },
+                "performed_at": {
+                    "description": "@Description When the task was performed",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "priority": {
+                    "description": "@Description How pressing the task is; the work queue serves urgent tasks first",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "recurring_task_id": {
+                    "description": "@Description The recurring task that generated the task, absent for other tasks",
+                    "type": "integer",
+                    "example": 1
+                },
+                "scheduled_for": {
+                    "description": "@Description The occurrence of the recurring task the task was generated for",
+                    "type": "string",
+                    "example": "2024-04-01T09:00:00Z"
+                },
+                "site_id": {
+                    "description": "@Description The customer site the task was performed at",
+                    "type": "integer",
+                    "example": 1
+                },
+                "sla_policy_id": {
+                    "description": "@Description The SLA policy that gave the task its due date when it was created",
+                    "type": "integer",
+                    "example": 1
+                },
+                "sla_status": {
+                    "description": "@Description SLA status: on_track, at_risk or breached while open, met or missed once completed; absent without a due date",
+                    "type": "string",
+                    "enum": [
+                        "on_track",
+                        "at_risk",
+                        "breached",
+                        "met",
+                        "missed"
+                    ],
+                    "example": "on_track"
+                },
+                "status": {
+                    "description": "@Description Whether the task is still open or completed",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed"
+                    ],
+                    "example": "completed"
+                },
+                "summary": {
+                    "description": "@Description The detailed summary of the task, in Markdown",
+                    "type": "string",
+                    "example": "Replaced **filters** and recharged coolant"
+                },
+                "summary_html": {
+                    "description": "@Description The summary rendered to sanitized HTML",
+                    "type": "string",
+                    "example": "\u003cp\u003eReplaced \u003cstrong\u003efilters\u003c/strong\u003e and recharged coolant\u003c/p\u003e"
+                },
+                "tags": {
+                    "description": "@Description Names of the tags classifying the task",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "technician_id": {
+                    "description": "@Description The ID of the technician who performed the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "template": {
+                    "description": "@Description The template revision the task was created from, absent for tasks written from scratch",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplateRef"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "@Description The title of the task",
+                    "type": "string",
+                    "example": "Fix air conditioning"
+                },
+                "updated_at": {
+                    "description": "@Description When the task was last updated",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "version": {
+                    "description": "@Description Incremented on every update; sent back as the ETag",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskAttachment": {
+            "description": "A file attached to a task",
+            "type": "object",
+            "properties": {
+                "captured_at": {
+                    "description": "@Description When the photo was taken, from its EXIF data",
+                    "type": "string",
+                    "example": "2024-03-20T14:12:09Z"
+                },
+                "checksum_sha256": {
+                    "description": "@Description Hex-encoded SHA-256 of the content",
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "description": "@Description The MIME type detected from the content",
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "created_at": {
+                    "description": "@Description When the file was uploaded",
                     "type": "string",
                     "example": "2024-03-20T14:30:00Z"
                 },
-                "summary": {
+                "file_name": {
+                    "description": "@Description The original file name",
+                    "type": "string",
+                    "example": "after.jpg"
+                },
+                "height": {
+                    "description": "@Description Image height in pixels, once processed",
+                    "type": "integer",
+                    "example": 4032
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the attachment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "processed_at": {
+                    "description": "@Description When the image was processed; thumbnails exist from then on",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:02Z"
+                },
+                "size": {
+                    "description": "@Description The size of the file in bytes",
+                    "type": "integer",
+                    "example": 482113
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "thumbnails": {
+                    "description": "@Description Available thumbnail sizes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "small",
+                        "medium",
+                        "large"
+                    ]
+                },
+                "uploader_id": {
+                    "description": "@Description The ID of the user who uploaded the file",
+                    "type": "integer",
+                    "example": 2
+                },
+                "width": {
+                    "description": "@Description Image width in pixels, once processed",
+                    "type": "integer",
+                    "example": 3024
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskChecklistItem": {
+            "description": "An item of a task checklist",
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "@Description When the item was marked done, absent while open",
+                    "type": "string",
+                    "example": "2024-03-20T14:10:00Z"
+                },
+                "completed_by": {
+                    "description": "@Description The ID of the user who marked the item done, absent while open",
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "description": "@Description When the item was added",
+                    "type": "string",
+                    "example": "2024-03-20T13:00:00Z"
+                },
+                "done": {
+                    "description": "@Description Whether the item is done",
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the item",
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "description": "@Description Optional remark, e.g. a reading taken",
+                    "type": "string",
+                    "example": "Filters were clogged"
+                },
+                "position": {
+                    "description": "@Description Position of the item in the checklist, starting at 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "required": {
+                    "description": "@Description Whether the task can only be completed once this item is done",
+                    "type": "boolean",
+                    "example": true
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "text": {
+                    "description": "@Description What has to be done",
+                    "type": "string",
+                    "example": "Replace air filters"
+                },
+                "updated_at": {
+                    "description": "@Description When the item was last changed",
+                    "type": "string",
+                    "example": "2024-03-20T14:10:00Z"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskComment": {
+            "description": "A comment on a task",
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "@Description The ID of the user who wrote the comment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "body": {
+                    "description": "@Description The comment text; users are mentioned with @ and their email",
+                    "type": "string",
+                    "example": "@sarah.j@company.com was the filter replaced too?"
+                },
+                "created_at": {
+                    "description": "@Description When the comment was written",
+                    "type": "string",
+                    "example": "2024-03-20T15:00:00Z"
+                },
+                "edited_at": {
+                    "description": "@Description When the comment was last edited, absent if never edited",
+                    "type": "string",
+                    "example": "2024-03-20T15:05:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the comment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskDependency": {
+            "description": "A dependency between two tasks",
+            "type": "object",
+            "properties": {
+                "depends_on_id": {
+                    "description": "@Description The ID of the prerequisite, which has to be completed first",
+                    "type": "integer",
+                    "example": 1
+                },
+                "task_id": {
+                    "description": "@Description The ID of the dependent task",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskDependencyGraph": {
+            "description": "The dependency graph of a task",
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "description": "@Description The dependencies between them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TaskDependency"
+                    }
+                },
+                "nodes": {
+                    "description": "@Description The tasks of the graph",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TaskDependencyNode"
+                    }
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task the graph is about",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskDependencyNode": {
+            "description": "A task of a dependency graph",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "@Description The status of the task",
                     "type": "string",
-                    "example": "Replaced filters and recharged coolant"
+                    "enum": [
+                        "open",
+                        "completed"
+                    ],
+                    "example": "open"
+                },
+                "technician_id": {
+                    "description": "@Description The ID of the technician the task belongs to",
+                    "type": "integer",
+                    "example": 2
                 },
                 "title": {
+                    "description": "@Description The title of the task, absent for tasks of other technicians",
                     "type": "string",
-                    "example": "Fix air conditioning"
+                    "example": "Install rack"
                 }
             }
         },
-        "sword-challenge_internal_models.Notification": {
-            "description": "Notification information",
+        "sword-challenge_internal_models.TaskLocation": {
+            "description": "Where a task was recorded",
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "description": "@Description Accuracy reported by the device, in meters",
+                    "type": "number",
+                    "example": 12
+                },
+                "distance": {
+                    "description": "@Description Distance to the site in meters, absent when unverified",
+                    "type": "integer",
+                    "example": 35
+                },
+                "geofence": {
+                    "description": "@Description inside or outside the geofence of the site, or unverified when the task has no site with coordinates",
+                    "type": "string",
+                    "enum": [
+                        "inside",
+                        "outside",
+                        "unverified"
+                    ],
+                    "example": "inside"
+                },
+                "latitude": {
+                    "description": "@Description Latitude in decimal degrees; absent once past the retention window",
+                    "type": "number",
+                    "example": 38.7199
+                },
+                "longitude": {
+                    "description": "@Description Longitude in decimal degrees; absent once past the retention window",
+                    "type": "number",
+                    "example": -9.1451
+                },
+                "recorded_at": {
+                    "description": "@Description When the location was received",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "reviewed_at": {
+                    "description": "@Description When a location outside the geofence was reviewed",
+                    "type": "string",
+                    "example": "2024-03-21T09:00:00Z"
+                },
+                "reviewed_by": {
+                    "description": "@Description The manager who reviewed a location outside the geofence",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskMaterial": {
+            "description": "A part used on a task",
             "type": "object",
             "properties": {
                 "created_at": {
-                    "description": "@Description When the notification was created",
+                    "description": "@Description When the material was recorded",
                     "type": "string",
                     "example": "2024-03-20T14:30:00Z"
                 },
+                "created_by": {
+                    "description": "@Description The ID of the user who recorded the material, absent once deleted",
+                    "type": "integer",
+                    "example": 2
+                },
                 "id": {
-                    "description": "@Description The unique identifier of the notification",
+                    "description": "@Description The unique identifier of the material line",
                     "type": "integer",
                     "example": 1
                 },
-                "is_read": {
-                    "description": "@Description Whether the notification has been read",
-                    "type": "boolean",
-                    "example": false
+                "name": {
+                    "description": "@Description Name of the part",
+                    "type": "string",
+                    "example": "MERV 13 air filter"
                 },
-                "message": {
-                    "description": "@Description The notification message",
+                "note": {
+                    "description": "@Description Optional remark, e.g. where the part went",
                     "type": "string",
-                    "example": "The tech John Doe performed the task on 2024-03-20 14:30:00"
+                    "example": "Rooftop unit 2"
+                },
+                "part_id": {
+                    "description": "@Description The ID of the part",
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "@Description Quantity used",
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "description": "@Description Stock keeping unit of the part",
+                    "type": "string",
+                    "example": "FLT-MERV13"
                 },
                 "task_id": {
-                    "description": "@Description The ID of the task this notification is about",
+                    "description": "@Description The ID of the task",
                     "type": "integer",
                     "example": 1
+                },
+                "unit": {
+                    "description": "@Description Unit of the quantity",
+                    "type": "string",
+                    "example": "pcs"
                 }
             }
         },
-        "sword-challenge_internal_models.Task": {
-            "description": "Task information",
+        "sword-challenge_internal_models.TaskRevision": {
+            "description": "A stored version of a task",
             "type": "object",
             "properties": {
+                "action": {
+                    "description": "@Description What produced this version",
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update"
+                    ],
+                    "example": "update"
+                },
+                "asset_id": {
+                    "description": "@Description The asset of the task at this revision",
+                    "type": "integer",
+                    "example": 1
+                },
                 "created_at": {
-                    "description": "@Description When the task was created",
+                    "description": "@Description When this version was written",
                     "type": "string",
                     "example": "2024-03-20T14:30:00Z"
                 },
+                "due_at": {
+                    "description": "@Description When the task was due, as of this revision",
+                    "type": "string",
+                    "example": "2024-03-21T14:30:00Z"
+                },
+                "editor_id": {
+                    "description": "@Description The ID of the user who wrote this version",
+                    "type": "integer",
+                    "example": 2
+                },
                 "id": {
-                    "description": "@Description The unique identifier of the task",
+                    "description": "@Description The unique identifier of the revision",
                     "type": "integer",
                     "example": 1
                 },
                 "performed_at": {
-                    "description": "@Description When the task was performed",
+                    "description": "@Description When the task was performed, as of this revision",
                     "type": "string",
                     "example": "2024-03-20T14:30:00Z"
                 },
+                "priority": {
+                    "description": "@Description The priority of the task at this revision; absent for revisions written before it was recorded",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "revision": {
+                    "description": "@Description Sequential revision number within the task, starting at 1",
+                    "type": "integer",
+                    "example": 2
+                },
+                "site_id": {
+                    "description": "@Description The site of the task at this revision",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "@Description The status of the task at this revision",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "completed"
+                    ],
+                    "example": "completed"
+                },
                 "summary": {
-                    "description": "@Description The detailed summary of the task",
+                    "description": "@Description The summary of the task at this revision",
                     "type": "string",
                     "example": "Replaced filters and recharged coolant"
                 },
-                "technician_id": {
-                    "description": "@Description The ID of the technician who performed the task",
+                "tags": {
+                    "description": "@Description The tags of the task at this revision, sorted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
                     "type": "integer",
                     "example": 1
                 },
                 "title": {
-                    "description": "@Description The title of the task",
+                    "description": "@Description The title of the task at this revision",
                     "type": "string",
                     "example": "Fix air conditioning"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskRevisionDiff": {
+            "description": "Differences between two revisions of a task",
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "@Description The changed fields, empty when both revisions are identical",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.FieldChange"
+                    }
                 },
-                "updated_at": {
-                    "description": "@Description When the task was last updated",
+                "from": {
+                    "description": "@Description The older revision",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskRevision"
+                        }
+                    ]
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "to": {
+                    "description": "@Description The newer revision",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.TaskRevision"
+                        }
+                    ]
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskSearchResult": {
+            "description": "A task matching a search, best matches first",
+            "type": "object",
+            "properties": {
+                "score": {
+                    "description": "@Description Relevance reported by the search backend, higher is better",
+                    "type": "number",
+                    "example": 1.42
+                },
+                "snippet": {
+                    "description": "@Description Excerpt of the summary around the first match, HTML-escaped with matches wrapped in \u003cmark\u003e",
+                    "type": "string",
+                    "example": "… replaced the \u003cmark\u003ecompressor\u003c/mark\u003e relay and …"
+                },
+                "task": {
+                    "description": "@Description The matching task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sword-challenge_internal_models.Task"
+                        }
+                    ]
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskTemplate": {
+            "description": "A task template, at its current or a past revision",
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "description": "@Description Checklist copied to created tasks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TaskTemplateChecklistItem"
+                    }
+                },
+                "created_at": {
+                    "description": "@Description When this revision was written",
+                    "type": "string",
+                    "example": "2024-03-20T14:30:00Z"
+                },
+                "editor_id": {
+                    "description": "@Description The ID of the manager who wrote this revision",
+                    "type": "integer",
+                    "example": 1
+                },
+                "estimated_minutes": {
+                    "description": "@Description Expected duration of the job in minutes, 0 when unknown",
+                    "type": "integer",
+                    "example": 45
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the template",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "@Description Name shown when picking a template",
+                    "type": "string",
+                    "example": "HVAC filter replacement"
+                },
+                "revision": {
+                    "description": "@Description The revision number, starting at 1 and incremented on every change",
+                    "type": "integer",
+                    "example": 2
+                },
+                "summary": {
+                    "description": "@Description Default summary of created tasks, in Markdown",
+                    "type": "string",
+                    "example": "Replaced the air filters and checked the airflow."
+                },
+                "tags": {
+                    "description": "@Description Names of tags from the managed vocabulary given to created tasks",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hvac",
+                        "preventive"
+                    ]
+                },
+                "title_pattern": {
+                    "description": "@Description Title of created tasks; {{name}} placeholders are filled from the request variables, {{date}} with the performed_at date",
+                    "type": "string",
+                    "example": "Replace HVAC filters - {{site}}"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskTemplateChecklistItem": {
+            "description": "A checklist item of a task template",
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "@Description Optional remark",
+                    "type": "string",
+                    "example": "Use MERV 13 filters"
+                },
+                "required": {
+                    "description": "@Description Whether created tasks can only be completed once this item is done",
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "description": "@Description What has to be done",
+                    "type": "string",
+                    "example": "Replace air filters"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskTemplateRef": {
+            "description": "The template revision a task was created from",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "@Description The ID of the template",
+                    "type": "integer",
+                    "example": 1
+                },
+                "revision": {
+                    "description": "@Description The template revision",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskTime": {
+            "description": "Time spent by a technician on a task",
+            "type": "object",
+            "properties": {
+                "seconds": {
+                    "description": "@Description Seconds spent in the period",
+                    "type": "integer",
+                    "example": 5400
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "technician_id": {
+                    "description": "@Description The ID of the technician",
+                    "type": "integer",
+                    "example": 2
+                },
+                "technician_name": {
+                    "description": "@Description Name of the technician",
+                    "type": "string",
+                    "example": "Sarah Johnson"
+                },
+                "title": {
+                    "description": "@Description Title of the task",
+                    "type": "string",
+                    "example": "Fix air conditioning"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TaskTimeEntries": {
+            "description": "Time entries of a task",
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "@Description The entries, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TimeEntry"
+                    }
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_seconds": {
+                    "description": "@Description Seconds spent on the task by every technician, running timers included",
+                    "type": "integer",
+                    "example": 9000
+                }
+            }
+        },
+        "sword-challenge_internal_models.TechnicianTime": {
+            "description": "Time spent by a technician",
+            "type": "object",
+            "properties": {
+                "seconds": {
+                    "description": "@Description Seconds spent in the period",
+                    "type": "integer",
+                    "example": 28800
+                },
+                "technician_id": {
+                    "description": "@Description The ID of the technician",
+                    "type": "integer",
+                    "example": 2
+                },
+                "technician_name": {
+                    "description": "@Description Name of the technician",
+                    "type": "string",
+                    "example": "Sarah Johnson"
+                }
+            }
+        },
+        "sword-challenge_internal_models.TimeEntry": {
+            "description": "Time spent on a task",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "@Description When the entry was recorded",
+                    "type": "string",
+                    "example": "2024-03-20T13:00:00Z"
+                },
+                "duration_seconds": {
+                    "description": "@Description Seconds spent, up to now while the timer runs",
+                    "type": "integer",
+                    "example": 5400
+                },
+                "ended_at": {
+                    "description": "@Description When the work ended, absent while the timer runs",
                     "type": "string",
                     "example": "2024-03-20T14:30:00Z"
+                },
+                "id": {
+                    "description": "@Description The unique identifier of the entry",
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "description": "@Description Optional remark on the work done",
+                    "type": "string",
+                    "example": "Replaced the compressor"
+                },
+                "running": {
+                    "description": "@Description Whether the timer is still running",
+                    "type": "boolean",
+                    "example": false
+                },
+                "started_at": {
+                    "description": "@Description When the work started",
+                    "type": "string",
+                    "example": "2024-03-20T13:00:00Z"
+                },
+                "task_id": {
+                    "description": "@Description The ID of the task",
+                    "type": "integer",
+                    "example": 1
+                },
+                "technician_id": {
+                    "description": "@Description The ID of the technician who did the work",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "sword-challenge_internal_models.TimeReport": {
+            "description": "Time tracking report",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "@Description Start of the period (inclusive)",
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "tasks": {
+                    "description": "@Description Time per task and technician",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TaskTime"
+                    }
+                },
+                "technicians": {
+                    "description": "@Description Totals per technician",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sword-challenge_internal_models.TechnicianTime"
+                    }
+                },
+                "to": {
+                    "description": "@Description End of the period (exclusive)",
+                    "type": "string",
+                    "example": "2024-04-01T00:00:00Z"
+                },
+                "total_seconds": {
+                    "description": "@Description Seconds spent by every technician in the report together",
+                    "type": "integer",
+                    "example": 57600
                 }
             }
         }
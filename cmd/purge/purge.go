@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/jobs"
+	"sword-challenge/internal/repository/mysql"
+	"sword-challenge/internal/service"
+)
+
+// One-off run of the retention jobs (read notifications, trashed tasks and
+// the coordinates of task locations), e.g. from a cron or after lowering a
+// retention setting. Each job takes the same leader lock as in the server.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db, err := config.InitDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	notificationRetention := config.NewNotificationRetention()
+	notificationRetentionService := service.NewNotificationRetentionService(mysql.NewNotificationRepository(db), notificationRetention)
+	blobStore, err := config.NewBlobStore(config.NewBlobStorage())
+	if err != nil {
+		log.Fatalf("Failed to open blob storage: %v", err)
+	}
+	taskRetention := config.NewTaskRetention()
+	taskRetentionService := service.NewTaskRetentionService(mysql.NewTaskRepository(db), blobStore, taskRetention)
+	locationRetention := config.NewLocationRetention()
+	locationRetentionService := service.NewLocationRetentionService(mysql.NewTaskRepository(db), locationRetention)
+	scheduler := jobs.NewScheduler(mysql.NewLockRepository(db))
+
+	if err := scheduler.RunOnce(ctx, jobs.NewNotificationPurgeJob(notificationRetentionService, notificationRetention)); err != nil {
+		log.Fatalf("Notification purge failed: %v", err)
+	}
+	if err := scheduler.RunOnce(ctx, jobs.NewTaskPurgeJob(taskRetentionService, taskRetention)); err != nil {
+		log.Fatalf("Task purge failed: %v", err)
+	}
+	if err := scheduler.RunOnce(ctx, jobs.NewLocationPurgeJob(locationRetentionService, locationRetention)); err != nil {
+		log.Fatalf("Location purge failed: %v", err)
+	}
+}
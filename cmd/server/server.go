@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 
 	"sword-challenge/config"
 	"sword-challenge/internal/controllers"
+	"sword-challenge/internal/jobs"
 	"sword-challenge/internal/middleware"
 	"sword-challenge/internal/repository/mysql"
 	"sword-challenge/internal/service"
@@ -50,9 +52,9 @@ func newRouter() *gin.Engine {
 	docs.SwaggerInfo.BasePath = "/"
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "X-User-ID"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "X-User-ID", "If-Match", "If-None-Match"},
+		ExposeHeaders:    []string{"Content-Length", "ETag"},
 		AllowCredentials: true,
 	}))
 
@@ -127,7 +129,12 @@ func newMessageBroker() (messaging.MessageBroker, error) {
 
 // --- Run server lifecycle ---
 
-func runServer(lc fx.Lifecycle, router *gin.Engine, consumer *messaging.NotificationConsumer) {
+func runServer(
+	lc fx.Lifecycle,
+	router *gin.Engine,
+	consumer *messaging.NotificationConsumer,
+	attachmentConsumer *messaging.AttachmentConsumer,
+) {
 	port := config.GetEnv("PORT", "3000")
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -135,6 +142,10 @@ func runServer(lc fx.Lifecycle, router *gin.Engine, consumer *messaging.Notifica
 			if err := consumer.Start(ctx); err != nil {
 				return err
 			}
+			// Start image attachment processing
+			if err := attachmentConsumer.Start(ctx); err != nil {
+				return err
+			}
 
 			go func() {
 				if err := router.Run(":" + port); err != nil {
@@ -150,11 +161,64 @@ func runServer(lc fx.Lifecycle, router *gin.Engine, consumer *messaging.Notifica
 	})
 }
 
+// --- Background jobs ---
+
+func runScheduler(
+	lc fx.Lifecycle,
+	scheduler *jobs.Scheduler,
+	notificationRetentionService *service.NotificationRetentionService,
+	notificationRetention config.NotificationRetention,
+	taskRetentionService *service.TaskRetentionService,
+	taskRetention config.TaskRetention,
+	locationRetentionService *service.LocationRetentionService,
+	locationRetention config.LocationRetention,
+	recurringTaskService *service.RecurringTaskService,
+	recurringTasks config.RecurringTasks,
+	slaService *service.SLAService,
+	sla config.SLA,
+) error {
+	// An interval of 0s or below in the environment fails startup
+	for _, job := range []jobs.Job{
+		jobs.NewNotificationPurgeJob(notificationRetentionService, notificationRetention),
+		jobs.NewTaskPurgeJob(taskRetentionService, taskRetention),
+		jobs.NewLocationPurgeJob(locationRetentionService, locationRetention),
+		jobs.NewRecurringTaskJob(recurringTaskService, recurringTasks),
+		jobs.NewSLAEscalationJob(slaService, sla),
+	} {
+		if err := scheduler.Register(job); err != nil {
+			return err
+		}
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: scheduler.Start,
+		OnStop:  scheduler.Stop,
+	})
+	return nil
+}
+
 // --- Route Registration ---
 
 func registerRoutes(
 	router *gin.Engine,
 	taskController *controllers.TaskController,
+	taskRevisionController *controllers.TaskRevisionController,
+	taskSearchController *controllers.TaskSearchController,
+	taskAttachmentController *controllers.TaskAttachmentController,
+	taskCommentController *controllers.TaskCommentController,
+	taskChecklistController *controllers.TaskChecklistController,
+	taskDependencyController *controllers.TaskDependencyController,
+	timeEntryController *controllers.TimeEntryController,
+	taskMaterialController *controllers.TaskMaterialController,
+	partController *controllers.PartController,
+	customerController *controllers.CustomerController,
+	assetController *controllers.AssetController,
+	assetLabelController *controllers.AssetLabelController,
+	taskTemplateController *controllers.TaskTemplateController,
+	recurringTaskController *controllers.RecurringTaskController,
+	slaController *controllers.SLAController,
+	queueController *controllers.QueueController,
+	tagController *controllers.TagController,
 	notificationController *controllers.NotificationController,
 ) {
 	// Create middleware instances
@@ -165,17 +229,169 @@ func registerRoutes(
 	{
 		// Technician routes - can only access their own tasks
 		tasks.POST("", middleware.RequireRole("technician"), taskController.CreateTask)
+		tasks.POST("/from-template/:id", middleware.RequireRole("technician"), taskTemplateController.CreateTask)
 		tasks.GET("", middleware.RequireRole("technician", "manager"), taskController.GetTasks)    // Both roles can access, but service layer filters results
 		tasks.GET("/:id", middleware.RequireRole("technician", "manager"), taskController.GetTask) // Both roles can access, but service layer filters results
+		tasks.GET("/trash", middleware.RequireRole("manager"), taskController.GetDeletedTasks)
+		tasks.GET("/search", middleware.RequireRole("technician", "manager"), taskSearchController.SearchTasks) // Service layer filters results like GetTasks
 		tasks.PUT("/:id", middleware.RequireRole("technician"), taskController.UpdateTask)
+		tasks.PATCH("/:id", middleware.RequireRole("technician"), taskController.PatchTask)
 		tasks.DELETE("/:id", middleware.RequireRole("manager"), taskController.DeleteTask)
+		tasks.POST("/:id/restore", middleware.RequireRole("manager"), taskController.RestoreTask)
+		tasks.POST("/:id/location/review", middleware.RequireRole("manager"), taskController.ReviewLocation)
+		tasks.GET("/:id/revisions", middleware.RequireRole("technician", "manager"), taskRevisionController.GetRevisions)
+		tasks.GET("/:id/revisions/diff", middleware.RequireRole("technician", "manager"), taskRevisionController.DiffRevisions)
+		tasks.GET("/:id/attachments", middleware.RequireRole("technician", "manager"), taskAttachmentController.GetAttachments)
+		tasks.POST("/:id/attachments", middleware.RequireRole("technician", "manager"), taskAttachmentController.UploadAttachment)
+		tasks.GET("/:id/attachments/:attachmentId", middleware.RequireRole("technician", "manager"), taskAttachmentController.DownloadAttachment)
+		tasks.GET("/:id/attachments/:attachmentId/thumbnails/:size", middleware.RequireRole("technician", "manager"), taskAttachmentController.GetThumbnail)
+		tasks.DELETE("/:id/attachments/:attachmentId", middleware.RequireRole("technician", "manager"), taskAttachmentController.DeleteAttachment)
+		tasks.GET("/:id/comments", middleware.RequireRole("technician", "manager"), taskCommentController.GetComments)
+		tasks.POST("/:id/comments", middleware.RequireRole("technician", "manager"), taskCommentController.CreateComment)
+		tasks.PUT("/:id/comments/:commentId", middleware.RequireRole("technician", "manager"), taskCommentController.UpdateComment)
+		tasks.DELETE("/:id/comments/:commentId", middleware.RequireRole("technician", "manager"), taskCommentController.DeleteComment)
+		tasks.GET("/:id/checklist", middleware.RequireRole("technician", "manager"), taskChecklistController.GetItems)
+		tasks.POST("/:id/checklist", middleware.RequireRole("technician", "manager"), taskChecklistController.CreateItem)
+		tasks.PUT("/:id/checklist/:itemId", middleware.RequireRole("technician", "manager"), taskChecklistController.UpdateItem)
+		tasks.DELETE("/:id/checklist/:itemId", middleware.RequireRole("technician", "manager"), taskChecklistController.DeleteItem)
+		tasks.GET("/:id/dependencies", middleware.RequireRole("technician", "manager"), taskDependencyController.GetGraph)
+		tasks.POST("/:id/dependencies", middleware.RequireRole("technician", "manager"), taskDependencyController.AddDependency)
+		tasks.DELETE("/:id/dependencies/:dependsOnId", middleware.RequireRole("technician", "manager"), taskDependencyController.RemoveDependency)
+		tasks.GET("/:id/time-entries", middleware.RequireRole("technician", "manager"), timeEntryController.GetEntries)
+		tasks.POST("/:id/time-entries", middleware.RequireRole("technician"), timeEntryController.CreateEntry)
+		tasks.POST("/:id/time-entries/start", middleware.RequireRole("technician"), timeEntryController.StartTimer)
+		tasks.POST("/:id/time-entries/stop", middleware.RequireRole("technician"), timeEntryController.StopTimer)
+		tasks.DELETE("/:id/time-entries/:entryId", middleware.RequireRole("technician", "manager"), timeEntryController.DeleteEntry)
+		tasks.GET("/:id/materials", middleware.RequireRole("technician", "manager"), taskMaterialController.GetMaterials)
+		tasks.POST("/:id/materials", middleware.RequireRole("technician", "manager"), taskMaterialController.AddMaterial)
+		tasks.DELETE("/:id/materials/:materialId", middleware.RequireRole("technician", "manager"), taskMaterialController.RemoveMaterial)
+	}
+
+	templates := router.Group("/api/task-templates")
+	templates.Use(authMiddleware)
+	{
+		// Everyone reads templates; only managers change them
+		templates.GET("", middleware.RequireRole("technician", "manager"), taskTemplateController.GetTemplates)
+		templates.GET("/:id", middleware.RequireRole("technician", "manager"), taskTemplateController.GetTemplate)
+		templates.GET("/:id/revisions/:revision", middleware.RequireRole("technician", "manager"), taskTemplateController.GetTemplateRevision)
+		templates.POST("", middleware.RequireRole("manager"), taskTemplateController.CreateTemplate)
+		templates.PUT("/:id", middleware.RequireRole("manager"), taskTemplateController.UpdateTemplate)
+		templates.DELETE("/:id", middleware.RequireRole("manager"), taskTemplateController.DeleteTemplate)
+	}
+
+	recurringTasks := router.Group("/api/recurring-tasks")
+	recurringTasks.Use(authMiddleware)
+	{
+		// Technicians see the recurring tasks assigned to them; only managers change them
+		recurringTasks.GET("", middleware.RequireRole("technician", "manager"), recurringTaskController.GetRecurringTasks)
+		recurringTasks.GET("/:id", middleware.RequireRole("technician", "manager"), recurringTaskController.GetRecurringTask)
+		recurringTasks.GET("/:id/occurrences", middleware.RequireRole("technician", "manager"), recurringTaskController.GetOccurrences)
+		recurringTasks.POST("", middleware.RequireRole("manager"), recurringTaskController.CreateRecurringTask)
+		recurringTasks.PUT("/:id", middleware.RequireRole("manager"), recurringTaskController.UpdateRecurringTask)
+		recurringTasks.DELETE("/:id", middleware.RequireRole("manager"), recurringTaskController.DeleteRecurringTask)
+	}
+
+	queue := router.Group("/api/queue")
+	queue.Use(authMiddleware)
+	{
+		// Technicians see and claim their own queue; managers see every queue
+		queue.GET("", middleware.RequireRole("technician", "manager"), queueController.GetQueue)
+		queue.POST("/claim", middleware.RequireRole("technician"), queueController.ClaimNext)
+		queue.POST("/:id/release", middleware.RequireRole("technician"), queueController.Release)
+	}
+
+	timeEntries := router.Group("/api/time-entries")
+	timeEntries.Use(authMiddleware)
+	{
+		timeEntries.GET("/report", middleware.RequireRole("technician", "manager"), timeEntryController.GetReport) // Technicians only get their own figures
+	}
+
+	parts := router.Group("/api/parts")
+	parts.Use(authMiddleware)
+	{
+		// Everyone reads the catalog; only managers change it and move stock by hand
+		parts.GET("", middleware.RequireRole("technician", "manager"), partController.GetParts)
+		parts.GET("/:id", middleware.RequireRole("technician", "manager"), partController.GetPart)
+		parts.POST("", middleware.RequireRole("manager"), partController.CreatePart)
+		parts.PUT("/:id", middleware.RequireRole("manager"), partController.UpdatePart)
+		parts.DELETE("/:id", middleware.RequireRole("manager"), partController.DeletePart)
+		parts.POST("/:id/stock", middleware.RequireRole("manager"), partController.MoveStock)
+		parts.GET("/:id/movements", middleware.RequireRole("manager"), partController.GetMovements)
+	}
+
+	customers := router.Group("/api/customers")
+	customers.Use(authMiddleware)
+	{
+		// Everyone reads customers, sites and assets; only managers change them
+		customers.GET("", middleware.RequireRole("technician", "manager"), customerController.GetCustomers)
+		customers.GET("/:id", middleware.RequireRole("technician", "manager"), customerController.GetCustomer)
+		customers.POST("", middleware.RequireRole("manager"), customerController.CreateCustomer)
+		customers.PUT("/:id", middleware.RequireRole("manager"), customerController.UpdateCustomer)
+		customers.DELETE("/:id", middleware.RequireRole("manager"), customerController.DeleteCustomer)
+	}
+
+	sites := router.Group("/api/sites")
+	sites.Use(authMiddleware)
+	{
+		sites.GET("", middleware.RequireRole("technician", "manager"), customerController.GetSites)
+		sites.GET("/:id", middleware.RequireRole("technician", "manager"), customerController.GetSite)
+		sites.POST("", middleware.RequireRole("manager"), customerController.CreateSite)
+		sites.PUT("/:id", middleware.RequireRole("manager"), customerController.UpdateSite)
+		sites.DELETE("/:id", middleware.RequireRole("manager"), customerController.DeleteSite)
+	}
+
+	assets := router.Group("/api/assets")
+	assets.Use(authMiddleware)
+	{
+		assets.GET("", middleware.RequireRole("technician", "manager"), assetController.GetAssets)
+		assets.GET("/:id", middleware.RequireRole("technician", "manager"), assetController.GetAsset)
+		assets.POST("", middleware.RequireRole("manager"), assetController.CreateAsset)
+		assets.PUT("/:id", middleware.RequireRole("manager"), assetController.UpdateAsset)
+		assets.DELETE("/:id", middleware.RequireRole("manager"), assetController.DeleteAsset)
+		assets.GET("/:id/tasks", middleware.RequireRole("technician", "manager"), assetController.GetHistory) // Technicians only get their own tasks
+		assets.GET("/:id/label", middleware.RequireRole("manager"), assetLabelController.GetLabel)
+		assets.GET("/labels", middleware.RequireRole("manager"), assetLabelController.GetLabelSheet)
+		assets.GET("/resolve", middleware.RequireRole("technician", "manager"), assetLabelController.Resolve) // Scanned labels; technicians only get their own tasks
+	}
+
+	sla := router.Group("/api/sla")
+	sla.Use(authMiddleware)
+	{
+		// Everyone reads the policies; only managers change them
+		sla.GET("/policies", middleware.RequireRole("technician", "manager"), slaController.GetPolicies)
+		sla.GET("/policies/:id", middleware.RequireRole("technician", "manager"), slaController.GetPolicy)
+		sla.POST("/policies", middleware.RequireRole("manager"), slaController.CreatePolicy)
+		sla.PUT("/policies/:id", middleware.RequireRole("manager"), slaController.UpdatePolicy)
+		sla.DELETE("/policies/:id", middleware.RequireRole("manager"), slaController.DeletePolicy)
+		sla.GET("/report", middleware.RequireRole("technician", "manager"), slaController.GetReport) // Technicians only get their own figures
+	}
+
+	tags := router.Group("/api/tags")
+	tags.Use(authMiddleware)
+	{
+		// Everyone reads the vocabulary; only managers change it
+		tags.GET("", middleware.RequireRole("technician", "manager"), tagController.GetTags)
+		tags.GET("/counts", middleware.RequireRole("technician", "manager"), tagController.GetTagCounts) // Service layer filters counts like GetTasks
+		tags.POST("", middleware.RequireRole("manager"), tagController.CreateTag)
+		tags.PUT("/:id", middleware.RequireRole("manager"), tagController.UpdateTag)
+		tags.DELETE("/:id", middleware.RequireRole("manager"), tagController.DeleteTag)
 	}
 
 	notifications := router.Group("/api/notifications")
 	notifications.Use(authMiddleware)
 	{
-		notifications.GET("", middleware.RequireRole("manager"), notificationController.GetUnreadNotifications)
-		notifications.PUT("/:id/read", middleware.RequireRole("manager"), notificationController.MarkAsRead)
+		// Technicians only see notifications addressed to them; the service layer filters results
+		notifications.GET("", middleware.RequireRole("technician", "manager"), notificationController.ListNotifications)
+		notifications.GET("/:id", middleware.RequireRole("technician", "manager"), notificationController.GetNotification)
+		notifications.PUT("/:id/read", middleware.RequireRole("technician", "manager"), notificationController.MarkAsRead)
+	}
+
+	debug := router.Group("/debug")
+	debug.Use(authMiddleware)
+	{
+		// Runtime and job metrics (expvar JSON); they expose memory stats and
+		// the command line, so only managers read them
+		debug.GET("/vars", middleware.RequireRole("manager"), gin.WrapH(expvar.Handler()))
 	}
 }
 
@@ -186,19 +402,86 @@ func main() {
 		// Providers
 		fx.Provide(
 			config.InitDB,
+			config.NewNotificationRetention,
+			config.NewTaskRetention,
+			config.NewLocationRetention,
+			config.NewRecurringTasks,
+			config.NewSLA,
+			config.NewBlobStorage,
+			config.NewBlobStore,
+			config.NewAttachmentLimits,
+			config.NewAssetLabels,
+			config.NewLabelSigner,
 			mysql.NewUserRepository,
 			mysql.NewTaskRepository,
+			mysql.NewTaskRevisionRepository,
+			mysql.NewTaskSearchRepository,
+			mysql.NewTaskAttachmentRepository,
+			mysql.NewTaskCommentRepository,
+			mysql.NewTaskChecklistRepository,
+			mysql.NewTaskDependencyRepository,
+			mysql.NewTimeEntryRepository,
+			mysql.NewPartRepository,
+			mysql.NewTaskMaterialRepository,
+			mysql.NewCustomerRepository,
+			mysql.NewSiteRepository,
+			mysql.NewAssetRepository,
+			mysql.NewTaskTemplateRepository,
+			mysql.NewRecurringTaskRepository,
+			mysql.NewSLARepository,
+			mysql.NewQueueRepository,
+			mysql.NewTagRepository,
 			mysql.NewNotificationRepository,
+			mysql.NewLockRepository,
 			newMessageBroker,
 			service.NewTaskService,
+			service.NewTaskRevisionService,
+			service.NewTaskSearchService,
+			service.NewTaskAttachmentService,
+			service.NewTaskCommentService,
+			service.NewTaskChecklistService,
+			service.NewTaskDependencyService,
+			service.NewTimeEntryService,
+			service.NewPartService,
+			service.NewTaskMaterialService,
+			service.NewCustomerService,
+			service.NewAssetService,
+			service.NewAssetLabelService,
+			service.NewTaskTemplateService,
+			service.NewRecurringTaskService,
+			service.NewSLAService,
+			service.NewQueueService,
+			service.NewTagService,
 			service.NewNotificationService,
+			service.NewNotificationRetentionService,
+			service.NewTaskRetentionService,
+			service.NewLocationRetentionService,
+			jobs.NewScheduler,
 			controllers.NewTaskController,
+			controllers.NewTaskRevisionController,
+			controllers.NewTaskSearchController,
+			controllers.NewTaskAttachmentController,
+			controllers.NewTaskCommentController,
+			controllers.NewTaskChecklistController,
+			controllers.NewTaskDependencyController,
+			controllers.NewTimeEntryController,
+			controllers.NewPartController,
+			controllers.NewTaskMaterialController,
+			controllers.NewCustomerController,
+			controllers.NewAssetController,
+			controllers.NewAssetLabelController,
+			controllers.NewTaskTemplateController,
+			controllers.NewRecurringTaskController,
+			controllers.NewSLAController,
+			controllers.NewQueueController,
+			controllers.NewTagController,
 			controllers.NewNotificationController,
 			newRouter,
 			messaging.NewNotificationConsumer,
+			messaging.NewAttachmentConsumer,
 		),
 		// Invokes
-		fx.Invoke(registerRoutes, runServer),
+		fx.Invoke(registerRoutes, runServer, runScheduler),
 	)
 
 	app.Run()
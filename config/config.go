@@ -3,8 +3,11 @@ package config
 import (
 	"database/sql"
 	"fmt"
+	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -108,3 +111,37 @@ func GetEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// GetEnvInt returns an integer environment variable, or defaultValue when it is unset or invalid
+func GetEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		if os.Getenv(key) != "" {
+			log.Printf("Invalid value for %s, using default %d", key, defaultValue)
+		}
+		return defaultValue
+	}
+	return value
+}
+
+// GetEnvDuration returns a duration environment variable (e.g. "90m", "720h"),
+// or defaultValue when it is unset or invalid
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		if os.Getenv(key) != "" {
+			log.Printf("Invalid value for %s, using default %s", key, defaultValue)
+		}
+		return defaultValue
+	}
+	return value
+}
+
+// GetEnvBool returns a boolean environment variable, or defaultValue when it is unset or invalid
+func GetEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
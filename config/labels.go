@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+
+	"sword-challenge/internal/labels"
+)
+
+// AssetLabels configures the QR code labels stuck on assets
+type AssetLabels struct {
+	// BaseURL is the deep link labels open; the signed token is added as the
+	// token query parameter
+	BaseURL string
+	// Secret signs label tokens; changing it voids every printed label
+	Secret string
+}
+
+func NewAssetLabels() AssetLabels {
+	return AssetLabels{
+		BaseURL: GetEnv("ASSET_LABEL_BASE_URL", "http://localhost:3000/scan"),
+		Secret:  GetEnv("ASSET_LABEL_SECRET", os.Getenv("JWT_SECRET")),
+	}
+}
+
+// NewLabelSigner makes the signer of label tokens
+func NewLabelSigner(cfg AssetLabels) (*labels.Signer, error) {
+	if len(cfg.Secret) < 32 {
+		return nil, &ConfigError{Message: "asset label secret must be at least 32 characters"}
+	}
+	return labels.NewSigner([]byte(cfg.Secret)), nil
+}
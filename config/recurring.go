@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// RecurringTasks controls how far ahead the scheduler creates the tasks of
+// recurring task definitions
+type RecurringTasks struct {
+	// Horizon is how far ahead of now occurrences are created as tasks
+	Horizon time.Duration
+	// Interval is how often the scheduler job runs
+	Interval time.Duration
+	// BatchSize is the most tasks created per definition in one run
+	BatchSize int
+}
+
+func NewRecurringTasks() RecurringTasks {
+	return RecurringTasks{
+		Horizon:   GetEnvDuration("RECURRING_TASK_HORIZON", 14*24*time.Hour),
+		Interval:  GetEnvDuration("RECURRING_TASK_INTERVAL", 15*time.Minute),
+		BatchSize: GetEnvInt("RECURRING_TASK_BATCH_SIZE", 100),
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import "time"
+
+// NotificationRetention controls how long read notifications are kept
+type NotificationRetention struct {
+	// MaxAge is how old a read notification must be to be purged
+	MaxAge time.Duration
+	// Interval is how often the purge job runs
+	Interval time.Duration
+	// BatchSize is the number of rows removed per transaction
+	BatchSize int
+	// Archive copies rows to notifications_archive instead of only deleting them
+	Archive bool
+}
+
+func NewNotificationRetention() NotificationRetention {
+	return NotificationRetention{
+		MaxAge:    GetEnvDuration("NOTIFICATION_RETENTION", 90*24*time.Hour),
+		Interval:  GetEnvDuration("NOTIFICATION_PURGE_INTERVAL", time.Hour),
+		BatchSize: GetEnvInt("NOTIFICATION_PURGE_BATCH_SIZE", 500),
+		Archive:   GetEnvBool("NOTIFICATION_PURGE_ARCHIVE", true),
+	}
+}
+
+// TaskRetention controls how long deleted tasks stay in the trash
+type TaskRetention struct {
+	// MaxAge is how long a task stays restorable after being deleted
+	MaxAge time.Duration
+	// Interval is how often the purge job runs
+	Interval time.Duration
+	// BatchSize is the number of tasks removed per transaction
+	BatchSize int
+}
+
+func NewTaskRetention() TaskRetention {
+	return TaskRetention{
+		MaxAge:    GetEnvDuration("TASK_TRASH_RETENTION", 30*24*time.Hour),
+		Interval:  GetEnvDuration("TASK_PURGE_INTERVAL", time.Hour),
+		BatchSize: GetEnvInt("TASK_PURGE_BATCH_SIZE", 100),
+	}
+}
+
+// LocationRetention controls how long the coordinates of task locations are
+// kept; the geofence outcome stays with the task
+type LocationRetention struct {
+	// MaxAge is how old a location must be to have its coordinates cleared
+	MaxAge time.Duration
+	// Interval is how often the purge job runs
+	Interval time.Duration
+	// BatchSize is the number of locations cleared per statement
+	BatchSize int
+}
+
+func NewLocationRetention() LocationRetention {
+	return LocationRetention{
+		MaxAge:    GetEnvDuration("TASK_LOCATION_RETENTION", 30*24*time.Hour),
+		Interval:  GetEnvDuration("TASK_LOCATION_PURGE_INTERVAL", time.Hour),
+		BatchSize: GetEnvInt("TASK_LOCATION_PURGE_BATCH_SIZE", 500),
+	}
+}
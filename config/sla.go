@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+// SLA controls the evaluator that escalates tasks at risk of missing, or past,
+// their due date
+type SLA struct {
+	// Interval is how often the evaluator job runs
+	Interval time.Duration
+	// BatchSize is how many tasks are escalated per query
+	BatchSize int
+}
+
+func NewSLA() SLA {
+	return SLA{
+		Interval:  GetEnvDuration("SLA_EVALUATION_INTERVAL", time.Minute),
+		BatchSize: GetEnvInt("SLA_EVALUATION_BATCH_SIZE", 100),
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-challenge/pkg/storage"
+)
+
+// Blob storage backends
+const (
+	BlobBackendLocal = "local"
+	BlobBackendS3    = "s3"
+)
+
+// BlobStorage selects where attachment files are kept
+type BlobStorage struct {
+	// Backend is "local" or "s3"
+	Backend string
+	// LocalPath is the root directory of the local backend
+	LocalPath string
+	// S3 settings, used by the s3 backend (any S3-compatible endpoint)
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+func NewBlobStorage() BlobStorage {
+	return BlobStorage{
+		Backend:     GetEnv("BLOB_BACKEND", BlobBackendLocal),
+		LocalPath:   GetEnv("BLOB_LOCAL_PATH", "data/blobs"),
+		S3Endpoint:  GetEnv("BLOB_S3_ENDPOINT", ""),
+		S3Region:    GetEnv("BLOB_S3_REGION", "us-east-1"),
+		S3Bucket:    GetEnv("BLOB_S3_BUCKET", ""),
+		S3AccessKey: GetEnv("BLOB_S3_ACCESS_KEY", ""),
+		S3SecretKey: GetEnv("BLOB_S3_SECRET_KEY", ""),
+	}
+}
+
+// NewBlobStore opens the configured blob storage backend
+func NewBlobStore(cfg BlobStorage) (storage.BlobStore, error) {
+	switch cfg.Backend {
+	case BlobBackendLocal:
+		return storage.NewLocalStore(cfg.LocalPath)
+	case BlobBackendS3:
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_BACKEND %q", cfg.Backend)
+	}
+}
+
+// AttachmentLimits restricts what can be uploaded to a task
+type AttachmentLimits struct {
+	// MaxSize is the largest accepted file, in bytes
+	MaxSize int64
+	// AllowedTypes lists the accepted MIME types, detected from the content
+	AllowedTypes []string
+}
+
+func NewAttachmentLimits() AttachmentLimits {
+	types := strings.Split(GetEnv("ATTACHMENT_ALLOWED_TYPES", "image/jpeg,image/png,image/webp,application/pdf"), ",")
+	for i := range types {
+		types[i] = strings.TrimSpace(types[i])
+	}
+	return AttachmentLimits{
+		MaxSize:      int64(GetEnvInt("ATTACHMENT_MAX_SIZE", 10<<20)),
+		AllowedTypes: types,
+	}
+}
@@ -0,0 +1,10 @@
+-- Per-user locale and timezone used to render notifications
+ALTER TABLE `users`
+  ADD COLUMN `locale` varchar(10) NOT NULL DEFAULT 'en' AFTER `role`,
+  ADD COLUMN `timezone` varchar(64) NOT NULL DEFAULT 'UTC' AFTER `locale`;
+
+-- Template key and parameters so notifications can be re-rendered.
+-- Existing rows keep template_key NULL and are served with their stored message.
+ALTER TABLE `notifications`
+  ADD COLUMN `template_key` varchar(100) DEFAULT NULL AFTER `message`,
+  ADD COLUMN `params` json DEFAULT NULL AFTER `template_key`;
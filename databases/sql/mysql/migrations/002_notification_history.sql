@@ -0,0 +1,2 @@
+-- Supports status-filtered, id-ordered notification listings
+ALTER TABLE `notifications` ADD KEY `is_read_id` (`is_read`, `id`);
@@ -0,0 +1,13 @@
+-- Read notifications moved out by the retention job
+CREATE TABLE `notifications_archive` (
+  `id` bigint NOT NULL,
+  `task_id` bigint NOT NULL,
+  `message` text NOT NULL,
+  `template_key` varchar(100) DEFAULT NULL,
+  `params` json DEFAULT NULL,
+  `is_read` tinyint(1) DEFAULT '0',
+  `created_at` timestamp NULL DEFAULT NULL,
+  `archived_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `task_id` (`task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
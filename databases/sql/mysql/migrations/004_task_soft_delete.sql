@@ -0,0 +1,4 @@
+-- Deleted tasks go to the trash instead of being removed with their notifications
+ALTER TABLE `tasks`
+  ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL AFTER `updated_at`,
+  ADD KEY `deleted_at` (`deleted_at`);
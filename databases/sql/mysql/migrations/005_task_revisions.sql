@@ -0,0 +1,22 @@
+-- Every version of a task, written in the same transaction as the task
+CREATE TABLE `task_revisions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `revision` int NOT NULL,
+  `editor_id` bigint NOT NULL,
+  `action` enum('create','update') NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `performed_at` timestamp NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `task_revision` (`task_id`, `revision`),
+  KEY `editor_id` (`editor_id`),
+  CONSTRAINT `task_revisions_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_revisions_ibfk_2` FOREIGN KEY (`editor_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- Existing tasks start with their current content as revision 1. Earlier
+-- edits were not recorded, so updated_at is the best known write time.
+INSERT INTO `task_revisions` (`task_id`, `revision`, `editor_id`, `action`, `title`, `summary`, `performed_at`, `created_at`)
+SELECT `id`, 1, `technician_id`, 'create', `title`, `summary`, `performed_at`, COALESCE(`updated_at`, `created_at`) FROM `tasks`;
@@ -0,0 +1,2 @@
+-- Optimistic concurrency: every update bumps the version and must match it
+ALTER TABLE `tasks` ADD COLUMN `version` int NOT NULL DEFAULT '1' AFTER `performed_at`;
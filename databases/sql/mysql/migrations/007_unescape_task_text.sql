@@ -0,0 +1,16 @@
+-- Tasks used to be HTML-escaped before saving. Text is now stored raw; undo
+-- exactly one level of escaping. Rows that hold "&amp;lt;" become "&lt;", since
+-- there is no telling an entity escaped by an edit from one typed on purpose.
+-- &amp; goes last so "&amp;lt;" is not un-escaped twice.
+UPDATE `tasks` SET
+  `title` = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(`title`, '&lt;', '<'), '&gt;', '>'), '&quot;', '"'), '&#34;', '"'), '&#39;', ''''), '&amp;', '&'),
+  `summary` = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(`summary`, '&lt;', '<'), '&gt;', '>'), '&quot;', '"'), '&#34;', '"'), '&#39;', ''''), '&amp;', '&'),
+  -- Clients holding the escaped text must not get a 304 for it
+  `version` = `version` + 1,
+  `updated_at` = `updated_at`
+WHERE `title` REGEXP '&(lt|gt|amp|quot|#39|#34);' OR `summary` REGEXP '&(lt|gt|amp|quot|#39|#34);';
+
+UPDATE `task_revisions` SET
+  `title` = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(`title`, '&lt;', '<'), '&gt;', '>'), '&quot;', '"'), '&#34;', '"'), '&#39;', ''''), '&amp;', '&'),
+  `summary` = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(`summary`, '&lt;', '<'), '&gt;', '>'), '&quot;', '"'), '&#34;', '"'), '&#39;', ''''), '&amp;', '&')
+WHERE `title` REGEXP '&(lt|gt|amp|quot|#39|#34);' OR `summary` REGEXP '&(lt|gt|amp|quot|#39|#34);';
@@ -0,0 +1,2 @@
+-- Full-text search over task titles and summaries (GET /api/tasks/search)
+ALTER TABLE `tasks` ADD FULLTEXT KEY `title_summary` (`title`, `summary`);
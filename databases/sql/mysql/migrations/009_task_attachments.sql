@@ -0,0 +1,18 @@
+-- Files attached to tasks; the content lives in the blob store (BLOB_BACKEND)
+CREATE TABLE `task_attachments` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `uploader_id` bigint NOT NULL,
+  `file_name` varchar(255) NOT NULL,
+  `content_type` varchar(100) NOT NULL,
+  `size_bytes` bigint NOT NULL,
+  `checksum_sha256` char(64) NOT NULL,
+  `storage_key` varchar(255) NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `storage_key` (`storage_key`),
+  KEY `task_id` (`task_id`),
+  KEY `uploader_id` (`uploader_id`),
+  CONSTRAINT `task_attachments_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_attachments_ibfk_2` FOREIGN KEY (`uploader_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
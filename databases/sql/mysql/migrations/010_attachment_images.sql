@@ -0,0 +1,7 @@
+-- Image attachments are processed in the background: metadata stripped,
+-- thumbnails made, dimensions and EXIF capture time recorded
+ALTER TABLE `task_attachments`
+  ADD COLUMN `width` int DEFAULT NULL AFTER `storage_key`,
+  ADD COLUMN `height` int DEFAULT NULL AFTER `width`,
+  ADD COLUMN `captured_at` timestamp NULL DEFAULT NULL AFTER `height`,
+  ADD COLUMN `processed_at` timestamp NULL DEFAULT NULL AFTER `captured_at`;
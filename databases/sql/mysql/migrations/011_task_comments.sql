@@ -0,0 +1,23 @@
+-- Comments on tasks, and notifications addressed to a single user (@mentions).
+-- Notifications without a recipient are shared by every manager, as before.
+CREATE TABLE `task_comments` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `author_id` bigint NOT NULL,
+  `body` text NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `edited_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `task_id_id` (`task_id`, `id`),
+  KEY `author_id` (`author_id`),
+  CONSTRAINT `task_comments_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_comments_ibfk_2` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+ALTER TABLE `notifications`
+  ADD COLUMN `recipient_id` bigint DEFAULT NULL AFTER `task_id`,
+  ADD KEY `recipient_id_id` (`recipient_id`, `id`),
+  ADD CONSTRAINT `notifications_ibfk_2` FOREIGN KEY (`recipient_id`) REFERENCES `users` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `notifications_archive`
+  ADD COLUMN `recipient_id` bigint DEFAULT NULL AFTER `task_id`;
@@ -0,0 +1,24 @@
+-- Task status and ordered checklist items. Tasks recorded so far were already
+-- performed, so they start as completed.
+ALTER TABLE `tasks` ADD COLUMN `status` enum('open','completed') NOT NULL DEFAULT 'completed' AFTER `performed_at`;
+
+ALTER TABLE `task_revisions` ADD COLUMN `status` enum('open','completed') NOT NULL DEFAULT 'completed' AFTER `performed_at`;
+
+CREATE TABLE `task_checklist_items` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `position` int NOT NULL,
+  `text` varchar(255) NOT NULL,
+  `required` tinyint(1) NOT NULL DEFAULT '0',
+  `done` tinyint(1) NOT NULL DEFAULT '0',
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `completed_by` bigint DEFAULT NULL,
+  `completed_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `task_id_position` (`task_id`, `position`),
+  KEY `completed_by` (`completed_by`),
+  CONSTRAINT `task_checklist_items_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_checklist_items_ibfk_2` FOREIGN KEY (`completed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,34 @@
+-- Due dates and SLA tracking. Policies give tasks of a tag a resolution time;
+-- escalations notify the technician's manager, or every manager when the
+-- technician has none.
+ALTER TABLE `users`
+  ADD COLUMN `manager_id` bigint DEFAULT NULL AFTER `role`,
+  ADD KEY `manager_id` (`manager_id`),
+  ADD CONSTRAINT `users_ibfk_1` FOREIGN KEY (`manager_id`) REFERENCES `users` (`id`) ON DELETE SET NULL;
+
+CREATE TABLE `sla_policies` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `tag_id` bigint NOT NULL,
+  `resolution_minutes` int NOT NULL,
+  `at_risk_minutes` int NOT NULL DEFAULT '0',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `tag_id` (`tag_id`),
+  CONSTRAINT `sla_policies_ibfk_1` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+ALTER TABLE `tasks`
+  ADD COLUMN `due_at` timestamp NULL DEFAULT NULL AFTER `scheduled_for`,
+  ADD COLUMN `at_risk_at` timestamp NULL DEFAULT NULL AFTER `due_at`,
+  ADD COLUMN `sla_policy_id` bigint DEFAULT NULL AFTER `at_risk_at`,
+  ADD COLUMN `sla_escalation` enum('none','at_risk','breached') NOT NULL DEFAULT 'none' AFTER `sla_policy_id`,
+  ADD COLUMN `completed_at` timestamp NULL DEFAULT NULL AFTER `sla_escalation`,
+  ADD KEY `sla_escalation` (`status`, `sla_escalation`, `at_risk_at`),
+  ADD KEY `due_at` (`due_at`),
+  ADD KEY `sla_policy_id` (`sla_policy_id`),
+  ADD CONSTRAINT `tasks_ibfk_4` FOREIGN KEY (`sla_policy_id`) REFERENCES `sla_policies` (`id`) ON DELETE SET NULL;
+
+-- Completed tasks have no completion time; the last update is the closest
+UPDATE `tasks` SET `completed_at` = `updated_at` WHERE `status` = 'completed';
@@ -0,0 +1,9 @@
+-- Task priority and the dispatcher work queue. A claimed task leaves the
+-- queue; claims are released when the technician is deleted.
+ALTER TABLE `tasks`
+  ADD COLUMN `priority` enum('low','normal','high','urgent') NOT NULL DEFAULT 'normal' AFTER `status`,
+  ADD COLUMN `claimed_by` bigint DEFAULT NULL AFTER `completed_at`,
+  ADD COLUMN `claimed_at` timestamp NULL DEFAULT NULL AFTER `claimed_by`,
+  ADD KEY `queue` (`status`, `claimed_by`, `priority`, `due_at`),
+  ADD KEY `claimed_by` (`claimed_by`),
+  ADD CONSTRAINT `tasks_ibfk_5` FOREIGN KEY (`claimed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL;
@@ -0,0 +1,20 @@
+-- Time entries logged on tasks. A running timer has no end; a technician runs
+-- at most one at a time.
+
+CREATE TABLE `time_entries` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `technician_id` bigint NOT NULL,
+  `started_at` timestamp NOT NULL,
+  `ended_at` timestamp NULL DEFAULT NULL,
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `running_technician_id` bigint GENERATED ALWAYS AS (IF(`ended_at` IS NULL, `technician_id`, NULL)) STORED,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `running_technician_id` (`running_technician_id`),
+  KEY `technician_started_at` (`technician_id`, `started_at`),
+  KEY `task_id_started_at` (`task_id`, `started_at`),
+  CONSTRAINT `time_entries_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `time_entries_ibfk_2` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
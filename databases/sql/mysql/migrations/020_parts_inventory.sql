@@ -0,0 +1,55 @@
+-- Parts catalog, materials used on tasks and the stock ledger. Stock only
+-- changes together with a stock_movements row.
+
+CREATE TABLE `parts` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `sku` varchar(64) NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `unit` varchar(20) NOT NULL DEFAULT 'pcs',
+  `stock` int NOT NULL DEFAULT '0',
+  `low_stock_threshold` int NOT NULL DEFAULT '0',
+  `active_sku` varchar(64) GENERATED ALWAYS AS (IF(`deleted_at` IS NULL, `sku`, NULL)) STORED,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `active_sku` (`active_sku`),
+  KEY `deleted_at` (`deleted_at`),
+  CONSTRAINT `parts_chk_1` CHECK (`stock` >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_materials` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `part_id` bigint NOT NULL,
+  `quantity` int NOT NULL,
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `created_by` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `task_id` (`task_id`),
+  KEY `part_id` (`part_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `task_materials_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_materials_ibfk_2` FOREIGN KEY (`part_id`) REFERENCES `parts` (`id`),
+  CONSTRAINT `task_materials_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `stock_movements` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `part_id` bigint NOT NULL,
+  `task_id` bigint DEFAULT NULL,
+  `quantity` int NOT NULL,
+  `balance` int NOT NULL,
+  `reason` enum('restock','consumption','return','adjustment') NOT NULL,
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `created_by` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `part_id_id` (`part_id`, `id`),
+  KEY `task_id` (`task_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `stock_movements_ibfk_1` FOREIGN KEY (`part_id`) REFERENCES `parts` (`id`),
+  CONSTRAINT `stock_movements_ibfk_2` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `stock_movements_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,59 @@
+-- Customers, their sites and the assets installed there. Tasks can be linked
+-- to a site and one of its assets; existing tasks have neither.
+
+CREATE TABLE `customers` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `email` varchar(255) NOT NULL DEFAULT '',
+  `phone` varchar(32) NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `sites` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `customer_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `address` varchar(500) NOT NULL,
+  `city` varchar(100) NOT NULL DEFAULT '',
+  `postal_code` varchar(20) NOT NULL DEFAULT '',
+  `country` char(2) NOT NULL DEFAULT '',
+  `latitude` double DEFAULT NULL,
+  `longitude` double DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `customer_id` (`customer_id`, `deleted_at`),
+  CONSTRAINT `sites_ibfk_1` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`),
+  CONSTRAINT `sites_chk_1` CHECK ((`latitude` IS NULL) = (`longitude` IS NULL)),
+  CONSTRAINT `sites_chk_2` CHECK (`latitude` BETWEEN -90 AND 90 AND `longitude` BETWEEN -180 AND 180)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `assets` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `site_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `serial_number` varchar(100) NOT NULL,
+  `model` varchar(255) NOT NULL DEFAULT '',
+  `installed_on` date DEFAULT NULL,
+  `active_serial_number` varchar(100) GENERATED ALWAYS AS (IF(`deleted_at` IS NULL, `serial_number`, NULL)) STORED,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `model_serial_number` (`model`, `active_serial_number`),
+  KEY `site_id` (`site_id`, `deleted_at`),
+  CONSTRAINT `assets_ibfk_1` FOREIGN KEY (`site_id`) REFERENCES `sites` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+ALTER TABLE `tasks`
+  ADD COLUMN `site_id` bigint DEFAULT NULL AFTER `claimed_at`,
+  ADD COLUMN `asset_id` bigint DEFAULT NULL AFTER `site_id`,
+  ADD KEY `site_id` (`site_id`),
+  ADD KEY `asset_history` (`asset_id`, `performed_at`),
+  ADD CONSTRAINT `tasks_ibfk_6` FOREIGN KEY (`site_id`) REFERENCES `sites` (`id`),
+  ADD CONSTRAINT `tasks_ibfk_7` FOREIGN KEY (`asset_id`) REFERENCES `assets` (`id`);
@@ -0,0 +1,24 @@
+-- Where technicians were when they recorded a task, checked against a
+-- geofence around the task's site. The coordinates are cleared once past the
+-- retention window; the outcome of the check is kept.
+
+ALTER TABLE `sites`
+  ADD COLUMN `geofence_radius` int NOT NULL DEFAULT 200 AFTER `longitude`;
+
+CREATE TABLE `task_locations` (
+  `task_id` bigint NOT NULL,
+  `latitude` double DEFAULT NULL,
+  `longitude` double DEFAULT NULL,
+  `accuracy` double DEFAULT NULL,
+  `recorded_at` timestamp NOT NULL,
+  `geofence` enum('inside','outside','unverified') NOT NULL,
+  `distance` int DEFAULT NULL,
+  `reviewed_by` bigint DEFAULT NULL,
+  `reviewed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`task_id`),
+  KEY `recorded_at` (`recorded_at`),
+  KEY `reviewed_by` (`reviewed_by`),
+  CONSTRAINT `task_locations_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_locations_ibfk_2` FOREIGN KEY (`reviewed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `task_locations_chk_1` CHECK ((`latitude` IS NULL) = (`longitude` IS NULL))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,10 @@
+-- Task revisions also record the priority, due date, tags, site and asset, so
+-- changes to them show in the history. Revisions written before stay NULL:
+-- what they held is unknown.
+
+ALTER TABLE `task_revisions`
+  ADD COLUMN `priority` enum('low','normal','high','urgent') DEFAULT NULL AFTER `status`,
+  ADD COLUMN `due_at` timestamp NULL DEFAULT NULL AFTER `priority`,
+  ADD COLUMN `tags` varchar(550) DEFAULT NULL AFTER `due_at`,
+  ADD COLUMN `site_id` bigint DEFAULT NULL AFTER `tags`,
+  ADD COLUMN `asset_id` bigint DEFAULT NULL AFTER `site_id`;
@@ -1,19 +1,19 @@
 -- name: Create :exec
-INSERT INTO notifications (task_id, message)
-VALUES (?, ?);
+INSERT INTO notifications (task_id, recipient_id, message, template_key, params)
+VALUES (?, ?, ?, ?, ?);
 
 -- name: GetAll :many
 SELECT * FROM notifications;
 
 -- name: GetByID :one
-SELECT * FROM notifications WHERE id = ?;
+-- Notifications about tasks in the trash are hidden with their task
+SELECT * FROM notifications
+WHERE id = ?
+  AND EXISTS (SELECT 1 FROM tasks WHERE tasks.id = notifications.task_id AND tasks.deleted_at IS NULL);
 
 -- name: GetByTaskID :many
 SELECT * FROM notifications WHERE task_id = ?;
 
--- name: GetUnread :many
-SELECT * FROM notifications WHERE is_read = 0;
-
 -- name: MarkAsRead :exec
 UPDATE notifications SET is_read = 1 WHERE id = ?;
 
@@ -21,4 +21,34 @@ UPDATE notifications SET is_read = 1 WHERE id = ?;
 DELETE FROM notifications WHERE id = ?;
 
 -- name: DeleteByTaskID :exec
-DELETE FROM notifications WHERE task_id = ?;
\ No newline at end of file
+DELETE FROM notifications WHERE task_id = ?;
+
+-- name: List :many
+SELECT * FROM notifications
+WHERE (recipient_id = sqlc.arg(recipient_id) OR (sqlc.arg(include_shared) = 1 AND recipient_id IS NULL))
+  AND (sqlc.arg(status) = 'all' OR is_read = (sqlc.arg(status) = 'read'))
+  AND (sqlc.arg(task_id) = 0 OR task_id = sqlc.arg(task_id))
+  AND (sqlc.arg(cursor) = 0 OR id < sqlc.arg(cursor))
+  AND EXISTS (SELECT 1 FROM tasks WHERE tasks.id = notifications.task_id AND tasks.deleted_at IS NULL)
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: CountUnread :one
+SELECT COUNT(*) FROM notifications
+WHERE is_read = 0
+  AND (recipient_id = sqlc.arg(recipient_id) OR (sqlc.arg(include_shared) = 1 AND recipient_id IS NULL))
+  AND EXISTS (SELECT 1 FROM tasks WHERE tasks.id = notifications.task_id AND tasks.deleted_at IS NULL);
+
+-- name: GetReadIDsBefore :many
+SELECT id FROM notifications
+WHERE is_read = 1 AND created_at < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: ArchiveByIDs :exec
+INSERT INTO notifications_archive (id, task_id, recipient_id, message, template_key, params, is_read, created_at)
+SELECT id, task_id, recipient_id, message, template_key, params, is_read, created_at FROM notifications
+WHERE notifications.id IN (sqlc.slice('ids'));
+
+-- name: DeleteByIDs :execrows
+DELETE FROM notifications WHERE id IN (sqlc.slice('ids'));
@@ -0,0 +1,57 @@
+-- name: CreateCustomer :execlastid
+INSERT INTO customers (name, email, phone) VALUES (?, ?, ?);
+
+-- name: GetCustomer :one
+SELECT * FROM customers WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetCustomers :many
+SELECT * FROM customers WHERE deleted_at IS NULL ORDER BY name, id;
+
+-- name: UpdateCustomer :exec
+UPDATE customers SET name = ?, email = ?, phone = ? WHERE id = ? AND deleted_at IS NULL;
+
+-- name: DeleteCustomer :exec
+UPDATE customers SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL;
+
+-- name: CreateSite :execlastid
+INSERT INTO sites (customer_id, name, address, city, postal_code, country, latitude, longitude, geofence_radius)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetSite :one
+SELECT * FROM sites WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetSites :many
+SELECT * FROM sites
+WHERE deleted_at IS NULL AND (sqlc.arg(customer_id) = 0 OR customer_id = sqlc.arg(customer_id))
+ORDER BY name, id;
+
+-- name: CountSitesByCustomerID :one
+SELECT COUNT(*) FROM sites WHERE customer_id = ? AND deleted_at IS NULL;
+
+-- name: UpdateSite :exec
+UPDATE sites SET name = ?, address = ?, city = ?, postal_code = ?, country = ?, latitude = ?, longitude = ?, geofence_radius = ?
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: DeleteSite :exec
+UPDATE sites SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL;
+
+-- name: CreateAsset :execlastid
+INSERT INTO assets (site_id, name, serial_number, model, installed_on) VALUES (?, ?, ?, ?, ?);
+
+-- name: GetAsset :one
+SELECT * FROM assets WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetAssets :many
+SELECT * FROM assets
+WHERE deleted_at IS NULL AND (sqlc.arg(site_id) = 0 OR site_id = sqlc.arg(site_id))
+ORDER BY name, id;
+
+-- name: CountAssetsBySiteID :one
+SELECT COUNT(*) FROM assets WHERE site_id = ? AND deleted_at IS NULL;
+
+-- name: UpdateAsset :exec
+UPDATE assets SET site_id = ?, name = ?, serial_number = ?, model = ?, installed_on = ?
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: DeleteAsset :exec
+UPDATE assets SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL;
@@ -0,0 +1,52 @@
+-- name: CreatePart :execlastid
+INSERT INTO parts (sku, name, unit, low_stock_threshold) VALUES (?, ?, ?, ?);
+
+-- name: GetPart :one
+SELECT * FROM parts WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetParts :many
+SELECT * FROM parts
+WHERE deleted_at IS NULL
+  AND (sqlc.arg(low_stock_only) = FALSE OR stock <= low_stock_threshold)
+ORDER BY name, id;
+
+-- name: UpdatePart :exec
+UPDATE parts SET sku = ?, name = ?, unit = ?, low_stock_threshold = ? WHERE id = ? AND deleted_at IS NULL;
+
+-- name: DeletePart :exec
+UPDATE parts SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL;
+
+-- name: LockPart :one
+SELECT * FROM parts WHERE id = ? FOR UPDATE;
+
+-- name: SetPartStock :exec
+UPDATE parts SET stock = ? WHERE id = ?;
+
+-- name: CreateStockMovement :execlastid
+INSERT INTO stock_movements (part_id, task_id, quantity, balance, reason, note, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetStockMovements :many
+SELECT * FROM stock_movements
+WHERE part_id = sqlc.arg(part_id) AND (sqlc.arg(cursor) = 0 OR id < sqlc.arg(cursor))
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: CreateTaskMaterial :execlastid
+INSERT INTO task_materials (task_id, part_id, quantity, note, created_by) VALUES (?, ?, ?, ?, ?);
+
+-- name: GetTaskMaterial :one
+SELECT m.id, m.task_id, m.part_id, p.sku, p.name, p.unit, m.quantity, m.note, m.created_by, m.created_at
+FROM task_materials m
+JOIN parts p ON p.id = m.part_id
+WHERE m.id = ? AND m.task_id = ?;
+
+-- name: GetTaskMaterialsByTaskID :many
+SELECT m.id, m.task_id, m.part_id, p.sku, p.name, p.unit, m.quantity, m.note, m.created_by, m.created_at
+FROM task_materials m
+JOIN parts p ON p.id = m.part_id
+WHERE m.task_id = ?
+ORDER BY m.id;
+
+-- name: DeleteTaskMaterial :execrows
+DELETE FROM task_materials WHERE id = ?;
@@ -0,0 +1,30 @@
+-- name: GetQueue :many
+SELECT * FROM tasks
+WHERE status = 'open' AND claimed_by IS NULL AND deleted_at IS NULL
+  AND (sqlc.arg(technician_id) = 0 OR technician_id = sqlc.arg(technician_id))
+  AND NOT EXISTS (
+    SELECT 1 FROM task_dependencies d JOIN tasks p ON p.id = d.depends_on_id
+    WHERE d.task_id = tasks.id AND p.status <> 'completed' AND p.deleted_at IS NULL
+  )
+ORDER BY priority DESC, due_at IS NULL, due_at, created_at, id
+LIMIT ?;
+
+-- name: GetNextQueuedTaskID :one
+SELECT id FROM tasks
+WHERE status = 'open' AND claimed_by IS NULL AND deleted_at IS NULL
+  AND technician_id = ?
+  AND NOT EXISTS (
+    SELECT 1 FROM task_dependencies d JOIN tasks p ON p.id = d.depends_on_id
+    WHERE d.task_id = tasks.id AND p.status <> 'completed' AND p.deleted_at IS NULL
+  )
+ORDER BY priority DESC, due_at IS NULL, due_at, created_at, id
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: ClaimTask :execrows
+UPDATE tasks SET claimed_by = sqlc.arg(claimed_by), claimed_at = CURRENT_TIMESTAMP, version = version + 1
+WHERE id = sqlc.arg(id) AND claimed_by IS NULL AND status = 'open' AND deleted_at IS NULL;
+
+-- name: ReleaseTask :execrows
+UPDATE tasks SET claimed_by = NULL, claimed_at = NULL, version = version + 1
+WHERE id = sqlc.arg(id) AND claimed_by = sqlc.arg(claimed_by) AND deleted_at IS NULL;
@@ -0,0 +1,28 @@
+-- name: CreateRecurringTask :execlastid
+INSERT INTO recurring_tasks (technician_id, title, summary, template_id, rrule, starts_at, timezone, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetRecurringTask :one
+SELECT * FROM recurring_tasks WHERE id = ?;
+
+-- name: GetRecurringTasks :many
+SELECT * FROM recurring_tasks ORDER BY id;
+
+-- name: GetRecurringTasksByTechnicianID :many
+SELECT * FROM recurring_tasks WHERE technician_id = ? ORDER BY id;
+
+-- name: UpdateRecurringTask :exec
+UPDATE recurring_tasks
+SET technician_id = ?, title = ?, summary = ?, template_id = ?, rrule = ?, starts_at = ?, timezone = ?
+WHERE id = ?;
+
+-- name: SetRecurringTaskGeneratedUntil :exec
+UPDATE recurring_tasks SET generated_until = ? WHERE id = ?;
+
+-- name: DeleteRecurringTask :exec
+DELETE FROM recurring_tasks WHERE id = ?;
+
+-- name: GetScheduledTasks :many
+SELECT id, scheduled_for FROM tasks
+WHERE recurring_task_id = ? AND scheduled_for > ? AND deleted_at IS NULL
+ORDER BY scheduled_for;
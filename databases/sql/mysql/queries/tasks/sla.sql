@@ -0,0 +1,56 @@
+-- name: CreateSLAPolicy :execlastid
+INSERT INTO sla_policies (name, tag_id, resolution_minutes, at_risk_minutes)
+VALUES (?, ?, ?, ?);
+
+-- name: GetSLAPolicy :one
+SELECT p.id, p.name, p.tag_id, g.name AS tag, p.resolution_minutes, p.at_risk_minutes, p.created_at, p.updated_at
+FROM sla_policies p
+JOIN tags g ON g.id = p.tag_id
+WHERE p.id = ?;
+
+-- name: GetSLAPolicies :many
+SELECT p.id, p.name, p.tag_id, g.name AS tag, p.resolution_minutes, p.at_risk_minutes, p.created_at, p.updated_at
+FROM sla_policies p
+JOIN tags g ON g.id = p.tag_id
+ORDER BY g.name;
+
+-- name: GetSLAPoliciesByTagNames :many
+SELECT p.id, p.name, p.tag_id, g.name AS tag, p.resolution_minutes, p.at_risk_minutes, p.created_at, p.updated_at
+FROM sla_policies p
+JOIN tags g ON g.id = p.tag_id
+WHERE g.name IN (sqlc.slice('names'))
+ORDER BY p.resolution_minutes, p.id;
+
+-- name: UpdateSLAPolicy :exec
+UPDATE sla_policies SET name = ?, tag_id = ?, resolution_minutes = ?, at_risk_minutes = ? WHERE id = ?;
+
+-- name: DeleteSLAPolicy :exec
+DELETE FROM sla_policies WHERE id = ?;
+
+-- name: GetEscalationCandidates :many
+SELECT id, technician_id, title, due_at, at_risk_at, sla_escalation
+FROM tasks
+WHERE status = 'open' AND deleted_at IS NULL
+  AND ((sla_escalation = 'none' AND at_risk_at <= sqlc.arg(now))
+    OR (sla_escalation <> 'breached' AND due_at <= sqlc.arg(now)))
+ORDER BY due_at, id
+LIMIT ?;
+
+-- name: SetTaskEscalation :execrows
+UPDATE tasks SET sla_escalation = sqlc.arg(escalation), updated_at = updated_at
+WHERE id = sqlc.arg(id) AND sla_escalation = sqlc.arg(current_escalation)
+  AND status = 'open' AND deleted_at IS NULL;
+
+-- name: GetSLAReport :many
+SELECT t.technician_id, u.name,
+  COUNT(*) AS total,
+  CAST(COALESCE(SUM(t.status = 'completed' AND t.completed_at <= t.due_at), 0) AS SIGNED) AS met,
+  CAST(COALESCE(SUM((t.status = 'completed' AND t.completed_at > t.due_at) OR (t.status = 'open' AND t.due_at <= sqlc.arg(now))), 0) AS SIGNED) AS missed,
+  CAST(COALESCE(SUM(t.status = 'open' AND t.due_at > sqlc.arg(now)), 0) AS SIGNED) AS pending
+FROM tasks t
+JOIN users u ON u.id = t.technician_id
+WHERE t.deleted_at IS NULL
+  AND t.due_at >= sqlc.arg(from_time) AND t.due_at < sqlc.arg(to_time)
+  AND (sqlc.arg(technician_id) = 0 OR t.technician_id = sqlc.arg(technician_id))
+GROUP BY t.technician_id, u.name
+ORDER BY u.name;
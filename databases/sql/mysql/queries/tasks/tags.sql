@@ -0,0 +1,51 @@
+-- name: CreateTag :execlastid
+INSERT INTO tags (name, category, description)
+VALUES (?, ?, ?);
+
+-- name: GetTag :one
+SELECT * FROM tags WHERE id = ?;
+
+-- name: GetTags :many
+SELECT * FROM tags ORDER BY category, name;
+
+-- name: GetTagsByNames :many
+SELECT * FROM tags WHERE name IN (sqlc.slice('names'));
+
+-- name: UpdateTag :exec
+UPDATE tags SET name = ?, category = ?, description = ? WHERE id = ?;
+
+-- name: DeleteTag :exec
+DELETE FROM tags WHERE id = ?;
+
+-- name: CountTasksByTag :many
+SELECT g.id, g.name, g.category, COUNT(t.id) AS task_count
+FROM tags g
+LEFT JOIN task_tags tt ON tt.tag_id = g.id
+LEFT JOIN tasks t ON t.id = tt.task_id AND t.deleted_at IS NULL
+  AND (sqlc.arg(technician_id) = 0 OR t.technician_id = sqlc.arg(technician_id))
+GROUP BY g.id, g.name, g.category
+ORDER BY g.category, g.name;
+
+-- name: AddTaskTags :exec
+INSERT INTO task_tags (task_id, tag_id)
+SELECT sqlc.arg(task_id), id FROM tags WHERE name IN (sqlc.slice('names'));
+
+-- name: DeleteTaskTags :exec
+DELETE FROM task_tags WHERE task_id = ?;
+
+-- name: GetTagNamesByTaskIDs :many
+SELECT tt.task_id, g.name
+FROM task_tags tt
+JOIN tags g ON g.id = tt.tag_id
+WHERE tt.task_id IN (sqlc.slice('task_ids'))
+ORDER BY tt.task_id, g.name;
+
+-- name: GetByTags :many
+SELECT t.* FROM tasks t
+JOIN task_tags tt ON tt.task_id = t.id
+JOIN tags g ON g.id = tt.tag_id
+WHERE t.deleted_at IS NULL
+  AND (sqlc.arg(technician_id) = 0 OR t.technician_id = sqlc.arg(technician_id))
+  AND g.name IN (sqlc.slice('names'))
+GROUP BY t.id
+HAVING COUNT(*) >= sqlc.arg(min_matches);
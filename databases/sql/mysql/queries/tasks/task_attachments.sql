@@ -0,0 +1,20 @@
+-- name: CreateAttachment :execlastid
+INSERT INTO task_attachments (task_id, uploader_id, file_name, content_type, size_bytes, checksum_sha256, storage_key)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetAttachment :one
+SELECT * FROM task_attachments WHERE id = ? AND task_id = ?;
+
+-- name: GetAttachmentsByTaskID :many
+SELECT * FROM task_attachments WHERE task_id = ? ORDER BY id;
+
+-- name: DeleteAttachment :exec
+DELETE FROM task_attachments WHERE id = ?;
+
+-- name: GetAttachmentKeysByTaskIDs :many
+SELECT storage_key FROM task_attachments WHERE task_id IN (sqlc.slice('task_ids'));
+
+-- name: MarkAttachmentProcessed :exec
+UPDATE task_attachments
+SET storage_key = ?, size_bytes = ?, checksum_sha256 = ?, width = ?, height = ?, captured_at = ?, processed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
@@ -0,0 +1,47 @@
+-- name: CreateChecklistItem :execlastid
+INSERT INTO task_checklist_items (task_id, position, text, required, note)
+SELECT sqlc.arg(task_id), COALESCE(MAX(position), 0) + 1, sqlc.arg(text), sqlc.arg(required), sqlc.arg(note)
+FROM task_checklist_items
+WHERE task_id = sqlc.arg(task_id);
+
+-- name: GetChecklistItem :one
+SELECT * FROM task_checklist_items WHERE id = ? AND task_id = ?;
+
+-- name: GetChecklistItemsByTaskID :many
+SELECT * FROM task_checklist_items WHERE task_id = ? ORDER BY position, id;
+
+-- name: CountChecklistItems :one
+SELECT COUNT(*) FROM task_checklist_items WHERE task_id = ?;
+
+-- name: CountOpenRequiredItems :one
+-- Locks the checklist, so no item can be added or reopened until the
+-- transaction ends
+SELECT COUNT(*) FROM task_checklist_items WHERE task_id = ? AND required AND NOT done FOR SHARE;
+
+-- name: UpdateChecklistItem :exec
+UPDATE task_checklist_items
+SET text = ?, required = ?, done = ?, note = ?, completed_by = ?, completed_at = ?
+WHERE id = ?;
+
+-- name: SetChecklistItemPosition :exec
+UPDATE task_checklist_items SET position = ? WHERE id = ?;
+
+-- name: ShiftChecklistItemsDown :exec
+UPDATE task_checklist_items SET position = position + 1
+WHERE task_id = sqlc.arg(task_id) AND position >= sqlc.arg(from_position) AND position < sqlc.arg(to_position);
+
+-- name: ShiftChecklistItemsUp :exec
+UPDATE task_checklist_items SET position = position - 1
+WHERE task_id = sqlc.arg(task_id) AND position > sqlc.arg(from_position) AND position <= sqlc.arg(to_position);
+
+-- name: DeleteChecklistItem :exec
+DELETE FROM task_checklist_items WHERE id = ?;
+
+-- name: GetChecklistProgressByTaskIDs :many
+SELECT task_id,
+  COUNT(*) AS total,
+  COUNT(CASE WHEN done THEN 1 END) AS done,
+  COUNT(CASE WHEN required AND NOT done THEN 1 END) AS required_open
+FROM task_checklist_items
+WHERE task_id IN (sqlc.slice('task_ids'))
+GROUP BY task_id;
@@ -0,0 +1,15 @@
+-- name: CreateComment :execlastid
+INSERT INTO task_comments (task_id, author_id, body)
+VALUES (?, ?, ?);
+
+-- name: GetComment :one
+SELECT * FROM task_comments WHERE id = ? AND task_id = ?;
+
+-- name: GetCommentsByTaskID :many
+SELECT * FROM task_comments WHERE task_id = ? ORDER BY id;
+
+-- name: UpdateComment :exec
+UPDATE task_comments SET body = ?, edited_at = NOW() WHERE id = ?;
+
+-- name: DeleteComment :exec
+DELETE FROM task_comments WHERE id = ?;
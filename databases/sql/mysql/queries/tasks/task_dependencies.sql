@@ -0,0 +1,62 @@
+-- name: CreateTaskDependency :exec
+INSERT INTO task_dependencies (task_id, depends_on_id, created_by) VALUES (?, ?, ?);
+
+-- name: DeleteTaskDependency :execrows
+DELETE FROM task_dependencies WHERE task_id = ? AND depends_on_id = ?;
+
+-- name: GetPrerequisiteIDs :many
+WITH RECURSIVE prerequisites (id) AS (
+  SELECT depends_on_id FROM task_dependencies WHERE task_dependencies.task_id = sqlc.arg(task_id)
+  UNION
+  SELECT d.depends_on_id FROM task_dependencies d JOIN prerequisites p ON d.task_id = p.id
+)
+SELECT id FROM prerequisites;
+
+-- name: GetDependencyGraphEdges :many
+WITH RECURSIVE upstream (id) AS (
+  SELECT CAST(sqlc.arg(task_id) AS SIGNED)
+  UNION
+  SELECT d.depends_on_id FROM task_dependencies d JOIN upstream u ON d.task_id = u.id
+), downstream (id) AS (
+  SELECT CAST(sqlc.arg(task_id) AS SIGNED)
+  UNION
+  SELECT d.task_id FROM task_dependencies d JOIN downstream w ON d.depends_on_id = w.id
+)
+SELECT d.task_id, d.depends_on_id FROM task_dependencies d
+WHERE d.task_id IN (SELECT id FROM upstream)
+UNION
+SELECT d.task_id, d.depends_on_id FROM task_dependencies d
+WHERE d.task_id IN (SELECT id FROM downstream) AND d.depends_on_id IN (SELECT id FROM downstream)
+ORDER BY task_id, depends_on_id;
+
+-- name: GetDependencyNodes :many
+SELECT id, technician_id, title, status FROM tasks
+WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: CountOpenPrerequisites :one
+-- Locks the dependencies and the prerequisites, so none can be added or
+-- reopened until the transaction ends
+SELECT COUNT(*)
+FROM task_dependencies d
+JOIN tasks p ON p.id = d.depends_on_id
+WHERE d.task_id = ? AND p.status <> 'completed' AND p.deleted_at IS NULL
+FOR SHARE;
+
+-- name: GetBlockingTaskIDs :many
+SELECT d.task_id, d.depends_on_id
+FROM task_dependencies d
+JOIN tasks p ON p.id = d.depends_on_id
+WHERE d.task_id IN (sqlc.slice('task_ids')) AND p.status <> 'completed' AND p.deleted_at IS NULL
+ORDER BY d.task_id, d.depends_on_id;
+
+-- name: GetUnblockedDependents :many
+SELECT t.id, t.technician_id, t.title, t.status
+FROM task_dependencies d
+JOIN tasks t ON t.id = d.task_id
+WHERE d.depends_on_id = ? AND t.status = 'open' AND t.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM task_dependencies b JOIN tasks p ON p.id = b.depends_on_id
+    WHERE b.task_id = t.id AND p.status <> 'completed' AND p.deleted_at IS NULL
+  )
+ORDER BY t.id;
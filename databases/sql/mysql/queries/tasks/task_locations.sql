@@ -0,0 +1,23 @@
+-- name: SaveTaskLocation :exec
+-- A review is kept while the coordinates are; it is assigned first, so it
+-- compares the stored coordinates with the new ones
+INSERT INTO task_locations (task_id, latitude, longitude, accuracy, recorded_at, geofence, distance)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+  reviewed_by = IF(latitude <=> VALUES(latitude) AND longitude <=> VALUES(longitude) AND latitude IS NOT NULL, reviewed_by, NULL),
+  reviewed_at = IF(reviewed_by IS NULL, NULL, reviewed_at),
+  latitude = VALUES(latitude), longitude = VALUES(longitude), accuracy = VALUES(accuracy),
+  recorded_at = VALUES(recorded_at), geofence = VALUES(geofence), distance = VALUES(distance);
+
+-- name: GetTaskLocationsByTaskIDs :many
+SELECT * FROM task_locations WHERE task_id IN (sqlc.slice('task_ids'));
+
+-- name: ReviewTaskLocation :execrows
+UPDATE task_locations SET reviewed_by = ?, reviewed_at = CURRENT_TIMESTAMP
+WHERE task_id = ? AND geofence = 'outside' AND reviewed_at IS NULL;
+
+-- name: ClearTaskLocationCoordinates :execrows
+UPDATE task_locations SET latitude = NULL, longitude = NULL
+WHERE recorded_at < ? AND latitude IS NOT NULL
+ORDER BY recorded_at
+LIMIT ?;
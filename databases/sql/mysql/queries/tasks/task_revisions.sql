@@ -0,0 +1,17 @@
+-- name: CreateRevision :exec
+-- Tags are stored sorted and comma-separated; tag names have no commas
+INSERT INTO task_revisions (task_id, revision, editor_id, action, title, summary, performed_at, status, priority, due_at, tags, site_id, asset_id)
+SELECT t.id, COALESCE(MAX(r.revision), 0) + 1, sqlc.arg(editor_id), sqlc.arg(action), t.title, t.summary, t.performed_at, t.status,
+  t.priority, t.due_at,
+  (SELECT COALESCE(GROUP_CONCAT(g.name ORDER BY g.name SEPARATOR ','), '') FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = t.id),
+  t.site_id, t.asset_id
+FROM tasks t
+LEFT JOIN task_revisions r ON r.task_id = t.id
+WHERE t.id = sqlc.arg(task_id)
+GROUP BY t.id;
+
+-- name: GetRevisionsByTaskID :many
+SELECT * FROM task_revisions WHERE task_id = ? ORDER BY revision;
+
+-- name: GetRevision :one
+SELECT * FROM task_revisions WHERE task_id = ? AND revision = ?;
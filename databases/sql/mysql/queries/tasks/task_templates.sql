@@ -0,0 +1,28 @@
+-- name: CreateTaskTemplate :execlastid
+INSERT INTO task_templates () VALUES ();
+
+-- name: CreateTaskTemplateRevision :exec
+INSERT INTO task_template_revisions (template_id, revision, name, title_pattern, summary, checklist, tags, estimated_minutes, editor_id)
+SELECT id, revision, sqlc.arg(name), sqlc.arg(title_pattern), sqlc.arg(summary), sqlc.arg(checklist), sqlc.arg(tags), sqlc.arg(estimated_minutes), sqlc.arg(editor_id)
+FROM task_templates
+WHERE id = sqlc.arg(template_id);
+
+-- name: GetTaskTemplate :one
+SELECT r.* FROM task_template_revisions r
+JOIN task_templates t ON t.id = r.template_id AND t.revision = r.revision
+WHERE t.id = ? AND t.deleted_at IS NULL;
+
+-- name: GetTaskTemplates :many
+SELECT r.* FROM task_template_revisions r
+JOIN task_templates t ON t.id = r.template_id AND t.revision = r.revision
+WHERE t.deleted_at IS NULL
+ORDER BY r.name, t.id;
+
+-- name: GetTaskTemplateRevision :one
+SELECT * FROM task_template_revisions WHERE template_id = ? AND revision = ?;
+
+-- name: BumpTaskTemplateRevision :execrows
+UPDATE task_templates SET revision = revision + 1 WHERE id = ? AND deleted_at IS NULL;
+
+-- name: DeleteTaskTemplate :exec
+UPDATE task_templates SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;
@@ -1,24 +1,57 @@
--- name: Create :exec
-INSERT INTO tasks (technician_id, title, summary, performed_at)
-VALUES (?, ?, ?, ?);
-
--- name: GetLastInsertTask :one
-SELECT * FROM tasks WHERE id = LAST_INSERT_ID();
+-- name: Create :execlastid
+INSERT INTO tasks (technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, site_id, asset_id, completed_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, IF(status = 'completed', CURRENT_TIMESTAMP, NULL));
 
 -- name: GetLastInsertUser :one
 SELECT * FROM users WHERE id = LAST_INSERT_ID();
 
 -- name: GetByID :one
-SELECT * FROM tasks WHERE id = ?;
+SELECT * FROM tasks WHERE id = ? AND deleted_at IS NULL;
 
 -- name: GetAll :many
-SELECT * FROM tasks;
+SELECT * FROM tasks WHERE deleted_at IS NULL;
 
 -- name: GetByTechnicianID :many
-SELECT * FROM tasks WHERE technician_id = ?;
+SELECT * FROM tasks WHERE technician_id = ? AND deleted_at IS NULL;
+
+-- name: GetByAssetID :many
+SELECT * FROM tasks
+WHERE asset_id = sqlc.arg(asset_id) AND deleted_at IS NULL
+  AND (sqlc.arg(technician_id) = 0 OR technician_id = sqlc.arg(technician_id))
+ORDER BY performed_at DESC, id DESC;
+
+-- name: Update :execrows
+UPDATE tasks SET title = ?, summary = ?, performed_at = ?, status = ?, priority = ?, site_id = ?, asset_id = ?,
+  completed_at = IF(status = 'completed', COALESCE(completed_at, CURRENT_TIMESTAMP), NULL),
+  sla_escalation = IF(due_at <=> sqlc.arg(due_at), sla_escalation, 'none'),
+  due_at = sqlc.arg(due_at), at_risk_at = ?, version = version + 1
+WHERE id = ? AND version = ? AND deleted_at IS NULL;
 
--- name: Update :exec
-UPDATE tasks SET title = ?, summary = ?, performed_at = ? WHERE id = ?;
+-- name: LockTask :one
+SELECT status, version FROM tasks WHERE id = ? AND deleted_at IS NULL FOR UPDATE;
+
+-- name: BumpVersion :exec
+-- For changes to what a task shows that are not written by Update, such as
+-- its checklist, so clients holding the old version see it changed
+UPDATE tasks SET version = version + 1 WHERE id = ?;
 
 -- name: Delete :exec
-DELETE FROM tasks WHERE id = ?;
\ No newline at end of file
+UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetDeletedByID :one
+SELECT * FROM tasks WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: GetDeleted :many
+SELECT * FROM tasks WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC;
+
+-- name: Restore :exec
+UPDATE tasks SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: GetDeletedIDsBefore :many
+SELECT id FROM tasks
+WHERE deleted_at IS NOT NULL AND deleted_at < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: PurgeByIDs :execrows
+DELETE FROM tasks WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,43 @@
+-- name: LockUser :one
+SELECT id FROM users WHERE id = ? FOR UPDATE;
+
+-- name: CountOverlappingTimeEntries :one
+SELECT COUNT(*) FROM time_entries
+WHERE technician_id = sqlc.arg(technician_id)
+  AND (sqlc.narg(ended_at) IS NULL OR started_at < sqlc.narg(ended_at))
+  AND (ended_at IS NULL OR ended_at > sqlc.arg(started_at));
+
+-- name: CreateTimeEntry :execlastid
+INSERT INTO time_entries (task_id, technician_id, started_at, ended_at, note) VALUES (?, ?, ?, ?, ?);
+
+-- name: GetTimeEntry :one
+SELECT * FROM time_entries WHERE id = ? AND task_id = ?;
+
+-- name: GetTimeEntriesByTaskID :many
+SELECT * FROM time_entries WHERE task_id = ? ORDER BY started_at, id;
+
+-- name: GetRunningTimeEntry :one
+SELECT * FROM time_entries WHERE task_id = ? AND technician_id = ? AND ended_at IS NULL;
+
+-- name: StopTimeEntry :execrows
+UPDATE time_entries SET ended_at = ? WHERE id = ? AND ended_at IS NULL;
+
+-- name: DeleteTimeEntry :exec
+DELETE FROM time_entries WHERE id = ?;
+
+-- name: GetTimeReport :many
+-- A timer stopped within the second it started counts as one second
+SELECT e.task_id, t.title, e.technician_id, u.name,
+  CAST(COALESCE(SUM(GREATEST(TIMESTAMPDIFF(SECOND,
+    GREATEST(e.started_at, sqlc.arg(from_time)),
+    LEAST(COALESCE(e.ended_at, sqlc.arg(now)), sqlc.arg(to_time))
+  ), IF(e.ended_at IS NULL, 0, 1))), 0) AS SIGNED) AS seconds
+FROM time_entries e
+JOIN tasks t ON t.id = e.task_id
+JOIN users u ON u.id = e.technician_id
+WHERE t.deleted_at IS NULL
+  AND e.started_at < sqlc.arg(to_time)
+  AND COALESCE(e.ended_at, sqlc.arg(now)) > sqlc.arg(from_time)
+  AND (sqlc.arg(technician_id) = 0 OR e.technician_id = sqlc.arg(technician_id))
+GROUP BY e.task_id, t.title, e.technician_id, u.name
+ORDER BY u.name, e.technician_id, e.task_id;
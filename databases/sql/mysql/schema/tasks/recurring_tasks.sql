@@ -0,0 +1,21 @@
+CREATE TABLE `recurring_tasks` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `technician_id` bigint NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `template_id` bigint DEFAULT NULL,
+  `rrule` varchar(500) NOT NULL,
+  `starts_at` timestamp NOT NULL,
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
+  `generated_until` timestamp NULL DEFAULT NULL,
+  `created_by` bigint NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `technician_id` (`technician_id`),
+  KEY `template_id` (`template_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `recurring_tasks_ibfk_1` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `recurring_tasks_ibfk_2` FOREIGN KEY (`template_id`) REFERENCES `task_templates` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `recurring_tasks_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
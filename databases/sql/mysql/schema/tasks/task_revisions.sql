@@ -0,0 +1,22 @@
+CREATE TABLE `task_revisions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `revision` int NOT NULL,
+  `editor_id` bigint NOT NULL,
+  `action` enum('create','update') NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `performed_at` timestamp NOT NULL,
+  `status` enum('open','completed') NOT NULL DEFAULT 'completed',
+  `priority` enum('low','normal','high','urgent') DEFAULT NULL,
+  `due_at` timestamp NULL DEFAULT NULL,
+  `tags` varchar(550) DEFAULT NULL,
+  `site_id` bigint DEFAULT NULL,
+  `asset_id` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `task_revision` (`task_id`, `revision`),
+  KEY `editor_id` (`editor_id`),
+  CONSTRAINT `task_revisions_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_revisions_ibfk_2` FOREIGN KEY (`editor_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,28 @@
+CREATE TABLE `task_templates` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `revision` int NOT NULL DEFAULT '1',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_template_revisions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `template_id` bigint NOT NULL,
+  `revision` int NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `title_pattern` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `checklist` json NOT NULL,
+  `tags` json NOT NULL,
+  `estimated_minutes` int NOT NULL DEFAULT '0',
+  `editor_id` bigint NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `template_revision` (`template_id`, `revision`),
+  KEY `editor_id` (`editor_id`),
+  CONSTRAINT `task_template_revisions_ibfk_1` FOREIGN KEY (`template_id`) REFERENCES `task_templates` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_template_revisions_ibfk_2` FOREIGN KEY (`editor_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
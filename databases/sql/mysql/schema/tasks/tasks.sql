@@ -4,11 +4,45 @@ CREATE TABLE `tasks` (
   `title` varchar(255) NOT NULL,
   `summary` text NOT NULL,
   `performed_at` timestamp NOT NULL,
+  `status` enum('open','completed') NOT NULL DEFAULT 'completed',
+  `priority` enum('low','normal','high','urgent') NOT NULL DEFAULT 'normal',
+  `template_id` bigint DEFAULT NULL,
+  `template_revision` int DEFAULT NULL,
+  `recurring_task_id` bigint DEFAULT NULL,
+  `scheduled_for` timestamp NULL DEFAULT NULL,
+  `due_at` timestamp NULL DEFAULT NULL,
+  `at_risk_at` timestamp NULL DEFAULT NULL,
+  `sla_policy_id` bigint DEFAULT NULL,
+  `sla_escalation` enum('none','at_risk','breached') NOT NULL DEFAULT 'none',
+  `completed_at` timestamp NULL DEFAULT NULL,
+  `claimed_by` bigint DEFAULT NULL,
+  `claimed_at` timestamp NULL DEFAULT NULL,
+  `site_id` bigint DEFAULT NULL,
+  `asset_id` bigint DEFAULT NULL,
+  `version` int NOT NULL DEFAULT '1',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `technician_id` (`technician_id`),
-  CONSTRAINT `tasks_ibfk_1` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+  KEY `deleted_at` (`deleted_at`),
+  UNIQUE KEY `recurring_occurrence` (`recurring_task_id`, `scheduled_for`),
+  KEY `template` (`template_id`, `template_revision`),
+  KEY `sla_escalation` (`status`, `sla_escalation`, `at_risk_at`),
+  KEY `due_at` (`due_at`),
+  KEY `sla_policy_id` (`sla_policy_id`),
+  KEY `queue` (`status`, `claimed_by`, `priority`, `due_at`),
+  KEY `claimed_by` (`claimed_by`),
+  KEY `site_id` (`site_id`),
+  KEY `asset_history` (`asset_id`, `performed_at`),
+  FULLTEXT KEY `title_summary` (`title`, `summary`),
+  CONSTRAINT `tasks_ibfk_1` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `tasks_ibfk_2` FOREIGN KEY (`template_id`, `template_revision`) REFERENCES `task_template_revisions` (`template_id`, `revision`),
+  CONSTRAINT `tasks_ibfk_3` FOREIGN KEY (`recurring_task_id`) REFERENCES `recurring_tasks` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `tasks_ibfk_4` FOREIGN KEY (`sla_policy_id`) REFERENCES `sla_policies` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `tasks_ibfk_5` FOREIGN KEY (`claimed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `tasks_ibfk_6` FOREIGN KEY (`site_id`) REFERENCES `sites` (`id`),
+  CONSTRAINT `tasks_ibfk_7` FOREIGN KEY (`asset_id`) REFERENCES `assets` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
 CREATE TABLE `users` (
@@ -17,8 +51,13 @@ CREATE TABLE `users` (
   `email` varchar(255) NOT NULL,
   `password_hash` varchar(255) NOT NULL,
   `role` enum('manager','technician') NOT NULL,
+  `manager_id` bigint DEFAULT NULL,
+  `locale` varchar(10) NOT NULL DEFAULT 'en',
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `email` (`email`)
+  UNIQUE KEY `email` (`email`),
+  KEY `manager_id` (`manager_id`),
+  CONSTRAINT `users_ibfk_1` FOREIGN KEY (`manager_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
\ No newline at end of file
@@ -1,7 +1,27 @@
 USE `dbdev`;
 
+DROP TABLE IF EXISTS `notifications_archive`;
+DROP TABLE IF EXISTS `stock_movements`;
+DROP TABLE IF EXISTS `task_materials`;
+DROP TABLE IF EXISTS `parts`;
+DROP TABLE IF EXISTS `task_locations`;
+DROP TABLE IF EXISTS `time_entries`;
+DROP TABLE IF EXISTS `task_dependencies`;
+DROP TABLE IF EXISTS `task_checklist_items`;
+DROP TABLE IF EXISTS `task_tags`;
+DROP TABLE IF EXISTS `task_comments`;
+DROP TABLE IF EXISTS `task_attachments`;
+DROP TABLE IF EXISTS `task_revisions`;
 DROP TABLE IF EXISTS `notifications`;
 DROP TABLE IF EXISTS `tasks`;
+DROP TABLE IF EXISTS `assets`;
+DROP TABLE IF EXISTS `sites`;
+DROP TABLE IF EXISTS `customers`;
+DROP TABLE IF EXISTS `sla_policies`;
+DROP TABLE IF EXISTS `tags`;
+DROP TABLE IF EXISTS `recurring_tasks`;
+DROP TABLE IF EXISTS `task_template_revisions`;
+DROP TABLE IF EXISTS `task_templates`;
 DROP TABLE IF EXISTS `users`;
 
 CREATE TABLE `users` (
@@ -10,37 +30,435 @@ CREATE TABLE `users` (
   `email` varchar(255) NOT NULL,
   `password_hash` varchar(255) NOT NULL,
   `role` enum('manager','technician') NOT NULL,
+  `manager_id` bigint DEFAULT NULL,
+  `locale` varchar(10) NOT NULL DEFAULT 'en',
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `email` (`email`)
+  UNIQUE KEY `email` (`email`),
+  KEY `manager_id` (`manager_id`),
+  CONSTRAINT `users_ibfk_1` FOREIGN KEY (`manager_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 LOCK TABLES `users` WRITE;
-INSERT INTO `users` VALUES (1,'John Smith','john.smith@company.com','$2a$10$dummyhash1','manager','2025-06-06 18:29:04','2025-06-06 18:29:04'),(2,'Sarah Johnson','sarah.j@company.com','$2a$10$dummyhash2','technician','2025-06-06 18:29:04','2025-06-06 18:29:04'),(3,'Mike Wilson','mike.w@company.com','$2a$10$dummyhash3','technician','2025-06-06 18:29:04','2025-06-06 18:29:04');
+INSERT INTO `users` VALUES (1,'John Smith','john.smith@company.com','$2a$10$dummyhash1','manager',NULL,'en','UTC','2025-06-06 18:29:04','2025-06-06 18:29:04'),(2,'Sarah Johnson','sarah.j@company.com','$2a$10$dummyhash2','technician',1,'en','UTC','2025-06-06 18:29:04','2025-06-06 18:29:04'),(3,'Mike Wilson','mike.w@company.com','$2a$10$dummyhash3','technician',1,'en','UTC','2025-06-06 18:29:04','2025-06-06 18:29:04');
 UNLOCK TABLES;
 
+CREATE TABLE `task_templates` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `revision` int NOT NULL DEFAULT '1',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_template_revisions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `template_id` bigint NOT NULL,
+  `revision` int NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `title_pattern` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `checklist` json NOT NULL,
+  `tags` json NOT NULL,
+  `estimated_minutes` int NOT NULL DEFAULT '0',
+  `editor_id` bigint NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `template_revision` (`template_id`, `revision`),
+  KEY `editor_id` (`editor_id`),
+  CONSTRAINT `task_template_revisions_ibfk_1` FOREIGN KEY (`template_id`) REFERENCES `task_templates` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_template_revisions_ibfk_2` FOREIGN KEY (`editor_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `tags` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `category` varchar(50) NOT NULL DEFAULT '',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`),
+  KEY `category` (`category`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `sla_policies` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `tag_id` bigint NOT NULL,
+  `resolution_minutes` int NOT NULL,
+  `at_risk_minutes` int NOT NULL DEFAULT '0',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `tag_id` (`tag_id`),
+  CONSTRAINT `sla_policies_ibfk_1` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `recurring_tasks` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `technician_id` bigint NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `template_id` bigint DEFAULT NULL,
+  `rrule` varchar(500) NOT NULL,
+  `starts_at` timestamp NOT NULL,
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
+  `generated_until` timestamp NULL DEFAULT NULL,
+  `created_by` bigint NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `technician_id` (`technician_id`),
+  KEY `template_id` (`template_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `recurring_tasks_ibfk_1` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `recurring_tasks_ibfk_2` FOREIGN KEY (`template_id`) REFERENCES `task_templates` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `recurring_tasks_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `customers` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `email` varchar(255) NOT NULL DEFAULT '',
+  `phone` varchar(32) NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `sites` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `customer_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `address` varchar(500) NOT NULL,
+  `city` varchar(100) NOT NULL DEFAULT '',
+  `postal_code` varchar(20) NOT NULL DEFAULT '',
+  `country` char(2) NOT NULL DEFAULT '',
+  `latitude` double DEFAULT NULL,
+  `longitude` double DEFAULT NULL,
+  `geofence_radius` int NOT NULL DEFAULT 200,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `customer_id` (`customer_id`, `deleted_at`),
+  CONSTRAINT `sites_ibfk_1` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`),
+  CONSTRAINT `sites_chk_1` CHECK ((`latitude` IS NULL) = (`longitude` IS NULL)),
+  CONSTRAINT `sites_chk_2` CHECK (`latitude` BETWEEN -90 AND 90 AND `longitude` BETWEEN -180 AND 180)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `assets` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `site_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `serial_number` varchar(100) NOT NULL,
+  `model` varchar(255) NOT NULL DEFAULT '',
+  `installed_on` date DEFAULT NULL,
+  `active_serial_number` varchar(100) GENERATED ALWAYS AS (IF(`deleted_at` IS NULL, `serial_number`, NULL)) STORED,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `model_serial_number` (`model`, `active_serial_number`),
+  KEY `site_id` (`site_id`, `deleted_at`),
+  CONSTRAINT `assets_ibfk_1` FOREIGN KEY (`site_id`) REFERENCES `sites` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
 CREATE TABLE `tasks` (
   `id` bigint NOT NULL AUTO_INCREMENT,
   `technician_id` bigint NOT NULL,
   `title` varchar(255) NOT NULL,
   `summary` text NOT NULL,
   `performed_at` timestamp NOT NULL,
+  `status` enum('open','completed') NOT NULL DEFAULT 'completed',
+  `priority` enum('low','normal','high','urgent') NOT NULL DEFAULT 'normal',
+  `template_id` bigint DEFAULT NULL,
+  `template_revision` int DEFAULT NULL,
+  `recurring_task_id` bigint DEFAULT NULL,
+  `scheduled_for` timestamp NULL DEFAULT NULL,
+  `due_at` timestamp NULL DEFAULT NULL,
+  `at_risk_at` timestamp NULL DEFAULT NULL,
+  `sla_policy_id` bigint DEFAULT NULL,
+  `sla_escalation` enum('none','at_risk','breached') NOT NULL DEFAULT 'none',
+  `completed_at` timestamp NULL DEFAULT NULL,
+  `claimed_by` bigint DEFAULT NULL,
+  `claimed_at` timestamp NULL DEFAULT NULL,
+  `site_id` bigint DEFAULT NULL,
+  `asset_id` bigint DEFAULT NULL,
+  `version` int NOT NULL DEFAULT '1',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `technician_id` (`technician_id`),
-  CONSTRAINT `tasks_ibfk_1` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+  KEY `deleted_at` (`deleted_at`),
+  UNIQUE KEY `recurring_occurrence` (`recurring_task_id`, `scheduled_for`),
+  KEY `template` (`template_id`, `template_revision`),
+  KEY `sla_escalation` (`status`, `sla_escalation`, `at_risk_at`),
+  KEY `due_at` (`due_at`),
+  KEY `sla_policy_id` (`sla_policy_id`),
+  KEY `queue` (`status`, `claimed_by`, `priority`, `due_at`),
+  KEY `claimed_by` (`claimed_by`),
+  KEY `site_id` (`site_id`),
+  KEY `asset_history` (`asset_id`, `performed_at`),
+  FULLTEXT KEY `title_summary` (`title`, `summary`),
+  CONSTRAINT `tasks_ibfk_1` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `tasks_ibfk_2` FOREIGN KEY (`template_id`, `template_revision`) REFERENCES `task_template_revisions` (`template_id`, `revision`),
+  CONSTRAINT `tasks_ibfk_3` FOREIGN KEY (`recurring_task_id`) REFERENCES `recurring_tasks` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `tasks_ibfk_4` FOREIGN KEY (`sla_policy_id`) REFERENCES `sla_policies` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `tasks_ibfk_5` FOREIGN KEY (`claimed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `tasks_ibfk_6` FOREIGN KEY (`site_id`) REFERENCES `sites` (`id`),
+  CONSTRAINT `tasks_ibfk_7` FOREIGN KEY (`asset_id`) REFERENCES `assets` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
+CREATE TABLE `task_revisions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `revision` int NOT NULL,
+  `editor_id` bigint NOT NULL,
+  `action` enum('create','update') NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `summary` text NOT NULL,
+  `performed_at` timestamp NOT NULL,
+  `status` enum('open','completed') NOT NULL DEFAULT 'completed',
+  `priority` enum('low','normal','high','urgent') DEFAULT NULL,
+  `due_at` timestamp NULL DEFAULT NULL,
+  `tags` varchar(550) DEFAULT NULL,
+  `site_id` bigint DEFAULT NULL,
+  `asset_id` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `task_revision` (`task_id`, `revision`),
+  KEY `editor_id` (`editor_id`),
+  CONSTRAINT `task_revisions_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_revisions_ibfk_2` FOREIGN KEY (`editor_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_attachments` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `uploader_id` bigint NOT NULL,
+  `file_name` varchar(255) NOT NULL,
+  `content_type` varchar(100) NOT NULL,
+  `size_bytes` bigint NOT NULL,
+  `checksum_sha256` char(64) NOT NULL,
+  `storage_key` varchar(255) NOT NULL,
+  `width` int DEFAULT NULL,
+  `height` int DEFAULT NULL,
+  `captured_at` timestamp NULL DEFAULT NULL,
+  `processed_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `storage_key` (`storage_key`),
+  KEY `task_id` (`task_id`),
+  KEY `uploader_id` (`uploader_id`),
+  CONSTRAINT `task_attachments_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_attachments_ibfk_2` FOREIGN KEY (`uploader_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_comments` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `author_id` bigint NOT NULL,
+  `body` text NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `edited_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `task_id_id` (`task_id`, `id`),
+  KEY `author_id` (`author_id`),
+  CONSTRAINT `task_comments_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_comments_ibfk_2` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_checklist_items` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `position` int NOT NULL,
+  `text` varchar(255) NOT NULL,
+  `required` tinyint(1) NOT NULL DEFAULT '0',
+  `done` tinyint(1) NOT NULL DEFAULT '0',
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `completed_by` bigint DEFAULT NULL,
+  `completed_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `task_id_position` (`task_id`, `position`),
+  KEY `completed_by` (`completed_by`),
+  CONSTRAINT `task_checklist_items_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_checklist_items_ibfk_2` FOREIGN KEY (`completed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_dependencies` (
+  `task_id` bigint NOT NULL,
+  `depends_on_id` bigint NOT NULL,
+  `created_by` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`task_id`, `depends_on_id`),
+  KEY `depends_on_id` (`depends_on_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `task_dependencies_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_dependencies_ibfk_2` FOREIGN KEY (`depends_on_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_dependencies_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `time_entries` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `technician_id` bigint NOT NULL,
+  `started_at` timestamp NOT NULL,
+  `ended_at` timestamp NULL DEFAULT NULL,
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `running_technician_id` bigint GENERATED ALWAYS AS (IF(`ended_at` IS NULL, `technician_id`, NULL)) STORED,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `running_technician_id` (`running_technician_id`),
+  KEY `technician_started_at` (`technician_id`, `started_at`),
+  KEY `task_id_started_at` (`task_id`, `started_at`),
+  CONSTRAINT `time_entries_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `time_entries_ibfk_2` FOREIGN KEY (`technician_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_locations` (
+  `task_id` bigint NOT NULL,
+  `latitude` double DEFAULT NULL,
+  `longitude` double DEFAULT NULL,
+  `accuracy` double DEFAULT NULL,
+  `recorded_at` timestamp NOT NULL,
+  `geofence` enum('inside','outside','unverified') NOT NULL,
+  `distance` int DEFAULT NULL,
+  `reviewed_by` bigint DEFAULT NULL,
+  `reviewed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`task_id`),
+  KEY `recorded_at` (`recorded_at`),
+  KEY `reviewed_by` (`reviewed_by`),
+  CONSTRAINT `task_locations_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_locations_ibfk_2` FOREIGN KEY (`reviewed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `task_locations_chk_1` CHECK ((`latitude` IS NULL) = (`longitude` IS NULL))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `parts` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `sku` varchar(64) NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `unit` varchar(20) NOT NULL DEFAULT 'pcs',
+  `stock` int NOT NULL DEFAULT '0',
+  `low_stock_threshold` int NOT NULL DEFAULT '0',
+  `active_sku` varchar(64) GENERATED ALWAYS AS (IF(`deleted_at` IS NULL, `sku`, NULL)) STORED,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `active_sku` (`active_sku`),
+  KEY `deleted_at` (`deleted_at`),
+  CONSTRAINT `parts_chk_1` CHECK (`stock` >= 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_materials` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `task_id` bigint NOT NULL,
+  `part_id` bigint NOT NULL,
+  `quantity` int NOT NULL,
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `created_by` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `task_id` (`task_id`),
+  KEY `part_id` (`part_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `task_materials_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_materials_ibfk_2` FOREIGN KEY (`part_id`) REFERENCES `parts` (`id`),
+  CONSTRAINT `task_materials_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `stock_movements` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `part_id` bigint NOT NULL,
+  `task_id` bigint DEFAULT NULL,
+  `quantity` int NOT NULL,
+  `balance` int NOT NULL,
+  `reason` enum('restock','consumption','return','adjustment') NOT NULL,
+  `note` varchar(500) NOT NULL DEFAULT '',
+  `created_by` bigint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `part_id_id` (`part_id`, `id`),
+  KEY `task_id` (`task_id`),
+  KEY `created_by` (`created_by`),
+  CONSTRAINT `stock_movements_ibfk_1` FOREIGN KEY (`part_id`) REFERENCES `parts` (`id`),
+  CONSTRAINT `stock_movements_ibfk_2` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `stock_movements_ibfk_3` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `task_tags` (
+  `task_id` bigint NOT NULL,
+  `tag_id` bigint NOT NULL,
+  PRIMARY KEY (`task_id`, `tag_id`),
+  KEY `tag_id` (`tag_id`),
+  CONSTRAINT `task_tags_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `task_tags_ibfk_2` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+INSERT INTO `tags` (`name`, `category`) VALUES
+('hvac', 'discipline'), ('electrical', 'discipline'), ('network', 'discipline'), ('plumbing', 'discipline'),
+('preventive', 'type'), ('corrective', 'type');
+
+-- Stock of a few common parts, received in one restock each
+INSERT INTO `parts` (`sku`, `name`, `unit`, `stock`, `low_stock_threshold`) VALUES
+('FLT-MERV13', 'MERV 13 air filter', 'pcs', 24, 6),
+('CBL-CAT6', 'Cat6 cable', 'm', 300, 50),
+('REF-R410A', 'R-410A refrigerant', 'g', 5000, 1000);
+INSERT INTO `stock_movements` (`part_id`, `quantity`, `balance`, `reason`, `note`, `created_by`)
+SELECT `id`, `stock`, `stock`, 'restock', 'Initial stock', 1 FROM `parts`;
+
+INSERT INTO `customers` (`name`, `email`, `phone`) VALUES
+('Northwind Offices', 'facilities@northwind.example', '+351 210 000 000');
+
+INSERT INTO `sites` (`customer_id`, `name`, `address`, `city`, `postal_code`, `country`, `latitude`, `longitude`) VALUES
+(1, 'Lisbon headquarters', 'Avenida da Liberdade 110', 'Lisboa', '1250-146', 'PT', 38.7197, -9.1453);
+
+INSERT INTO `assets` (`site_id`, `name`, `serial_number`, `model`, `installed_on`) VALUES
+(1, 'Rooftop chiller 1', 'CH-2019-0042', 'Carrier 30RB-160', '2019-06-01');
+
+-- Corrective work must be resolved within a day
+INSERT INTO `sla_policies` (`name`, `tag_id`, `resolution_minutes`, `at_risk_minutes`)
+SELECT 'Corrective maintenance', `id`, 1440, 240 FROM `tags` WHERE `name` = 'corrective';
+
 CREATE TABLE `notifications` (
   `id` bigint NOT NULL AUTO_INCREMENT,
   `task_id` bigint NOT NULL,
+  `recipient_id` bigint DEFAULT NULL,
   `message` text NOT NULL,
+  `template_key` varchar(100) DEFAULT NULL,
+  `params` json DEFAULT NULL,
   `is_read` tinyint(1) DEFAULT '0',
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `task_id` (`task_id`),
-  CONSTRAINT `notifications_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE
+  KEY `is_read_id` (`is_read`, `id`),
+  KEY `recipient_id_id` (`recipient_id`, `id`),
+  CONSTRAINT `notifications_ibfk_1` FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `notifications_ibfk_2` FOREIGN KEY (`recipient_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `notifications_archive` (
+  `id` bigint NOT NULL,
+  `task_id` bigint NOT NULL,
+  `recipient_id` bigint DEFAULT NULL,
+  `message` text NOT NULL,
+  `template_key` varchar(100) DEFAULT NULL,
+  `params` json DEFAULT NULL,
+  `is_read` tinyint(1) DEFAULT '0',
+  `created_at` timestamp NULL DEFAULT NULL,
+  `archived_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `task_id` (`task_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
 -- Insert some tasks for technicians
@@ -50,9 +468,27 @@ INSERT INTO `tasks` (`technician_id`, `title`, `summary`, `performed_at`) VALUES
 (3, 'Hardware Installation', 'Installed new workstations in Marketing', '2024-03-22 11:45:00'),
 (3, 'Software Update', 'Updated security software across all systems', '2024-03-23 16:20:00');
 
+-- First revision of each seeded task
+INSERT INTO `task_revisions` (`task_id`, `revision`, `editor_id`, `action`, `title`, `summary`, `performed_at`, `status`)
+SELECT `id`, 1, `technician_id`, 'create', `title`, `summary`, `performed_at`, `status` FROM `tasks`;
+
+-- Tag the seeded tasks
+INSERT INTO `task_tags` (`task_id`, `tag_id`)
+SELECT t.`id`, g.`id` FROM `tasks` t JOIN `tags` g
+ON (t.`title` = 'Server Maintenance' AND g.`name` IN ('network', 'preventive'))
+OR (t.`title` = 'Network Configuration' AND g.`name` = 'network')
+OR (t.`title` = 'Hardware Installation' AND g.`name` = 'electrical');
+
+-- A template for a routine job
+INSERT INTO `task_templates` (`id`) VALUES (1);
+INSERT INTO `task_template_revisions` (`template_id`, `revision`, `name`, `title_pattern`, `summary`, `checklist`, `tags`, `estimated_minutes`, `editor_id`) VALUES
+(1, 1, 'HVAC filter replacement', 'Replace HVAC filters - {{site}}', 'Replaced the air filters and checked the airflow.',
+'[{"text": "Switch off the unit", "required": true, "note": ""}, {"text": "Replace air filters", "required": true, "note": "Use MERV 13 filters"}, {"text": "Check airflow", "required": false, "note": ""}]',
+'["hvac", "preventive"]', 45, 1);
+
 -- Insert notifications based on the tasks
-INSERT INTO `notifications` (`task_id`, `message`, `is_read`) VALUES
-(1, 'The tech Sarah Johnson performed the task on 2024-03-20 14:30:00', 0),
-(2, 'The tech Sarah Johnson performed the task on 2024-03-21 09:15:00', 0),
-(3, 'The tech Mike Wilson performed the task on 2024-03-22 11:45:00', 0),
-(4, 'The tech Mike Wilson performed the task on 2024-03-23 16:20:00', 0); 
\ No newline at end of file
+INSERT INTO `notifications` (`task_id`, `message`, `template_key`, `params`, `is_read`) VALUES
+(1, 'The tech Sarah Johnson performed the task on 2024-03-20 14:30:00', 'task_performed', '{"tech_name": "Sarah Johnson", "performed_at": "2024-03-20T14:30:00Z"}', 0),
+(2, 'The tech Sarah Johnson performed the task on 2024-03-21 09:15:00', 'task_performed', '{"tech_name": "Sarah Johnson", "performed_at": "2024-03-21T09:15:00Z"}', 0),
+(3, 'The tech Mike Wilson performed the task on 2024-03-22 11:45:00', 'task_performed', '{"tech_name": "Mike Wilson", "performed_at": "2024-03-22T11:45:00Z"}', 0),
+(4, 'The tech Mike Wilson performed the task on 2024-03-23 16:20:00', 'task_performed', '{"tech_name": "Mike Wilson", "performed_at": "2024-03-23T16:20:00Z"}', 0);
\ No newline at end of file
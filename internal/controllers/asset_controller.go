@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetController struct {
+	assetService *service.AssetService
+}
+
+func NewAssetController(assetService *service.AssetService) *AssetController {
+	return &AssetController{
+		assetService: assetService,
+	}
+}
+
+type AssetRequest struct {
+	SiteID       int64  `json:"site_id" binding:"required" example:"1"`
+	Name         string `json:"name" binding:"required" example:"Rooftop chiller 1"`
+	SerialNumber string `json:"serial_number" binding:"required" example:"CH-2019-0042"`
+	Model        string `json:"model" example:"Carrier 30RB-160"`
+	// InstalledOn is a date (YYYY-MM-DD), omitted when unknown
+	InstalledOn string `json:"installed_on" example:"2019-06-01"`
+}
+
+// @Summary      List assets
+// @Description  List the assets by name, optionally only those installed on a site
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        site_id query int false "Only the assets of this site"
+// @Success      200  {array}   models.Asset
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets [get]
+func (h *AssetController) GetAssets(c *gin.Context) {
+	siteID, err := queryInt64(c, "site_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid site_id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	assets, err := h.assetService.GetAssets(c.Request.Context(), siteID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid site_id"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, assets)
+}
+
+// @Summary      Get an asset
+// @Description  Get an asset by ID
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Asset ID"
+// @Success      200  {object}  models.Asset
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/{id} [get]
+func (h *AssetController) GetAsset(c *gin.Context) {
+	assetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid asset id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	asset, err := h.assetService.GetAsset(c.Request.Context(), assetID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, asset)
+}
+
+// @Summary      Create an asset
+// @Description  Add an asset to a site (managers only). The serial number is unique among the assets of the model
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        asset  body AssetRequest true "Asset"
+// @Success      201  {object}  models.Asset
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets [post]
+func (h *AssetController) CreateAsset(c *gin.Context) {
+	var req AssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	asset := &models.Asset{
+		SiteID:       req.SiteID,
+		Name:         req.Name,
+		SerialNumber: req.SerialNumber,
+		Model:        req.Model,
+		InstalledOn:  req.InstalledOn,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdAsset, err := h.assetService.CreateAsset(c.Request.Context(), asset, userID)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdAsset)
+}
+
+// @Summary      Update an asset
+// @Description  Change an asset or move it to another site (managers only). The tasks performed on it keep their site
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        id     path int          true "Asset ID"
+// @Param        asset  body AssetRequest true "Asset"
+// @Success      200  {object}  models.Asset
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/{id} [put]
+func (h *AssetController) UpdateAsset(c *gin.Context) {
+	assetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid asset id"})
+		return
+	}
+
+	var req AssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	asset := &models.Asset{
+		ID:           assetID,
+		SiteID:       req.SiteID,
+		Name:         req.Name,
+		SerialNumber: req.SerialNumber,
+		Model:        req.Model,
+		InstalledOn:  req.InstalledOn,
+	}
+
+	userID := getUserIDFromContext(c)
+	updatedAsset, err := h.assetService.UpdateAsset(c.Request.Context(), asset, userID)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedAsset)
+}
+
+// @Summary      Delete an asset
+// @Description  Remove an asset (managers only). Its maintenance history stays readable
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        id  path int true "Asset ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/{id} [delete]
+func (h *AssetController) DeleteAsset(c *gin.Context) {
+	assetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid asset id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.assetService.DeleteAsset(c.Request.Context(), assetID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Asset maintenance history
+// @Description  The tasks performed on an asset, most recent first. Technicians only see their own tasks
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Asset ID"
+// @Success      200  {array}   models.Task
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/{id}/tasks [get]
+func (h *AssetController) GetHistory(c *gin.Context) {
+	assetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid asset id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	tasks, err := h.assetService.GetHistory(c.Request.Context(), assetID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// handleWriteError maps the errors of changing assets to responses
+func (h *AssetController) handleWriteError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case service.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+	case service.ErrAssetExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "another asset of the model has the serial number"})
+	case service.ErrUnknownSite:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown site"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
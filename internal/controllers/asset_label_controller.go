@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetLabelController struct {
+	labelService *service.AssetLabelService
+}
+
+func NewAssetLabelController(labelService *service.AssetLabelService) *AssetLabelController {
+	return &AssetLabelController{
+		labelService: labelService,
+	}
+}
+
+// labelContentTypes maps label formats to their MIME type
+var labelContentTypes = map[string]string{
+	models.LabelFormatPNG: "image/png",
+	models.LabelFormatSVG: "image/svg+xml",
+}
+
+// @Summary      Get an asset label
+// @Description  A QR code of the asset's deep link, with a signed token, as a PNG or an SVG (managers only)
+// @Tags         assets
+// @Produce      png
+// @Produce      image/svg+xml
+// @Param        id      path  int    true  "Asset ID"
+// @Param        format  query string false "Image format" Enums(png, svg) default(png)
+// @Param        size    query int    false "Width and height of the PNG in pixels (64-1024)" default(256)
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/{id}/label [get]
+func (h *AssetLabelController) GetLabel(c *gin.Context) {
+	assetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid asset id"})
+		return
+	}
+	size, err := queryInt64(c, "size")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid size"})
+		return
+	}
+	format := c.DefaultQuery("format", models.LabelFormatPNG)
+
+	userID := getUserIDFromContext(c)
+	label, err := h.labelService.GetLabel(c.Request.Context(), assetID, format, int(size), userID)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format or size"})
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"asset-%d.%s\"", assetID, format))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, labelContentTypes[format], label)
+}
+
+// @Summary      Print asset labels
+// @Description  A PDF sheet of labels (A4, 3 x 7 labels of 63.5 x 38.1 mm) for the assets of a site, or every asset (managers only). Each label has the QR code, name, serial number, model and site of the asset
+// @Tags         assets
+// @Produce      application/pdf
+// @Param        site_id query int false "Only the assets of this site"
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/labels [get]
+func (h *AssetLabelController) GetLabelSheet(c *gin.Context) {
+	siteID, err := queryInt64(c, "site_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid site_id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	sheet, err := h.labelService.GetLabelSheet(c.Request.Context(), siteID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": fmt.Sprintf("invalid site_id or more than %d assets", models.MaxLabelSheetAssets)})
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "no assets to label"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\"asset-labels.pdf\"")
+	c.Data(http.StatusOK, "application/pdf", sheet)
+}
+
+// @Summary      Resolve a scanned label
+// @Description  The asset of a scanned label, with its site and open tasks, to pick a task or start a new one on the asset. Technicians only get their own tasks
+// @Tags         assets
+// @Accept       json
+// @Produce      json
+// @Param        token query string true "Token of the label's deep link"
+// @Success      200  {object}  models.AssetScan
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/assets/resolve [get]
+func (h *AssetLabelController) Resolve(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token is required"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	scan, err := h.labelService.Resolve(c.Request.Context(), token, userID)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidLabel:
+			c.JSON(http.StatusNotFound, gin.H{"error": "unknown label"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, scan)
+}
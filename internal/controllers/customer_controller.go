@@ -0,0 +1,438 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerController struct {
+	customerService *service.CustomerService
+}
+
+func NewCustomerController(customerService *service.CustomerService) *CustomerController {
+	return &CustomerController{
+		customerService: customerService,
+	}
+}
+
+type CustomerRequest struct {
+	Name  string `json:"name" binding:"required" example:"Northwind Offices"`
+	Email string `json:"email" example:"facilities@northwind.example"`
+	Phone string `json:"phone" example:"+351 210 000 000"`
+}
+
+type SiteRequest struct {
+	// CustomerID is ignored on updates; a site cannot move to another customer
+	CustomerID int64  `json:"customer_id" example:"1"`
+	Name       string `json:"name" binding:"required" example:"Lisbon headquarters"`
+	Address    string `json:"address" binding:"required" example:"Avenida da Liberdade 110"`
+	City       string `json:"city" example:"Lisboa"`
+	PostalCode string `json:"postal_code" example:"1250-146"`
+	Country    string `json:"country" example:"PT"`
+	// Latitude and Longitude are set together, or omitted when unknown
+	Latitude  *float64 `json:"latitude" example:"38.7197"`
+	Longitude *float64 `json:"longitude" example:"-9.1453"`
+	// GeofenceRadius in meters defaults to 200 on creation and is kept when
+	// omitted on updates
+	GeofenceRadius int `json:"geofence_radius" example:"200"`
+}
+
+// @Summary      List customers
+// @Description  List the customers by name
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.Customer
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/customers [get]
+func (h *CustomerController) GetCustomers(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	customers, err := h.customerService.GetCustomers(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, customers)
+}
+
+// @Summary      Get a customer
+// @Description  Get a customer by ID
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Customer ID"
+// @Success      200  {object}  models.Customer
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/customers/{id} [get]
+func (h *CustomerController) GetCustomer(c *gin.Context) {
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	customer, err := h.customerService.GetCustomer(c.Request.Context(), customerID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "customer not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+// @Summary      Create a customer
+// @Description  Add a customer (managers only)
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        customer  body CustomerRequest true "Customer"
+// @Success      201  {object}  models.Customer
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/customers [post]
+func (h *CustomerController) CreateCustomer(c *gin.Context) {
+	var req CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer := &models.Customer{
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdCustomer, err := h.customerService.CreateCustomer(c.Request.Context(), customer, userID)
+	if err != nil {
+		h.handleWriteError(c, err, "customer not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdCustomer)
+}
+
+// @Summary      Update a customer
+// @Description  Change the name and contact details of a customer (managers only)
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        id        path int             true "Customer ID"
+// @Param        customer  body CustomerRequest true "Customer"
+// @Success      200  {object}  models.Customer
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/customers/{id} [put]
+func (h *CustomerController) UpdateCustomer(c *gin.Context) {
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	var req CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer := &models.Customer{
+		ID:    customerID,
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	}
+
+	userID := getUserIDFromContext(c)
+	updatedCustomer, err := h.customerService.UpdateCustomer(c.Request.Context(), customer, userID)
+	if err != nil {
+		h.handleWriteError(c, err, "customer not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedCustomer)
+}
+
+// @Summary      Delete a customer
+// @Description  Remove a customer once its sites are deleted (managers only)
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        id  path int true "Customer ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/customers/{id} [delete]
+func (h *CustomerController) DeleteCustomer(c *gin.Context) {
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.customerService.DeleteCustomer(c.Request.Context(), customerID, userID); err != nil {
+		h.handleWriteError(c, err, "customer not found")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      List sites
+// @Description  List the sites by name, optionally only those of a customer
+// @Tags         sites
+// @Accept       json
+// @Produce      json
+// @Param        customer_id query int false "Only the sites of this customer"
+// @Success      200  {array}   models.Site
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sites [get]
+func (h *CustomerController) GetSites(c *gin.Context) {
+	customerID, err := queryInt64(c, "customer_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	sites, err := h.customerService.GetSites(c.Request.Context(), customerID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sites)
+}
+
+// @Summary      Get a site
+// @Description  Get a site by ID with its address and coordinates
+// @Tags         sites
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200  {object}  models.Site
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sites/{id} [get]
+func (h *CustomerController) GetSite(c *gin.Context) {
+	siteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid site id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	site, err := h.customerService.GetSite(c.Request.Context(), siteID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "site not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, site)
+}
+
+// @Summary      Create a site
+// @Description  Add a site to a customer (managers only)
+// @Tags         sites
+// @Accept       json
+// @Produce      json
+// @Param        site  body SiteRequest true "Site"
+// @Success      201  {object}  models.Site
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sites [post]
+func (h *CustomerController) CreateSite(c *gin.Context) {
+	var req SiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	site := &models.Site{
+		CustomerID:     req.CustomerID,
+		Name:           req.Name,
+		Address:        req.Address,
+		City:           req.City,
+		PostalCode:     req.PostalCode,
+		Country:        req.Country,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		GeofenceRadius: req.GeofenceRadius,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdSite, err := h.customerService.CreateSite(c.Request.Context(), site, userID)
+	if err != nil {
+		h.handleWriteError(c, err, "site not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdSite)
+}
+
+// @Summary      Update a site
+// @Description  Change the name, address, coordinates or geofence radius of a site (managers only). A site cannot move to another customer
+// @Tags         sites
+// @Accept       json
+// @Produce      json
+// @Param        id    path int         true "Site ID"
+// @Param        site  body SiteRequest true "Site"
+// @Success      200  {object}  models.Site
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sites/{id} [put]
+func (h *CustomerController) UpdateSite(c *gin.Context) {
+	siteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid site id"})
+		return
+	}
+
+	var req SiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	site := &models.Site{
+		ID:             siteID,
+		CustomerID:     req.CustomerID,
+		Name:           req.Name,
+		Address:        req.Address,
+		City:           req.City,
+		PostalCode:     req.PostalCode,
+		Country:        req.Country,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		GeofenceRadius: req.GeofenceRadius,
+	}
+
+	userID := getUserIDFromContext(c)
+	updatedSite, err := h.customerService.UpdateSite(c.Request.Context(), site, userID)
+	if err != nil {
+		h.handleWriteError(c, err, "site not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedSite)
+}
+
+// @Summary      Delete a site
+// @Description  Remove a site once its assets are deleted (managers only). Tasks performed there keep the link
+// @Tags         sites
+// @Accept       json
+// @Produce      json
+// @Param        id  path int true "Site ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sites/{id} [delete]
+func (h *CustomerController) DeleteSite(c *gin.Context) {
+	siteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid site id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.customerService.DeleteSite(c.Request.Context(), siteID, userID); err != nil {
+		h.handleWriteError(c, err, "site not found")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleWriteError maps the errors of changing customers and sites to
+// responses
+func (h *CustomerController) handleWriteError(c *gin.Context, err error, notFound string) {
+	switch err {
+	case service.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case service.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case service.ErrCustomerHasSites:
+		c.JSON(http.StatusConflict, gin.H{"error": "the customer has sites"})
+	case service.ErrSiteHasAssets:
+		c.JSON(http.StatusConflict, gin.H{"error": "the site has assets"})
+	case service.ErrUnknownCustomer:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown customer"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// taskETag builds the entity tag of a task version
+func taskETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseETagVersion reads the version out of an If-Match value. "*" matches any
+// version and yields 0.
+func parseETagVersion(header string) (int, bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, true
+	}
+	header = strings.TrimPrefix(header, "W/")
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// etagMatches reports whether an If-None-Match value (a list of tags or "*")
+// contains etag, using weak comparison
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import "testing"
+
+func TestParseETagVersion(t *testing.T) {
+	tests := []struct {
+		header      string
+		wantVersion int
+		wantOK      bool
+	}{
+		{`"3"`, 3, true},
+		{`W/"3"`, 3, true},
+		{`*`, 0, true},
+		{`3`, 0, false},
+		{`"abc"`, 0, false},
+		{`"0"`, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			version, ok := parseETagVersion(tt.header)
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("parseETagVersion(%q) = %v, %v, want %v, %v", tt.header, version, ok, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"3"`, true},
+		{`W/"3"`, true},
+		{`"1", "3"`, true},
+		{`*`, true},
+		{`"2"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := etagMatches(tt.header, taskETag(3)); got != tt.want {
+				t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
-	_ "sword-challenge/internal/models"
+	"sword-challenge/internal/models"
 	"sword-challenge/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -20,34 +20,96 @@ func NewNotificationController(notificationService *service.NotificationService)
 	}
 }
 
-// @Summary      Get unread notifications
-// @Description  Get all unread notifications for the authenticated manager
+// @Summary      List notifications
+// @Description  List the authenticated user's notifications, newest first, with cursor pagination. Managers see shared notifications and those addressed to them; technicians only those addressed to them.
 // @Tags         notifications
 // @Accept       json
 // @Produce      json
-// @Success      200  {array}   models.Notification
+// @Param        status   query string false "Filter by read status" Enums(unread, read, all) default(unread)
+// @Param        task_id  query int    false "Filter by task ID"
+// @Param        cursor   query int    false "next_cursor returned by the previous page"
+// @Param        limit    query int    false "Page size (1-100)" default(20)
+// @Success      200  {object}  models.NotificationPage
+// @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/notifications [get]
-func (h *NotificationController) GetUnreadNotifications(c *gin.Context) {
+func (h *NotificationController) ListNotifications(c *gin.Context) {
+	filter := models.NotificationFilter{Status: c.Query("status")}
+	var err error
+	if filter.TaskID, err = queryInt64(c, "task_id"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task_id"})
+		return
+	}
+	if filter.Cursor, err = queryInt64(c, "cursor"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+	limit, err := queryInt64(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+	filter.Limit = int(limit)
+
 	userID := getUserIDFromContext(c)
-	notifications, err := h.notificationService.GetUnreadNotifications(c.Request.Context(), userID)
+	page, err := h.notificationService.ListNotifications(c.Request.Context(), filter, userID)
 	if err != nil {
 		switch err {
 		case service.ErrUnauthorized:
 			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
 		case service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary      Get a specific notification
+// @Description  Get a notification by its ID, read or unread
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Notification ID"
+// @Success      200  {object}  models.Notification
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/notifications/{id} [get]
+func (h *NotificationController) GetNotification(c *gin.Context) {
+	notificationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	notification, err := h.notificationService.GetNotification(c.Request.Context(), notificationID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, notifications)
+	c.JSON(http.StatusOK, notification)
 }
 
 // @Summary      Mark notification as read
@@ -0,0 +1,358 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PartController struct {
+	partService *service.PartService
+}
+
+func NewPartController(partService *service.PartService) *PartController {
+	return &PartController{
+		partService: partService,
+	}
+}
+
+type PartRequest struct {
+	SKU               string `json:"sku" binding:"required" example:"FLT-MERV13"`
+	Name              string `json:"name" binding:"required" example:"MERV 13 air filter"`
+	Unit              string `json:"unit" binding:"required" example:"pcs"`
+	LowStockThreshold int    `json:"low_stock_threshold" example:"6"`
+}
+
+type StockMovementRequest struct {
+	// Quantity is added to the stock; adjustments can be negative
+	Quantity int    `json:"quantity" binding:"required" example:"10"`
+	Reason   string `json:"reason" binding:"required" enums:"restock,adjustment" example:"restock"`
+	Note     string `json:"note" example:"PO 2024-117"`
+}
+
+// @Summary      List parts
+// @Description  List the parts catalog with the stock of each part, by SKU
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        low_stock query bool false "Only parts at or below their low stock threshold"
+// @Success      200  {array}   models.Part
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts [get]
+func (h *PartController) GetParts(c *gin.Context) {
+	lowStockOnly := false
+	if value := c.Query("low_stock"); value != "" {
+		var err error
+		if lowStockOnly, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid low_stock"})
+			return
+		}
+	}
+
+	userID := getUserIDFromContext(c)
+	parts, err := h.partService.GetParts(c.Request.Context(), lowStockOnly, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, parts)
+}
+
+// @Summary      Get a part
+// @Description  Get a part of the catalog with its stock
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Part ID"
+// @Success      200  {object}  models.Part
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts/{id} [get]
+func (h *PartController) GetPart(c *gin.Context) {
+	partID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid part id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	part, err := h.partService.GetPart(c.Request.Context(), partID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "part not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, part)
+}
+
+// @Summary      Create a part
+// @Description  Add a part to the catalog (managers only). It starts with no stock; restock it to receive parts
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        part  body PartRequest true "Part"
+// @Success      201  {object}  models.Part
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts [post]
+func (h *PartController) CreatePart(c *gin.Context) {
+	var req PartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	part := &models.Part{
+		SKU:               req.SKU,
+		Name:              req.Name,
+		Unit:              req.Unit,
+		LowStockThreshold: req.LowStockThreshold,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdPart, err := h.partService.CreatePart(c.Request.Context(), part, userID)
+	if err != nil {
+		h.handleCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdPart)
+}
+
+// @Summary      Update a part
+// @Description  Change the SKU, name, unit or low stock threshold of a part (managers only). The stock only changes through stock movements
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        id    path int         true "Part ID"
+// @Param        part  body PartRequest true "Part"
+// @Success      200  {object}  models.Part
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts/{id} [put]
+func (h *PartController) UpdatePart(c *gin.Context) {
+	partID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid part id"})
+		return
+	}
+
+	var req PartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	part := &models.Part{
+		ID:                partID,
+		SKU:               req.SKU,
+		Name:              req.Name,
+		Unit:              req.Unit,
+		LowStockThreshold: req.LowStockThreshold,
+	}
+
+	userID := getUserIDFromContext(c)
+	updatedPart, err := h.partService.UpdatePart(c.Request.Context(), part, userID)
+	if err != nil {
+		h.handleCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedPart)
+}
+
+// @Summary      Delete a part
+// @Description  Remove a part from the catalog (managers only). Its ledger and the materials already recorded on tasks are kept
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        id  path int true "Part ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts/{id} [delete]
+func (h *PartController) DeletePart(c *gin.Context) {
+	partID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid part id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.partService.DeletePart(c.Request.Context(), partID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "part not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Move stock
+// @Description  Restock a part or correct its stock after a count (managers only). The movement is recorded in the ledger; the stock never goes below zero
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        id        path int                  true "Part ID"
+// @Param        movement  body StockMovementRequest true "Stock movement"
+// @Success      200  {object}  models.Part
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts/{id}/stock [post]
+func (h *PartController) MoveStock(c *gin.Context) {
+	partID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid part id"})
+		return
+	}
+
+	var req StockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movement := &models.StockMovement{
+		PartID:   partID,
+		Quantity: req.Quantity,
+		Reason:   req.Reason,
+		Note:     req.Note,
+	}
+
+	userID := getUserIDFromContext(c)
+	part, err := h.partService.MoveStock(c.Request.Context(), movement, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "part not found"})
+		case service.ErrInsufficientStock:
+			c.JSON(http.StatusConflict, gin.H{"error": "not enough parts in stock"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, part)
+}
+
+// @Summary      List stock movements
+// @Description  The ledger of a part, newest first, with cursor pagination (managers only)
+// @Tags         parts
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int true  "Part ID"
+// @Param        cursor  query int false "next_cursor returned by the previous page"
+// @Param        limit   query int false "Page size (1-200)" default(50)
+// @Success      200  {object}  models.StockMovementPage
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/parts/{id}/movements [get]
+func (h *PartController) GetMovements(c *gin.Context) {
+	partID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid part id"})
+		return
+	}
+	cursor, err := queryInt64(c, "cursor")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+	limit, err := queryInt64(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	page, err := h.partService.GetMovements(c.Request.Context(), partID, cursor, int(limit), userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "part not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// handleCatalogError maps the errors of changing the catalog to responses
+func (h *PartController) handleCatalogError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case service.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "part not found"})
+	case service.ErrPartExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "another part has the sku"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	_ "sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QueueController struct {
+	queueService *service.QueueService
+}
+
+func NewQueueController(queueService *service.QueueService) *QueueController {
+	return &QueueController{
+		queueService: queueService,
+	}
+}
+
+// @Summary      Get the work queue
+// @Description  List the open tasks nobody has claimed yet: urgent first, then by due date (tasks without one last) and age. Tasks are assigned when they are created, so every technician has their own queue; technicians only see theirs
+// @Tags         queue
+// @Accept       json
+// @Produce      json
+// @Param        limit query int false "Maximum number of tasks (1-100)" default(20)
+// @Success      200  {array}   models.Task
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/queue [get]
+func (h *QueueController) GetQueue(c *gin.Context) {
+	limit, err := queryInt64(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	tasks, err := h.queueService.GetQueue(c.Request.Context(), int(limit), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// @Summary      Claim the next task
+// @Description  Claim the first task of the technician's own work queue (Technician only); there is no shared pool of unassigned tasks. Concurrent claims never get the same task
+// @Tags         queue
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.Task
+// @Header       200  {string}  ETag "Current version of the task"
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/queue/claim [post]
+func (h *QueueController) ClaimNext(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	task, err := h.queueService.ClaimNext(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrQueueEmpty:
+			c.JSON(http.StatusNotFound, gin.H{"error": "no open task to claim"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(task.Version))
+	c.JSON(http.StatusOK, task)
+}
+
+// @Summary      Release a claimed task
+// @Description  Give up a claim, putting the task back in the technician's work queue (Technician only, for tasks they claimed)
+// @Tags         queue
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Task ID"
+// @Success      200  {object}  models.Task
+// @Header       200  {string}  ETag "Current version of the task"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/queue/{id}/release [post]
+func (h *QueueController) Release(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	task, err := h.queueService.Release(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrTaskNotClaimed:
+			c.JSON(http.StatusConflict, gin.H{"error": "the task is not claimed by you"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(task.Version))
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,307 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringTaskController struct {
+	recurringTaskService *service.RecurringTaskService
+}
+
+func NewRecurringTaskController(recurringTaskService *service.RecurringTaskService) *RecurringTaskController {
+	return &RecurringTaskController{
+		recurringTaskService: recurringTaskService,
+	}
+}
+
+type RecurringTaskRequest struct {
+	// The technician the generated tasks are assigned to
+	TechnicianID int64  `json:"technician_id" binding:"required" example:"2"`
+	Title        string `json:"title" binding:"required,max=255" example:"Monthly HVAC inspection"`
+	// Summary of the generated tasks; required unless a template is given
+	Summary string `json:"summary" binding:"max=2500" example:"Inspect the rooftop units and replace the filters."`
+	// Template giving the generated tasks their checklist and tags
+	TemplateID *int64 `json:"template_id" example:"1"`
+	// iCalendar recurrence rule (RFC 5545); BYHOUR, BYMINUTE, BYSECOND, BYYEARDAY and BYWEEKNO are not supported
+	RRule string `json:"rrule" binding:"required" example:"FREQ=MONTHLY;BYDAY=1MO"`
+	// First occurrence (ISO 8601 format); later occurrences keep its time of day
+	StartsAt string `json:"starts_at" binding:"required" example:"2024-04-01T09:00:00+01:00"`
+	// IANA time zone the rule is expanded in (default UTC)
+	Timezone string `json:"timezone" example:"Europe/Lisbon"`
+}
+
+// @Summary      List recurring tasks
+// @Description  List recurring task definitions. Technicians only see the ones assigned to them
+// @Tags         recurring-tasks
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.RecurringTask
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/recurring-tasks [get]
+func (h *RecurringTaskController) GetRecurringTasks(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	recurringTasks, err := h.recurringTaskService.GetRecurringTasks(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringTasks)
+}
+
+// @Summary      Get a recurring task
+// @Description  Get a recurring task definition. Technicians can only get the ones assigned to them
+// @Tags         recurring-tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Recurring task ID"
+// @Success      200  {object}  models.RecurringTask
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/recurring-tasks/{id} [get]
+func (h *RecurringTaskController) GetRecurringTask(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurring task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	recurringTask, err := h.recurringTaskService.GetRecurringTask(c.Request.Context(), id, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "recurring task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringTask)
+}
+
+// @Summary      List upcoming occurrences
+// @Description  List the next occurrences of a recurring task, with the task already created for each one the scheduler has reached
+// @Tags         recurring-tasks
+// @Accept       json
+// @Produce      json
+// @Param        id     path  int true  "Recurring task ID"
+// @Param        limit  query int false "Maximum number of occurrences (1-100)" default(10)
+// @Success      200  {array}   models.RecurringOccurrence
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/recurring-tasks/{id}/occurrences [get]
+func (h *RecurringTaskController) GetOccurrences(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurring task id"})
+		return
+	}
+	limit, err := queryInt64(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	occurrences, err := h.recurringTaskService.GetOccurrences(c.Request.Context(), id, int(limit), userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "recurring task not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, occurrences)
+}
+
+// @Summary      Create a recurring task
+// @Description  Schedule a task that recurs following an iCalendar RRULE (Manager only). The scheduler creates each occurrence ahead of time as an open task assigned to the technician
+// @Tags         recurring-tasks
+// @Accept       json
+// @Produce      json
+// @Param        recurringTask body RecurringTaskRequest true "Recurring task"
+// @Success      201  {object}  models.RecurringTask
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/recurring-tasks [post]
+func (h *RecurringTaskController) CreateRecurringTask(c *gin.Context) {
+	var req RecurringTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startsAt, err := parseTime(req.StartsAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid starts_at date format"})
+		return
+	}
+
+	recurringTask := &models.RecurringTask{
+		TechnicianID: req.TechnicianID,
+		Title:        req.Title,
+		Summary:      req.Summary,
+		TemplateID:   req.TemplateID,
+		RRule:        req.RRule,
+		StartsAt:     startsAt,
+		Timezone:     req.Timezone,
+	}
+
+	userID := getUserIDFromContext(c)
+	recurringTask, err = h.recurringTaskService.CreateRecurringTask(c.Request.Context(), recurringTask, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurringTask)
+}
+
+// @Summary      Update a recurring task
+// @Description  Change a recurring task (Manager only). Tasks already created are kept; the new schedule applies to the occurrences that have no task yet
+// @Tags         recurring-tasks
+// @Accept       json
+// @Produce      json
+// @Param        id             path int                  true "Recurring task ID"
+// @Param        recurringTask  body RecurringTaskRequest true "Recurring task"
+// @Success      200  {object}  models.RecurringTask
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/recurring-tasks/{id} [put]
+func (h *RecurringTaskController) UpdateRecurringTask(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurring task id"})
+		return
+	}
+
+	var req RecurringTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startsAt, err := parseTime(req.StartsAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid starts_at date format"})
+		return
+	}
+
+	recurringTask := &models.RecurringTask{
+		ID:           id,
+		TechnicianID: req.TechnicianID,
+		Title:        req.Title,
+		Summary:      req.Summary,
+		TemplateID:   req.TemplateID,
+		RRule:        req.RRule,
+		StartsAt:     startsAt,
+		Timezone:     req.Timezone,
+	}
+
+	userID := getUserIDFromContext(c)
+	recurringTask, err = h.recurringTaskService.UpdateRecurringTask(c.Request.Context(), recurringTask, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "recurring task not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringTask)
+}
+
+// @Summary      Delete a recurring task
+// @Description  Stop a recurring task (Manager only). The tasks it created are kept
+// @Tags         recurring-tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Recurring task ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/recurring-tasks/{id} [delete]
+func (h *RecurringTaskController) DeleteRecurringTask(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurring task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.recurringTaskService.DeleteRecurringTask(c.Request.Context(), id, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "recurring task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
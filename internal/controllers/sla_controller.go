@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SLAController struct {
+	slaService *service.SLAService
+}
+
+func NewSLAController(slaService *service.SLAService) *SLAController {
+	return &SLAController{
+		slaService: slaService,
+	}
+}
+
+type SLAPolicyRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Corrective maintenance"`
+	// Tag whose tasks follow the policy
+	Tag string `json:"tag" binding:"required" example:"corrective"`
+	// Minutes from creation until the task is due
+	ResolutionMinutes int `json:"resolution_minutes" binding:"required" example:"1440"`
+	// Minutes before the due date when the task is at risk
+	AtRiskMinutes int `json:"at_risk_minutes" example:"240"`
+}
+
+// @Summary      List SLA policies
+// @Description  List the SLA policies giving the tasks of a tag a resolution time
+// @Tags         sla
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.SLAPolicy
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sla/policies [get]
+func (h *SLAController) GetPolicies(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	policies, err := h.slaService.GetPolicies(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// @Summary      Get an SLA policy
+// @Description  Get an SLA policy by its ID
+// @Tags         sla
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Policy ID"
+// @Success      200  {object}  models.SLAPolicy
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sla/policies/{id} [get]
+func (h *SLAController) GetPolicy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid policy id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	policy, err := h.slaService.GetPolicy(c.Request.Context(), id, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "policy not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary      Create an SLA policy
+// @Description  Give the tasks of a tag a resolution time (Manager only). Open tasks created with the tag from then on get a due date; a task with several tags follows the strictest policy
+// @Tags         sla
+// @Accept       json
+// @Produce      json
+// @Param        policy body SLAPolicyRequest true "SLA policy"
+// @Success      201  {object}  models.SLAPolicy
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sla/policies [post]
+func (h *SLAController) CreatePolicy(c *gin.Context) {
+	var req SLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy := &models.SLAPolicy{
+		Name:              req.Name,
+		Tag:               req.Tag,
+		ResolutionMinutes: req.ResolutionMinutes,
+		AtRiskMinutes:     req.AtRiskMinutes,
+	}
+
+	userID := getUserIDFromContext(c)
+	policy, err := h.slaService.CreatePolicy(c.Request.Context(), policy, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrSLAPolicyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "the tag already has an SLA policy"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tag"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// @Summary      Update an SLA policy
+// @Description  Change an SLA policy (Manager only). Tasks keep the due date the policy already gave them
+// @Tags         sla
+// @Accept       json
+// @Produce      json
+// @Param        id      path int              true "Policy ID"
+// @Param        policy  body SLAPolicyRequest true "SLA policy"
+// @Success      200  {object}  models.SLAPolicy
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sla/policies/{id} [put]
+func (h *SLAController) UpdatePolicy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid policy id"})
+		return
+	}
+
+	var req SLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy := &models.SLAPolicy{
+		ID:                id,
+		Name:              req.Name,
+		Tag:               req.Tag,
+		ResolutionMinutes: req.ResolutionMinutes,
+		AtRiskMinutes:     req.AtRiskMinutes,
+	}
+
+	userID := getUserIDFromContext(c)
+	policy, err = h.slaService.UpdatePolicy(c.Request.Context(), policy, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "policy not found"})
+		case service.ErrSLAPolicyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "the tag already has an SLA policy"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tag"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary      Delete an SLA policy
+// @Description  Remove an SLA policy (Manager only). Tasks keep the due date it gave them
+// @Tags         sla
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Policy ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sla/policies/{id} [delete]
+func (h *SLAController) DeletePolicy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid policy id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.slaService.DeletePolicy(c.Request.Context(), id, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "policy not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      SLA compliance report
+// @Description  Count the tasks due in a period that met or missed their due date, overall and per technician. Open tasks past their due date count as missed. Technicians only get their own figures
+// @Tags         sla
+// @Accept       json
+// @Produce      json
+// @Param        from query string true "Start of the period, inclusive (ISO 8601 format)"
+// @Param        to   query string true "End of the period, exclusive (ISO 8601 format)"
+// @Success      200  {object}  models.SLAReport
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/sla/report [get]
+func (h *SLAController) GetReport(c *gin.Context) {
+	from, err := parseTime(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date format"})
+		return
+	}
+	to, err := parseTime(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date format"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	report, err := h.slaService.GetReport(c.Request.Context(), models.SLAReportQuery{From: from, To: to}, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
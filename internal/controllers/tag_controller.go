@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagController struct {
+	tagService *service.TagService
+}
+
+func NewTagController(tagService *service.TagService) *TagController {
+	return &TagController{
+		tagService: tagService,
+	}
+}
+
+type TagRequest struct {
+	Name        string `json:"name" binding:"required" example:"hvac"`
+	Category    string `json:"category" example:"discipline"`
+	Description string `json:"description" example:"Heating, ventilation and air conditioning"`
+}
+
+// @Summary      List tags
+// @Description  List the managed tag vocabulary, grouped by category
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.Tag
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tags [get]
+func (h *TagController) GetTags(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	tags, err := h.tagService.GetTags(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary      Count tasks per tag
+// @Description  Number of active tasks carrying each tag, for dashboards. Technicians only count their own tasks
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.TagCount
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tags/counts [get]
+func (h *TagController) GetTagCounts(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	counts, err := h.tagService.CountTasks(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// @Summary      Create a tag
+// @Description  Add a tag to the managed vocabulary (managers only)
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        tag  body TagRequest true "Tag"
+// @Success      201  {object}  models.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tags [post]
+func (h *TagController) CreateTag(c *gin.Context) {
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag := &models.Tag{
+		Name:        req.Name,
+		Category:    req.Category,
+		Description: req.Description,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdTag, err := h.tagService.CreateTag(c.Request.Context(), tag, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrTagExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "tag already exists"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdTag)
+}
+
+// @Summary      Update a tag
+// @Description  Rename, recategorize or describe a tag (managers only); tagged tasks follow the new name
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path int        true "Tag ID"
+// @Param        tag  body TagRequest true "Tag"
+// @Success      200  {object}  models.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tags/{id} [put]
+func (h *TagController) UpdateTag(c *gin.Context) {
+	tagID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag := &models.Tag{
+		ID:          tagID,
+		Name:        req.Name,
+		Category:    req.Category,
+		Description: req.Description,
+	}
+
+	userID := getUserIDFromContext(c)
+	updatedTag, err := h.tagService.UpdateTag(c.Request.Context(), tag, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "tag not found"})
+		case service.ErrTagExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "tag already exists"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedTag)
+}
+
+// @Summary      Delete a tag
+// @Description  Remove a tag from the vocabulary and from every task (managers only)
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id  path int true "Tag ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tags/{id} [delete]
+func (h *TagController) DeleteTag(c *gin.Context) {
+	tagID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.tagService.DeleteTag(c.Request.Context(), tagID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "tag not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
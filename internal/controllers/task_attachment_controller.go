@@ -0,0 +1,280 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is allowed on top of the file size for the multipart
+// boundaries and headers
+const multipartOverhead = 1 << 20
+
+type TaskAttachmentController struct {
+	attachmentService *service.TaskAttachmentService
+}
+
+func NewTaskAttachmentController(attachmentService *service.TaskAttachmentService) *TaskAttachmentController {
+	return &TaskAttachmentController{
+		attachmentService: attachmentService,
+	}
+}
+
+// @Summary      List task attachments
+// @Description  List the files attached to a task, oldest first
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {array}   models.TaskAttachment
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/attachments [get]
+func (h *TaskAttachmentController) GetAttachments(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	attachments, err := h.attachmentService.GetAttachments(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// @Summary      Upload a task attachment
+// @Description  Attach a photo or document to a task. The type is detected from the content and must be allowed; the size is limited
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id   path     int  true "Task ID"
+// @Param        file formData file true "The file to attach"
+// @Success      201  {object}  models.TaskAttachment
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      413  {object}  map[string]string
+// @Failure      415  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/attachments [post]
+func (h *TaskAttachmentController) UploadAttachment(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentService.MaxSize()+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid file"})
+		return
+	}
+	defer file.Close()
+
+	userID := getUserIDFromContext(c)
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), taskID, header.Filename, file, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is empty"})
+		case service.ErrFileTooLarge:
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		case service.ErrUnsupportedMediaType:
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "file type is not allowed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// @Summary      Download a task attachment
+// @Description  Download the content of an attachment
+// @Tags         attachments
+// @Produce      octet-stream
+// @Param        id            path int true "Task ID"
+// @Param        attachmentId  path int true "Attachment ID"
+// @Success      200  {file}    file
+// @Header       200  {string}  ETag "SHA-256 of the content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/attachments/{attachmentId} [get]
+func (h *TaskAttachmentController) DownloadAttachment(c *gin.Context) {
+	taskID, attachmentID, ok := parseAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	attachment, content, err := h.attachmentService.OpenAttachment(c.Request.Context(), taskID, attachmentID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    attachmentDisposition(attachment),
+		"ETag":                   fmt.Sprintf("%q", attachment.Checksum),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary      Get an attachment thumbnail
+// @Description  Get a JPEG preview of an image attachment, without metadata. Sizes fit the longest side in 160 (small), 480 (medium) or 1024 (large) pixels. Images are processed in the background after upload; until then this returns 404.
+// @Tags         attachments
+// @Produce      jpeg
+// @Param        id            path int    true "Task ID"
+// @Param        attachmentId  path int    true "Attachment ID"
+// @Param        size          path string true "Thumbnail size" Enums(small, medium, large)
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/attachments/{attachmentId}/thumbnails/{size} [get]
+func (h *TaskAttachmentController) GetThumbnail(c *gin.Context) {
+	taskID, attachmentID, ok := parseAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	_, content, err := h.attachmentService.OpenThumbnail(c.Request.Context(), taskID, attachmentID, c.Param("size"), userID)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thumbnail size"})
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "thumbnail not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	defer content.Close()
+
+	// A thumbnail never changes once written
+	c.DataFromReader(http.StatusOK, -1, "image/jpeg", content, map[string]string{
+		"Cache-Control":          "private, max-age=86400",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary      Delete a task attachment
+// @Description  Delete an attachment; only its uploader or a manager can delete it
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Param        id            path int true "Task ID"
+// @Param        attachmentId  path int true "Attachment ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/attachments/{attachmentId} [delete]
+func (h *TaskAttachmentController) DeleteAttachment(c *gin.Context) {
+	taskID, attachmentID, ok := parseAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.attachmentService.DeleteAttachment(c.Request.Context(), taskID, attachmentID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseAttachmentPath reads the task and attachment IDs, answering 400 when
+// either is invalid
+func parseAttachmentPath(c *gin.Context) (int64, int64, bool) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return 0, 0, false
+	}
+	attachmentID, err := strconv.ParseInt(c.Param("attachmentId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment id"})
+		return 0, 0, false
+	}
+	return taskID, attachmentID, true
+}
+
+func attachmentDisposition(attachment *models.TaskAttachment) string {
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if disposition == "" {
+		return "attachment"
+	}
+	return disposition
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskChecklistController struct {
+	checklistService *service.TaskChecklistService
+}
+
+func NewTaskChecklistController(checklistService *service.TaskChecklistService) *TaskChecklistController {
+	return &TaskChecklistController{
+		checklistService: checklistService,
+	}
+}
+
+type CreateChecklistItemRequest struct {
+	Text     string `json:"text" binding:"required" example:"Replace air filters"`
+	Required bool   `json:"required" example:"true"`
+	Note     string `json:"note" example:"Use MERV 13 filters"`
+	// Position in the checklist, starting at 1; omit to append the item
+	Position int `json:"position" example:"1"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text     string `json:"text" binding:"required" example:"Replace air filters"`
+	Required bool   `json:"required" example:"true"`
+	Done     bool   `json:"done" example:"true"`
+	Note     string `json:"note" example:"Filters were clogged"`
+	// Position in the checklist, starting at 1; omit to keep the item in place
+	Position int `json:"position" example:"1"`
+}
+
+// @Summary      List checklist items
+// @Description  List the checklist of a task in order
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {array}   models.TaskChecklistItem
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/checklist [get]
+func (h *TaskChecklistController) GetItems(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	items, err := h.checklistService.GetItems(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary      Add a checklist item
+// @Description  Add an open item to the checklist of a task (max 100 items). Completed tasks cannot get required items.
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id    path int                        true "Task ID"
+// @Param        item  body CreateChecklistItemRequest true "Checklist item"
+// @Success      201  {object}  models.TaskChecklistItem
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/checklist [post]
+func (h *TaskChecklistController) CreateItem(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	var req CreateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item := &models.TaskChecklistItem{
+		Text:     req.Text,
+		Required: req.Required,
+		Note:     req.Note,
+		Position: req.Position,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdItem, err := h.checklistService.CreateItem(c.Request.Context(), taskID, item, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrChecklistOpen:
+			c.JSON(http.StatusConflict, gin.H{"error": "task is completed; reopen it to add required items"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdItem)
+}
+
+// @Summary      Update a checklist item
+// @Description  Change an item, tick it off or move it. Marking it done records who completed it and when. Required items of completed tasks cannot be reopened.
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                        true "Task ID"
+// @Param        itemId  path int                        true "Checklist item ID"
+// @Param        item    body UpdateChecklistItemRequest true "Checklist item"
+// @Success      200  {object}  models.TaskChecklistItem
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/checklist/{itemId} [put]
+func (h *TaskChecklistController) UpdateItem(c *gin.Context) {
+	taskID, itemID, ok := parseChecklistItemPath(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item := &models.TaskChecklistItem{
+		ID:       itemID,
+		Text:     req.Text,
+		Required: req.Required,
+		Done:     req.Done,
+		Note:     req.Note,
+		Position: req.Position,
+	}
+
+	userID := getUserIDFromContext(c)
+	updatedItem, err := h.checklistService.UpdateItem(c.Request.Context(), taskID, item, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "checklist item not found"})
+		case service.ErrChecklistOpen:
+			c.JSON(http.StatusConflict, gin.H{"error": "task is completed; reopen it to leave required items open"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedItem)
+}
+
+// @Summary      Delete a checklist item
+// @Description  Remove an item from the checklist of a task
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id      path int true "Task ID"
+// @Param        itemId  path int true "Checklist item ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/checklist/{itemId} [delete]
+func (h *TaskChecklistController) DeleteItem(c *gin.Context) {
+	taskID, itemID, ok := parseChecklistItemPath(c)
+	if !ok {
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.checklistService.DeleteItem(c.Request.Context(), taskID, itemID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "checklist item not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseChecklistItemPath reads the task and item IDs from the URL, answering
+// 400 when either is invalid
+func parseChecklistItemPath(c *gin.Context) (int64, int64, bool) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return 0, 0, false
+	}
+	itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid checklist item id"})
+		return 0, 0, false
+	}
+	return taskID, itemID, true
+}
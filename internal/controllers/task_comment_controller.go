@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskCommentController struct {
+	commentService *service.TaskCommentService
+}
+
+func NewTaskCommentController(commentService *service.TaskCommentService) *TaskCommentController {
+	return &TaskCommentController{
+		commentService: commentService,
+	}
+}
+
+type CommentRequest struct {
+	Body string `json:"body" binding:"required" example:"@sarah.j@company.com was the filter replaced too?"`
+}
+
+// @Summary      List task comments
+// @Description  List the comments on a task, oldest first
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {array}   models.TaskComment
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/comments [get]
+func (h *TaskCommentController) GetComments(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	comments, err := h.commentService.GetComments(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// @Summary      Comment on a task
+// @Description  Add a comment to a task. Users mentioned as @email who can see the task are notified.
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path int            true "Task ID"
+// @Param        comment  body CommentRequest true "Comment"
+// @Success      201  {object}  models.TaskComment
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/comments [post]
+func (h *TaskCommentController) CreateComment(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	comment, err := h.commentService.CreateComment(c.Request.Context(), taskID, &models.TaskComment{Body: req.Body}, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// @Summary      Edit a task comment
+// @Description  Change the text of a comment; only its author can edit it. Users mentioned for the first time are notified.
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id         path int            true "Task ID"
+// @Param        commentId  path int            true "Comment ID"
+// @Param        comment    body CommentRequest true "Comment"
+// @Success      200  {object}  models.TaskComment
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/comments/{commentId} [put]
+func (h *TaskCommentController) UpdateComment(c *gin.Context) {
+	taskID, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	comment, err := h.commentService.UpdateComment(c.Request.Context(), taskID, commentID, req.Body, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// @Summary      Delete a task comment
+// @Description  Delete a comment; only its author or a manager can delete it
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id         path int true "Task ID"
+// @Param        commentId  path int true "Comment ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/comments/{commentId} [delete]
+func (h *TaskCommentController) DeleteComment(c *gin.Context) {
+	taskID, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.commentService.DeleteComment(c.Request.Context(), taskID, commentID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseCommentPath reads the task and comment IDs from the URL, answering 400
+// when either is invalid
+func parseCommentPath(c *gin.Context) (int64, int64, bool) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return 0, 0, false
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
@@ -1,6 +1,9 @@
 package controllers
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 
@@ -21,19 +24,60 @@ func NewTaskController(taskService *service.TaskService) *TaskController {
 }
 
 type CreateTaskRequest struct {
-	Title       string `json:"title" binding:"required" example:"Fix air conditioning"`
-	Summary     string `json:"summary" binding:"required" example:"Replaced filters and recharged coolant"`
-	PerformedAt string `json:"performed_at" binding:"required" example:"2024-03-20T14:30:00Z"`
+	Title       string   `json:"title" binding:"required" example:"Fix air conditioning"`
+	Summary     string   `json:"summary" binding:"required" example:"Replaced filters and recharged coolant"`
+	PerformedAt string   `json:"performed_at" binding:"required" example:"2024-03-20T14:30:00Z"`
+	Status      string   `json:"status" example:"completed" enums:"open,completed"` // defaults to completed
+	Tags        []string `json:"tags" example:"hvac,preventive"`
+	Priority    string   `json:"priority" example:"normal" enums:"low,normal,high,urgent"` // defaults to normal
+	// When the task has to be completed; defaults to the strictest SLA policy
+	// of its tags when it is created open
+	DueAt string `json:"due_at" example:"2024-03-21T14:30:00Z"`
+	// SiteID defaults to the site of the asset
+	SiteID  *int64 `json:"site_id" example:"1"`
+	AssetID *int64 `json:"asset_id" example:"1"`
+	// Where the technician's device is, checked against the geofence of the
+	// site; Accuracy is in meters
+	Latitude  *float64 `json:"latitude" example:"38.7199"`
+	Longitude *float64 `json:"longitude" example:"-9.1451"`
+	Accuracy  *float64 `json:"accuracy" example:"12"`
 }
 
 type UpdateTaskRequest struct {
 	Title       string `json:"title" binding:"required" example:"Fix air conditioning"`
 	Summary     string `json:"summary" binding:"required" example:"Replaced filters and recharged coolant"`
 	PerformedAt string `json:"performed_at" binding:"required" example:"2024-03-20T14:30:00Z"`
+	// Status can only become completed once every required checklist item is
+	// done; omit it to keep the current status
+	Status string `json:"status" example:"completed" enums:"open,completed"`
+	// Tags replace the task's tags; omit them to keep the current ones
+	Tags []string `json:"tags" example:"hvac,preventive"`
+	// Priority is kept when omitted
+	Priority string `json:"priority" example:"high" enums:"low,normal,high,urgent"`
+	// DueAt replaces the task's due date; omit it to keep the current one
+	DueAt string `json:"due_at" example:"2024-03-21T14:30:00Z"`
+	// SiteID and AssetID are kept when both are omitted, and the asset when
+	// only the current site is given
+	SiteID  *int64 `json:"site_id" example:"1"`
+	AssetID *int64 `json:"asset_id" example:"1"`
+	// Latitude, Longitude and Accuracy replace the task's location; omit them
+	// to keep the current one
+	Latitude  *float64 `json:"latitude" example:"38.7199"`
+	Longitude *float64 `json:"longitude" example:"-9.1451"`
+	Accuracy  *float64 `json:"accuracy" example:"12"`
+}
+
+// requestLocation returns the location sent with a task, nil when the
+// request has none
+func requestLocation(latitude, longitude, accuracy *float64) *models.TaskLocation {
+	if latitude == nil && longitude == nil && accuracy == nil {
+		return nil
+	}
+	return &models.TaskLocation{Latitude: latitude, Longitude: longitude, Accuracy: accuracy}
 }
 
 // @Summary      Create a new task
-// @Description  Create a new task for the authenticated technician
+// @Description  Create a new task for the authenticated technician. A task recorded with the device location outside the geofence of its site is flagged and its technician's manager notified
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
@@ -57,11 +101,23 @@ func (h *TaskController) CreateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid performed_at date format"})
 		return
 	}
+	dueAt, err := parseOptionalTime(req.DueAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid due_at date format"})
+		return
+	}
 
 	task := &models.Task{
 		Title:       req.Title,
 		Summary:     req.Summary,
 		PerformedAt: performedAt,
+		Status:      req.Status,
+		Priority:    req.Priority,
+		Tags:        req.Tags,
+		DueAt:       dueAt,
+		SiteID:      req.SiteID,
+		AssetID:     req.AssetID,
+		Location:    requestLocation(req.Latitude, req.Longitude, req.Accuracy),
 	}
 
 	userID := getUserIDFromContext(c)
@@ -74,12 +130,21 @@ func (h *TaskController) CreateTask(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
 		case service.ErrInvalidInput:
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tags"})
+		case service.ErrUnknownSite:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown site"})
+		case service.ErrUnknownAsset:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown asset"})
+		case service.ErrAssetNotOnSite:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "the asset is not installed on the site"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
 		return
 	}
 
+	c.Header("ETag", taskETag(task.Version))
 	c.JSON(http.StatusCreated, task)
 }
 
@@ -89,7 +154,10 @@ func (h *TaskController) CreateTask(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "Task ID"
+// @Param        If-None-Match header string false "ETag of a cached copy"
 // @Success      200  {object}  models.Task
+// @Header       200  {string}  ETag "Current version of the task"
+// @Success      304  "Not Modified"
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      403  {object}  map[string]string
@@ -118,27 +186,51 @@ func (h *TaskController) GetTask(c *gin.Context) {
 		return
 	}
 
+	etag := taskETag(task.Version)
+	c.Header("ETag", etag)
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, task)
 }
 
 // @Summary      Get all tasks
-// @Description  Get all tasks for the authenticated user (if technician) or all tasks (if manager)
+// @Description  Get all tasks for the authenticated user (if technician) or all tasks (if manager), optionally only those with some tags or SLA status
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
+// @Param        tag    query []string false "Tag names; repeat the parameter for several tags" collectionFormat(multi)
+// @Param        match  query string   false "Whether tasks need any or all of the tags" Enums(any, all) default(any)
+// @Param        sla    query string   false "Only tasks with this SLA status" Enums(on_track, at_risk, breached, met, missed)
+// @Param        geofence query string false "Only tasks whose location has this geofence outcome, or flagged for those outside the geofence not reviewed yet" Enums(inside, outside, unverified, flagged)
 // @Success      200  {array}   models.Task
+// @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/tasks [get]
 func (h *TaskController) GetTasks(c *gin.Context) {
+	filter := models.TaskFilter{Tags: c.QueryArray("tag"), SLA: c.Query("sla"), Geofence: c.Query("geofence")}
+	switch c.DefaultQuery("match", "any") {
+	case "any":
+	case "all":
+		filter.MatchAll = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "match must be any or all"})
+		return
+	}
+
 	userID := getUserIDFromContext(c)
-	tasks, err := h.taskService.GetTasks(c.Request.Context(), userID)
+	tasks, err := h.taskService.GetTasks(c.Request.Context(), userID, filter)
 	if err != nil {
 		switch err {
 		case service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag, sla or geofence filter"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -149,17 +241,23 @@ func (h *TaskController) GetTasks(c *gin.Context) {
 }
 
 // @Summary      Update a task
-// @Description  Update an existing task
+// @Description  Update an existing task. It can only be completed once every required checklist item is done and the tasks it depends on are completed
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "Task ID"
+// @Param        If-Match header string true "ETag returned by GET /api/tasks/{id}"
 // @Param        task body UpdateTaskRequest true "Task Information"
 // @Success      200  {object}  models.Task
+// @Header       200  {string}  ETag "New version of the task"
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      412  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      428  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /api/tasks/{id} [put]
@@ -170,6 +268,17 @@ func (h *TaskController) UpdateTask(c *gin.Context) {
 		return
 	}
 
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return
+	}
+	version, ok := parseETagVersion(ifMatch)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return
+	}
+
 	var req UpdateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -181,12 +290,25 @@ func (h *TaskController) UpdateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid performed_at date format"})
 		return
 	}
+	dueAt, err := parseOptionalTime(req.DueAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid due_at date format"})
+		return
+	}
 
 	task := &models.Task{
 		ID:          taskID,
 		Title:       req.Title,
 		Summary:     req.Summary,
 		PerformedAt: performedAt,
+		Status:      req.Status,
+		Priority:    req.Priority,
+		Tags:        req.Tags,
+		DueAt:       dueAt,
+		SiteID:      req.SiteID,
+		AssetID:     req.AssetID,
+		Location:    requestLocation(req.Latitude, req.Longitude, req.Accuracy),
+		Version:     version,
 	}
 
 	userID := getUserIDFromContext(c)
@@ -196,17 +318,130 @@ func (h *TaskController) UpdateTask(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
 		case service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrPreconditionFailed:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "task was modified by another request"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tags"})
+		case service.ErrUnknownSite:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown site"})
+		case service.ErrUnknownAsset:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown asset"})
+		case service.ErrAssetNotOnSite:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "the asset is not installed on the site"})
+		case service.ErrChecklistOpen:
+			c.JSON(http.StatusConflict, gin.H{"error": "required checklist items are not done"})
+		case service.ErrTaskBlocked:
+			c.JSON(http.StatusConflict, gin.H{"error": "the task depends on tasks that are not completed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(task.Version))
+	c.JSON(http.StatusOK, task)
+}
+
+// @Summary      Partially update a task
+// @Description  Apply a JSON Merge Patch (RFC 7386) to a task. Only title, summary, performed_at, status, priority, tags, due_at, site_id and asset_id can be patched; omitted fields keep their value. Tags, due_at, site_id and asset_id can be removed with null
+// @Tags         tasks
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Param        If-Match header string true "ETag returned by GET /api/tasks/{id}"
+// @Param        patch body object true "Fields to change, e.g. {\"summary\": \"Replaced filters\"}"
+// @Success      200  {object}  models.Task
+// @Header       200  {string}  ETag "New version of the task"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      412  {object}  map[string]string
+// @Failure      415  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      428  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id} [patch]
+func (h *TaskController) PatchTask(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil || mediaType != models.MergePatchContentType {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + models.MergePatchContentType})
+		return
+	}
+
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return
+	}
+	version, ok := parseETagVersion(ifMatch)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	patch, err := models.ParseTaskMergePatch(body)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidPatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	task, err := h.taskService.PatchTask(c.Request.Context(), taskID, patch, version, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrPreconditionFailed:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "task was modified by another request"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tags"})
+		case service.ErrUnknownSite:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown site"})
+		case service.ErrUnknownAsset:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown asset"})
+		case service.ErrAssetNotOnSite:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "the asset is not installed on the site"})
+		case service.ErrChecklistOpen:
+			c.JSON(http.StatusConflict, gin.H{"error": "required checklist items are not done"})
+		case service.ErrTaskBlocked:
+			c.JSON(http.StatusConflict, gin.H{"error": "the task depends on tasks that are not completed"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
 		return
 	}
 
+	c.Header("ETag", taskETag(task.Version))
 	c.JSON(http.StatusOK, task)
 }
 
 // @Summary      Delete a task
-// @Description  Delete a task by its ID
+// @Description  Move a task to the trash; it can be restored until the trash retention window expires
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
@@ -241,3 +476,112 @@ func (h *TaskController) DeleteTask(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary      Restore a deleted task
+// @Description  Restore a task from the trash
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {object}  models.Task
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/restore [post]
+func (h *TaskController) RestoreTask(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	task, err := h.taskService.RestoreTask(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "deleted task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// @Summary      Review a task location
+// @Description  Mark a task recorded outside the geofence of its site as reviewed, which takes it off the flagged tasks (managers only)
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {object}  models.Task
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/location/review [post]
+func (h *TaskController) ReviewLocation(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	task, err := h.taskService.ReviewLocation(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrLocationNotFlagged:
+			c.JSON(http.StatusConflict, gin.H{"error": "the task location is not flagged for review"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// @Summary      List deleted tasks
+// @Description  List the tasks in the trash, most recently deleted first
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.Task
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/trash [get]
+func (h *TaskController) GetDeletedTasks(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	tasks, err := h.taskService.GetDeletedTasks(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
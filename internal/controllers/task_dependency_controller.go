@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	_ "sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskDependencyController struct {
+	dependencyService *service.TaskDependencyService
+}
+
+func NewTaskDependencyController(dependencyService *service.TaskDependencyService) *TaskDependencyController {
+	return &TaskDependencyController{
+		dependencyService: dependencyService,
+	}
+}
+
+type CreateTaskDependencyRequest struct {
+	// ID of the task that has to be completed first
+	DependsOnID int64 `json:"depends_on_id" binding:"required" example:"1"`
+}
+
+// @Summary      Get the dependency graph of a task
+// @Description  List the tasks a task depends on, directly or not, and the tasks depending on it, with the dependencies between them. Technicians get no titles for other technicians' tasks
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {object}  models.TaskDependencyGraph
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/dependencies [get]
+func (h *TaskDependencyController) GetGraph(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	graph, err := h.dependencyService.GetGraph(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
+// @Summary      Add a dependency
+// @Description  Make a task wait for another one: it cannot be completed before its prerequisite. Links closing a cycle are rejected, as are open prerequisites of a completed task
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id          path int                         true "Task ID"
+// @Param        dependency  body CreateTaskDependencyRequest true "Prerequisite"
+// @Success      201  {object}  models.TaskDependencyGraph
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/dependencies [post]
+func (h *TaskDependencyController) AddDependency(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	var req CreateTaskDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	graph, err := h.dependencyService.AddDependency(c.Request.Context(), taskID, req.DependsOnID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrDependencyCycle:
+			c.JSON(http.StatusConflict, gin.H{"error": "the prerequisite already depends on the task"})
+		case service.ErrDependencyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "the task already depends on the prerequisite"})
+		case service.ErrTaskBlocked:
+			c.JSON(http.StatusConflict, gin.H{"error": "a completed task cannot depend on a task that is not completed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, graph)
+}
+
+// @Summary      Remove a dependency
+// @Description  Stop a task waiting for a prerequisite. When it was the last one, the technician of the open task is notified
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id           path int true "Task ID"
+// @Param        dependsOnId  path int true "Prerequisite task ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/dependencies/{dependsOnId} [delete]
+func (h *TaskDependencyController) RemoveDependency(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+	dependsOnID, err := strconv.ParseInt(c.Param("dependsOnId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid prerequisite id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.dependencyService.RemoveDependency(c.Request.Context(), taskID, dependsOnID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "dependency not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
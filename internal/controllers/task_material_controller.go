@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskMaterialController struct {
+	materialService *service.TaskMaterialService
+}
+
+func NewTaskMaterialController(materialService *service.TaskMaterialService) *TaskMaterialController {
+	return &TaskMaterialController{
+		materialService: materialService,
+	}
+}
+
+type TaskMaterialRequest struct {
+	PartID   int64  `json:"part_id" binding:"required" example:"1"`
+	Quantity int    `json:"quantity" binding:"required" example:"2"`
+	Note     string `json:"note" example:"Rooftop unit 2"`
+}
+
+// @Summary      List task materials
+// @Description  List the parts used on a task, in the order they were recorded
+// @Tags         materials
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {array}   models.TaskMaterial
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/materials [get]
+func (h *TaskMaterialController) GetMaterials(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	materials, err := h.materialService.GetMaterials(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, materials)
+}
+
+// @Summary      Record a material
+// @Description  Record parts used on a task and take them out of stock. Managers are notified when the part reaches its low stock threshold
+// @Tags         materials
+// @Accept       json
+// @Produce      json
+// @Param        id        path int                 true "Task ID"
+// @Param        material  body TaskMaterialRequest true "Material"
+// @Success      201  {object}  models.TaskMaterial
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/materials [post]
+func (h *TaskMaterialController) AddMaterial(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	var req TaskMaterialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	material := &models.TaskMaterial{
+		PartID:   req.PartID,
+		Quantity: req.Quantity,
+		Note:     req.Note,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdMaterial, err := h.materialService.AddMaterial(c.Request.Context(), taskID, material, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case service.ErrInsufficientStock:
+			c.JSON(http.StatusConflict, gin.H{"error": "not enough parts in stock"})
+		case service.ErrUnknownPart:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown part"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdMaterial)
+}
+
+// @Summary      Remove a material
+// @Description  Remove a material recorded by mistake and put its quantity back in stock
+// @Tags         materials
+// @Accept       json
+// @Produce      json
+// @Param        id          path int true "Task ID"
+// @Param        materialId  path int true "Material ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/materials/{materialId} [delete]
+func (h *TaskMaterialController) RemoveMaterial(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+	materialID, err := strconv.ParseInt(c.Param("materialId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid material id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.materialService.RemoveMaterial(c.Request.Context(), taskID, materialID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "material not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"net/http"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskSearchController struct {
+	searchService *service.TaskSearchService
+}
+
+func NewTaskSearchController(searchService *service.TaskSearchService) *TaskSearchController {
+	return &TaskSearchController{
+		searchService: searchService,
+	}
+}
+
+// @Summary      Search tasks
+// @Description  Full-text search over task titles and summaries, ranked by relevance. Technicians only get their own tasks
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        q      query string true  "Search text (max 200 characters)"
+// @Param        limit  query int    false "Maximum number of results (1-100)" default(20)
+// @Success      200  {array}   models.TaskSearchResult
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/search [get]
+func (h *TaskSearchController) SearchTasks(c *gin.Context) {
+	limit, err := queryInt64(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+	query := models.TaskSearchQuery{Query: c.Query("q"), Limit: int(limit)}
+
+	userID := getUserIDFromContext(c)
+	results, err := h.searchService.Search(c.Request.Context(), query, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,371 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskTemplateController struct {
+	templateService *service.TaskTemplateService
+}
+
+func NewTaskTemplateController(templateService *service.TaskTemplateService) *TaskTemplateController {
+	return &TaskTemplateController{
+		templateService: templateService,
+	}
+}
+
+type TaskTemplateRequest struct {
+	Name         string                             `json:"name" binding:"required" example:"HVAC filter replacement"`
+	TitlePattern string                             `json:"title_pattern" binding:"required" example:"Replace HVAC filters - {{site}}"`
+	Summary      string                             `json:"summary" example:"Replaced the air filters and checked the airflow."`
+	Checklist    []models.TaskTemplateChecklistItem `json:"checklist"`
+	Tags         []string                           `json:"tags" example:"hvac,preventive"`
+	// Expected duration in minutes, 0 when unknown (max 10080)
+	EstimatedMinutes int `json:"estimated_minutes" example:"45"`
+}
+
+type CreateTaskFromTemplateRequest struct {
+	// When the task was performed (ISO 8601 format)
+	PerformedAt string `json:"performed_at" binding:"required" example:"2024-03-20T14:30:00Z"`
+	// Values of the title pattern placeholders
+	Variables map[string]string `json:"variables"`
+	// Overrides the title rendered from the title pattern
+	Title string `json:"title" example:"Replace HVAC filters - Lisbon office"`
+	// Overrides the template summary
+	Summary string `json:"summary" example:"Replaced the air filters and checked the airflow."`
+	// open (default) while work is pending, completed once done
+	Status string `json:"status" example:"open" enums:"open,completed"`
+	// low, normal (default), high or urgent
+	Priority string `json:"priority" example:"normal" enums:"low,normal,high,urgent"`
+	// Overrides the template tags; send [] for none
+	Tags []string `json:"tags" example:"hvac,preventive"`
+}
+
+// @Summary      List task templates
+// @Description  List the current revision of every template, by name
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.TaskTemplate
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/task-templates [get]
+func (h *TaskTemplateController) GetTemplates(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	templates, err := h.templateService.GetTemplates(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// @Summary      Get a task template
+// @Description  Get the current revision of a template
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Template ID"
+// @Success      200  {object}  models.TaskTemplate
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/task-templates/{id} [get]
+func (h *TaskTemplateController) GetTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	template, err := h.templateService.GetTemplate(c.Request.Context(), templateID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// @Summary      Get a task template revision
+// @Description  Get a past or current revision of a template, e.g. the one a task was created from. Revisions of deleted templates remain available
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id        path int true "Template ID"
+// @Param        revision  path int true "Revision number"
+// @Success      200  {object}  models.TaskTemplate
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/task-templates/{id}/revisions/{revision} [get]
+func (h *TaskTemplateController) GetTemplateRevision(c *gin.Context) {
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	template, err := h.templateService.GetTemplateRevision(c.Request.Context(), templateID, revision, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "template revision not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// @Summary      Create a task template
+// @Description  Add a template for a routine job (Manager only). Placeholders such as {{site}} in the title pattern are filled when a task is created; {{date}} defaults to the performed_at date
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        template body TaskTemplateRequest true "Template"
+// @Success      201  {object}  models.TaskTemplate
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/task-templates [post]
+func (h *TaskTemplateController) CreateTemplate(c *gin.Context) {
+	var req TaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template := &models.TaskTemplate{
+		Name:             req.Name,
+		TitlePattern:     req.TitlePattern,
+		Summary:          req.Summary,
+		Checklist:        req.Checklist,
+		Tags:             req.Tags,
+		EstimatedMinutes: req.EstimatedMinutes,
+	}
+
+	userID := getUserIDFromContext(c)
+	template, err := h.templateService.CreateTemplate(c.Request.Context(), template, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tags"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// @Summary      Update a task template
+// @Description  Save a new revision of a template (Manager only). Tasks created earlier keep pointing at the revision they were created from
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id        path int                 true "Template ID"
+// @Param        template  body TaskTemplateRequest true "Template"
+// @Success      200  {object}  models.TaskTemplate
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/task-templates/{id} [put]
+func (h *TaskTemplateController) UpdateTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return
+	}
+
+	var req TaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template := &models.TaskTemplate{
+		ID:               templateID,
+		Name:             req.Name,
+		TitlePattern:     req.TitlePattern,
+		Summary:          req.Summary,
+		Checklist:        req.Checklist,
+		Tags:             req.Tags,
+		EstimatedMinutes: req.EstimatedMinutes,
+	}
+
+	userID := getUserIDFromContext(c)
+	template, err = h.templateService.UpdateTemplate(c.Request.Context(), template, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tags"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// @Summary      Delete a task template
+// @Description  Remove a template from the list (Manager only). Its revisions remain available to the tasks created from it
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Template ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/task-templates/{id} [delete]
+func (h *TaskTemplateController) DeleteTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), templateID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Create a task from a template
+// @Description  Create a task from the current revision of a template (Technician only). The title is rendered from the title pattern with the given variables; summary, tags and status (default open) can be overridden. The template checklist is copied to the task
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id    path int                           true "Template ID"
+// @Param        task  body CreateTaskFromTemplateRequest true "Task fields"
+// @Success      201  {object}  models.Task
+// @Header       201  {string}  ETag "Current version of the task"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/from-template/{id} [post]
+func (h *TaskTemplateController) CreateTask(c *gin.Context) {
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return
+	}
+
+	var req CreateTaskFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	performedAt, err := parseTime(req.PerformedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid performed_at date format"})
+		return
+	}
+
+	fields := &models.TaskFromTemplate{
+		Title:       req.Title,
+		Summary:     req.Summary,
+		PerformedAt: performedAt,
+		Status:      req.Status,
+		Priority:    req.Priority,
+		Tags:        req.Tags,
+		Variables:   req.Variables,
+	}
+
+	userID := getUserIDFromContext(c)
+	task, err := h.templateService.CreateTask(c.Request.Context(), templateID, fields, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+		case service.ErrUnknownTags:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "unknown tags"})
+		case service.ErrChecklistOpen:
+			c.JSON(http.StatusConflict, gin.H{"error": "required checklist items are not done"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(task.Version))
+	c.JSON(http.StatusCreated, task)
+}
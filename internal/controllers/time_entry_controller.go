@@ -0,0 +1,317 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeEntryController struct {
+	timeEntryService *service.TimeEntryService
+}
+
+func NewTimeEntryController(timeEntryService *service.TimeEntryService) *TimeEntryController {
+	return &TimeEntryController{
+		timeEntryService: timeEntryService,
+	}
+}
+
+type CreateTimeEntryRequest struct {
+	StartedAt string `json:"started_at" binding:"required" example:"2024-03-20T13:00:00Z"`
+	// EndedAt closes the entry; omit it to start a timer at started_at
+	EndedAt string `json:"ended_at" example:"2024-03-20T14:30:00Z"`
+	Note    string `json:"note" example:"Replaced the compressor"`
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note" example:"On site"`
+}
+
+// @Summary      List time entries
+// @Description  List the time entries of a task, oldest first, with the total time spent on it. Running timers count up to now
+// @Tags         time-entries
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {object}  models.TaskTimeEntries
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/time-entries [get]
+func (h *TimeEntryController) GetEntries(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	entries, err := h.timeEntryService.GetEntries(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// @Summary      Record a time entry
+// @Description  Record time spent on one of your tasks. Entries cannot be in the future nor overlap your other entries; without ended_at a timer starts at started_at
+// @Tags         time-entries
+// @Accept       json
+// @Produce      json
+// @Param        id     path int                    true "Task ID"
+// @Param        entry  body CreateTimeEntryRequest true "Time entry"
+// @Success      201  {object}  models.TimeEntry
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/time-entries [post]
+func (h *TimeEntryController) CreateEntry(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	var req CreateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startedAt, err := parseTime(req.StartedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid started_at date format"})
+		return
+	}
+	endedAt, err := parseOptionalTime(req.EndedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ended_at date format"})
+		return
+	}
+
+	entry := &models.TimeEntry{
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Note:      req.Note,
+	}
+
+	userID := getUserIDFromContext(c)
+	createdEntry, err := h.timeEntryService.CreateEntry(c.Request.Context(), taskID, entry, userID)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdEntry)
+}
+
+// @Summary      Start a timer
+// @Description  Start tracking time on one of your tasks now. You can only run one timer at a time
+// @Tags         time-entries
+// @Accept       json
+// @Produce      json
+// @Param        id     path int               true  "Task ID"
+// @Param        timer  body StartTimerRequest false "Timer"
+// @Success      201  {object}  models.TimeEntry
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      422  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/time-entries/start [post]
+func (h *TimeEntryController) StartTimer(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	// The body is optional
+	var req StartTimerRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID := getUserIDFromContext(c)
+	entry, err := h.timeEntryService.StartTimer(c.Request.Context(), taskID, req.Note, userID)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// @Summary      Stop the timer
+// @Description  Stop your running timer on a task
+// @Tags         time-entries
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Task ID"
+// @Success      200  {object}  models.TimeEntry
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/time-entries/stop [post]
+func (h *TimeEntryController) StopTimer(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	entry, err := h.timeEntryService.StopTimer(c.Request.Context(), taskID, userID)
+	if err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "no running timer on this task"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// @Summary      Delete a time entry
+// @Description  Delete a time entry. Technicians can only delete their own entries
+// @Tags         time-entries
+// @Accept       json
+// @Produce      json
+// @Param        id       path int true "Task ID"
+// @Param        entryId  path int true "Time entry ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/tasks/{id}/time-entries/{entryId} [delete]
+func (h *TimeEntryController) DeleteEntry(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+	entryID, err := strconv.ParseInt(c.Param("entryId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time entry id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	if err := h.timeEntryService.DeleteEntry(c.Request.Context(), taskID, entryID, userID); err != nil {
+		switch err {
+		case service.ErrUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "time entry not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Time tracking report
+// @Description  Total the time spent in a period per technician and per task. Entries crossing the bounds only count for their part inside the period, and running timers count up to now. Technicians only get their own figures
+// @Tags         time-entries
+// @Accept       json
+// @Produce      json
+// @Param        from           query string true  "Start of the period, inclusive (ISO 8601 format)"
+// @Param        to             query string true  "End of the period, exclusive (ISO 8601 format)"
+// @Param        technician_id  query int    false "Restrict the report to a technician (managers only)"
+// @Success      200  {object}  models.TimeReport
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /api/time-entries/report [get]
+func (h *TimeEntryController) GetReport(c *gin.Context) {
+	from, err := parseTime(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date format"})
+		return
+	}
+	to, err := parseTime(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date format"})
+		return
+	}
+	technicianID, err := queryInt64(c, "technician_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid technician_id"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	query := models.TimeReportQuery{From: from, To: to, TechnicianID: technicianID}
+	report, err := h.timeEntryService.GetReport(c.Request.Context(), query, userID)
+	if err != nil {
+		switch err {
+		case service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case service.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// handleWriteError maps the errors of recording time to responses
+func (h *TimeEntryController) handleWriteError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+	case service.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case service.ErrTimeEntryOverlap:
+		c.JSON(http.StatusConflict, gin.H{"error": "the time entry overlaps another of your entries or a running timer"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid input"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
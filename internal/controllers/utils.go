@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,3 +20,24 @@ func getUserIDFromContext(c *gin.Context) int64 {
 func parseTime(timeStr string) (time.Time, error) {
 	return time.Parse(time.RFC3339, timeStr)
 }
+
+// parseOptionalTime parses an optional RFC3339 time, returning nil when empty
+func parseOptionalTime(timeStr string) (*time.Time, error) {
+	if timeStr == "" {
+		return nil, nil
+	}
+	t, err := parseTime(timeStr)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// queryInt64 parses an optional integer query parameter, returning 0 when absent
+func queryInt64(c *gin.Context, key string) (int64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
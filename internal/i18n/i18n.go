@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+	_ "time/tzdata" // timezone database for containers without zoneinfo
+)
+
+// Template keys, one per notification event type
+const (
+	KeyTaskPerformed  = "task_performed"
+	KeyCommentMention = "comment_mention"
+	KeyTaskAtRisk     = "task_at_risk"
+	KeyTaskBreached   = "task_breached"
+	KeyTaskUnblocked  = "task_unblocked"
+	KeyPartLowStock   = "part_low_stock"
+	KeyTaskOffSite    = "task_off_site"
+)
+
+// DefaultLocale is used when the user has no locale or it is not supported
+const DefaultLocale = "en"
+
+var ErrUnknownTemplate = errors.New("unknown notification template")
+
+// dateLayouts holds the date format used by each locale
+var dateLayouts = map[string]string{
+	"en": "2006-01-02 15:04:05",
+	"pt": "02/01/2006 15:04:05",
+	"es": "02/01/2006 15:04:05",
+}
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// catalog maps a locale to the parsed templates of that locale
+var catalog = mustLoadCatalog()
+
+func mustLoadCatalog() map[string]*template.Template {
+	files, err := templateFS.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+
+	catalog := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		locale := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		tmpl := template.New(locale).Funcs(template.FuncMap{
+			"datetime": func(string) string { return "" },
+		})
+		catalog[locale] = template.Must(tmpl.ParseFS(templateFS, "templates/"+file.Name()))
+	}
+	return catalog
+}
+
+// Supported reports whether a locale (or its base language) has templates
+func Supported(locale string) bool {
+	locale = normalizeLocale(locale)
+	_, exact := catalog[locale]
+	_, base := catalog[baseLanguage(locale)]
+	return exact || base
+}
+
+// resolveLocale picks the best available locale, e.g. "pt-BR" -> "pt"
+func resolveLocale(locale string) string {
+	locale = normalizeLocale(locale)
+	if _, ok := catalog[locale]; ok {
+		return locale
+	}
+	if _, ok := catalog[baseLanguage(locale)]; ok {
+		return baseLanguage(locale)
+	}
+	return DefaultLocale
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func baseLanguage(locale string) string {
+	if i := strings.Index(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// Render executes the template identified by key for the given locale and
+// timezone. Dates are passed in params as RFC3339 strings; an empty timezone
+// keeps the offset they were stored with.
+func Render(key, locale, timezone string, params map[string]string) (string, error) {
+	locale = resolveLocale(locale)
+	base := catalog[locale]
+	if base.Lookup(key) == nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownTemplate, key)
+	}
+
+	var loc *time.Location
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			loc = nil
+		}
+	}
+
+	tmpl, err := base.Clone()
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{
+		"datetime": func(value string) string {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return value
+			}
+			if loc != nil {
+				t = t.In(loc)
+			}
+			return t.Format(dateLayouts[locale])
+		},
+	})
+
+	var sb strings.Builder
+	if err := tmpl.ExecuteTemplate(&sb, key, params); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
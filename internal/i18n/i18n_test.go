@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	params := map[string]string{
+		"tech_name":    "John Doe",
+		"performed_at": "2024-03-20T14:30:00Z",
+		"author_name":  "Jane Roe",
+		"task_id":      "7",
+		"title":        "Fix AC",
+		"due_at":       "2024-03-21T14:30:00Z",
+		"sku":          "FLT-MERV13",
+		"name":         "MERV 13 air filter",
+		"unit":         "pcs",
+		"stock":        "4",
+		"site_name":    "Lisbon headquarters",
+		"distance":     "1250",
+	}
+	tests := []struct {
+		name     string
+		key      string
+		locale   string
+		timezone string
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "default locale keeps stored offset",
+			key:  KeyTaskPerformed,
+			want: "The tech John Doe performed the task on 2024-03-20 14:30:00",
+		},
+		{
+			name:     "portuguese with regional locale and timezone",
+			key:      KeyTaskPerformed,
+			locale:   "pt-BR",
+			timezone: "America/Sao_Paulo",
+			want:     "O técnico John Doe realizou a tarefa em 20/03/2024 11:30:00",
+		},
+		{
+			name:     "spanish",
+			key:      KeyTaskPerformed,
+			locale:   "es",
+			timezone: "Europe/Madrid",
+			want:     "El técnico John Doe realizó la tarea el 20/03/2024 15:30:00",
+		},
+		{
+			name:   "unsupported locale falls back to english",
+			key:    KeyTaskPerformed,
+			locale: "fr",
+			want:   "The tech John Doe performed the task on 2024-03-20 14:30:00",
+		},
+		{
+			name:     "invalid timezone keeps stored offset",
+			key:      KeyTaskPerformed,
+			timezone: "Mars/Olympus",
+			want:     "The tech John Doe performed the task on 2024-03-20 14:30:00",
+		},
+		{
+			name: "comment mention",
+			key:  KeyCommentMention,
+			want: "Jane Roe mentioned you in a comment on task #7",
+		},
+		{
+			name:   "comment mention in portuguese",
+			key:    KeyCommentMention,
+			locale: "pt",
+			want:   "Jane Roe mencionou você em um comentário na tarefa #7",
+		},
+		{
+			name: "task at risk",
+			key:  KeyTaskAtRisk,
+			want: `Task #7 "Fix AC" of John Doe is at risk of missing its due date 2024-03-21 14:30:00`,
+		},
+		{
+			name:     "task breached in spanish",
+			key:      KeyTaskBreached,
+			locale:   "es",
+			timezone: "Europe/Madrid",
+			want:     `La tarea #7 "Fix AC" de John Doe incumplió su plazo del 21/03/2024 15:30:00`,
+		},
+		{
+			name:   "task unblocked in portuguese",
+			key:    KeyTaskUnblocked,
+			locale: "pt",
+			want:   `A tarefa #7 "Fix AC" está desbloqueada: as tarefas de que depende estão concluídas`,
+		},
+		{
+			name:   "part low stock in spanish",
+			key:    KeyPartLowStock,
+			locale: "es",
+			want:   `La pieza FLT-MERV13 "MERV 13 air filter" tiene poco stock: quedan 4 pcs tras la tarea #7`,
+		},
+		{
+			name:   "task off site in english",
+			key:    KeyTaskOffSite,
+			locale: "en",
+			want:   `Task #7 "Fix AC" of John Doe was recorded 1250 m from site "Lisbon headquarters", outside its geofence`,
+		},
+		{
+			name:    "unknown template",
+			key:     "missing",
+			wantErr: ErrUnknownTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.key, tt.locale, tt.timezone, params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupported(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   bool
+	}{
+		{"en", true},
+		{"pt-BR", true},
+		{"es_MX", true},
+		{"fr", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := Supported(tt.locale); got != tt.want {
+				t.Errorf("Supported(%q) = %v, want %v", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+func findPNGChunk(data []byte, name string) []byte {
+	for i := len(pngSignature); i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 8 + length + 4 // header, data, CRC
+		if length < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[i+4:i+8]) == name {
+			return data[i+8 : i+8+length]
+		}
+		i = end
+	}
+	return nil
+}
+
+func isWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// webpChunks calls fn for every chunk of a WebP file with its fourcc and the
+// full chunk bytes (header, data and padding)
+func webpChunks(data []byte, fn func(fourcc string, chunk []byte)) {
+	for i := 12; i+8 <= len(data); {
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			end = len(data)
+		}
+		fn(string(data[i:i+4]), data[i:end])
+		i = end
+	}
+}
+
+func findWebPChunk(data []byte, name string) []byte {
+	var found []byte
+	webpChunks(data, func(fourcc string, chunk []byte) {
+		if found == nil && fourcc == name && len(chunk) >= 8 {
+			size := int(binary.LittleEndian.Uint32(chunk[4:]))
+			found = chunk[8:min(8+size, len(chunk))]
+		}
+	})
+	return found
+}
+
+// VP8X feature flags
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebPMetadata drops the EXIF and XMP chunks of a WebP file without
+// re-encoding the image
+func stripWebPMetadata(data []byte) []byte {
+	var out bytes.Buffer
+	out.Write(data[:12])
+	webpChunks(data, func(fourcc string, chunk []byte) {
+		switch fourcc {
+		case "EXIF", "XMP ":
+			return
+		case "VP8X":
+			chunk = append([]byte(nil), chunk...)
+			if len(chunk) > 8 {
+				chunk[8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		}
+		out.Write(chunk)
+	})
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+	return stripped
+}
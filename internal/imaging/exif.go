@@ -0,0 +1,165 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// EXIF tags read by the pipeline
+const (
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+)
+
+const exifDateLayout = "2006:01:02 15:04:05"
+
+// exifInfo is the metadata kept from an image before its EXIF is removed
+type exifInfo struct {
+	orientation int
+	capturedAt  *time.Time
+}
+
+// findEXIF returns the TIFF-formatted EXIF block of a JPEG, PNG or WebP file,
+// or nil when there is none
+func findEXIF(data []byte) []byte {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		return findJPEGEXIF(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return findPNGChunk(data, "eXIf")
+	case isWebP(data):
+		exif := findWebPChunk(data, "EXIF")
+		return bytes.TrimPrefix(exif, []byte("Exif\x00\x00"))
+	}
+	return nil
+}
+
+func findJPEGEXIF(data []byte) []byte {
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // start of scan, end of image
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+		segment := data[i+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i = end
+	}
+	return nil
+}
+
+// parseEXIF reads the orientation and capture time from a TIFF block.
+// Malformed data yields the zero values.
+func parseEXIF(tiff []byte) exifInfo {
+	info := exifInfo{orientation: 1}
+	if len(tiff) < 8 {
+		return info
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return info
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return info
+	}
+
+	ifd0 := readIFD(tiff, order, order.Uint32(tiff[4:]))
+	if v, ok := ifd0[tagOrientation]; ok && v.short >= 1 && v.short <= 8 {
+		info.orientation = int(v.short)
+	}
+
+	dateTime, offset := ifd0[tagDateTime].text, ""
+	if ptr, ok := ifd0[tagExifIFD]; ok {
+		exif := readIFD(tiff, order, ptr.long)
+		if v, ok := exif[tagDateTimeOriginal]; ok && v.text != "" {
+			dateTime = v.text
+		}
+		offset = exif[tagOffsetTimeOriginal].text
+	}
+	info.capturedAt = parseEXIFTime(dateTime, offset)
+	return info
+}
+
+// ifdValue holds the decoded value of the tag types the pipeline needs
+type ifdValue struct {
+	short uint16
+	long  uint32
+	text  string
+}
+
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32) map[uint16]ifdValue {
+	values := make(map[uint16]ifdValue)
+	if int(offset)+2 > len(tiff) {
+		return values
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := int(offset) + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		tag := order.Uint16(tiff[entry:])
+		typ := order.Uint16(tiff[entry+2:])
+		n := order.Uint32(tiff[entry+4:])
+		raw := tiff[entry+8 : entry+12]
+
+		switch typ {
+		case 2: // ASCII, inline when it fits in 4 bytes
+			text := raw
+			if n > 4 {
+				start := order.Uint32(raw)
+				if uint64(start)+uint64(n) > uint64(len(tiff)) {
+					continue
+				}
+				text = tiff[start : start+n]
+			} else {
+				text = raw[:n]
+			}
+			values[tag] = ifdValue{text: strings.TrimRight(string(text), "\x00 ")}
+		case 3: // SHORT
+			values[tag] = ifdValue{short: order.Uint16(raw)}
+		case 4: // LONG
+			values[tag] = ifdValue{long: order.Uint32(raw)}
+		}
+	}
+	return values
+}
+
+// parseEXIFTime reads an EXIF date. Without an offset the camera's local time
+// is unknown and the value is taken as UTC.
+func parseEXIFTime(value, offset string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	loc := time.UTC
+	if offset != "" {
+		if t, err := time.Parse("-07:00", offset); err == nil {
+			loc = t.Location()
+		}
+	}
+	t, err := time.ParseInLocation(exifDateLayout, value, loc)
+	if err != nil || t.Year() < 1900 {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,162 @@
+// Package imaging prepares uploaded photos: it removes their metadata (EXIF
+// GPS position, camera serials) and makes thumbnails.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register the WebP decoder
+)
+
+// MaxPixels bounds the decoded size of an image, so a small file cannot
+// expand into gigabytes of memory
+const MaxPixels = 50_000_000
+
+const (
+	originalQuality  = 90
+	thumbnailQuality = 80
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrImageTooLarge     = errors.New("image has too many pixels")
+)
+
+// Result is a processed image
+type Result struct {
+	// Original is the image without metadata, in its original format
+	Original []byte
+	// Thumbnails holds a JPEG per requested size name
+	Thumbnails map[string][]byte
+	// Width and Height are the dimensions as displayed (after orientation)
+	Width  int
+	Height int
+	// CapturedAt is the EXIF capture time, nil when unknown
+	CapturedAt *time.Time
+}
+
+// Process strips the metadata of a JPEG, PNG or WebP image and renders one
+// JPEG thumbnail per entry of sizes, fitting the longest side in that many
+// pixels. JPEG orientation is applied to the pixels since the EXIF tag that
+// carried it is removed.
+func Process(data []byte, sizes map[string]int) (*Result, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	info := parseEXIF(findEXIF(data))
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Thumbnails: make(map[string][]byte, len(sizes)), CapturedAt: info.capturedAt}
+	var out bytes.Buffer
+	switch format {
+	case "jpeg":
+		img = orient(img, info.orientation)
+		if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: originalQuality}); err != nil {
+			return nil, err
+		}
+		result.Original = out.Bytes()
+	case "png":
+		// Re-encoding drops every ancillary chunk (eXIf, tEXt, iTXt, ...)
+		if err := png.Encode(&out, img); err != nil {
+			return nil, err
+		}
+		result.Original = out.Bytes()
+	case "webp":
+		// There is no WebP encoder; remove the metadata chunks instead
+		result.Original = stripWebPMetadata(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	bounds := img.Bounds()
+	result.Width, result.Height = bounds.Dx(), bounds.Dy()
+	for name, size := range sizes {
+		thumbnail, err := thumbnail(img, size)
+		if err != nil {
+			return nil, err
+		}
+		result.Thumbnails[name] = thumbnail
+	}
+	return result, nil
+}
+
+// thumbnail scales img to fit in a size×size box, never enlarging it, over a
+// white background for images with transparency
+func thumbnail(img image.Image, size int) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// orient turns the pixels so the image displays upright without its EXIF
+// orientation tag (values 2-8; 1 is already upright)
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // needs a 90° clockwise turn
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // needs a 90° counter-clockwise turn
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,179 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+)
+
+// testEXIF builds a little-endian TIFF block with an orientation and a
+// DateTimeOriginal
+func testEXIF(orientation uint16, captured string) []byte {
+	le := binary.LittleEndian
+	tiff := make([]byte, 56)
+	copy(tiff, "II")
+	le.PutUint16(tiff[2:], 42)
+	le.PutUint32(tiff[4:], 8)
+
+	// IFD0: orientation and pointer to the EXIF IFD at 38
+	le.PutUint16(tiff[8:], 2)
+	le.PutUint16(tiff[10:], tagOrientation)
+	le.PutUint16(tiff[12:], 3)
+	le.PutUint32(tiff[14:], 1)
+	le.PutUint16(tiff[18:], orientation)
+	le.PutUint16(tiff[22:], tagExifIFD)
+	le.PutUint16(tiff[24:], 4)
+	le.PutUint32(tiff[26:], 1)
+	le.PutUint32(tiff[30:], 38)
+
+	// EXIF IFD: DateTimeOriginal stored at 56
+	le.PutUint16(tiff[38:], 1)
+	le.PutUint16(tiff[40:], tagDateTimeOriginal)
+	le.PutUint16(tiff[42:], 2)
+	le.PutUint32(tiff[44:], uint32(len(captured)+1))
+	le.PutUint32(tiff[48:], 56)
+	return append(tiff, append([]byte(captured), 0)...)
+}
+
+// halfAndHalf is a landscape image, red on the left and blue on the right
+func halfAndHalf(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xC000 && b < 0x4000
+}
+
+func TestProcess_JPEG(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, halfAndHalf(40, 20), &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	tiff := testEXIF(6, "2024:03:20 14:30:00")
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(2+6+len(tiff)))
+	app1 = append(append(app1, "Exif\x00\x00"...), tiff...)
+	data := append(append([]byte{0xFF, 0xD8}, app1...), encoded.Bytes()[2:]...)
+
+	result, err := Process(data, map[string]int{"small": 10})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if bytes.Contains(result.Original, []byte("Exif")) {
+		t.Errorf("Process() kept the EXIF block")
+	}
+	want := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	if result.CapturedAt == nil || !result.CapturedAt.Equal(want) {
+		t.Errorf("Process() CapturedAt = %v, want %v", result.CapturedAt, want)
+	}
+
+	// Orientation 6 turns the landscape image upright: red on top
+	if result.Width != 20 || result.Height != 40 {
+		t.Fatalf("Process() size = %dx%d, want 20x40", result.Width, result.Height)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(result.Original))
+	if err != nil {
+		t.Fatalf("decoding the original: %v", err)
+	}
+	if !isRed(img.At(10, 5)) || isRed(img.At(10, 35)) {
+		t.Errorf("Process() did not apply the orientation")
+	}
+
+	thumb, err := jpeg.Decode(bytes.NewReader(result.Thumbnails["small"]))
+	if err != nil {
+		t.Fatalf("decoding the thumbnail: %v", err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 5 || b.Dy() != 10 {
+		t.Errorf("thumbnail size = %dx%d, want 5x10", b.Dx(), b.Dy())
+	}
+}
+
+func TestProcess_PNG(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, halfAndHalf(8, 8)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Insert an eXIf chunk after IHDR (signature 8 bytes + IHDR 25 bytes)
+	tiff := testEXIF(1, "2023:01:02 03:04:05")
+	chunk := make([]byte, 4, 12+len(tiff))
+	binary.BigEndian.PutUint32(chunk, uint32(len(tiff)))
+	chunk = append(append(chunk, "eXIf"...), tiff...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	raw := encoded.Bytes()
+	data := append(append(append([]byte{}, raw[:33]...), chunk...), raw[33:]...)
+
+	result, err := Process(data, map[string]int{"large": 100})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if bytes.Contains(result.Original, []byte("eXIf")) {
+		t.Errorf("Process() kept the eXIf chunk")
+	}
+	if result.CapturedAt == nil || result.CapturedAt.Year() != 2023 {
+		t.Errorf("Process() CapturedAt = %v", result.CapturedAt)
+	}
+	thumb, _ := jpeg.Decode(bytes.NewReader(result.Thumbnails["large"]))
+	if thumb == nil || thumb.Bounds().Dx() != 8 {
+		t.Errorf("thumbnails must not enlarge small images")
+	}
+}
+
+func TestProcess_NotAnImage(t *testing.T) {
+	if _, err := Process([]byte("%PDF-1.7"), map[string]int{"small": 10}); err != ErrUnsupportedFormat {
+		t.Errorf("Process() error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	chunk := func(fourcc string, data []byte) []byte {
+		c := append([]byte(fourcc), 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(c[4:], uint32(len(data)))
+		c = append(c, data...)
+		if len(data)%2 == 1 {
+			c = append(c, 0)
+		}
+		return c
+	}
+	vp8x := chunk("VP8X", []byte{webpFlagEXIF | webpFlagXMP, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	body := append(append(append([]byte("WEBP"), vp8x...), chunk("VP8 ", []byte("frame"))...), chunk("EXIF", testEXIF(1, "2024:01:01 00:00:00"))...)
+	body = append(body, chunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	data := append([]byte("RIFF\x00\x00\x00\x00"), body...)
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(body)))
+
+	if findEXIF(data) == nil {
+		t.Fatalf("findEXIF() found nothing in the test file")
+	}
+
+	stripped := stripWebPMetadata(data)
+	if bytes.Contains(stripped, []byte("EXIF")) || bytes.Contains(stripped, []byte("XMP ")) {
+		t.Errorf("stripWebPMetadata() kept metadata chunks")
+	}
+	if !bytes.Contains(stripped, []byte("frame")) {
+		t.Errorf("stripWebPMetadata() dropped the image data")
+	}
+	if stripped[20]&(webpFlagEXIF|webpFlagXMP) != 0 {
+		t.Errorf("stripWebPMetadata() left the VP8X metadata flags set")
+	}
+	if size := binary.LittleEndian.Uint32(stripped[4:]); int(size) != len(stripped)-8 {
+		t.Errorf("RIFF size = %d, want %d", size, len(stripped)-8)
+	}
+}
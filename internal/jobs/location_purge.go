@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/service"
+)
+
+const LocationPurgeJobName = "task_location_purge"
+
+// NewLocationPurgeJob clears the coordinates of task locations past the
+// retention window
+func NewLocationPurgeJob(retentionService *service.LocationRetentionService, retention config.LocationRetention) Job {
+	return Job{
+		Name:     LocationPurgeJobName,
+		Interval: retention.Interval,
+		Run: func(ctx context.Context) error {
+			_, err := retentionService.Purge(ctx)
+			return err
+		},
+	}
+}
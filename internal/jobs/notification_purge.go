@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"context"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/service"
+)
+
+const NotificationPurgeJobName = "notification_purge"
+
+// NewNotificationPurgeJob removes read notifications past the retention age
+func NewNotificationPurgeJob(retentionService *service.NotificationRetentionService, retention config.NotificationRetention) Job {
+	return Job{
+		Name:     NotificationPurgeJobName,
+		Interval: retention.Interval,
+		Run: func(ctx context.Context) error {
+			_, err := retentionService.Purge(ctx)
+			return err
+		},
+	}
+}
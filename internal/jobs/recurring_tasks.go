@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"context"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/service"
+)
+
+const RecurringTaskJobName = "recurring_tasks"
+
+// NewRecurringTaskJob creates the tasks of upcoming recurring task occurrences
+func NewRecurringTaskJob(recurringTaskService *service.RecurringTaskService, recurring config.RecurringTasks) Job {
+	return Job{
+		Name:     RecurringTaskJobName,
+		Interval: recurring.Interval,
+		Run: func(ctx context.Context) error {
+			_, err := recurringTaskService.Generate(ctx)
+			return err
+		},
+	}
+}
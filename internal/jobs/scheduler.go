@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"sword-challenge/internal/repository"
+)
+
+// Run and failure counters per job, exposed on /debug/vars
+var (
+	jobRuns     = expvar.NewMap("job_runs_total")
+	jobFailures = expvar.NewMap("job_failures_total")
+	jobSkipped  = expvar.NewMap("job_skipped_total")
+)
+
+// ErrInvalidInterval is returned when registering a job that would never wait
+// between two runs
+var ErrInvalidInterval = errors.New("job interval must be positive")
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job struct {
+	// Name identifies the job and its leader lock
+	Name string
+	// Interval between two runs
+	Interval time.Duration
+	// Run does the work; it must stop when ctx is cancelled
+	Run func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their interval. Every run happens under a
+// leader lock so only one replica works on a job at a time.
+type Scheduler struct {
+	locker repository.Locker
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(locker repository.Locker) *Scheduler {
+	return &Scheduler{locker: locker}
+}
+
+// Register adds a job; it must be called before Start
+func (s *Scheduler) Register(job Job) error {
+	if job.Interval <= 0 {
+		return fmt.Errorf("job %s: %w", job.Name, ErrInvalidInterval)
+	}
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Start launches one goroutine per job
+func (s *Scheduler) Start(ctx context.Context) error {
+	ctx, s.cancel = context.WithCancel(context.Background())
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := s.RunOnce(ctx, job); err != nil {
+						log.Printf("Job %s failed: %v", job.Name, err)
+					}
+				}
+			}
+		}(job)
+	}
+	return nil
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunOnce runs a job immediately if this process can take its leader lock.
+// It returns without error when another replica holds the lock.
+func (s *Scheduler) RunOnce(ctx context.Context, job Job) error {
+	release, acquired, err := s.locker.TryLock(ctx, "job:"+job.Name)
+	if err != nil {
+		jobFailures.Add(job.Name, 1)
+		return err
+	}
+	if !acquired {
+		jobSkipped.Add(job.Name, 1)
+		return nil
+	}
+	defer release()
+
+	jobRuns.Add(job.Name, 1)
+	if err := job.Run(ctx); err != nil {
+		jobFailures.Add(job.Name, 1)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryLocker is an in-process repository.Locker
+type memoryLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+	err  error
+}
+
+func newMemoryLocker() *memoryLocker {
+	return &memoryLocker{held: make(map[string]bool)}
+}
+
+func (l *memoryLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return nil, false, l.err
+	}
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, name)
+	}, true, nil
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupLocker func(*memoryLocker)
+		jobErr      error
+		expectedRun bool
+		expectedErr error
+	}{
+		{
+			name:        "runs when the lock is free",
+			setupLocker: func(l *memoryLocker) {},
+			expectedRun: true,
+		},
+		{
+			name:        "skips when another replica holds the lock",
+			setupLocker: func(l *memoryLocker) { l.held["job:test"] = true },
+			expectedRun: false,
+		},
+		{
+			name:        "returns the job error",
+			setupLocker: func(l *memoryLocker) {},
+			jobErr:      errors.New("job error"),
+			expectedRun: true,
+			expectedErr: errors.New("job error"),
+		},
+		{
+			name:        "returns the lock error",
+			setupLocker: func(l *memoryLocker) { l.err = errors.New("lock error") },
+			expectedRun: false,
+			expectedErr: errors.New("lock error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker := newMemoryLocker()
+			tt.setupLocker(locker)
+
+			ran := false
+			job := Job{Name: "test", Interval: time.Minute, Run: func(ctx context.Context) error {
+				ran = true
+				return tt.jobErr
+			}}
+
+			err := NewScheduler(locker).RunOnce(context.Background(), job)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedRun, ran)
+			if tt.expectedRun {
+				assert.False(t, locker.held["job:test"], "lock must be released after the run")
+			}
+		})
+	}
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	runs := make(chan struct{}, 10)
+	scheduler := NewScheduler(newMemoryLocker())
+	assert.NoError(t, scheduler.Register(Job{Name: "tick", Interval: 5 * time.Millisecond, Run: func(ctx context.Context) error {
+		runs <- struct{}{}
+		return nil
+	}}))
+
+	assert.NoError(t, scheduler.Start(context.Background()))
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("job did not run")
+	}
+	assert.NoError(t, scheduler.Stop(context.Background()))
+}
+
+func TestScheduler_RegisterInvalidInterval(t *testing.T) {
+	scheduler := NewScheduler(newMemoryLocker())
+	for _, interval := range []time.Duration{0, -time.Minute} {
+		err := scheduler.Register(Job{Name: "tick", Interval: interval, Run: func(ctx context.Context) error { return nil }})
+		assert.ErrorIs(t, err, ErrInvalidInterval)
+	}
+	assert.Empty(t, scheduler.jobs)
+}
@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/service"
+)
+
+const SLAEscalationJobName = "sla_escalation"
+
+// NewSLAEscalationJob escalates the open tasks that became at risk or
+// breached their due date since the last run
+func NewSLAEscalationJob(slaService *service.SLAService, sla config.SLA) Job {
+	return Job{
+		Name:     SLAEscalationJobName,
+		Interval: sla.Interval,
+		Run: func(ctx context.Context) error {
+			_, err := slaService.Evaluate(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"context"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/service"
+)
+
+const TaskPurgeJobName = "task_purge"
+
+// NewTaskPurgeJob permanently removes tasks past the trash retention window
+func NewTaskPurgeJob(retentionService *service.TaskRetentionService, retention config.TaskRetention) Job {
+	return Job{
+		Name:     TaskPurgeJobName,
+		Interval: retention.Interval,
+		Run: func(ctx context.Context) error {
+			_, err := retentionService.Purge(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,117 @@
+// Package labels makes the QR code labels stuck on assets. A label encodes a
+// deep link carrying a signed token, so scanning it opens the asset in the
+// app and labels cannot be forged for other assets.
+package labels
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// signatureLength is the number of bytes of the HMAC kept in tokens; 128 bits
+// cannot be guessed and keep the QR code small enough to scan from afar
+const signatureLength = 16
+
+var ErrInvalidToken = errors.New("invalid label token")
+
+// Signer makes and checks the tokens of asset labels. Tokens do not expire,
+// since labels stay on the equipment for years; changing the secret voids
+// every printed label.
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+// Token returns the token of the asset's label, "<asset id>.<signature>"
+func (s *Signer) Token(assetID int64) string {
+	id := strconv.FormatInt(assetID, 10)
+	return id + "." + base64.RawURLEncoding.EncodeToString(s.sign(id))
+}
+
+// Parse returns the asset a token was made for
+func (s *Signer) Parse(token string) (int64, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalidToken
+	}
+	assetID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || assetID <= 0 || strconv.FormatInt(assetID, 10) != id {
+		return 0, ErrInvalidToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, s.sign(id)) {
+		return 0, ErrInvalidToken
+	}
+	return assetID, nil
+}
+
+func (s *Signer) sign(id string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("asset-label:" + id))
+	return mac.Sum(nil)[:signatureLength]
+}
+
+// PNG renders content as a size x size pixels QR code
+func PNG(content string, size int) ([]byte, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(size)
+}
+
+// SVG renders content as a QR code that scales to any size, one unit per
+// module
+func SVG(content string) ([]byte, error) {
+	modules, err := bitmap(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	n := len(modules)
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y, row := range modules {
+		for x := 0; x < n; {
+			width := darkRun(row, x)
+			if width == 0 {
+				x++
+				continue
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", x, y, width, width)
+			x += width
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return []byte(b.String()), nil
+}
+
+// bitmap returns the modules of the QR code of content, true for dark ones,
+// including the quiet zone around the code
+func bitmap(content string) ([][]bool, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return code.Bitmap(), nil
+}
+
+// darkRun returns the number of dark modules of row starting at x
+func darkRun(row []bool, x int) int {
+	width := 0
+	for x+width < len(row) && row[x+width] {
+		width++
+	}
+	return width
+}
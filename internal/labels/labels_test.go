@@ -0,0 +1,85 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner([]byte("a-label-secret-of-at-least-32-bytes"))
+	token := signer.Token(42)
+
+	assetID, err := signer.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), assetID)
+
+	signature := token[strings.Index(token, ".")+1:]
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "other asset", token: "43." + signature},
+		{name: "leading zero", token: "042." + signature},
+		{name: "no signature", token: "42"},
+		{name: "truncated signature", token: token[:len(token)-2]},
+		{name: "other secret", token: NewSigner([]byte("another-secret-of-at-least-32-bytes")).Token(42)},
+		{name: "empty", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Parse(tt.token)
+			assert.Equal(t, ErrInvalidToken, err)
+		})
+	}
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG("https://app.example/scan?token=42.abc", 256)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 256, img.Bounds().Dx())
+	assert.Equal(t, 256, img.Bounds().Dy())
+}
+
+func TestSVG(t *testing.T) {
+	data, err := SVG("https://app.example/scan?token=42.abc")
+	require.NoError(t, err)
+
+	svg := string(data)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	assert.True(t, strings.HasSuffix(svg, "</svg>"))
+	assert.Contains(t, svg, `<path fill="#000" d="M`)
+}
+
+func TestSheet(t *testing.T) {
+	labels := make([]Label, LabelsPerPage+1)
+	for i := range labels {
+		labels[i] = Label{
+			Content: fmt.Sprintf("https://app.example/scan?token=%d.abc", i+1),
+			Lines:   []string{"Rooftop chiller (north) é", "CH-2019-0042", "A model name that is much too long for a label"},
+		}
+	}
+
+	data, err := Sheet(labels)
+	require.NoError(t, err)
+
+	pdf := string(data)
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, "/Count 2")
+}
+
+func TestPDFString(t *testing.T) {
+	assert.Equal(t, `Chiller \(north\) \\ 1`, pdfString(`Chiller (north) \ 1`))
+	assert.Equal(t, "Caf\xe9 ?", pdfString("Café 中"))
+	assert.Equal(t, "A model name that is …", truncate("A model name that is much too long", maxLineLength))
+}
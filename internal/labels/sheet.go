@@ -0,0 +1,192 @@
+package labels
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// The sheet is A4 with 3 x 7 labels of 63.5 x 38.1 mm, the layout of common
+// stock such as Avery L7160. Dimensions are in points (1/72 inch).
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	marginLeft   = 20.4
+	marginTop    = 42.8
+	labelWidth   = 180
+	labelHeight  = 108
+	columnPitch  = 187.1
+	columns      = 3
+	rows         = 7
+	qrSize       = 84
+	labelPadding = 12
+	fontSize     = 7
+	lineHeight   = 9
+	// maxLineLength keeps text lines inside the label, next to the QR code
+	maxLineLength = 22
+)
+
+// LabelsPerPage is the number of labels on a sheet
+const LabelsPerPage = columns * rows
+
+// Label is a label of the sheet: a QR code of Content with the first line in
+// bold next to it, and the other lines below it
+type Label struct {
+	Content string
+	Lines   []string
+}
+
+// Sheet renders labels as a PDF, LabelsPerPage labels per page
+func Sheet(labels []Label) ([]byte, error) {
+	var pages [][]byte
+	for start := 0; start < len(labels); start += LabelsPerPage {
+		end := min(start+LabelsPerPage, len(labels))
+		content, err := pageContent(labels[start:end])
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, content)
+	}
+	if len(pages) == 0 {
+		pages = append(pages, nil)
+	}
+	return writePDF(pages)
+}
+
+// pageContent draws up to LabelsPerPage labels, row by row
+func pageContent(labels []Label) ([]byte, error) {
+	var b bytes.Buffer
+	for i, label := range labels {
+		left := marginLeft + float64(i%columns)*columnPitch
+		top := pageHeight - marginTop - float64(i/columns)*labelHeight
+		if err := drawLabel(&b, label, left, top); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+func drawLabel(b *bytes.Buffer, label Label, left, top float64) error {
+	modules, err := bitmap(label.Content)
+	if err != nil {
+		return err
+	}
+
+	// The QR code is drawn as rectangles, one per run of dark modules; its
+	// quiet zone is part of the bitmap
+	module := float64(qrSize) / float64(len(modules))
+	qrLeft := left + labelPadding/2
+	qrTop := top - (labelHeight-qrSize)/2
+	b.WriteString("0 g\n")
+	for y, row := range modules {
+		for x := 0; x < len(row); {
+			width := darkRun(row, x)
+			if width == 0 {
+				x++
+				continue
+			}
+			fmt.Fprintf(b, "%.3f %.3f %.3f %.3f re\n",
+				qrLeft+float64(x)*module, qrTop-float64(y+1)*module, float64(width)*module, module)
+			x += width
+		}
+	}
+	b.WriteString("f\n")
+
+	textLeft := qrLeft + qrSize + labelPadding/2
+	baseline := top - labelPadding - fontSize
+	for i, line := range label.Lines {
+		font := "F1"
+		if i == 0 {
+			font = "F2"
+		}
+		fmt.Fprintf(b, "BT /%s %d Tf %.3f %.3f Td (%s) Tj ET\n",
+			font, fontSize, textLeft, baseline-float64(i*lineHeight), pdfString(truncate(line, maxLineLength)))
+	}
+	return nil
+}
+
+// writePDF assembles a PDF with one page per content stream, using the
+// standard Helvetica fonts every reader has
+func writePDF(pages [][]byte) ([]byte, error) {
+	var b bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-4 are the catalog, the page tree and the fonts; each page
+	// is followed by its content stream
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range pages {
+		var compressed bytes.Buffer
+		w := zlib.NewWriter(&compressed)
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return b.Bytes(), nil
+}
+
+// pdfString encodes s as the body of a PDF literal string, in the encoding of
+// the standard fonts
+func pdfString(s string) string {
+	encoded, err := encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder()).String(s)
+	if err != nil {
+		encoded = ""
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(encoded); i++ {
+		switch c := encoded[i]; {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == 0x1a:
+			// The encoder's replacement for characters the fonts lack
+			b.WriteByte('?')
+		case c < 0x20:
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// truncate shortens s to at most n characters, ending with an ellipsis
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
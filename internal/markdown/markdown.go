@@ -0,0 +1,51 @@
+// Package markdown renders user-written Markdown to HTML that is safe to
+// embed in a page.
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// renderer converts CommonMark plus strikethrough and autolinks. Raw HTML in
+// the source is not passed through.
+var renderer = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+)
+
+// policy is the allowlist applied to the rendered HTML: text formatting,
+// lists, quotes, code and links. Images are left out so summaries cannot load
+// remote content.
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"p", "br", "hr",
+		"strong", "b", "em", "i", "del", "s",
+		"ul", "ol", "li",
+		"blockquote", "code", "pre",
+		"h1", "h2", "h3", "h4", "h5", "h6",
+	)
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	return p
+}
+
+// ToHTML renders Markdown source to sanitized HTML. Anything that fails to
+// render is returned as escaped text.
+func ToHTML(source string) string {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return policy.Sanitize("<p>" + bluemonday.StrictPolicy().Sanitize(source) + "</p>")
+	}
+	return strings.TrimSpace(policy.Sanitize(buf.String()))
+}
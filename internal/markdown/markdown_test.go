@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "bold and lists",
+			source: "**Done**\n\n- filters\n- coolant",
+			want:   []string{"<strong>Done</strong>", "<ul>", "<li>filters</li>"},
+		},
+		{
+			name:    "raw html is dropped",
+			source:  "text <script>alert('x')</script>",
+			want:    []string{"text"},
+			notWant: []string{"<script"},
+		},
+		{
+			name:    "javascript links are removed",
+			source:  "[click](javascript:alert(1))",
+			notWant: []string{"javascript:", "href"},
+		},
+		{
+			name:   "http links get nofollow",
+			source: "[manual](https://example.com/manual)",
+			want:   []string{`href="https://example.com/manual"`, "nofollow"},
+		},
+		{
+			name:    "images are not allowed",
+			source:  "![pixel](https://example.com/p.png)",
+			notWant: []string{"<img"},
+		},
+		{
+			name:   "special characters are escaped",
+			source: "5 < 6 & 7 > 3",
+			want:   []string{"5 &lt; 6 &amp; 7 &gt; 3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToHTML(tt.source)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("ToHTML() = %q, want it to contain %q", got, w)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(got, nw) {
+					t.Errorf("ToHTML() = %q, must not contain %q", got, nw)
+				}
+			}
+		})
+	}
+}
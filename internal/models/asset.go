@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DateLayout is the format of calendar dates, e.g. install dates
+const DateLayout = "2006-01-02"
+
+// Asset limits, counted in characters
+const (
+	MaxAssetNameLength    = 255
+	MaxSerialNumberLength = 100
+	MaxAssetModelLength   = 255
+)
+
+var (
+	ErrInvalidAssetSite    = errors.New("asset must be installed on a site")
+	ErrEmptyAssetName      = errors.New("asset name cannot be empty")
+	ErrAssetNameTooLong    = errors.New("asset name exceeds maximum length of 255 characters")
+	ErrInvalidSerialNumber = errors.New("serial number must be 1-100 characters")
+	ErrAssetModelTooLong   = errors.New("model exceeds maximum length of 255 characters")
+	ErrInvalidInstallDate  = errors.New("installed_on must be a date (YYYY-MM-DD) not in the future")
+)
+
+// Asset is a piece of equipment installed on a site, such as a chiller or a
+// boiler, that tasks can be performed on
+// @Description An asset installed on a site
+type Asset struct {
+	// @Description The unique identifier of the asset
+	ID int64 `json:"id" example:"1"`
+	// @Description The site the asset is installed on
+	SiteID int64 `json:"site_id" example:"1"`
+	// @Description Name of the asset
+	Name string `json:"name" example:"Rooftop chiller 1"`
+	// @Description Serial number, unique among the assets of the model
+	SerialNumber string `json:"serial_number" example:"CH-2019-0042"`
+	// @Description Manufacturer model, may be empty
+	Model string `json:"model" example:"Carrier 30RB-160"`
+	// @Description Install date (YYYY-MM-DD), absent when unknown
+	InstalledOn string `json:"installed_on,omitempty" example:"2019-06-01"`
+	// @Description When the asset was added
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the asset was last changed
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize removes surrounding spaces and control characters
+func (a *Asset) Sanitize() {
+	a.Name = strings.TrimSpace(sanitizeText(a.Name))
+	a.SerialNumber = strings.TrimSpace(sanitizeText(a.SerialNumber))
+	a.Model = strings.TrimSpace(sanitizeText(a.Model))
+	a.InstalledOn = strings.TrimSpace(a.InstalledOn)
+}
+
+// Validate checks the asset; the install date cannot be after now
+func (a *Asset) Validate(now time.Time) error {
+	if a.SiteID <= 0 {
+		return ErrInvalidAssetSite
+	}
+	if a.Name == "" {
+		return ErrEmptyAssetName
+	}
+	if utf8.RuneCountInString(a.Name) > MaxAssetNameLength {
+		return ErrAssetNameTooLong
+	}
+	if a.SerialNumber == "" || utf8.RuneCountInString(a.SerialNumber) > MaxSerialNumberLength {
+		return ErrInvalidSerialNumber
+	}
+	if utf8.RuneCountInString(a.Model) > MaxAssetModelLength {
+		return ErrAssetModelTooLong
+	}
+	if a.InstalledOn != "" {
+		installedOn, err := time.Parse(DateLayout, a.InstalledOn)
+		if err != nil || installedOn.After(now) {
+			return ErrInvalidInstallDate
+		}
+	}
+	return nil
+}
+
+// InstallDate returns the install date, or nil when it is unknown. The asset
+// must be valid.
+func (a *Asset) InstallDate() *time.Time {
+	if a.InstalledOn == "" {
+		return nil
+	}
+	installedOn, err := time.Parse(DateLayout, a.InstalledOn)
+	if err != nil {
+		return nil
+	}
+	return &installedOn
+}
@@ -0,0 +1,32 @@
+package models
+
+// Asset label formats
+const (
+	LabelFormatPNG = "png"
+	LabelFormatSVG = "svg"
+)
+
+// Label limits; sizes are in pixels
+const (
+	DefaultLabelSize    = 256
+	MinLabelSize        = 64
+	MaxLabelSize        = 1024
+	MaxLabelSheetAssets = 500
+)
+
+// IsValidLabelFormat reports whether labels can be rendered in format
+func IsValidLabelFormat(format string) bool {
+	return format == LabelFormatPNG || format == LabelFormatSVG
+}
+
+// AssetScan is what a scanned asset label resolves to: the asset, its site
+// and the open tasks on it, to pick one or start a new task
+// @Description A scanned asset label
+type AssetScan struct {
+	// @Description The asset the label is stuck on
+	Asset *Asset `json:"asset"`
+	// @Description The site the asset is installed on
+	Site *Site `json:"site"`
+	// @Description The open tasks on the asset, most recent first; technicians only get their own
+	OpenTasks []*Task `json:"open_tasks"`
+}
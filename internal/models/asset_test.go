@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsset_Validate(t *testing.T) {
+	now := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		asset   Asset
+		wantErr error
+	}{
+		{name: "valid asset", asset: Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", Model: "Carrier 30RB-160", InstalledOn: "2019-06-01"}},
+		{name: "unknown install date", asset: Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042"}},
+		{name: "installed today", asset: Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", InstalledOn: "2024-03-20"}},
+		{name: "no site", asset: Asset{Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042"}, wantErr: ErrInvalidAssetSite},
+		{name: "empty name", asset: Asset{SiteID: 1, SerialNumber: "CH-2019-0042"}, wantErr: ErrEmptyAssetName},
+		{name: "no serial number", asset: Asset{SiteID: 1, Name: "Rooftop chiller 1"}, wantErr: ErrInvalidSerialNumber},
+		{name: "install date with a time", asset: Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", InstalledOn: "2019-06-01T00:00:00Z"}, wantErr: ErrInvalidInstallDate},
+		{name: "installed in the future", asset: Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", InstalledOn: "2024-03-21"}, wantErr: ErrInvalidInstallDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.asset.Validate(now))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Customer and site limits, counted in characters
+const (
+	MaxCustomerNameLength = 255
+	MaxPhoneLength        = 32
+	MaxSiteNameLength     = 255
+	MaxAddressLength      = 500
+	MaxCityLength         = 100
+	MaxPostalCodeLength   = 20
+)
+
+var (
+	ErrEmptyCustomerName   = errors.New("customer name cannot be empty")
+	ErrCustomerNameTooLong = errors.New("customer name exceeds maximum length of 255 characters")
+	ErrInvalidEmail        = errors.New("email must be a valid address")
+	ErrPhoneTooLong        = errors.New("phone exceeds maximum length of 32 characters")
+	ErrEmptySiteName       = errors.New("site name cannot be empty")
+	ErrSiteNameTooLong     = errors.New("site name exceeds maximum length of 255 characters")
+	ErrEmptyAddress        = errors.New("address cannot be empty")
+	ErrAddressTooLong      = errors.New("address exceeds maximum length of 500 characters")
+	ErrCityTooLong         = errors.New("city exceeds maximum length of 100 characters")
+	ErrPostalCodeTooLong   = errors.New("postal code exceeds maximum length of 20 characters")
+	ErrInvalidCountry      = errors.New("country must be an ISO 3166-1 alpha-2 code")
+	ErrInvalidCoordinates  = errors.New("latitude and longitude must be set together, within -90..90 and -180..180")
+	ErrInvalidSiteCustomer = errors.New("site must belong to a customer")
+)
+
+// countryPattern matches ISO 3166-1 alpha-2 codes once upper-cased
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Customer is a client whose sites technicians work on
+// @Description A customer
+type Customer struct {
+	// @Description The unique identifier of the customer
+	ID int64 `json:"id" example:"1"`
+	// @Description Name of the customer
+	Name string `json:"name" example:"Northwind Offices"`
+	// @Description Contact email, may be empty
+	Email string `json:"email" example:"facilities@northwind.example"`
+	// @Description Contact phone, may be empty
+	Phone string `json:"phone" example:"+351 210 000 000"`
+	// @Description When the customer was added
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the customer was last changed
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize removes surrounding spaces and control characters
+func (c *Customer) Sanitize() {
+	c.Name = strings.TrimSpace(sanitizeText(c.Name))
+	c.Email = strings.TrimSpace(c.Email)
+	c.Phone = strings.TrimSpace(sanitizeText(c.Phone))
+}
+
+func (c *Customer) Validate() error {
+	if c.Name == "" {
+		return ErrEmptyCustomerName
+	}
+	if utf8.RuneCountInString(c.Name) > MaxCustomerNameLength {
+		return ErrCustomerNameTooLong
+	}
+	if c.Email != "" {
+		address, err := mail.ParseAddress(c.Email)
+		if err != nil || address.Address != c.Email {
+			return ErrInvalidEmail
+		}
+	}
+	if utf8.RuneCountInString(c.Phone) > MaxPhoneLength {
+		return ErrPhoneTooLong
+	}
+	return nil
+}
+
+// Site is a location of a customer where work is performed
+// @Description A customer site
+type Site struct {
+	// @Description The unique identifier of the site
+	ID int64 `json:"id" example:"1"`
+	// @Description The customer the site belongs to
+	CustomerID int64 `json:"customer_id" example:"1"`
+	// @Description Name of the site
+	Name string `json:"name" example:"Lisbon headquarters"`
+	// @Description Street address
+	Address string `json:"address" example:"Avenida da Liberdade 110"`
+	// @Description City, may be empty
+	City string `json:"city" example:"Lisboa"`
+	// @Description Postal code, may be empty
+	PostalCode string `json:"postal_code" example:"1250-146"`
+	// @Description ISO 3166-1 alpha-2 country code, may be empty
+	Country string `json:"country" example:"PT"`
+	// @Description Latitude in decimal degrees, absent when the site has no coordinates
+	Latitude *float64 `json:"latitude,omitempty" example:"38.7197"`
+	// @Description Longitude in decimal degrees, absent when the site has no coordinates
+	Longitude *float64 `json:"longitude,omitempty" example:"-9.1453"`
+	// @Description Radius in meters around the coordinates within which tasks are recorded on site
+	GeofenceRadius int `json:"geofence_radius" example:"200"`
+	// @Description When the site was added
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the site was last changed
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize removes surrounding spaces and control characters and upper-cases
+// the country code
+func (s *Site) Sanitize() {
+	s.Name = strings.TrimSpace(sanitizeText(s.Name))
+	s.Address = strings.TrimSpace(sanitizeText(s.Address))
+	s.City = strings.TrimSpace(sanitizeText(s.City))
+	s.PostalCode = strings.TrimSpace(sanitizeText(s.PostalCode))
+	s.Country = strings.ToUpper(strings.TrimSpace(s.Country))
+}
+
+func (s *Site) Validate() error {
+	if s.CustomerID <= 0 {
+		return ErrInvalidSiteCustomer
+	}
+	if s.Name == "" {
+		return ErrEmptySiteName
+	}
+	if utf8.RuneCountInString(s.Name) > MaxSiteNameLength {
+		return ErrSiteNameTooLong
+	}
+	if s.Address == "" {
+		return ErrEmptyAddress
+	}
+	if utf8.RuneCountInString(s.Address) > MaxAddressLength {
+		return ErrAddressTooLong
+	}
+	if utf8.RuneCountInString(s.City) > MaxCityLength {
+		return ErrCityTooLong
+	}
+	if utf8.RuneCountInString(s.PostalCode) > MaxPostalCodeLength {
+		return ErrPostalCodeTooLong
+	}
+	if s.Country != "" && !countryPattern.MatchString(s.Country) {
+		return ErrInvalidCountry
+	}
+	if (s.Latitude == nil) != (s.Longitude == nil) {
+		return ErrInvalidCoordinates
+	}
+	if s.Latitude != nil && (*s.Latitude < -90 || *s.Latitude > 90 || *s.Longitude < -180 || *s.Longitude > 180) {
+		return ErrInvalidCoordinates
+	}
+	if s.GeofenceRadius < MinGeofenceRadius || s.GeofenceRadius > MaxGeofenceRadius {
+		return ErrInvalidGeofenceRadius
+	}
+	return nil
+}
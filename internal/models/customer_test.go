@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomer_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer Customer
+		wantErr  error
+	}{
+		{name: "valid customer", customer: Customer{Name: "Northwind Offices", Email: "facilities@northwind.example", Phone: "+351 210 000 000"}},
+		{name: "no contact", customer: Customer{Name: "Northwind Offices"}},
+		{name: "empty name", customer: Customer{}, wantErr: ErrEmptyCustomerName},
+		{name: "name too long", customer: Customer{Name: strings.Repeat("é", MaxCustomerNameLength+1)}, wantErr: ErrCustomerNameTooLong},
+		{name: "invalid email", customer: Customer{Name: "Northwind Offices", Email: "facilities"}, wantErr: ErrInvalidEmail},
+		{name: "email with a display name", customer: Customer{Name: "Northwind Offices", Email: "Facilities <facilities@northwind.example>"}, wantErr: ErrInvalidEmail},
+		{name: "phone too long", customer: Customer{Name: "Northwind Offices", Phone: strings.Repeat("1", MaxPhoneLength+1)}, wantErr: ErrPhoneTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.customer.Validate())
+		})
+	}
+}
+
+func TestSite_Validate(t *testing.T) {
+	latitude, longitude, outOfRange := 38.7197, -9.1453, 91.0
+
+	tests := []struct {
+		name    string
+		site    Site
+		wantErr error
+	}{
+		{name: "valid site", site: Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Country: "PT", Latitude: &latitude, Longitude: &longitude, GeofenceRadius: DefaultGeofenceRadius}},
+		{name: "no coordinates", site: Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", GeofenceRadius: DefaultGeofenceRadius}},
+		{name: "no customer", site: Site{Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110"}, wantErr: ErrInvalidSiteCustomer},
+		{name: "empty name", site: Site{CustomerID: 1, Address: "Avenida da Liberdade 110"}, wantErr: ErrEmptySiteName},
+		{name: "empty address", site: Site{CustomerID: 1, Name: "Lisbon headquarters"}, wantErr: ErrEmptyAddress},
+		{name: "country name", site: Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Country: "PRT"}, wantErr: ErrInvalidCountry},
+		{name: "latitude only", site: Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Latitude: &latitude}, wantErr: ErrInvalidCoordinates},
+		{name: "latitude out of range", site: Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Latitude: &outOfRange, Longitude: &longitude}, wantErr: ErrInvalidCoordinates},
+		{name: "geofence too small", site: Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Latitude: &latitude, Longitude: &longitude, GeofenceRadius: 10}, wantErr: ErrInvalidGeofenceRadius},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.site.Validate())
+		})
+	}
+}
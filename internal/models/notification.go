@@ -2,7 +2,10 @@ package models
 
 import (
 	"errors"
+	"strconv"
 	"time"
+
+	"sword-challenge/internal/i18n"
 )
 
 // Notification represents a notification in the system
@@ -12,19 +15,91 @@ type Notification struct {
 	ID int64 `json:"id" example:"1"`
 	// @Description The ID of the task this notification is about
 	TaskID int64 `json:"task_id" example:"1"`
-	// @Description The notification message
+	// @Description The user this notification is for; absent when it is shared by all managers
+	RecipientID *int64 `json:"recipient_id,omitempty" example:"2"`
+	// @Description The notification message, rendered in the reader's locale
 	Message string `json:"message" example:"The tech John Doe performed the task on 2024-03-20 14:30:00"`
+	// @Description The template used to render the message
+	TemplateKey string `json:"template_key,omitempty" example:"task_performed"`
+	// @Description The parameters used to render the message
+	Params map[string]string `json:"params,omitempty"`
 	// @Description Whether the notification has been read
 	IsRead bool `json:"is_read" example:"false"`
 	// @Description When the notification was created
 	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
 }
 
+// Notification status filters
+const (
+	NotificationStatusUnread = "unread"
+	NotificationStatusRead   = "read"
+	NotificationStatusAll    = "all"
+)
+
+// Page size limits for notification listings
+const (
+	DefaultNotificationLimit = 20
+	MaxNotificationLimit     = 100
+)
+
 var (
 	ErrNilTask       = errors.New("task cannot be nil")
 	ErrNilTechnician = errors.New("technician cannot be nil")
+	ErrNilAuthor     = errors.New("author cannot be nil")
+	ErrNilPart       = errors.New("part cannot be nil")
+	ErrNilSite       = errors.New("site cannot be nil")
+	ErrInvalidStatus = errors.New("status must be one of unread, read or all")
+	ErrInvalidLimit  = errors.New("limit must be between 1 and 100")
+	ErrInvalidCursor = errors.New("cursor must be a positive notification id")
 )
 
+// NotificationFilter narrows a notification listing. Cursor is the id of the
+// last notification of the previous page; results are ordered newest first.
+// RecipientID and IncludeShared select whose notifications are listed and are
+// set from the reader, never from the request.
+type NotificationFilter struct {
+	Status        string
+	TaskID        int64
+	Cursor        int64
+	Limit         int
+	RecipientID   int64
+	IncludeShared bool
+}
+
+// Validate applies defaults and checks the filter values
+func (f *NotificationFilter) Validate() error {
+	if f.Status == "" {
+		f.Status = NotificationStatusUnread
+	}
+	if f.Limit == 0 {
+		f.Limit = DefaultNotificationLimit
+	}
+
+	switch f.Status {
+	case NotificationStatusUnread, NotificationStatusRead, NotificationStatusAll:
+	default:
+		return ErrInvalidStatus
+	}
+	if f.Limit < 1 || f.Limit > MaxNotificationLimit {
+		return ErrInvalidLimit
+	}
+	if f.Cursor < 0 {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// NotificationPage is one page of a notification listing
+// @Description A page of notifications
+type NotificationPage struct {
+	// @Description The notifications of this page, newest first
+	Notifications []*Notification `json:"notifications"`
+	// @Description Cursor to request the next page, absent on the last page
+	NextCursor int64 `json:"next_cursor,omitempty" example:"42"`
+	// @Description Total number of unread notifications
+	UnreadCount int64 `json:"unread_count" example:"3"`
+}
+
 func NewTaskNotification(task *Task, technician *User) (*Notification, error) {
 	if task == nil {
 		return nil, ErrNilTask
@@ -39,12 +114,197 @@ func NewTaskNotification(task *Task, technician *User) (*Notification, error) {
 	}
 
 	return &Notification{
-		TaskID:    task.ID,
-		Message:   message,
-		CreatedAt: time.Now(),
+		TaskID:      task.ID,
+		Message:     message,
+		TemplateKey: i18n.KeyTaskPerformed,
+		Params:      taskNotificationParams(technician, task),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewCommentMentionNotification notifies recipientID that author mentioned them
+// in a comment on a task
+func NewCommentMentionNotification(taskID int64, commentID int64, author *User, recipientID int64) (*Notification, error) {
+	if author == nil {
+		return nil, ErrNilAuthor
+	}
+
+	params := map[string]string{
+		"author_name": author.Name,
+		"task_id":     strconv.FormatInt(taskID, 10),
+		"comment_id":  strconv.FormatInt(commentID, 10),
+	}
+	message, err := i18n.Render(i18n.KeyCommentMention, i18n.DefaultLocale, "", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		TaskID:      taskID,
+		RecipientID: &recipientID,
+		Message:     message,
+		TemplateKey: i18n.KeyCommentMention,
+		Params:      params,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewTaskEscalationNotification tells the technician's manager that a task is
+// at risk of missing its due date or missed it. It is shared by all managers
+// when the technician has no manager.
+func NewTaskEscalationNotification(task *Task, technician *User, level string) (*Notification, error) {
+	if task == nil || task.DueAt == nil {
+		return nil, ErrNilTask
+	}
+	if technician == nil {
+		return nil, ErrNilTechnician
+	}
+
+	key := i18n.KeyTaskAtRisk
+	if level == SLAEscalationBreached {
+		key = i18n.KeyTaskBreached
+	}
+	params := map[string]string{
+		"tech_name": technician.Name,
+		"task_id":   strconv.FormatInt(task.ID, 10),
+		"title":     task.Title,
+		"due_at":    task.DueAt.Format(time.RFC3339),
+	}
+	message, err := i18n.Render(key, i18n.DefaultLocale, "", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		TaskID:      task.ID,
+		RecipientID: technician.ManagerID,
+		Message:     message,
+		TemplateKey: key,
+		Params:      params,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewTaskUnblockedNotification tells the technician of a task that the tasks
+// it depends on are completed
+func NewTaskUnblockedNotification(task *Task) (*Notification, error) {
+	if task == nil {
+		return nil, ErrNilTask
+	}
+
+	params := map[string]string{
+		"task_id": strconv.FormatInt(task.ID, 10),
+		"title":   task.Title,
+	}
+	message, err := i18n.Render(i18n.KeyTaskUnblocked, i18n.DefaultLocale, "", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		TaskID:      task.ID,
+		RecipientID: &task.TechnicianID,
+		Message:     message,
+		TemplateKey: i18n.KeyTaskUnblocked,
+		Params:      params,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewLowStockNotification tells every manager that using parts on a task
+// brought their stock down to the low stock threshold
+func NewLowStockNotification(part *Part, taskID int64) (*Notification, error) {
+	if part == nil {
+		return nil, ErrNilPart
+	}
+
+	params := map[string]string{
+		"sku":     part.SKU,
+		"name":    part.Name,
+		"unit":    part.Unit,
+		"stock":   strconv.Itoa(part.Stock),
+		"task_id": strconv.FormatInt(taskID, 10),
+	}
+	message, err := i18n.Render(i18n.KeyPartLowStock, i18n.DefaultLocale, "", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		TaskID:      taskID,
+		Message:     message,
+		TemplateKey: i18n.KeyPartLowStock,
+		Params:      params,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewTaskOffSiteNotification tells the technician's manager that a task was
+// recorded outside the geofence of its site, distance meters away. It is
+// shared by all managers when the technician has no manager.
+func NewTaskOffSiteNotification(task *Task, technician *User, site *Site, distance int) (*Notification, error) {
+	if task == nil {
+		return nil, ErrNilTask
+	}
+	if technician == nil {
+		return nil, ErrNilTechnician
+	}
+	if site == nil {
+		return nil, ErrNilSite
+	}
+
+	params := map[string]string{
+		"tech_name": technician.Name,
+		"task_id":   strconv.FormatInt(task.ID, 10),
+		"title":     task.Title,
+		"site_name": site.Name,
+		"distance":  strconv.Itoa(distance),
+	}
+	message, err := i18n.Render(i18n.KeyTaskOffSite, i18n.DefaultLocale, "", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		TaskID:      task.ID,
+		RecipientID: technician.ManagerID,
+		Message:     message,
+		TemplateKey: i18n.KeyTaskOffSite,
+		Params:      params,
+		CreatedAt:   time.Now(),
 	}, nil
 }
 
+// VisibleTo reports whether user can read the notification: shared
+// notifications are for managers, the others for their recipient only
+func (n *Notification) VisibleTo(user *User) bool {
+	if n.RecipientID == nil {
+		return user.IsManager()
+	}
+	return *n.RecipientID == user.ID
+}
+
+// Localize re-renders the message in the given locale and timezone. Notifications
+// stored before templates existed keep their original message.
+func (n *Notification) Localize(locale, timezone string) error {
+	if n.TemplateKey == "" {
+		return nil
+	}
+	message, err := i18n.Render(n.TemplateKey, locale, timezone, n.Params)
+	if err != nil {
+		return err
+	}
+	n.Message = message
+	return nil
+}
+
+func taskNotificationParams(tech *User, task *Task) map[string]string {
+	return map[string]string{
+		"tech_name":    tech.Name,
+		"performed_at": task.PerformedAt.Format(time.RFC3339),
+	}
+}
+
 func formatNotificationMessage(tech *User, task *Task) (string, error) {
 	if tech == nil {
 		return "", ErrNilTechnician
@@ -52,5 +312,5 @@ func formatNotificationMessage(tech *User, task *Task) (string, error) {
 	if task == nil {
 		return "", ErrNilTask
 	}
-	return "The tech " + tech.Name + " performed the task on " + task.PerformedAt.Format("2006-01-02 15:04:05"), nil
+	return i18n.Render(i18n.KeyTaskPerformed, i18n.DefaultLocale, "", taskNotificationParams(tech, task))
 }
@@ -113,3 +113,223 @@ func TestFormatNotificationMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestNotification_Localize(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification *Notification
+		locale       string
+		timezone     string
+		want         string
+	}{
+		{
+			name: "templated notification is re-rendered",
+			notification: &Notification{
+				Message:     "The tech John Doe performed the task on 2024-03-20 14:30:00",
+				TemplateKey: "task_performed",
+				Params:      map[string]string{"tech_name": "John Doe", "performed_at": "2024-03-20T14:30:00Z"},
+			},
+			locale:   "es",
+			timezone: "UTC",
+			want:     "El técnico John Doe realizó la tarea el 20/03/2024 14:30:00",
+		},
+		{
+			name: "legacy notification keeps stored message",
+			notification: &Notification{
+				Message: "The tech John Doe performed the task on 2024-03-20 14:30:00",
+			},
+			locale: "pt",
+			want:   "The tech John Doe performed the task on 2024-03-20 14:30:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.notification.Localize(tt.locale, tt.timezone); err != nil {
+				t.Fatalf("Localize() error = %v", err)
+			}
+			if tt.notification.Message != tt.want {
+				t.Errorf("Localize() message = %v, want %v", tt.notification.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCommentMentionNotification(t *testing.T) {
+	author := &User{ID: 1, Name: "John Smith", Role: RoleManager}
+
+	notification, err := NewCommentMentionNotification(7, 10, author, 2)
+	if err != nil {
+		t.Fatalf("NewCommentMentionNotification() error = %v", err)
+	}
+	if notification.TaskID != 7 || notification.RecipientID == nil || *notification.RecipientID != 2 {
+		t.Errorf("NewCommentMentionNotification() = %+v, want task 7 for recipient 2", notification)
+	}
+	if want := "John Smith mentioned you in a comment on task #7"; notification.Message != want {
+		t.Errorf("Message = %q, want %q", notification.Message, want)
+	}
+	if notification.Params["comment_id"] != "10" {
+		t.Errorf("Params = %v, want comment_id 10", notification.Params)
+	}
+
+	if _, err := NewCommentMentionNotification(7, 10, nil, 2); !errors.Is(err, ErrNilAuthor) {
+		t.Errorf("NewCommentMentionNotification(nil author) error = %v, want %v", err, ErrNilAuthor)
+	}
+}
+
+func TestNotification_VisibleTo(t *testing.T) {
+	recipientID := int64(2)
+	manager := &User{ID: 1, Role: RoleManager}
+	technician := &User{ID: 2, Role: RoleTechnician}
+	otherTechnician := &User{ID: 3, Role: RoleTechnician}
+
+	shared := &Notification{ID: 1}
+	addressed := &Notification{ID: 2, RecipientID: &recipientID}
+
+	tests := []struct {
+		name         string
+		notification *Notification
+		user         *User
+		want         bool
+	}{
+		{"manager sees shared", shared, manager, true},
+		{"technician does not see shared", shared, technician, false},
+		{"recipient sees their notification", addressed, technician, true},
+		{"manager does not see another user's notification", addressed, manager, false},
+		{"other technician does not see it", addressed, otherTechnician, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.notification.VisibleTo(tt.user); got != tt.want {
+				t.Errorf("VisibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTaskEscalationNotification(t *testing.T) {
+	dueAt := time.Date(2024, 3, 21, 14, 30, 0, 0, time.UTC)
+	managerID := int64(1)
+	tests := []struct {
+		name          string
+		task          *Task
+		technician    *User
+		level         string
+		wantKey       string
+		wantRecipient *int64
+		wantErr       error
+	}{
+		{
+			name:          "at risk goes to the manager",
+			task:          &Task{ID: 7, Title: "Fix AC", DueAt: &dueAt},
+			technician:    &User{ID: 2, Name: "John Doe", ManagerID: &managerID},
+			level:         SLAEscalationAtRisk,
+			wantKey:       "task_at_risk",
+			wantRecipient: &managerID,
+		},
+		{
+			name:       "breached is shared without a manager",
+			task:       &Task{ID: 7, Title: "Fix AC", DueAt: &dueAt},
+			technician: &User{ID: 2, Name: "John Doe"},
+			level:      SLAEscalationBreached,
+			wantKey:    "task_breached",
+		},
+		{
+			name:       "task without due date",
+			task:       &Task{ID: 7, Title: "Fix AC"},
+			technician: &User{ID: 2, Name: "John Doe"},
+			level:      SLAEscalationBreached,
+			wantErr:    ErrNilTask,
+		},
+		{
+			name:    "nil technician",
+			task:    &Task{ID: 7, Title: "Fix AC", DueAt: &dueAt},
+			level:   SLAEscalationAtRisk,
+			wantErr: ErrNilTechnician,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTaskEscalationNotification(tt.task, tt.technician, tt.level)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewTaskEscalationNotification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.TemplateKey != tt.wantKey {
+				t.Errorf("NewTaskEscalationNotification().TemplateKey = %v, want %v", got.TemplateKey, tt.wantKey)
+			}
+			if (got.RecipientID == nil) != (tt.wantRecipient == nil) ||
+				(got.RecipientID != nil && *got.RecipientID != *tt.wantRecipient) {
+				t.Errorf("NewTaskEscalationNotification().RecipientID = %v, want %v", got.RecipientID, tt.wantRecipient)
+			}
+			if got.Params["due_at"] != "2024-03-21T14:30:00Z" {
+				t.Errorf("NewTaskEscalationNotification().Params = %v", got.Params)
+			}
+		})
+	}
+}
+
+func TestNewTaskUnblockedNotification(t *testing.T) {
+	got, err := NewTaskUnblockedNotification(&Task{ID: 8, TechnicianID: 2, Title: "Configure network"})
+	if err != nil {
+		t.Fatalf("NewTaskUnblockedNotification() error = %v", err)
+	}
+	if got.RecipientID == nil || *got.RecipientID != 2 {
+		t.Errorf("NewTaskUnblockedNotification().RecipientID = %v, want 2", got.RecipientID)
+	}
+	if got.TemplateKey != "task_unblocked" {
+		t.Errorf("NewTaskUnblockedNotification().TemplateKey = %v, want task_unblocked", got.TemplateKey)
+	}
+	if want := `Task #8 "Configure network" is unblocked: the tasks it depends on are completed`; got.Message != want {
+		t.Errorf("NewTaskUnblockedNotification().Message = %v, want %v", got.Message, want)
+	}
+
+	if _, err := NewTaskUnblockedNotification(nil); !errors.Is(err, ErrNilTask) {
+		t.Errorf("NewTaskUnblockedNotification(nil) error = %v, want %v", err, ErrNilTask)
+	}
+}
+
+func TestNewLowStockNotification(t *testing.T) {
+	got, err := NewLowStockNotification(&Part{ID: 1, SKU: "FLT-MERV13", Name: "MERV 13 air filter", Unit: "pcs", Stock: 4}, 7)
+	if err != nil {
+		t.Fatalf("NewLowStockNotification() error = %v", err)
+	}
+	if got.RecipientID != nil {
+		t.Errorf("NewLowStockNotification().RecipientID = %v, want shared", *got.RecipientID)
+	}
+	if got.TaskID != 7 {
+		t.Errorf("NewLowStockNotification().TaskID = %v, want 7", got.TaskID)
+	}
+	if want := `Part FLT-MERV13 "MERV 13 air filter" is low on stock: 4 pcs left after task #7`; got.Message != want {
+		t.Errorf("NewLowStockNotification().Message = %v, want %v", got.Message, want)
+	}
+
+	if _, err := NewLowStockNotification(nil, 7); !errors.Is(err, ErrNilPart) {
+		t.Errorf("NewLowStockNotification(nil) error = %v, want %v", err, ErrNilPart)
+	}
+}
+
+func TestNewTaskOffSiteNotification(t *testing.T) {
+	managerID := int64(1)
+	task := &Task{ID: 7, Title: "Fix AC"}
+	site := &Site{ID: 1, Name: "Lisbon headquarters"}
+
+	got, err := NewTaskOffSiteNotification(task, &User{ID: 2, Name: "John Doe", ManagerID: &managerID}, site, 1250)
+	if err != nil {
+		t.Fatalf("NewTaskOffSiteNotification() error = %v", err)
+	}
+	if got.RecipientID == nil || *got.RecipientID != managerID {
+		t.Errorf("NewTaskOffSiteNotification().RecipientID = %v, want %v", got.RecipientID, managerID)
+	}
+	if want := `Task #7 "Fix AC" of John Doe was recorded 1250 m from site "Lisbon headquarters", outside its geofence`; got.Message != want {
+		t.Errorf("NewTaskOffSiteNotification().Message = %v, want %v", got.Message, want)
+	}
+
+	if _, err := NewTaskOffSiteNotification(task, &User{ID: 2}, nil, 1250); !errors.Is(err, ErrNilSite) {
+		t.Errorf("NewTaskOffSiteNotification(nil site) error = %v, want %v", err, ErrNilSite)
+	}
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Stock movement reasons. Technicians consume and return parts through the
+// materials of their tasks; managers restock and adjust.
+const (
+	StockReasonRestock     = "restock"
+	StockReasonConsumption = "consumption"
+	StockReasonReturn      = "return"
+	StockReasonAdjustment  = "adjustment"
+)
+
+// Part limits; quantities are whole numbers of the part's unit
+const (
+	MaxPartNameLength          = 255
+	MaxPartUnitLength          = 20
+	MaxStockQuantity           = 1000000
+	MaxStockMovementNoteLength = 500
+	DefaultStockMovementLimit  = 50
+	MaxStockMovementLimit      = 200
+)
+
+var (
+	ErrInvalidSKU            = errors.New("sku must be 1-64 letters, digits, ., - or _")
+	ErrEmptyPartName         = errors.New("part name cannot be empty")
+	ErrPartNameTooLong       = errors.New("part name exceeds maximum length of 255 characters")
+	ErrInvalidPartUnit       = errors.New("unit must be 1-20 characters")
+	ErrInvalidStockThreshold = errors.New("low_stock_threshold must be between 0 and 1000000")
+	ErrInvalidStockQuantity  = errors.New("quantity must be a non-zero number between -1000000 and 1000000")
+	ErrInvalidStockReason    = errors.New("reason must be restock or adjustment")
+	ErrStockNoteTooLong      = errors.New("stock movement note exceeds maximum length of 500 characters")
+)
+
+// skuPattern is the format of stock keeping units, e.g. "FLT-MERV13"
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Part is an item of the parts catalog, such as a filter, a cable or a
+// refrigerant, with its stock
+// @Description A part of the catalog
+type Part struct {
+	// @Description The unique identifier of the part
+	ID int64 `json:"id" example:"1"`
+	// @Description Stock keeping unit, unique among the parts in the catalog
+	SKU string `json:"sku" example:"FLT-MERV13"`
+	// @Description Name of the part
+	Name string `json:"name" example:"MERV 13 air filter"`
+	// @Description Unit the quantities are counted in, e.g. pcs, m or g
+	Unit string `json:"unit" example:"pcs"`
+	// @Description Quantity in stock
+	Stock int `json:"stock" example:"24"`
+	// @Description Managers are alerted when a task brings the stock down to this quantity
+	LowStockThreshold int `json:"low_stock_threshold" example:"6"`
+	// @Description Whether the stock is at or below the threshold
+	LowStock bool `json:"low_stock" example:"false"`
+	// @Description When the part was added
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the part was last changed
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize removes surrounding spaces and control characters
+func (p *Part) Sanitize() {
+	p.SKU = strings.TrimSpace(p.SKU)
+	p.Name = strings.TrimSpace(sanitizeText(p.Name))
+	p.Unit = strings.TrimSpace(sanitizeText(p.Unit))
+}
+
+func (p *Part) Validate() error {
+	if !skuPattern.MatchString(p.SKU) {
+		return ErrInvalidSKU
+	}
+	if p.Name == "" {
+		return ErrEmptyPartName
+	}
+	if utf8.RuneCountInString(p.Name) > MaxPartNameLength {
+		return ErrPartNameTooLong
+	}
+	if p.Unit == "" || utf8.RuneCountInString(p.Unit) > MaxPartUnitLength {
+		return ErrInvalidPartUnit
+	}
+	if p.LowStockThreshold < 0 || p.LowStockThreshold > MaxStockQuantity {
+		return ErrInvalidStockThreshold
+	}
+	return nil
+}
+
+// UpdateLowStock fills LowStock from the stock and threshold
+func (p *Part) UpdateLowStock() {
+	p.LowStock = p.Stock <= p.LowStockThreshold
+}
+
+// BecameLowStock reports whether a stock change of change units, already
+// applied to Stock, brought the part to or below its threshold
+func (p *Part) BecameLowStock(change int) bool {
+	before := p.Stock - change
+	return before > p.LowStockThreshold && p.Stock <= p.LowStockThreshold
+}
+
+// StockMovement is an entry of the stock ledger. Every change of a part's
+// stock is recorded with the stock it left.
+// @Description A stock ledger entry
+type StockMovement struct {
+	// @Description The unique identifier of the movement
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the part
+	PartID int64 `json:"part_id" example:"1"`
+	// @Description The task the part was used on or returned from, absent otherwise
+	TaskID *int64 `json:"task_id,omitempty" example:"1"`
+	// @Description Change of the stock, negative when parts left it
+	Quantity int `json:"quantity" example:"-2"`
+	// @Description Stock after the movement
+	Balance int `json:"balance" example:"22"`
+	// @Description Why the stock changed: restock, consumption, return or adjustment
+	Reason string `json:"reason" example:"consumption"`
+	// @Description Optional remark, e.g. a purchase order or why stock was adjusted
+	Note string `json:"note" example:"PO 2024-117"`
+	// @Description The ID of the user who moved the stock, absent once deleted
+	CreatedBy *int64 `json:"created_by,omitempty" example:"2"`
+	// @Description When the stock moved
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize removes invalid UTF-8 and control characters from the note
+func (m *StockMovement) Sanitize() {
+	m.Note = strings.TrimSpace(sanitizeText(m.Note))
+}
+
+// ValidateAdjustment checks a movement entered by a manager: a restock adds
+// parts, an adjustment corrects the stock either way
+func (m *StockMovement) ValidateAdjustment() error {
+	switch m.Reason {
+	case StockReasonRestock:
+		if m.Quantity <= 0 || m.Quantity > MaxStockQuantity {
+			return ErrInvalidStockQuantity
+		}
+	case StockReasonAdjustment:
+		if m.Quantity == 0 || m.Quantity < -MaxStockQuantity || m.Quantity > MaxStockQuantity {
+			return ErrInvalidStockQuantity
+		}
+	default:
+		return ErrInvalidStockReason
+	}
+	if utf8.RuneCountInString(m.Note) > MaxStockMovementNoteLength {
+		return ErrStockNoteTooLong
+	}
+	return nil
+}
+
+// StockMovementPage is one page of a part's ledger
+// @Description A page of stock movements
+type StockMovementPage struct {
+	// @Description The movements of this page, newest first
+	Movements []*StockMovement `json:"movements"`
+	// @Description Cursor to request the next page, absent on the last page
+	NextCursor int64 `json:"next_cursor,omitempty" example:"42"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPart_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		part    Part
+		wantErr error
+	}{
+		{name: "valid part", part: Part{SKU: "FLT-MERV13", Name: "MERV 13 air filter", Unit: "pcs", LowStockThreshold: 6}},
+		{name: "sku with spaces", part: Part{SKU: "FLT MERV13", Name: "Filter", Unit: "pcs"}, wantErr: ErrInvalidSKU},
+		{name: "empty sku", part: Part{Name: "Filter", Unit: "pcs"}, wantErr: ErrInvalidSKU},
+		{name: "empty name", part: Part{SKU: "FLT", Unit: "pcs"}, wantErr: ErrEmptyPartName},
+		{name: "name too long", part: Part{SKU: "FLT", Name: strings.Repeat("é", MaxPartNameLength+1), Unit: "pcs"}, wantErr: ErrPartNameTooLong},
+		{name: "empty unit", part: Part{SKU: "FLT", Name: "Filter"}, wantErr: ErrInvalidPartUnit},
+		{name: "negative threshold", part: Part{SKU: "FLT", Name: "Filter", Unit: "pcs", LowStockThreshold: -1}, wantErr: ErrInvalidStockThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.part.Validate())
+		})
+	}
+}
+
+func TestPart_BecameLowStock(t *testing.T) {
+	tests := []struct {
+		name   string
+		stock  int
+		change int
+		want   bool
+	}{
+		{name: "consumption crosses the threshold", stock: 6, change: -2, want: true},
+		{name: "consumption stays above the threshold", stock: 7, change: -2, want: false},
+		{name: "already low before", stock: 4, change: -1, want: false},
+		{name: "restock", stock: 10, change: 5, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part := Part{Stock: tt.stock, LowStockThreshold: 6}
+			assert.Equal(t, tt.want, part.BecameLowStock(tt.change))
+		})
+	}
+}
+
+func TestStockMovement_ValidateAdjustment(t *testing.T) {
+	tests := []struct {
+		name     string
+		movement StockMovement
+		wantErr  error
+	}{
+		{name: "restock", movement: StockMovement{Reason: StockReasonRestock, Quantity: 10, Note: "PO 2024-117"}},
+		{name: "adjustment down", movement: StockMovement{Reason: StockReasonAdjustment, Quantity: -3}},
+		{name: "negative restock", movement: StockMovement{Reason: StockReasonRestock, Quantity: -3}, wantErr: ErrInvalidStockQuantity},
+		{name: "empty adjustment", movement: StockMovement{Reason: StockReasonAdjustment}, wantErr: ErrInvalidStockQuantity},
+		{name: "consumption is recorded through tasks", movement: StockMovement{Reason: StockReasonConsumption, Quantity: -1}, wantErr: ErrInvalidStockReason},
+		{name: "note too long", movement: StockMovement{Reason: StockReasonRestock, Quantity: 1, Note: strings.Repeat("a", MaxStockMovementNoteLength+1)}, wantErr: ErrStockNoteTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.movement.ValidateAdjustment())
+		})
+	}
+}
+
+func TestTaskMaterial_Validate(t *testing.T) {
+	assert.NoError(t, (&TaskMaterial{Quantity: 2}).Validate())
+	assert.Equal(t, ErrInvalidMaterialQuantity, (&TaskMaterial{}).Validate())
+	assert.Equal(t, ErrInvalidMaterialQuantity, (&TaskMaterial{Quantity: MaxStockQuantity + 1}).Validate())
+	assert.Equal(t, ErrMaterialNoteTooLong, (&TaskMaterial{Quantity: 1, Note: strings.Repeat("a", MaxTaskMaterialNoteLength+1)}).Validate())
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sword-challenge/internal/rrule"
+)
+
+// MaxRRuleLength is the size of the rrule column
+const MaxRRuleLength = 500
+
+var (
+	ErrEmptyRRule       = errors.New("rrule cannot be empty")
+	ErrRRuleTooLong     = errors.New("rrule exceeds maximum length of 500 characters")
+	ErrInvalidRRule     = errors.New("rrule is not a valid or supported recurrence rule")
+	ErrUnknownTimezone  = errors.New("timezone must be an IANA time zone name")
+	ErrInvalidStartDate = errors.New("starts_at must be between 1900-01-01 and 2100-12-31")
+)
+
+// RecurringTask is a schedule of tasks, e.g. preventive maintenance, whose
+// occurrences follow an iCalendar RRULE. The scheduler creates each
+// occurrence as an open task assigned to the technician ahead of time.
+// @Description A recurring task definition
+type RecurringTask struct {
+	// @Description The unique identifier of the recurring task
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the technician the generated tasks are assigned to
+	TechnicianID int64 `json:"technician_id" example:"2"`
+	// @Description Title of the generated tasks
+	Title string `json:"title" example:"Monthly HVAC inspection"`
+	// @Description Summary of the generated tasks, in Markdown; the template summary when empty
+	Summary string `json:"summary" example:"Inspect the rooftop units and replace the filters."`
+	// @Description Template giving the generated tasks their checklist and tags
+	TemplateID *int64 `json:"template_id,omitempty" example:"1"`
+	// @Description iCalendar recurrence rule (RFC 5545)
+	RRule string `json:"rrule" example:"FREQ=MONTHLY;BYDAY=1MO"`
+	// @Description First occurrence; later occurrences keep its time of day
+	StartsAt time.Time `json:"starts_at" example:"2024-04-01T09:00:00+01:00"`
+	// @Description IANA time zone the rule is expanded in
+	Timezone string `json:"timezone" example:"Europe/Lisbon"`
+	// @Description Occurrences up to this time have been created as tasks
+	GeneratedUntil *time.Time `json:"generated_until,omitempty" example:"2024-04-15T09:00:00Z"`
+	// @Description The ID of the manager who created the recurring task
+	CreatedBy int64 `json:"created_by" example:"1"`
+	// @Description When the recurring task was created
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the recurring task was last updated
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// RecurringOccurrence is an upcoming occurrence of a recurring task
+// @Description An upcoming occurrence of a recurring task
+type RecurringOccurrence struct {
+	// @Description When the occurrence is scheduled
+	ScheduledFor time.Time `json:"scheduled_for" example:"2024-04-01T09:00:00+01:00"`
+	// @Description The task created for the occurrence, absent until the scheduler reaches it
+	TaskID *int64 `json:"task_id,omitempty" example:"42"`
+}
+
+// Sanitize normalizes the text fields and drops the "RRULE:" prefix; the
+// timezone defaults to UTC
+func (r *RecurringTask) Sanitize() {
+	r.Title = strings.TrimSpace(sanitizeText(r.Title))
+	r.Summary = strings.TrimSpace(sanitizeText(r.Summary))
+	r.RRule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(r.RRule)), "RRULE:")
+	r.Timezone = strings.TrimSpace(r.Timezone)
+	if r.Timezone == "" {
+		r.Timezone = "UTC"
+	}
+}
+
+func (r *RecurringTask) Validate() error {
+	if r.Title == "" {
+		return ErrEmptyTitle
+	}
+	if utf8.RuneCountInString(r.Title) > MaxTitleLength {
+		return ErrTitleTooLong
+	}
+	if r.Summary == "" && r.TemplateID == nil {
+		return ErrEmptySummary
+	}
+	if utf8.RuneCountInString(r.Summary) > MaxSummaryLength {
+		return ErrSummaryTooLong
+	}
+	if r.RRule == "" {
+		return ErrEmptyRRule
+	}
+	if len(r.RRule) > MaxRRuleLength {
+		return ErrRRuleTooLong
+	}
+	if _, err := rrule.Parse(r.RRule); err != nil {
+		return ErrInvalidRRule
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return ErrUnknownTimezone
+	}
+
+	minDate := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxDate := time.Date(2100, 12, 31, 23, 59, 59, 0, time.UTC)
+	if r.StartsAt.Before(minDate) || r.StartsAt.After(maxDate) {
+		return ErrInvalidStartDate
+	}
+	return nil
+}
+
+// Occurrences returns up to limit occurrences in (after, before], expanded in
+// the recurring task's time zone
+func (r *RecurringTask) Occurrences(after, before time.Time, limit int) ([]time.Time, error) {
+	rule, err := rrule.Parse(r.RRule)
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	return rule.Between(r.StartsAt.In(location), after, before, limit), nil
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringTask_Validate(t *testing.T) {
+	templateID := int64(1)
+	valid := func() RecurringTask {
+		return RecurringTask{
+			TechnicianID: 2,
+			Title:        "Monthly HVAC inspection",
+			Summary:      "Inspect the rooftop units.",
+			RRule:        "FREQ=MONTHLY;BYDAY=1MO",
+			StartsAt:     time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC),
+			Timezone:     "Europe/Lisbon",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(*RecurringTask)
+		wantErr error
+	}{
+		{name: "valid recurring task", change: func(r *RecurringTask) {}},
+		{name: "summary may come from the template", change: func(r *RecurringTask) { r.Summary = ""; r.TemplateID = &templateID }},
+		{name: "empty summary", change: func(r *RecurringTask) { r.Summary = "" }, wantErr: ErrEmptySummary},
+		{name: "empty title", change: func(r *RecurringTask) { r.Title = "" }, wantErr: ErrEmptyTitle},
+		{name: "empty rrule", change: func(r *RecurringTask) { r.RRule = "" }, wantErr: ErrEmptyRRule},
+		{name: "invalid rrule", change: func(r *RecurringTask) { r.RRule = "FREQ=MONTHLY;BYDAY=XX" }, wantErr: ErrInvalidRRule},
+		{name: "unsupported rrule", change: func(r *RecurringTask) { r.RRule = "FREQ=DAILY;BYHOUR=9" }, wantErr: ErrInvalidRRule},
+		{name: "unknown timezone", change: func(r *RecurringTask) { r.Timezone = "Mars/Olympus" }, wantErr: ErrUnknownTimezone},
+		{name: "start out of range", change: func(r *RecurringTask) { r.StartsAt = time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC) }, wantErr: ErrInvalidStartDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurringTask := valid()
+			tt.change(&recurringTask)
+			assert.Equal(t, tt.wantErr, recurringTask.Validate())
+		})
+	}
+}
+
+func TestRecurringTask_Occurrences(t *testing.T) {
+	// Lisbon is on UTC in winter and UTC+1 in summer
+	recurringTask := RecurringTask{
+		RRule:    "FREQ=MONTHLY;BYDAY=1MO;COUNT=3",
+		StartsAt: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+		Timezone: "Europe/Lisbon",
+	}
+
+	occurrences, err := recurringTask.Occurrences(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 10)
+
+	assert.NoError(t, err)
+	if assert.Len(t, occurrences, 3) {
+		assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), occurrences[0].UTC())
+		// Lisbon moved to summer time; the local time of day is kept
+		assert.Equal(t, time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC), occurrences[1].UTC())
+		assert.Equal(t, time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC), occurrences[2].UTC())
+	}
+}
+
+func TestRecurringTask_Sanitize(t *testing.T) {
+	recurringTask := RecurringTask{Title: "  Inspection ", RRule: " rrule:freq=weekly "}
+	recurringTask.Sanitize()
+
+	assert.Equal(t, "Inspection", recurringTask.Title)
+	assert.Equal(t, "FREQ=WEEKLY", recurringTask.RRule)
+	assert.Equal(t, "UTC", recurringTask.Timezone)
+}
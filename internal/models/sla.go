@@ -0,0 +1,282 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SLA statuses of a task with a due date. Open tasks are on track, at risk or
+// breached; completed tasks met or missed their due date.
+const (
+	SLAStatusOnTrack  = "on_track"
+	SLAStatusAtRisk   = "at_risk"
+	SLAStatusBreached = "breached"
+	SLAStatusMet      = "met"
+	SLAStatusMissed   = "missed"
+)
+
+// Escalation levels recorded on a task once its manager has been notified
+const (
+	SLAEscalationNone     = "none"
+	SLAEscalationAtRisk   = "at_risk"
+	SLAEscalationBreached = "breached"
+)
+
+// SLA policy limits
+const (
+	MaxSLAPolicyNameLength  = 100
+	MaxSLAResolutionMinutes = 365 * 24 * 60
+)
+
+// DefaultAtRiskWindow is how long before the due date a task without a policy
+// is at risk
+const DefaultAtRiskWindow = 2 * time.Hour
+
+var (
+	ErrEmptyPolicyName     = errors.New("policy name cannot be empty")
+	ErrPolicyNameTooLong   = errors.New("policy name exceeds maximum length of 100 characters")
+	ErrInvalidResolution   = errors.New("resolution_minutes must be between 1 and 525600")
+	ErrInvalidAtRiskWindow = errors.New("at_risk_minutes must be at least 0 and less than resolution_minutes")
+	ErrInvalidDueDate      = errors.New("due_at must be between 1900-01-01 and 2100-12-31")
+	ErrInvalidSLAStatus    = errors.New("sla must be one of on_track, at_risk, breached, met or missed")
+	ErrInvalidReportPeriod = errors.New("from must be before to")
+)
+
+// SLAPolicy gives the tasks of a tag, e.g. "corrective", a resolution time.
+// A task with several tags follows the strictest matching policy.
+// @Description An SLA policy
+type SLAPolicy struct {
+	// @Description The unique identifier of the policy
+	ID int64 `json:"id" example:"1"`
+	// @Description Name of the policy
+	Name string `json:"name" example:"Corrective maintenance"`
+	// @Description The tag whose tasks follow the policy
+	Tag string `json:"tag" example:"corrective"`
+	// The ID of Tag, resolved by the service
+	TagID int64 `json:"-"`
+	// @Description Minutes from creation until the task is due
+	ResolutionMinutes int `json:"resolution_minutes" example:"1440"`
+	// @Description Minutes before the due date when the task is at risk
+	AtRiskMinutes int `json:"at_risk_minutes" example:"240"`
+	// @Description When the policy was created
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the policy was last updated
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize normalizes the name and tag
+func (p *SLAPolicy) Sanitize() {
+	p.Name = strings.TrimSpace(sanitizeText(p.Name))
+	p.Tag = NormalizeTagName(p.Tag)
+}
+
+func (p *SLAPolicy) Validate() error {
+	if p.Name == "" {
+		return ErrEmptyPolicyName
+	}
+	if utf8.RuneCountInString(p.Name) > MaxSLAPolicyNameLength {
+		return ErrPolicyNameTooLong
+	}
+	if len(p.Tag) > 50 || !tagPattern.MatchString(p.Tag) {
+		return ErrInvalidTagName
+	}
+	if p.ResolutionMinutes < 1 || p.ResolutionMinutes > MaxSLAResolutionMinutes {
+		return ErrInvalidResolution
+	}
+	if p.AtRiskMinutes < 0 || p.AtRiskMinutes >= p.ResolutionMinutes {
+		return ErrInvalidAtRiskWindow
+	}
+	return nil
+}
+
+// Resolution is the time a task following the policy has to be completed
+func (p *SLAPolicy) Resolution() time.Duration {
+	return time.Duration(p.ResolutionMinutes) * time.Minute
+}
+
+// AtRiskWindow is how long before the due date the task is at risk
+func (p *SLAPolicy) AtRiskWindow() time.Duration {
+	return time.Duration(p.AtRiskMinutes) * time.Minute
+}
+
+// StrictestSLAPolicy returns the policy with the shortest resolution time, or
+// nil when there is none
+func StrictestSLAPolicy(policies []*SLAPolicy) *SLAPolicy {
+	var strictest *SLAPolicy
+	for _, policy := range policies {
+		if strictest == nil || policy.ResolutionMinutes < strictest.ResolutionMinutes {
+			strictest = policy
+		}
+	}
+	return strictest
+}
+
+// ValidSLAStatus reports whether status is one of the SLA statuses
+func ValidSLAStatus(status string) bool {
+	switch status {
+	case SLAStatusOnTrack, SLAStatusAtRisk, SLAStatusBreached, SLAStatusMet, SLAStatusMissed:
+		return true
+	}
+	return false
+}
+
+// ApplySLAPolicy makes the task due the policy's resolution time after from
+func (t *Task) ApplySLAPolicy(policy *SLAPolicy, from time.Time) {
+	dueAt := from.Add(policy.Resolution())
+	t.SetDueAt(&dueAt, policy.AtRiskWindow())
+	t.SLAPolicyID = &policy.ID
+}
+
+// SetDueAt changes the due date; the task is at risk window before it. A nil
+// dueAt removes the due date.
+func (t *Task) SetDueAt(dueAt *time.Time, window time.Duration) {
+	t.DueAt = dueAt
+	t.AtRiskAt = nil
+	if dueAt != nil {
+		atRiskAt := dueAt.Add(-window)
+		t.AtRiskAt = &atRiskAt
+	}
+}
+
+// AtRiskWindow is how long before its due date the task is at risk:
+// DefaultAtRiskWindow unless the task has its own window
+func (t *Task) AtRiskWindow() time.Duration {
+	if t.DueAt == nil || t.AtRiskAt == nil {
+		return DefaultAtRiskWindow
+	}
+	return t.DueAt.Sub(*t.AtRiskAt)
+}
+
+// ValidateDueDate checks the due date range, if the task has one
+func (t *Task) ValidateDueDate() error {
+	if t.DueAt == nil {
+		return nil
+	}
+	minDate := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxDate := time.Date(2100, 12, 31, 23, 59, 59, 0, time.UTC)
+	if t.DueAt.Before(minDate) || t.DueAt.After(maxDate) {
+		return ErrInvalidDueDate
+	}
+	return nil
+}
+
+// UpdateSLAStatus fills SLAStatus as of now; tasks without a due date have
+// none
+func (t *Task) UpdateSLAStatus(now time.Time) {
+	switch {
+	case t.DueAt == nil:
+		t.SLAStatus = ""
+	case t.Status == TaskStatusCompleted:
+		completedAt := t.UpdatedAt
+		if t.CompletedAt != nil {
+			completedAt = *t.CompletedAt
+		}
+		if completedAt.After(*t.DueAt) {
+			t.SLAStatus = SLAStatusMissed
+		} else {
+			t.SLAStatus = SLAStatusMet
+		}
+	case !now.Before(*t.DueAt):
+		t.SLAStatus = SLAStatusBreached
+	case t.AtRiskAt != nil && !now.Before(*t.AtRiskAt):
+		t.SLAStatus = SLAStatusAtRisk
+	default:
+		t.SLAStatus = SLAStatusOnTrack
+	}
+}
+
+// SLAEscalation is an open task the SLA evaluator has to look at, with the
+// escalation level already notified
+type SLAEscalation struct {
+	TaskID       int64
+	TechnicianID int64
+	Title        string
+	DueAt        time.Time
+	AtRiskAt     *time.Time
+	Level        string
+}
+
+// Next returns the escalation level the task has reached at now, which is
+// Level when there is nothing new to notify
+func (e *SLAEscalation) Next(now time.Time) string {
+	if !now.Before(e.DueAt) {
+		return SLAEscalationBreached
+	}
+	if e.Level == SLAEscalationNone && e.AtRiskAt != nil && !now.Before(*e.AtRiskAt) {
+		return SLAEscalationAtRisk
+	}
+	return e.Level
+}
+
+// SLAReportQuery selects the tasks due in [From, To). TechnicianID restricts
+// the report to one technician; 0 reports on everyone.
+type SLAReportQuery struct {
+	From         time.Time
+	To           time.Time
+	TechnicianID int64
+}
+
+func (q *SLAReportQuery) Validate() error {
+	if !q.From.Before(q.To) {
+		return ErrInvalidReportPeriod
+	}
+	return nil
+}
+
+// SLACompliance counts the tasks due in a period by outcome. Open tasks past
+// their due date count as missed.
+// @Description SLA compliance of the tasks due in a period
+type SLACompliance struct {
+	// @Description The technician, absent in the overall figures
+	TechnicianID int64 `json:"technician_id,omitempty" example:"2"`
+	// @Description Name of the technician, absent in the overall figures
+	TechnicianName string `json:"technician_name,omitempty" example:"Sarah Johnson"`
+	// @Description Tasks due in the period
+	Total int64 `json:"total" example:"20"`
+	// @Description Tasks completed by their due date
+	Met int64 `json:"met" example:"17"`
+	// @Description Tasks completed late or still open past their due date
+	Missed int64 `json:"missed" example:"2"`
+	// @Description Open tasks not due yet
+	Pending int64 `json:"pending" example:"1"`
+	// @Description Percentage of met over met and missed tasks, absent when none is decided yet
+	CompliancePercent *float64 `json:"compliance_percent,omitempty" example:"89.47"`
+}
+
+// UpdateCompliance computes CompliancePercent from the counts
+func (c *SLACompliance) UpdateCompliance() {
+	c.CompliancePercent = nil
+	if decided := c.Met + c.Missed; decided > 0 {
+		percent := float64(c.Met) * 100 / float64(decided)
+		c.CompliancePercent = &percent
+	}
+}
+
+// SLAReport is the SLA compliance of the tasks due in a period
+// @Description SLA compliance report
+type SLAReport struct {
+	// @Description Start of the period (inclusive)
+	From time.Time `json:"from" example:"2024-03-01T00:00:00Z"`
+	// @Description End of the period (exclusive)
+	To time.Time `json:"to" example:"2024-04-01T00:00:00Z"`
+	// @Description Figures of every technician in the report together
+	Overall SLACompliance `json:"overall"`
+	// @Description Figures per technician
+	Technicians []*SLACompliance `json:"technicians"`
+}
+
+// NewSLAReport totals the figures of each technician
+func NewSLAReport(query SLAReportQuery, technicians []*SLACompliance) *SLAReport {
+	report := &SLAReport{From: query.From, To: query.To, Technicians: technicians}
+	for _, technician := range technicians {
+		technician.UpdateCompliance()
+		report.Overall.Total += technician.Total
+		report.Overall.Met += technician.Met
+		report.Overall.Missed += technician.Missed
+		report.Overall.Pending += technician.Pending
+	}
+	report.Overall.UpdateCompliance()
+	return report
+}
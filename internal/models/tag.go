@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Tag limits
+const (
+	MaxTagDescriptionLength = 255
+	MaxTaskTags             = 10
+)
+
+var (
+	ErrInvalidTagName     = errors.New("tag name must be 1-50 lowercase letters, digits, - or _")
+	ErrInvalidTagCategory = errors.New("tag category must be up to 50 lowercase letters, digits, - or _")
+	ErrTagDescription     = errors.New("tag description exceeds maximum length of 255 characters")
+	ErrTooManyTags        = errors.New("a task can have at most 10 tags")
+)
+
+// tagPattern is the format of tag names and categories, e.g. "hvac" or
+// "fire-safety"
+var tagPattern = regexp.MustCompile(`^[a-z0-9]+(?:[-_][a-z0-9]+)*$`)
+
+// Tag is a term of the managed vocabulary used to classify tasks. Tags are
+// grouped by category, e.g. "discipline" (hvac, electrical) or "type"
+// (preventive, corrective).
+// @Description A tag that classifies tasks
+type Tag struct {
+	// @Description The unique identifier of the tag
+	ID int64 `json:"id" example:"1"`
+	// @Description The tag name, unique, lowercase
+	Name string `json:"name" example:"hvac"`
+	// @Description The category grouping related tags, empty when uncategorized
+	Category string `json:"category" example:"discipline"`
+	// @Description What the tag is used for
+	Description string `json:"description" example:"Heating, ventilation and air conditioning"`
+	// @Description When the tag was created
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize normalizes the name and category to lowercase without surrounding
+// spaces
+func (t *Tag) Sanitize() {
+	t.Name = NormalizeTagName(t.Name)
+	t.Category = NormalizeTagName(t.Category)
+	t.Description = strings.TrimSpace(sanitizeText(t.Description))
+}
+
+func (t *Tag) Validate() error {
+	if len(t.Name) > 50 || !tagPattern.MatchString(t.Name) {
+		return ErrInvalidTagName
+	}
+	if t.Category != "" && (len(t.Category) > 50 || !tagPattern.MatchString(t.Category)) {
+		return ErrInvalidTagCategory
+	}
+	if utf8.RuneCountInString(t.Description) > MaxTagDescriptionLength {
+		return ErrTagDescription
+	}
+	return nil
+}
+
+// TagCount is the number of tasks carrying a tag
+// @Description Number of tasks with a tag
+type TagCount struct {
+	// @Description The ID of the tag
+	TagID int64 `json:"tag_id" example:"1"`
+	// @Description The tag name
+	Name string `json:"name" example:"hvac"`
+	// @Description The category of the tag
+	Category string `json:"category" example:"discipline"`
+	// @Description Number of active tasks with the tag
+	TaskCount int64 `json:"task_count" example:"12"`
+}
+
+// NormalizeTagName lowercases and trims a tag name or category
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags normalizes a list of tag names, dropping empty entries and
+// duplicates. The result is sorted and never nil.
+func NormalizeTags(names []string) []string {
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name != "" && !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// ValidateTaskTags checks the number and format of a task's tags; whether
+// they exist in the vocabulary is checked against the repository
+func ValidateTaskTags(tags []string) error {
+	if len(tags) > MaxTaskTags {
+		return ErrTooManyTags
+	}
+	for _, tag := range tags {
+		if len(tag) > 50 || !tagPattern.MatchString(tag) {
+			return ErrInvalidTagName
+		}
+	}
+	return nil
+}
+
+// TaskFilter narrows a task listing
+type TaskFilter struct {
+	// Tags lists tag names; tasks need one of them, or all when MatchAll is set
+	Tags     []string
+	MatchAll bool
+	// SLA keeps the tasks with this SLA status, e.g. breached
+	SLA string
+	// Geofence keeps the tasks whose location has this geofence outcome, or
+	// the flagged ones
+	Geofence string
+}
+
+// Validate normalizes the tag names and checks them, the SLA status and the
+// geofence outcome
+func (f *TaskFilter) Validate() error {
+	f.Tags = NormalizeTags(f.Tags)
+	if f.SLA != "" && !ValidSLAStatus(f.SLA) {
+		return ErrInvalidSLAStatus
+	}
+	if f.Geofence != "" && !ValidGeofenceFilter(f.Geofence) {
+		return ErrInvalidGeofence
+	}
+	return ValidateTaskTags(f.Tags)
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTag_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     Tag
+		wantErr error
+	}{
+		{name: "valid tag", tag: Tag{Name: "hvac", Category: "discipline"}},
+		{name: "uncategorized", tag: Tag{Name: "fire-safety"}},
+		{name: "empty name", tag: Tag{Name: ""}, wantErr: ErrInvalidTagName},
+		{name: "spaces in name", tag: Tag{Name: "air conditioning"}, wantErr: ErrInvalidTagName},
+		{name: "uppercase name", tag: Tag{Name: "HVAC"}, wantErr: ErrInvalidTagName},
+		{name: "name too long", tag: Tag{Name: strings.Repeat("a", 51)}, wantErr: ErrInvalidTagName},
+		{name: "invalid category", tag: Tag{Name: "hvac", Category: "trade/discipline"}, wantErr: ErrInvalidTagCategory},
+		{name: "description too long", tag: Tag{Name: "hvac", Description: strings.Repeat("a", 256)}, wantErr: ErrTagDescription},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tag.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTag_Sanitize(t *testing.T) {
+	tag := Tag{Name: "  HVAC ", Category: " Discipline", Description: " Heating\u0000 "}
+	tag.Sanitize()
+
+	if tag.Name != "hvac" || tag.Category != "discipline" || tag.Description != "Heating" {
+		t.Errorf("Sanitize() = %+v", tag)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{"Preventive", " hvac", "", "HVAC", "preventive"})
+	want := []string{"hvac", "preventive"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeTags() = %v, want %v", got, want)
+	}
+
+	if got := NormalizeTags(nil); got == nil || len(got) != 0 {
+		t.Errorf("NormalizeTags(nil) = %#v, want an empty list", got)
+	}
+}
+
+func TestValidateTaskTags(t *testing.T) {
+	tooMany := make([]string, MaxTaskTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag-" + string(rune('a'+i))
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		wantErr error
+	}{
+		{name: "no tags", tags: []string{}},
+		{name: "valid tags", tags: []string{"hvac", "preventive"}},
+		{name: "malformed tag", tags: []string{"hvac!"}, wantErr: ErrInvalidTagName},
+		{name: "too many tags", tags: tooMany, wantErr: ErrTooManyTags},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTaskTags(tt.tags); err != tt.wantErr {
+				t.Errorf("ValidateTaskTags() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,17 +2,42 @@ package models
 
 import (
 	"errors"
-	"html"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"sword-challenge/internal/markdown"
+)
+
+// Length limits, counted in characters (runes) rather than bytes
+const (
+	MaxTitleLength   = 255
+	MaxSummaryLength = 2500
+)
+
+// Task statuses
+const (
+	TaskStatusOpen      = "open"
+	TaskStatusCompleted = "completed"
+)
+
+// Task priorities, from least to most pressing
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityNormal = "normal"
+	TaskPriorityHigh   = "high"
+	TaskPriorityUrgent = "urgent"
 )
 
 var (
-	ErrSummaryTooLong   = errors.New("summary exceeds maximum length of 2500 characters")
-	ErrTitleTooLong     = errors.New("title exceeds maximum length of 255 characters")
-	ErrInvalidDateRange = errors.New("performed_at date must be between 1900-01-01 and 2100-12-31")
-	ErrEmptyTitle       = errors.New("title cannot be empty")
-	ErrEmptySummary     = errors.New("summary cannot be empty")
+	ErrSummaryTooLong    = errors.New("summary exceeds maximum length of 2500 characters")
+	ErrTitleTooLong      = errors.New("title exceeds maximum length of 255 characters")
+	ErrInvalidDateRange  = errors.New("performed_at date must be between 1900-01-01 and 2100-12-31")
+	ErrEmptyTitle        = errors.New("title cannot be empty")
+	ErrEmptySummary      = errors.New("summary cannot be empty")
+	ErrInvalidTaskStatus = errors.New("status must be open or completed")
+	ErrInvalidPriority   = errors.New("priority must be low, normal, high or urgent")
 )
 
 // Task represents a task in the system
@@ -24,14 +49,58 @@ type Task struct {
 	TechnicianID int64 `json:"technician_id" example:"1"`
 	// @Description The title of the task
 	Title string `json:"title" example:"Fix air conditioning"`
-	// @Description The detailed summary of the task
-	Summary string `json:"summary" example:"Replaced filters and recharged coolant"`
+	// @Description The detailed summary of the task, in Markdown
+	Summary string `json:"summary" example:"Replaced **filters** and recharged coolant"`
+	// @Description The summary rendered to sanitized HTML
+	SummaryHTML string `json:"summary_html" example:"<p>Replaced <strong>filters</strong> and recharged coolant</p>"`
 	// @Description When the task was performed
 	PerformedAt time.Time `json:"performed_at" example:"2024-03-20T14:30:00Z"`
+	// @Description Whether the task is still open or completed
+	Status string `json:"status" example:"completed" enums:"open,completed"`
+	// @Description How pressing the task is; the work queue serves urgent tasks first
+	Priority string `json:"priority" example:"normal" enums:"low,normal,high,urgent"`
+	// @Description Completion of the task checklist
+	Checklist ChecklistProgress `json:"checklist"`
+	// @Description Names of the tags classifying the task
+	Tags []string `json:"tags" example:"hvac,preventive"`
+	// @Description The template revision the task was created from, absent for tasks written from scratch
+	Template *TaskTemplateRef `json:"template,omitempty"`
+	// @Description The recurring task that generated the task, absent for other tasks
+	RecurringTaskID *int64 `json:"recurring_task_id,omitempty" example:"1"`
+	// @Description The occurrence of the recurring task the task was generated for
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty" example:"2024-04-01T09:00:00Z"`
+	// @Description When the task has to be completed, absent when it has no due date
+	DueAt *time.Time `json:"due_at,omitempty" example:"2024-03-21T14:30:00Z"`
+	// @Description When the task becomes at risk of missing its due date
+	AtRiskAt *time.Time `json:"at_risk_at,omitempty" example:"2024-03-21T10:30:00Z"`
+	// @Description The SLA policy that gave the task its due date when it was created
+	SLAPolicyID *int64 `json:"sla_policy_id,omitempty" example:"1"`
+	// @Description SLA status: on_track, at_risk or breached while open, met or missed once completed; absent without a due date
+	SLAStatus string `json:"sla_status,omitempty" example:"on_track" enums:"on_track,at_risk,breached,met,missed"`
+	// @Description When the task was completed
+	CompletedAt *time.Time `json:"completed_at,omitempty" example:"2024-03-21T09:00:00Z"`
+	// @Description The technician who claimed the task from the work queue
+	ClaimedBy *int64 `json:"claimed_by,omitempty" example:"2"`
+	// @Description When the task was claimed from the work queue
+	ClaimedAt *time.Time `json:"claimed_at,omitempty" example:"2024-03-21T08:00:00Z"`
+	// @Description The customer site the task was performed at
+	SiteID *int64 `json:"site_id,omitempty" example:"1"`
+	// @Description The asset of the site the task was performed on
+	AssetID *int64 `json:"asset_id,omitempty" example:"1"`
+	// @Description Where the technician recorded the task, checked against the geofence of the site
+	Location *TaskLocation `json:"location,omitempty"`
+	// @Description The prerequisites that are not completed yet; the task cannot be completed before them
+	BlockedBy []int64 `json:"blocked_by,omitempty" example:"5"`
+	// Checklist items created together with the task
+	ChecklistItems []*TaskChecklistItem `json:"-"`
+	// @Description Incremented on every update; sent back as the ETag
+	Version int `json:"version" example:"1"`
 	// @Description When the task was created
 	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
 	// @Description When the task was last updated
 	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:30:00Z"`
+	// @Description When the task was moved to the trash, absent for active tasks
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2024-03-21T09:00:00Z"`
 }
 
 func (t *Task) Validate() error {
@@ -48,10 +117,10 @@ func (t *Task) Validate() error {
 	}
 
 	// Check length constraints
-	if len(t.Title) > 255 {
+	if utf8.RuneCountInString(t.Title) > MaxTitleLength {
 		return ErrTitleTooLong
 	}
-	if len(t.Summary) > 2500 {
+	if utf8.RuneCountInString(t.Summary) > MaxSummaryLength {
 		return ErrSummaryTooLong
 	}
 
@@ -62,13 +131,47 @@ func (t *Task) Validate() error {
 		return ErrInvalidDateRange
 	}
 
-	return nil
+	if t.Status != TaskStatusOpen && t.Status != TaskStatusCompleted {
+		return ErrInvalidTaskStatus
+	}
+	if !ValidPriority(t.Priority) {
+		return ErrInvalidPriority
+	}
+
+	return t.ValidateDueDate()
+}
+
+// ValidPriority reports whether priority is one of the task priorities
+func ValidPriority(priority string) bool {
+	switch priority {
+	case TaskPriorityLow, TaskPriorityNormal, TaskPriorityHigh, TaskPriorityUrgent:
+		return true
+	}
+	return false
 }
 
+// Sanitize normalizes the text fields. Tasks are stored as raw text; output
+// encoding (JSON, HTML, email) is applied by whoever renders them.
 func (t *Task) Sanitize() {
-	// Sanitize HTML/script content
-	t.Title = html.EscapeString(t.Title)
-	t.Summary = html.EscapeString(t.Summary)
+	t.Title = sanitizeText(t.Title)
+	t.Summary = sanitizeText(t.Summary)
+}
+
+// RenderSummary fills SummaryHTML from the Markdown summary
+func (t *Task) RenderSummary() {
+	t.SummaryHTML = markdown.ToHTML(t.Summary)
+}
+
+// sanitizeText replaces invalid UTF-8 and drops control characters other
+// than line breaks and tabs
+func sanitizeText(s string) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return -1
+		}
+		return r
+	}, s)
 }
 
 // CreateTaskRequest represents the request body for creating a task
@@ -80,6 +183,24 @@ type CreateTaskRequest struct {
 	Summary string `json:"summary" binding:"required,max=2500" example:"Replaced filters and recharged coolant"`
 	// @Description When the task was performed (ISO 8601 format)
 	PerformedAt string `json:"performed_at" binding:"required" example:"2024-03-20T14:30:00Z"`
+	// @Description open while work is pending, completed (default) once done
+	Status string `json:"status" example:"completed" enums:"open,completed"`
+	// @Description Names of tags from the managed vocabulary (max 10)
+	Tags []string `json:"tags" example:"hvac,preventive"`
+	// @Description low, normal (default), high or urgent
+	Priority string `json:"priority" example:"normal" enums:"low,normal,high,urgent"`
+	// @Description When the task has to be completed (ISO 8601 format); defaults to the strictest SLA policy of its tags
+	DueAt string `json:"due_at" example:"2024-03-21T14:30:00Z"`
+	// @Description The customer site the task is performed at; defaults to the site of the asset
+	SiteID *int64 `json:"site_id" example:"1"`
+	// @Description The asset of the site the task is performed on
+	AssetID *int64 `json:"asset_id" example:"1"`
+	// @Description Latitude of the technician's device in decimal degrees, checked against the geofence of the site
+	Latitude *float64 `json:"latitude" example:"38.7199"`
+	// @Description Longitude of the technician's device in decimal degrees
+	Longitude *float64 `json:"longitude" example:"-9.1451"`
+	// @Description Accuracy of the device location in meters
+	Accuracy *float64 `json:"accuracy" example:"12"`
 }
 
 // UpdateTaskRequest represents the request body for updating a task
@@ -91,4 +212,22 @@ type UpdateTaskRequest struct {
 	Summary string `json:"summary" binding:"required,max=2500" example:"Replaced filters and recharged coolant"`
 	// @Description When the task was performed (ISO 8601 format)
 	PerformedAt string `json:"performed_at" binding:"required" example:"2024-03-20T14:30:00Z"`
+	// @Description open or completed; omit to keep the current status
+	Status string `json:"status" example:"completed" enums:"open,completed"`
+	// @Description Names of tags from the managed vocabulary (max 10); omit to keep the current tags
+	Tags []string `json:"tags" example:"hvac,preventive"`
+	// @Description low, normal, high or urgent; omit to keep the current priority
+	Priority string `json:"priority" example:"high" enums:"low,normal,high,urgent"`
+	// @Description When the task has to be completed (ISO 8601 format); omit to keep the current due date
+	DueAt string `json:"due_at" example:"2024-03-21T14:30:00Z"`
+	// @Description The customer site the task is performed at; omit to keep the current site
+	SiteID *int64 `json:"site_id" example:"1"`
+	// @Description The asset of the site the task is performed on; omit to keep the current asset
+	AssetID *int64 `json:"asset_id" example:"1"`
+	// @Description Latitude of the technician's device in decimal degrees; omit to keep the current location
+	Latitude *float64 `json:"latitude" example:"38.7199"`
+	// @Description Longitude of the technician's device in decimal degrees
+	Longitude *float64 `json:"longitude" example:"-9.1451"`
+	// @Description Accuracy of the device location in meters
+	Accuracy *float64 `json:"accuracy" example:"12"`
 }
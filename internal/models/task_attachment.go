@@ -0,0 +1,133 @@
+package models
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultAttachmentName is used when an upload has no usable file name
+const DefaultAttachmentName = "attachment"
+
+// ThumbnailSizes maps each thumbnail size name to its longest side in pixels
+var ThumbnailSizes = map[string]int{
+	"small":  160,
+	"medium": 480,
+	"large":  1024,
+}
+
+// TaskAttachment is a file uploaded to a task. The content lives in the blob
+// store under StorageKey.
+// @Description A file attached to a task
+type TaskAttachment struct {
+	// @Description The unique identifier of the attachment
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description The ID of the user who uploaded the file
+	UploaderID int64 `json:"uploader_id" example:"2"`
+	// @Description The original file name
+	FileName string `json:"file_name" example:"after.jpg"`
+	// @Description The MIME type detected from the content
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	// @Description The size of the file in bytes
+	Size int64 `json:"size" example:"482113"`
+	// @Description Hex-encoded SHA-256 of the content
+	Checksum string `json:"checksum_sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	// @Description Image width in pixels, once processed
+	Width int `json:"width,omitempty" example:"3024"`
+	// @Description Image height in pixels, once processed
+	Height int `json:"height,omitempty" example:"4032"`
+	// @Description When the photo was taken, from its EXIF data
+	CapturedAt *time.Time `json:"captured_at,omitempty" example:"2024-03-20T14:12:09Z"`
+	// @Description When the image was processed; thumbnails exist from then on
+	ProcessedAt *time.Time `json:"processed_at,omitempty" example:"2024-03-20T14:30:02Z"`
+	// @Description Available thumbnail sizes
+	Thumbnails []string `json:"thumbnails,omitempty" example:"small,medium,large"`
+	// @Description When the file was uploaded
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+
+	StorageKey string `json:"-"`
+}
+
+// IsImage reports whether the attachment goes through image processing
+func (a *TaskAttachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// SetThumbnails lists the thumbnail sizes once the image has been processed
+func (a *TaskAttachment) SetThumbnails() {
+	a.Thumbnails = nil
+	if a.ProcessedAt != nil && a.IsImage() {
+		a.Thumbnails = ThumbnailSizeNames()
+	}
+}
+
+// ThumbnailSizeNames returns the size names, smallest first
+func ThumbnailSizeNames() []string {
+	names := make([]string, 0, len(ThumbnailSizes))
+	for name := range ThumbnailSizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return ThumbnailSizes[names[i]] < ThumbnailSizes[names[j]] })
+	return names
+}
+
+// strippedSuffix marks the blob key of an image copied without its metadata
+const strippedSuffix = ".stripped"
+
+// ThumbnailKey is the blob key of one thumbnail of an attachment
+func ThumbnailKey(storageKey, size string) string {
+	return storageKey + "." + size
+}
+
+// StrippedKey is the blob key an uploaded image moves to once its metadata is
+// stripped. The upload itself is never overwritten, so the row always points
+// to a blob matching its size and checksum.
+func StrippedKey(storageKey string) string {
+	return storageKey + strippedSuffix
+}
+
+// UploadKey is the blob key of the file as uploaded: storageKey itself, or
+// the key it was stripped from
+func UploadKey(storageKey string) string {
+	return strings.TrimSuffix(storageKey, strippedSuffix)
+}
+
+// AttachmentBlobKeys returns every blob key that may belong to an attachment:
+// the file itself, its thumbnails and the upload it was stripped from
+func AttachmentBlobKeys(storageKey string) []string {
+	keys := []string{storageKey}
+	for _, size := range ThumbnailSizeNames() {
+		keys = append(keys, ThumbnailKey(storageKey, size))
+	}
+	if upload := UploadKey(storageKey); upload != storageKey {
+		keys = append(keys, upload)
+	}
+	return keys
+}
+
+// SanitizeFileName keeps the base name of an uploaded file, without path
+// separators, quotes or control characters, and at most 255 characters
+func SanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == '/' {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(name, ""))
+	name = strings.TrimSpace(name)
+
+	if name == "" || name == "." || name == ".." {
+		return DefaultAttachmentName
+	}
+	for utf8.RuneCountInString(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain name", in: "after.jpg", want: "after.jpg"},
+		{name: "unix path", in: "../../etc/passwd", want: "passwd"},
+		{name: "windows path", in: `C:\Users\tech\before.png`, want: "before.png"},
+		{name: "quotes and control characters", in: "sig\"ned\r\n.pdf", want: "signed.pdf"},
+		{name: "empty", in: "", want: DefaultAttachmentName},
+		{name: "dot dot", in: "..", want: DefaultAttachmentName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeFileName(tt.in); got != tt.want {
+				t.Errorf("SanitizeFileName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if got := SanitizeFileName(strings.Repeat("é", 300)); utf8.RuneCountInString(got) != 255 {
+		t.Errorf("SanitizeFileName() kept %d characters, want 255", utf8.RuneCountInString(got))
+	}
+}
+
+func TestAttachmentBlobKeys(t *testing.T) {
+	assert.Equal(t, []string{"tasks/1/a", "tasks/1/a.small", "tasks/1/a.medium", "tasks/1/a.large"}, AttachmentBlobKeys("tasks/1/a"))
+
+	// A processed image also owns the upload it was stripped from
+	stripped := StrippedKey("tasks/1/a")
+	assert.Equal(t, "tasks/1/a", UploadKey(stripped))
+	assert.Equal(t, []string{stripped, stripped + ".small", stripped + ".medium", stripped + ".large", "tasks/1/a"}, AttachmentBlobKeys(stripped))
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Checklist limits; lengths are counted in characters
+const (
+	MaxChecklistItems          = 100
+	MaxChecklistItemTextLength = 255
+	MaxChecklistItemNoteLength = 500
+)
+
+var (
+	ErrEmptyChecklistItem       = errors.New("checklist item text cannot be empty")
+	ErrChecklistItemTooLong     = errors.New("checklist item text exceeds maximum length of 255 characters")
+	ErrChecklistItemNoteTooLong = errors.New("checklist item note exceeds maximum length of 500 characters")
+	ErrInvalidChecklistPosition = errors.New("checklist item position must be positive")
+)
+
+// TaskChecklistItem is one step of a task's procedure
+// @Description An item of a task checklist
+type TaskChecklistItem struct {
+	// @Description The unique identifier of the item
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description Position of the item in the checklist, starting at 1
+	Position int `json:"position" example:"1"`
+	// @Description What has to be done
+	Text string `json:"text" example:"Replace air filters"`
+	// @Description Whether the task can only be completed once this item is done
+	Required bool `json:"required" example:"true"`
+	// @Description Whether the item is done
+	Done bool `json:"done" example:"true"`
+	// @Description Optional remark, e.g. a reading taken
+	Note string `json:"note" example:"Filters were clogged"`
+	// @Description The ID of the user who marked the item done, absent while open
+	CompletedBy *int64 `json:"completed_by,omitempty" example:"2"`
+	// @Description When the item was marked done, absent while open
+	CompletedAt *time.Time `json:"completed_at,omitempty" example:"2024-03-20T14:10:00Z"`
+	// @Description When the item was added
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T13:00:00Z"`
+	// @Description When the item was last changed
+	UpdatedAt time.Time `json:"updated_at" example:"2024-03-20T14:10:00Z"`
+}
+
+// Sanitize removes invalid UTF-8 and control characters from the text and note
+func (i *TaskChecklistItem) Sanitize() {
+	i.Text = strings.TrimSpace(sanitizeText(i.Text))
+	i.Note = strings.TrimSpace(sanitizeText(i.Note))
+}
+
+func (i *TaskChecklistItem) Validate() error {
+	if i.Text == "" {
+		return ErrEmptyChecklistItem
+	}
+	if utf8.RuneCountInString(i.Text) > MaxChecklistItemTextLength {
+		return ErrChecklistItemTooLong
+	}
+	if utf8.RuneCountInString(i.Note) > MaxChecklistItemNoteLength {
+		return ErrChecklistItemNoteTooLong
+	}
+	if i.Position < 0 {
+		return ErrInvalidChecklistPosition
+	}
+	return nil
+}
+
+// SetDone marks the item done by userID at the given time, or open again.
+// Marking an item that is already done keeps its original completion.
+func (i *TaskChecklistItem) SetDone(done bool, userID int64, at time.Time) {
+	switch {
+	case done && !i.Done:
+		i.Done = true
+		i.CompletedBy = &userID
+		i.CompletedAt = &at
+	case !done:
+		i.Done = false
+		i.CompletedBy = nil
+		i.CompletedAt = nil
+	}
+}
+
+// BlocksCompletion reports whether the item keeps its task from being completed
+func (i *TaskChecklistItem) BlocksCompletion() bool {
+	return i.Required && !i.Done
+}
+
+// ChecklistProgress summarizes the checklist of a task
+// @Description Checklist completion of a task
+type ChecklistProgress struct {
+	// @Description Number of checklist items
+	Total int `json:"total" example:"4"`
+	// @Description Number of items done
+	Done int `json:"done" example:"3"`
+	// @Description Number of required items still open; the task cannot be completed until it is 0
+	RequiredOpen int `json:"required_open" example:"1"`
+	// @Description Share of items done, 0-100; 0 when the task has no checklist
+	Percent int `json:"percent" example:"75"`
+}
+
+// NewChecklistProgress computes the completion percentage, rounded down
+func NewChecklistProgress(total, done, requiredOpen int) ChecklistProgress {
+	progress := ChecklistProgress{Total: total, Done: done, RequiredOpen: requiredOpen}
+	if total > 0 {
+		progress.Percent = done * 100 / total
+	}
+	return progress
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskChecklistItem_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    TaskChecklistItem
+		wantErr error
+	}{
+		{name: "valid item", item: TaskChecklistItem{Text: "Replace air filters", Note: "MERV 13"}},
+		{name: "empty text", item: TaskChecklistItem{Text: ""}, wantErr: ErrEmptyChecklistItem},
+		{name: "text too long", item: TaskChecklistItem{Text: strings.Repeat("é", MaxChecklistItemTextLength+1)}, wantErr: ErrChecklistItemTooLong},
+		{name: "note too long", item: TaskChecklistItem{Text: "Check", Note: strings.Repeat("a", MaxChecklistItemNoteLength+1)}, wantErr: ErrChecklistItemNoteTooLong},
+		{name: "negative position", item: TaskChecklistItem{Text: "Check", Position: -1}, wantErr: ErrInvalidChecklistPosition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.item.Validate())
+		})
+	}
+}
+
+func TestTaskChecklistItem_SetDone(t *testing.T) {
+	first := time.Date(2024, 3, 20, 14, 0, 0, 0, time.UTC)
+	later := first.Add(time.Hour)
+
+	item := TaskChecklistItem{Required: true}
+	assert.True(t, item.BlocksCompletion())
+
+	item.SetDone(true, 2, first)
+	assert.True(t, item.Done)
+	assert.Equal(t, int64(2), *item.CompletedBy)
+	assert.Equal(t, first, *item.CompletedAt)
+	assert.False(t, item.BlocksCompletion())
+
+	// Ticking it again keeps the original completion
+	item.SetDone(true, 3, later)
+	assert.Equal(t, int64(2), *item.CompletedBy)
+	assert.Equal(t, first, *item.CompletedAt)
+
+	item.SetDone(false, 3, later)
+	assert.False(t, item.Done)
+	assert.Nil(t, item.CompletedBy)
+	assert.Nil(t, item.CompletedAt)
+}
+
+func TestNewChecklistProgress(t *testing.T) {
+	assert.Equal(t, ChecklistProgress{}, NewChecklistProgress(0, 0, 0))
+	assert.Equal(t, ChecklistProgress{Total: 3, Done: 2, RequiredOpen: 1, Percent: 66}, NewChecklistProgress(3, 2, 1))
+}
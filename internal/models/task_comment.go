@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Comment limits; the body length is counted in characters
+const (
+	MaxCommentLength   = 2500
+	MaxCommentMentions = 20
+)
+
+var (
+	ErrEmptyComment    = errors.New("comment cannot be empty")
+	ErrCommentTooLong  = errors.New("comment exceeds maximum length of 2500 characters")
+	ErrTooManyMentions = errors.New("comment mentions more than 20 users")
+)
+
+// mentionPattern matches "@" followed by an email, e.g. "@sarah.j@company.com",
+// when the "@" starts a word
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.+-])@([\w.%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// TaskComment is a message in the discussion thread of a task
+// @Description A comment on a task
+type TaskComment struct {
+	// @Description The unique identifier of the comment
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description The ID of the user who wrote the comment
+	AuthorID int64 `json:"author_id" example:"1"`
+	// @Description The comment text; users are mentioned with @ and their email
+	Body string `json:"body" example:"@sarah.j@company.com was the filter replaced too?"`
+	// @Description When the comment was written
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T15:00:00Z"`
+	// @Description When the comment was last edited, absent if never edited
+	EditedAt *time.Time `json:"edited_at,omitempty" example:"2024-03-20T15:05:00Z"`
+}
+
+// Sanitize removes invalid UTF-8 and control characters from the body
+func (c *TaskComment) Sanitize() {
+	c.Body = sanitizeText(c.Body)
+}
+
+func (c *TaskComment) Validate() error {
+	c.Body = strings.TrimSpace(c.Body)
+	if c.Body == "" {
+		return ErrEmptyComment
+	}
+	if utf8.RuneCountInString(c.Body) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	if len(c.Mentions()) > MaxCommentMentions {
+		return ErrTooManyMentions
+	}
+	return nil
+}
+
+// Mentions returns the emails mentioned in the body as @email, lowercased and
+// without duplicates, in order of appearance
+func (c *TaskComment) Mentions() []string {
+	var mentions []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(c.Body, -1) {
+		email := strings.ToLower(match[1])
+		if !seen[email] {
+			seen[email] = true
+			mentions = append(mentions, email)
+		}
+	}
+	return mentions
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskComment_Mentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "mentions in order, without duplicates",
+			body: "@sarah.j@company.com and @Mike.W@company.com, see above. @SARAH.J@company.com?",
+			want: []string{"sarah.j@company.com", "mike.w@company.com"},
+		},
+		{
+			name: "trailing punctuation is not part of the email",
+			body: "Thanks @mike.w@company.com.",
+			want: []string{"mike.w@company.com"},
+		},
+		{
+			name: "plain emails are not mentions",
+			body: "Sent to mike.w@company.com and a@@company.com",
+			want: nil,
+		},
+		{
+			name: "no mentions",
+			body: "Replaced the filters",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := &TaskComment{Body: tt.body}
+			assert.Equal(t, tt.want, comment.Mentions())
+		})
+	}
+}
+
+func TestTaskComment_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{name: "valid", body: "  Looks good  "},
+		{name: "empty", body: " \n ", wantErr: ErrEmptyComment},
+		{name: "too long in characters", body: strings.Repeat("é", MaxCommentLength+1), wantErr: ErrCommentTooLong},
+		{name: "limit counts characters, not bytes", body: strings.Repeat("é", MaxCommentLength)},
+		{name: "too many mentions", body: manyMentions(MaxCommentMentions + 1), wantErr: ErrTooManyMentions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := &TaskComment{Body: tt.body}
+			assert.Equal(t, tt.wantErr, comment.Validate())
+		})
+	}
+}
+
+func manyMentions(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteString("@user")
+		sb.WriteByte(byte('a' + i%26))
+		sb.WriteString(strings.Repeat("x", i/26))
+		sb.WriteString("@company.com ")
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package models
+
+import "errors"
+
+var ErrSelfDependency = errors.New("a task cannot depend on itself")
+
+// TaskDependency says that a task cannot be completed before the task it
+// depends on
+// @Description A dependency between two tasks
+type TaskDependency struct {
+	// @Description The ID of the dependent task
+	TaskID int64 `json:"task_id" example:"2"`
+	// @Description The ID of the prerequisite, which has to be completed first
+	DependsOnID int64 `json:"depends_on_id" example:"1"`
+}
+
+func (d *TaskDependency) Validate() error {
+	if d.TaskID == d.DependsOnID {
+		return ErrSelfDependency
+	}
+	return nil
+}
+
+// TaskDependencyNode is a task of a dependency graph
+// @Description A task of a dependency graph
+type TaskDependencyNode struct {
+	// @Description The ID of the task
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the technician the task belongs to
+	TechnicianID int64 `json:"technician_id" example:"2"`
+	// @Description The title of the task, absent for tasks of other technicians
+	Title string `json:"title,omitempty" example:"Install rack"`
+	// @Description The status of the task
+	Status string `json:"status" example:"open" enums:"open,completed"`
+}
+
+// TaskDependencyGraph holds every task a task depends on, directly or not,
+// and every task that depends on it
+// @Description The dependency graph of a task
+type TaskDependencyGraph struct {
+	// @Description The ID of the task the graph is about
+	TaskID int64 `json:"task_id" example:"2"`
+	// @Description The tasks of the graph
+	Nodes []*TaskDependencyNode `json:"nodes"`
+	// @Description The dependencies between them
+	Edges []*TaskDependency `json:"edges"`
+}
+
+// RedactFor hides the titles of the tasks user cannot see: technicians only
+// see their own tasks
+func (g *TaskDependencyGraph) RedactFor(user *User) {
+	if !user.IsTechnician() {
+		return
+	}
+	for _, node := range g.Nodes {
+		if node.TechnicianID != user.ID {
+			node.Title = ""
+		}
+	}
+}
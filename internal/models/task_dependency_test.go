@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskDependency_Validate(t *testing.T) {
+	assert.NoError(t, (&TaskDependency{TaskID: 2, DependsOnID: 1}).Validate())
+	assert.Equal(t, ErrSelfDependency, (&TaskDependency{TaskID: 1, DependsOnID: 1}).Validate())
+}
+
+func TestTaskDependencyGraph_RedactFor(t *testing.T) {
+	newGraph := func() *TaskDependencyGraph {
+		return &TaskDependencyGraph{
+			TaskID: 2,
+			Nodes: []*TaskDependencyNode{
+				{ID: 1, TechnicianID: 3, Title: "Install rack", Status: TaskStatusOpen},
+				{ID: 2, TechnicianID: 2, Title: "Configure network", Status: TaskStatusOpen},
+			},
+			Edges: []*TaskDependency{{TaskID: 2, DependsOnID: 1}},
+		}
+	}
+
+	graph := newGraph()
+	graph.RedactFor(&User{ID: 1, Role: RoleManager})
+	assert.Equal(t, "Install rack", graph.Nodes[0].Title)
+	assert.Equal(t, "Configure network", graph.Nodes[1].Title)
+
+	graph = newGraph()
+	graph.RedactFor(&User{ID: 2, Role: RoleTechnician})
+	assert.Empty(t, graph.Nodes[0].Title)
+	assert.Equal(t, TaskStatusOpen, graph.Nodes[0].Status)
+	assert.Equal(t, "Configure network", graph.Nodes[1].Title)
+}
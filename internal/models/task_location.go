@@ -0,0 +1,147 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Geofence outcomes of a task location
+const (
+	GeofenceInside     = "inside"
+	GeofenceOutside    = "outside"
+	GeofenceUnverified = "unverified"
+)
+
+// GeofenceFlagged filters the tasks recorded outside the geofence that no
+// manager reviewed yet
+const GeofenceFlagged = "flagged"
+
+// Geofence limits, in meters
+const (
+	DefaultGeofenceRadius = 200
+	MinGeofenceRadius     = 25
+	MaxGeofenceRadius     = 10000
+	MaxLocationAccuracy   = 10000
+)
+
+// earthRadius is the mean radius of the Earth in meters
+const earthRadius = 6371008.8
+
+var (
+	ErrInvalidLocation       = errors.New("location needs a latitude within -90..90, a longitude within -180..180 and an accuracy within 0..10000 meters")
+	ErrInvalidGeofenceRadius = errors.New("geofence radius must be between 25 and 10000 meters")
+	ErrInvalidGeofence       = errors.New("geofence must be inside, outside, unverified or flagged")
+)
+
+// TaskLocation is where the technician's device was when the task was
+// recorded, checked against the geofence of the task's site
+// @Description Where a task was recorded
+type TaskLocation struct {
+	// @Description Latitude in decimal degrees; absent once past the retention window
+	Latitude *float64 `json:"latitude,omitempty" example:"38.7199"`
+	// @Description Longitude in decimal degrees; absent once past the retention window
+	Longitude *float64 `json:"longitude,omitempty" example:"-9.1451"`
+	// @Description Accuracy reported by the device, in meters
+	Accuracy *float64 `json:"accuracy,omitempty" example:"12"`
+	// @Description When the location was received
+	RecordedAt time.Time `json:"recorded_at" example:"2024-03-20T14:30:00Z"`
+	// @Description inside or outside the geofence of the site, or unverified when the task has no site with coordinates
+	Geofence string `json:"geofence" example:"inside" enums:"inside,outside,unverified"`
+	// @Description Distance to the site in meters, absent when unverified
+	Distance *int `json:"distance,omitempty" example:"35"`
+	// @Description The manager who reviewed a location outside the geofence
+	ReviewedBy *int64 `json:"reviewed_by,omitempty" example:"1"`
+	// @Description When a location outside the geofence was reviewed
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" example:"2024-03-21T09:00:00Z"`
+}
+
+func (l *TaskLocation) Validate() error {
+	if l.Latitude == nil || l.Longitude == nil {
+		return ErrInvalidLocation
+	}
+	if math.IsNaN(*l.Latitude) || *l.Latitude < -90 || *l.Latitude > 90 {
+		return ErrInvalidLocation
+	}
+	if math.IsNaN(*l.Longitude) || *l.Longitude < -180 || *l.Longitude > 180 {
+		return ErrInvalidLocation
+	}
+	if l.Accuracy != nil && (math.IsNaN(*l.Accuracy) || *l.Accuracy < 0 || *l.Accuracy > MaxLocationAccuracy) {
+		return ErrInvalidLocation
+	}
+	return nil
+}
+
+// CheckGeofence compares the location with the geofence of site, which may
+// be nil. The location is only outside when it is farther than the radius
+// even allowing for its accuracy, so a poor fix next to the site is not
+// flagged. The allowance is capped at the radius: a device cannot claim a fix
+// poor enough to never be outside.
+func (l *TaskLocation) CheckGeofence(site *Site) {
+	if site == nil || site.Latitude == nil || l.Latitude == nil {
+		l.Geofence, l.Distance = GeofenceUnverified, nil
+		return
+	}
+
+	distance := Distance(*l.Latitude, *l.Longitude, *site.Latitude, *site.Longitude)
+	meters := int(math.Round(distance))
+	l.Distance = &meters
+
+	var accuracy float64
+	if l.Accuracy != nil {
+		accuracy = min(*l.Accuracy, float64(site.GeofenceRadius))
+	}
+	l.Geofence = GeofenceInside
+	if distance-accuracy > float64(site.GeofenceRadius) {
+		l.Geofence = GeofenceOutside
+	}
+}
+
+// KeepReview keeps the review of previous, which may be nil, when the
+// location has the same coordinates, and clears it otherwise: a review
+// vouches for where the task was recorded.
+func (l *TaskLocation) KeepReview(previous *TaskLocation) {
+	l.ReviewedBy, l.ReviewedAt = nil, nil
+	if previous == nil || previous.Latitude == nil || l.Latitude == nil {
+		return
+	}
+	if *previous.Latitude == *l.Latitude && *previous.Longitude == *l.Longitude {
+		l.ReviewedBy, l.ReviewedAt = previous.ReviewedBy, previous.ReviewedAt
+	}
+}
+
+// Flagged reports whether the location is outside the geofence and waits
+// for a manager's review
+func (l *TaskLocation) Flagged() bool {
+	return l != nil && l.Geofence == GeofenceOutside && l.ReviewedAt == nil
+}
+
+// MatchesGeofence reports whether the location has the geofence outcome,
+// or is flagged for GeofenceFlagged; l may be nil
+func (l *TaskLocation) MatchesGeofence(geofence string) bool {
+	if geofence == GeofenceFlagged {
+		return l.Flagged()
+	}
+	return l != nil && l.Geofence == geofence
+}
+
+// ValidGeofenceFilter reports whether geofence can filter task listings
+func ValidGeofenceFilter(geofence string) bool {
+	switch geofence {
+	case GeofenceInside, GeofenceOutside, GeofenceUnverified, GeofenceFlagged:
+		return true
+	}
+	return false
+}
+
+// Distance returns the great-circle distance in meters between two points
+// given in decimal degrees
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1, phi2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dPhi := phi2 - phi1
+	dLambda := (lng2 - lng1) * math.Pi / 180
+
+	// Haversine formula
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskLocation_Validate(t *testing.T) {
+	lat, lng, accuracy := 38.7223, -9.1393, 12.0
+	tooFar, negative, nan := 91.0, -1.0, math.NaN()
+
+	tests := []struct {
+		name     string
+		location TaskLocation
+		wantErr  error
+	}{
+		{name: "with accuracy", location: TaskLocation{Latitude: &lat, Longitude: &lng, Accuracy: &accuracy}},
+		{name: "without accuracy", location: TaskLocation{Latitude: &lat, Longitude: &lng}},
+		{name: "missing longitude", location: TaskLocation{Latitude: &lat, Accuracy: &accuracy}, wantErr: ErrInvalidLocation},
+		{name: "latitude out of range", location: TaskLocation{Latitude: &tooFar, Longitude: &lng}, wantErr: ErrInvalidLocation},
+		{name: "not a number", location: TaskLocation{Latitude: &lat, Longitude: &nan}, wantErr: ErrInvalidLocation},
+		{name: "negative accuracy", location: TaskLocation{Latitude: &lat, Longitude: &lng, Accuracy: &negative}, wantErr: ErrInvalidLocation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.location.Validate())
+		})
+	}
+}
+
+func TestTaskLocation_CheckGeofence(t *testing.T) {
+	siteLat, siteLng := 38.7223, -9.1393
+	site := &Site{ID: 1, Latitude: &siteLat, Longitude: &siteLng, GeofenceRadius: DefaultGeofenceRadius}
+	// About 111, 333 and 556 meters north of the site
+	nearLat, midLat, farLat := 38.7233, 38.7253, 38.7273
+	poorFix, uselessFix := 400.0, float64(MaxLocationAccuracy)
+
+	tests := []struct {
+		name             string
+		location         TaskLocation
+		site             *Site
+		expectedGeofence string
+		expectedFlagged  bool
+	}{
+		{name: "inside", location: TaskLocation{Latitude: &nearLat, Longitude: &siteLng}, site: site, expectedGeofence: GeofenceInside},
+		{name: "outside", location: TaskLocation{Latitude: &farLat, Longitude: &siteLng}, site: site, expectedGeofence: GeofenceOutside, expectedFlagged: true},
+		{name: "within the accuracy", location: TaskLocation{Latitude: &midLat, Longitude: &siteLng, Accuracy: &poorFix}, site: site, expectedGeofence: GeofenceInside},
+		{name: "accuracy capped at the radius", location: TaskLocation{Latitude: &farLat, Longitude: &siteLng, Accuracy: &uselessFix}, site: site, expectedGeofence: GeofenceOutside, expectedFlagged: true},
+		{name: "no site", location: TaskLocation{Latitude: &farLat, Longitude: &siteLng}, expectedGeofence: GeofenceUnverified},
+		{name: "site without coordinates", location: TaskLocation{Latitude: &farLat, Longitude: &siteLng}, site: &Site{ID: 2, GeofenceRadius: DefaultGeofenceRadius}, expectedGeofence: GeofenceUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.location.CheckGeofence(tt.site)
+
+			assert.Equal(t, tt.expectedGeofence, tt.location.Geofence)
+			assert.Equal(t, tt.expectedFlagged, tt.location.Flagged())
+			assert.Equal(t, tt.expectedFlagged, tt.location.MatchesGeofence(GeofenceFlagged))
+			assert.Equal(t, tt.expectedGeofence == GeofenceUnverified, tt.location.Distance == nil)
+		})
+	}
+
+	var none *TaskLocation
+	assert.False(t, none.Flagged())
+	assert.False(t, none.MatchesGeofence(GeofenceUnverified))
+}
+
+func TestDistance(t *testing.T) {
+	// Lisbon to Porto is about 274 km
+	assert.InDelta(t, 274000, Distance(38.7223, -9.1393, 41.1579, -8.6291), 1000)
+	assert.Zero(t, Distance(38.7223, -9.1393, 38.7223, -9.1393))
+}
+
+func TestTaskLocation_KeepReview(t *testing.T) {
+	lat, lng, otherLat := 38.7273, -9.1393, 38.7283
+	reviewer, reviewedAt := int64(1), time.Now()
+	previous := &TaskLocation{Latitude: &lat, Longitude: &lng, Geofence: GeofenceOutside, ReviewedBy: &reviewer, ReviewedAt: &reviewedAt}
+	forged := int64(2)
+
+	tests := []struct {
+		name         string
+		location     TaskLocation
+		previous     *TaskLocation
+		expectReview bool
+	}{
+		{name: "same coordinates", location: TaskLocation{Latitude: &lat, Longitude: &lng}, previous: previous, expectReview: true},
+		{name: "new coordinates", location: TaskLocation{Latitude: &otherLat, Longitude: &lng}, previous: previous},
+		{name: "no previous location", location: TaskLocation{Latitude: &lat, Longitude: &lng}},
+		{name: "previous coordinates cleared", location: TaskLocation{Latitude: &lat, Longitude: &lng}, previous: &TaskLocation{ReviewedBy: &reviewer, ReviewedAt: &reviewedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A review sent by the client is never trusted
+			tt.location.ReviewedBy = &forged
+
+			tt.location.KeepReview(tt.previous)
+
+			if tt.expectReview {
+				assert.Equal(t, &reviewer, tt.location.ReviewedBy)
+				assert.Equal(t, &reviewedAt, tt.location.ReviewedAt)
+			} else {
+				assert.Nil(t, tt.location.ReviewedBy)
+				assert.Nil(t, tt.location.ReviewedAt)
+			}
+		})
+	}
+}
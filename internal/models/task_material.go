@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTaskMaterialNoteLength is counted in characters
+const MaxTaskMaterialNoteLength = 500
+
+var (
+	ErrInvalidMaterialQuantity = errors.New("quantity must be between 1 and 1000000")
+	ErrMaterialNoteTooLong     = errors.New("material note exceeds maximum length of 500 characters")
+)
+
+// TaskMaterial is a quantity of a part used on a task. Recording it takes the
+// quantity out of stock; removing it puts the quantity back.
+// @Description A part used on a task
+type TaskMaterial struct {
+	// @Description The unique identifier of the material line
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description The ID of the part
+	PartID int64 `json:"part_id" example:"1"`
+	// @Description Stock keeping unit of the part
+	SKU string `json:"sku" example:"FLT-MERV13"`
+	// @Description Name of the part
+	Name string `json:"name" example:"MERV 13 air filter"`
+	// @Description Unit of the quantity
+	Unit string `json:"unit" example:"pcs"`
+	// @Description Quantity used
+	Quantity int `json:"quantity" example:"2"`
+	// @Description Optional remark, e.g. where the part went
+	Note string `json:"note" example:"Rooftop unit 2"`
+	// @Description The ID of the user who recorded the material, absent once deleted
+	CreatedBy *int64 `json:"created_by,omitempty" example:"2"`
+	// @Description When the material was recorded
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// Sanitize removes invalid UTF-8 and control characters from the note
+func (m *TaskMaterial) Sanitize() {
+	m.Note = strings.TrimSpace(sanitizeText(m.Note))
+}
+
+func (m *TaskMaterial) Validate() error {
+	if m.Quantity < 1 || m.Quantity > MaxStockQuantity {
+		return ErrInvalidMaterialQuantity
+	}
+	if utf8.RuneCountInString(m.Note) > MaxTaskMaterialNoteLength {
+		return ErrMaterialNoteTooLong
+	}
+	return nil
+}
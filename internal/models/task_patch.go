@@ -0,0 +1,203 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MergePatchContentType is the media type of JSON Merge Patch (RFC 7386)
+const MergePatchContentType = "application/merge-patch+json"
+
+var (
+	ErrInvalidPatch   = errors.New("patch must be a JSON object")
+	ErrPatchReadOnly  = errors.New("field cannot be patched")
+	ErrPatchNullField = errors.New("field cannot be removed")
+	ErrPatchFieldType = errors.New("field has an invalid value")
+)
+
+// TaskPatch holds the fields present in a merge patch; nil means "unchanged"
+type TaskPatch struct {
+	Title       *string
+	Summary     *string
+	PerformedAt *time.Time
+	Status      *string
+	Priority    *string
+	Tags        *[]string
+	DueAt       *time.Time
+	SiteID      *int64
+	AssetID     *int64
+	// ClearDueAt is set when the patch removes the due date
+	ClearDueAt bool
+	// ClearSite is set when the patch unlinks the task from its site, and
+	// ClearAsset when it unlinks it from its asset
+	ClearSite  bool
+	ClearAsset bool
+}
+
+// ParseTaskMergePatch decodes a JSON Merge Patch document for a task. Only
+// title, summary, performed_at, status, priority, tags, due_at, site_id and
+// asset_id can be patched; tags, due_at, site_id and asset_id can be removed
+// with null, the other fields cannot.
+func ParseTaskMergePatch(data []byte) (*TaskPatch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, ErrInvalidPatch
+	}
+
+	patch := &TaskPatch{}
+	for name, raw := range fields {
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if name == "tags" {
+				patch.Tags = &[]string{}
+				continue
+			}
+			if name == "due_at" {
+				patch.ClearDueAt = true
+				continue
+			}
+			if name == "site_id" {
+				patch.ClearSite = true
+				continue
+			}
+			if name == "asset_id" {
+				patch.ClearAsset = true
+				continue
+			}
+			if isPatchableTaskField(name) {
+				return nil, fmt.Errorf("%w: %s", ErrPatchNullField, name)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrPatchReadOnly, name)
+		}
+
+		switch name {
+		case "title":
+			patch.Title = new(string)
+			if err := json.Unmarshal(raw, patch.Title); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+		case "summary":
+			patch.Summary = new(string)
+			if err := json.Unmarshal(raw, patch.Summary); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+		case "performed_at":
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+			performedAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+			patch.PerformedAt = &performedAt
+		case "due_at":
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+			dueAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+			patch.DueAt = &dueAt
+		case "status":
+			patch.Status = new(string)
+			if err := json.Unmarshal(raw, patch.Status); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+		case "priority":
+			patch.Priority = new(string)
+			if err := json.Unmarshal(raw, patch.Priority); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+		case "site_id":
+			patch.SiteID = new(int64)
+			if err := json.Unmarshal(raw, patch.SiteID); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+		case "asset_id":
+			patch.AssetID = new(int64)
+			if err := json.Unmarshal(raw, patch.AssetID); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+		case "tags":
+			tags := []string{}
+			if err := json.Unmarshal(raw, &tags); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrPatchFieldType, name)
+			}
+			patch.Tags = &tags
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPatchReadOnly, name)
+		}
+	}
+	return patch, nil
+}
+
+func isPatchableTaskField(name string) bool {
+	return name == "title" || name == "summary" || name == "performed_at" || name == "status" || name == "priority"
+}
+
+// Sanitize applies the same normalization as Task.Sanitize to the patched fields
+func (p *TaskPatch) Sanitize() {
+	if p.Title != nil {
+		*p.Title = sanitizeText(*p.Title)
+	}
+	if p.Summary != nil {
+		*p.Summary = sanitizeText(*p.Summary)
+	}
+}
+
+// Apply returns a copy of task with the patched fields replaced
+func (p *TaskPatch) Apply(task *Task) *Task {
+	patched := *task
+	if p.Title != nil {
+		patched.Title = *p.Title
+	}
+	if p.Summary != nil {
+		patched.Summary = *p.Summary
+	}
+	if p.PerformedAt != nil {
+		patched.PerformedAt = *p.PerformedAt
+	}
+	if p.Status != nil {
+		patched.Status = *p.Status
+	}
+	if p.Priority != nil {
+		patched.Priority = *p.Priority
+	}
+	if p.Tags != nil {
+		patched.Tags = *p.Tags
+	}
+	if p.DueAt != nil {
+		// A new due date keeps the task's at-risk window
+		patched.SetDueAt(p.DueAt, task.AtRiskWindow())
+	}
+	if p.ClearDueAt {
+		patched.SetDueAt(nil, 0)
+	}
+	// The asset is on the site, so unlinking the site unlinks the asset unless
+	// the patch sets one, and a new asset alone leaves the site to be taken
+	// from the asset
+	if p.SiteID != nil {
+		patched.SiteID = p.SiteID
+	}
+	if p.ClearSite {
+		patched.SiteID = nil
+		if p.AssetID == nil {
+			patched.AssetID = nil
+		}
+	}
+	if p.AssetID != nil {
+		patched.AssetID = p.AssetID
+		if p.SiteID == nil {
+			patched.SiteID = nil
+		}
+	}
+	if p.ClearAsset {
+		patched.AssetID = nil
+	}
+	return &patched
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTaskMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{name: "single field", body: `{"summary": "Replaced filters"}`},
+		{name: "all fields", body: `{"title": "Fix AC", "summary": "Done", "performed_at": "2024-03-20T14:30:00Z"}`},
+		{name: "empty patch", body: `{}`},
+		{name: "not an object", body: `["title"]`, wantErr: ErrInvalidPatch},
+		{name: "null document", body: `null`, wantErr: ErrInvalidPatch},
+		{name: "read-only field", body: `{"technician_id": 2}`, wantErr: ErrPatchReadOnly},
+		{name: "removing a required field", body: `{"title": null}`, wantErr: ErrPatchNullField},
+		{name: "wrong type", body: `{"title": 42}`, wantErr: ErrPatchFieldType},
+		{name: "invalid date", body: `{"performed_at": "yesterday"}`, wantErr: ErrPatchFieldType},
+		{name: "tags", body: `{"tags": ["hvac", "preventive"]}`},
+		{name: "removing tags", body: `{"tags": null}`},
+		{name: "tags not a list", body: `{"tags": "hvac"}`, wantErr: ErrPatchFieldType},
+		{name: "due date", body: `{"due_at": "2024-03-21T14:30:00Z"}`},
+		{name: "removing the due date", body: `{"due_at": null}`},
+		{name: "invalid due date", body: `{"due_at": "tomorrow"}`, wantErr: ErrPatchFieldType},
+		{name: "priority", body: `{"priority": "urgent"}`},
+		{name: "removing the priority", body: `{"priority": null}`, wantErr: ErrPatchNullField},
+		{name: "site and asset", body: `{"site_id": 1, "asset_id": 2}`},
+		{name: "removing the site", body: `{"site_id": null}`},
+		{name: "site not a number", body: `{"site_id": "1"}`, wantErr: ErrPatchFieldType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTaskMergePatch([]byte(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseTaskMergePatch() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTaskPatch_Apply(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	task := &Task{ID: 1, TechnicianID: 1, Title: "Fix AC", Summary: "Replaced filters", PerformedAt: performedAt, Version: 2}
+
+	patch, err := ParseTaskMergePatch([]byte(`{"summary": "<b>Recharged</b>\u0000"}`))
+	if err != nil {
+		t.Fatalf("ParseTaskMergePatch() error = %v", err)
+	}
+	patch.Sanitize()
+	patched := patch.Apply(task)
+
+	if patched.Title != "Fix AC" || !patched.PerformedAt.Equal(performedAt) {
+		t.Errorf("Apply() changed omitted fields: %+v", patched)
+	}
+	if patched.Summary != "<b>Recharged</b>" {
+		t.Errorf("Apply() summary = %q", patched.Summary)
+	}
+	if task.Summary != "Replaced filters" {
+		t.Errorf("Apply() modified the original task")
+	}
+}
+
+func TestTaskPatch_ApplyDueAt(t *testing.T) {
+	dueAt := time.Date(2024, 3, 21, 14, 30, 0, 0, time.UTC)
+	atRiskAt := dueAt.Add(-4 * time.Hour)
+	task := &Task{ID: 1, Title: "Fix AC", DueAt: &dueAt, AtRiskAt: &atRiskAt}
+
+	patch, err := ParseTaskMergePatch([]byte(`{"due_at": "2024-03-22T14:30:00Z"}`))
+	if err != nil {
+		t.Fatalf("ParseTaskMergePatch() error = %v", err)
+	}
+	patched := patch.Apply(task)
+	wantDue := dueAt.Add(24 * time.Hour)
+	if patched.DueAt == nil || !patched.DueAt.Equal(wantDue) {
+		t.Fatalf("Apply() due_at = %v, want %v", patched.DueAt, wantDue)
+	}
+	if !patched.AtRiskAt.Equal(wantDue.Add(-4 * time.Hour)) {
+		t.Errorf("Apply() at_risk_at = %v, want the 4h window kept", patched.AtRiskAt)
+	}
+	if !task.DueAt.Equal(dueAt) {
+		t.Errorf("Apply() modified the original task")
+	}
+
+	patch, err = ParseTaskMergePatch([]byte(`{"due_at": null}`))
+	if err != nil {
+		t.Fatalf("ParseTaskMergePatch() error = %v", err)
+	}
+	patched = patch.Apply(task)
+	if patched.DueAt != nil || patched.AtRiskAt != nil {
+		t.Errorf("Apply() kept the due date: %v, %v", patched.DueAt, patched.AtRiskAt)
+	}
+}
+
+func TestTaskPatch_ApplySite(t *testing.T) {
+	siteID, assetID := int64(1), int64(2)
+	otherSiteID, otherAssetID := int64(4), int64(3)
+	task := &Task{ID: 1, Title: "Fix AC", SiteID: &siteID, AssetID: &assetID}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantSite  *int64
+		wantAsset *int64
+	}{
+		{name: "omitted", body: `{"title": "Fix chiller"}`, wantSite: &siteID, wantAsset: &assetID},
+		{name: "removing the site removes the asset", body: `{"site_id": null}`},
+		{name: "removing the asset keeps the site", body: `{"asset_id": null}`, wantSite: &siteID},
+		{name: "new asset leaves the site to the asset", body: `{"asset_id": 3}`, wantAsset: &otherAssetID},
+		{name: "new site and asset", body: `{"site_id": 4, "asset_id": 3}`, wantSite: &otherSiteID, wantAsset: &otherAssetID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := ParseTaskMergePatch([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("ParseTaskMergePatch() error = %v", err)
+			}
+			patched := patch.Apply(task)
+			if !equalID(patched.SiteID, tt.wantSite) || !equalID(patched.AssetID, tt.wantAsset) {
+				t.Errorf("Apply() site = %v, asset = %v", patched.SiteID, patched.AssetID)
+			}
+		})
+	}
+}
+
+func equalID(a, b *int64) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
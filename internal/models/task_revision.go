@@ -0,0 +1,144 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Revision actions
+const (
+	RevisionActionCreate = "create"
+	RevisionActionUpdate = "update"
+)
+
+var ErrNilRevision = errors.New("revision cannot be nil")
+
+// TaskRevision is a snapshot of a task taken each time it is written
+// @Description A stored version of a task
+type TaskRevision struct {
+	// @Description The unique identifier of the revision
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description Sequential revision number within the task, starting at 1
+	Revision int `json:"revision" example:"2"`
+	// @Description The ID of the user who wrote this version
+	EditorID int64 `json:"editor_id" example:"2"`
+	// @Description What produced this version
+	Action string `json:"action" example:"update" enums:"create,update"`
+	// @Description The title of the task at this revision
+	Title string `json:"title" example:"Fix air conditioning"`
+	// @Description The summary of the task at this revision
+	Summary string `json:"summary" example:"Replaced filters and recharged coolant"`
+	// @Description When the task was performed, as of this revision
+	PerformedAt time.Time `json:"performed_at" example:"2024-03-20T14:30:00Z"`
+	// @Description The status of the task at this revision
+	Status string `json:"status" example:"completed" enums:"open,completed"`
+	// @Description The priority of the task at this revision; absent for revisions written before it was recorded
+	Priority string `json:"priority,omitempty" example:"normal" enums:"low,normal,high,urgent"`
+	// @Description When the task was due, as of this revision
+	DueAt *time.Time `json:"due_at,omitempty" example:"2024-03-21T14:30:00Z"`
+	// @Description The tags of the task at this revision, sorted
+	Tags []string `json:"tags,omitempty" example:"hvac,preventive"`
+	// @Description The site of the task at this revision
+	SiteID *int64 `json:"site_id,omitempty" example:"1"`
+	// @Description The asset of the task at this revision
+	AssetID *int64 `json:"asset_id,omitempty" example:"1"`
+	// @Description When this version was written
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// FieldChange is the before and after value of one task field
+// @Description A changed field between two revisions
+type FieldChange struct {
+	// @Description The name of the field
+	Field string `json:"field" example:"summary"`
+	// @Description The value in the older revision
+	Before string `json:"before" example:"Replaced filters"`
+	// @Description The value in the newer revision
+	After string `json:"after" example:"Replaced filters and recharged coolant"`
+}
+
+// TaskRevisionDiff lists the fields that differ between two revisions
+// @Description Differences between two revisions of a task
+type TaskRevisionDiff struct {
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description The older revision
+	From *TaskRevision `json:"from"`
+	// @Description The newer revision
+	To *TaskRevision `json:"to"`
+	// @Description The changed fields, empty when both revisions are identical
+	Changes []FieldChange `json:"changes"`
+}
+
+// recorded reports whether the revision holds the priority, due date, tags,
+// site and asset; revisions written before they were recorded do not
+func (r *TaskRevision) recorded() bool {
+	return r.Priority != ""
+}
+
+// DiffRevisions compares two revisions field by field. The priority, due
+// date, tags, site and asset are only compared when both revisions hold them.
+func DiffRevisions(from, to *TaskRevision) (*TaskRevisionDiff, error) {
+	if from == nil || to == nil {
+		return nil, ErrNilRevision
+	}
+
+	changes := make([]FieldChange, 0, 9)
+	if from.Title != to.Title {
+		changes = append(changes, FieldChange{Field: "title", Before: from.Title, After: to.Title})
+	}
+	if from.Summary != to.Summary {
+		changes = append(changes, FieldChange{Field: "summary", Before: from.Summary, After: to.Summary})
+	}
+	if !from.PerformedAt.Equal(to.PerformedAt) {
+		changes = append(changes, FieldChange{
+			Field:  "performed_at",
+			Before: from.PerformedAt.UTC().Format(time.RFC3339),
+			After:  to.PerformedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if from.Status != to.Status {
+		changes = append(changes, FieldChange{Field: "status", Before: from.Status, After: to.Status})
+	}
+	if from.recorded() && to.recorded() {
+		fields := []FieldChange{
+			{Field: "priority", Before: from.Priority, After: to.Priority},
+			{Field: "due_at", Before: formatRevisionTime(from.DueAt), After: formatRevisionTime(to.DueAt)},
+			{Field: "tags", Before: strings.Join(from.Tags, ","), After: strings.Join(to.Tags, ",")},
+			{Field: "site_id", Before: formatRevisionID(from.SiteID), After: formatRevisionID(to.SiteID)},
+			{Field: "asset_id", Before: formatRevisionID(from.AssetID), After: formatRevisionID(to.AssetID)},
+		}
+		for _, field := range fields {
+			if field.Before != field.After {
+				changes = append(changes, field)
+			}
+		}
+	}
+
+	return &TaskRevisionDiff{
+		TaskID:  to.TaskID,
+		From:    from,
+		To:      to,
+		Changes: changes,
+	}, nil
+}
+
+// formatRevisionTime formats an optional time of a revision; none is empty
+func formatRevisionTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatRevisionID formats an optional reference of a revision; none is empty
+func formatRevisionID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
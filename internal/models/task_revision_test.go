@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	dueAt := time.Date(2024, 3, 21, 14, 30, 0, 0, time.UTC)
+	siteID := int64(7)
+	base := TaskRevision{TaskID: 1, Revision: 1, Title: "Fix AC", Summary: "Replaced filters", PerformedAt: performedAt}
+
+	tests := []struct {
+		name        string
+		from        *TaskRevision
+		to          func() *TaskRevision
+		wantChanges []FieldChange
+		wantErr     error
+	}{
+		{
+			name: "summary rewritten",
+			from: &base,
+			to: func() *TaskRevision {
+				r := base
+				r.Revision = 2
+				r.Summary = "Replaced filters and recharged coolant"
+				return &r
+			},
+			wantChanges: []FieldChange{
+				{Field: "summary", Before: "Replaced filters", After: "Replaced filters and recharged coolant"},
+			},
+		},
+		{
+			name: "title and performed_at changed",
+			from: &base,
+			to: func() *TaskRevision {
+				r := base
+				r.Revision = 3
+				r.Title = "Fix air conditioning"
+				r.PerformedAt = performedAt.Add(time.Hour)
+				return &r
+			},
+			wantChanges: []FieldChange{
+				{Field: "title", Before: "Fix AC", After: "Fix air conditioning"},
+				{Field: "performed_at", Before: "2024-03-20T14:30:00Z", After: "2024-03-20T15:30:00Z"},
+			},
+		},
+		{
+			name: "recorded fields changed",
+			from: func() *TaskRevision {
+				r := base
+				r.Priority = "normal"
+				r.Tags = []string{"hvac"}
+				return &r
+			}(),
+			to: func() *TaskRevision {
+				r := base
+				r.Revision = 4
+				r.Priority = "urgent"
+				r.DueAt = &dueAt
+				r.Tags = []string{"hvac", "preventive"}
+				r.SiteID = &siteID
+				return &r
+			},
+			wantChanges: []FieldChange{
+				{Field: "priority", Before: "normal", After: "urgent"},
+				{Field: "due_at", Before: "", After: "2024-03-21T14:30:00Z"},
+				{Field: "tags", Before: "hvac", After: "hvac,preventive"},
+				{Field: "site_id", Before: "", After: "7"},
+			},
+		},
+		{
+			name: "fields not recorded by the older revision",
+			from: &base,
+			to: func() *TaskRevision {
+				r := base
+				r.Revision = 5
+				r.Priority = "high"
+				r.Tags = []string{"hvac"}
+				return &r
+			},
+			wantChanges: []FieldChange{},
+		},
+		{
+			name: "identical revisions",
+			from: &base,
+			to: func() *TaskRevision {
+				r := base
+				return &r
+			},
+			wantChanges: []FieldChange{},
+		},
+		{
+			name:    "nil revision",
+			from:    nil,
+			to:      func() *TaskRevision { return &base },
+			wantErr: ErrNilRevision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffRevisions(tt.from, tt.to())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DiffRevisions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got.Changes, tt.wantChanges) {
+				t.Errorf("DiffRevisions().Changes = %v, want %v", got.Changes, tt.wantChanges)
+			}
+		})
+	}
+}
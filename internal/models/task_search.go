@@ -0,0 +1,172 @@
+package models
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Search limits
+const (
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 100
+	MaxSearchQueryLength = 200
+	SnippetLength        = 160
+)
+
+var (
+	ErrEmptySearchQuery   = errors.New("search query cannot be empty")
+	ErrSearchQueryTooLong = errors.New("search query exceeds maximum length of 200 characters")
+	ErrInvalidSearchLimit = errors.New("limit must be between 1 and 100")
+)
+
+// TaskSearchQuery describes a full-text search. TechnicianID restricts the
+// search to one technician's tasks; 0 searches every task.
+type TaskSearchQuery struct {
+	Query        string
+	TechnicianID int64
+	Limit        int
+}
+
+// Validate applies defaults and checks the query values
+func (q *TaskSearchQuery) Validate() error {
+	q.Query = strings.TrimSpace(q.Query)
+	if q.Limit == 0 {
+		q.Limit = DefaultSearchLimit
+	}
+
+	if q.Query == "" {
+		return ErrEmptySearchQuery
+	}
+	if utf8.RuneCountInString(q.Query) > MaxSearchQueryLength {
+		return ErrSearchQueryTooLong
+	}
+	if q.Limit < 1 || q.Limit > MaxSearchLimit {
+		return ErrInvalidSearchLimit
+	}
+	return nil
+}
+
+// TaskSearchHit is a task matched by a search backend with its relevance
+type TaskSearchHit struct {
+	Task  *Task
+	Score float64
+}
+
+// TaskSearchResult is one ranked search result
+// @Description A task matching a search, best matches first
+type TaskSearchResult struct {
+	// @Description The matching task
+	Task *Task `json:"task"`
+	// @Description Relevance reported by the search backend, higher is better
+	Score float64 `json:"score" example:"1.42"`
+	// @Description Excerpt of the summary around the first match, HTML-escaped with matches wrapped in <mark>
+	Snippet string `json:"snippet" example:"… replaced the <mark>compressor</mark> relay and …"`
+}
+
+// SearchTerms splits a query into lower-case words
+func SearchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		word = strings.ToLower(word)
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// HighlightSnippet returns up to maxLength characters of text around the first
+// occurrence of any term. The result is HTML-escaped and every occurrence is
+// wrapped in <mark>.
+func HighlightSnippet(text string, terms []string, maxLength int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	termRunes := make([][]rune, 0, len(terms))
+	for _, term := range terms {
+		if term != "" {
+			termRunes = append(termRunes, []rune(strings.ToLower(term)))
+		}
+	}
+
+	// Find non-overlapping matches, preferring the longest term at a position
+	type match struct{ start, end int }
+	var matches []match
+	for i := 0; i < len(lower); {
+		length := 0
+		for _, term := range termRunes {
+			if len(term) > length && hasRunePrefix(lower[i:], term) {
+				length = len(term)
+			}
+		}
+		if length == 0 {
+			i++
+			continue
+		}
+		matches = append(matches, match{i, i + length})
+		i += length
+	}
+
+	start, first := 0, 0
+	if len(matches) > 0 {
+		first = matches[0].start
+		start = max(first-maxLength/4, 0)
+	}
+	end := min(start+maxLength, len(runes))
+
+	// Cut on word boundaries, never past the first match
+	for start > 0 && start < first && runes[start-1] != ' ' {
+		start++
+	}
+	for end < len(runes) && end > first && runes[end] != ' ' {
+		end--
+	}
+	for start < end && runes[start] == ' ' {
+		start++
+	}
+	for end > start && runes[end-1] == ' ' {
+		end--
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("… ")
+	}
+	pos := start
+	for _, m := range matches {
+		if m.start < start || m.end > end {
+			continue
+		}
+		sb.WriteString(html.EscapeString(string(runes[pos:m.start])))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(string(runes[m.start:m.end])))
+		sb.WriteString("</mark>")
+		pos = m.end
+	}
+	sb.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		sb.WriteString(" …")
+	}
+	return sb.String()
+}
+
+func hasRunePrefix(s, prefix []rune) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
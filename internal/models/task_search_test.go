@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskSearchQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   TaskSearchQuery
+		wantErr error
+	}{
+		{name: "defaults the limit", query: TaskSearchQuery{Query: "compressor"}},
+		{name: "blank query", query: TaskSearchQuery{Query: "   "}, wantErr: ErrEmptySearchQuery},
+		{name: "query too long", query: TaskSearchQuery{Query: strings.Repeat("a", 201)}, wantErr: ErrSearchQueryTooLong},
+		{name: "limit too high", query: TaskSearchQuery{Query: "compressor", Limit: 101}, wantErr: ErrInvalidSearchLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.query
+			if err := q.Validate(); err != tt.wantErr {
+				t.Errorf("TaskSearchQuery.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && q.Limit != DefaultSearchLimit {
+				t.Errorf("TaskSearchQuery.Validate() limit = %d, want %d", q.Limit, DefaultSearchLimit)
+			}
+		})
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	got := SearchTerms("Compressor, compressor & AC-unit")
+	want := []string{"compressor", "ac", "unit"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("SearchTerms() = %v, want %v", got, want)
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		terms     []string
+		maxLength int
+		want      string
+	}{
+		{
+			name:      "highlights every occurrence",
+			text:      "Compressor noisy, replaced compressor relay",
+			terms:     []string{"compressor"},
+			maxLength: 160,
+			want:      "<mark>Compressor</mark> noisy, replaced <mark>compressor</mark> relay",
+		},
+		{
+			name:      "escapes the text around matches",
+			text:      "<b>fan</b> & compressor",
+			terms:     []string{"compressor"},
+			maxLength: 160,
+			want:      "&lt;b&gt;fan&lt;/b&gt; &amp; <mark>compressor</mark>",
+		},
+		{
+			name:      "windows long text around the first match",
+			text:      "one two three four five six compressor seven eight nine",
+			terms:     []string{"compressor"},
+			maxLength: 26,
+			want:      "… six <mark>compressor</mark> seven …",
+		},
+		{
+			name:      "no match returns the beginning",
+			text:      "Replaced filters and recharged coolant",
+			terms:     []string{"compressor"},
+			maxLength: 18,
+			want:      "Replaced filters …",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightSnippet(tt.text, tt.terms, tt.maxLength); got != tt.want {
+				t.Errorf("HighlightSnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
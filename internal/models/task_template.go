@@ -0,0 +1,165 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Template limits; lengths are counted in characters
+const (
+	MaxTemplateNameLength       = 255
+	MaxTemplateEstimatedMinutes = 7 * 24 * 60
+)
+
+var (
+	ErrEmptyTemplateName        = errors.New("template name cannot be empty")
+	ErrTemplateNameTooLong      = errors.New("template name exceeds maximum length of 255 characters")
+	ErrEmptyTitlePattern        = errors.New("title pattern cannot be empty")
+	ErrTitlePatternTooLong      = errors.New("title pattern exceeds maximum length of 255 characters")
+	ErrInvalidEstimatedDuration = errors.New("estimated_minutes must be between 0 and 10080")
+	ErrTooManyChecklistItems    = errors.New("a checklist can have at most 100 items")
+	ErrMissingTemplateVariable  = errors.New("title pattern has variables without a value")
+)
+
+// placeholderPattern matches the variables of a title pattern, e.g. {{site}}
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z0-9_]+)\s*\}\}`)
+
+// TaskTemplate is one revision of a manager-defined blueprint for routine
+// tasks. Every change creates a new revision; tasks keep a reference to the
+// revision they were created from.
+// @Description A task template, at its current or a past revision
+type TaskTemplate struct {
+	// @Description The unique identifier of the template
+	ID int64 `json:"id" example:"1"`
+	// @Description The revision number, starting at 1 and incremented on every change
+	Revision int `json:"revision" example:"2"`
+	// @Description Name shown when picking a template
+	Name string `json:"name" example:"HVAC filter replacement"`
+	// @Description Title of created tasks; {{name}} placeholders are filled from the request variables, {{date}} with the performed_at date
+	TitlePattern string `json:"title_pattern" example:"Replace HVAC filters - {{site}}"`
+	// @Description Default summary of created tasks, in Markdown
+	Summary string `json:"summary" example:"Replaced the air filters and checked the airflow."`
+	// @Description Checklist copied to created tasks
+	Checklist []TaskTemplateChecklistItem `json:"checklist"`
+	// @Description Names of tags from the managed vocabulary given to created tasks
+	Tags []string `json:"tags" example:"hvac,preventive"`
+	// @Description Expected duration of the job in minutes, 0 when unknown
+	EstimatedMinutes int `json:"estimated_minutes" example:"45"`
+	// @Description The ID of the manager who wrote this revision
+	EditorID int64 `json:"editor_id" example:"1"`
+	// @Description When this revision was written
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T14:30:00Z"`
+}
+
+// TaskTemplateChecklistItem is a checklist item to add to created tasks
+// @Description A checklist item of a task template
+type TaskTemplateChecklistItem struct {
+	// @Description What has to be done
+	Text string `json:"text" example:"Replace air filters"`
+	// @Description Whether created tasks can only be completed once this item is done
+	Required bool `json:"required" example:"true"`
+	// @Description Optional remark
+	Note string `json:"note" example:"Use MERV 13 filters"`
+}
+
+// TaskTemplateRef points at the template revision a task was created from
+// @Description The template revision a task was created from
+type TaskTemplateRef struct {
+	// @Description The ID of the template
+	ID int64 `json:"id" example:"1"`
+	// @Description The template revision
+	Revision int `json:"revision" example:"2"`
+}
+
+// TaskFromTemplate holds the fields of a task created from a template. Empty
+// fields take their value from the template.
+type TaskFromTemplate struct {
+	Title       string
+	Summary     string
+	PerformedAt time.Time
+	Status      string
+	Priority    string
+	// Tags replace the template tags unless nil
+	Tags      []string
+	Variables map[string]string
+}
+
+// Sanitize removes invalid UTF-8 and control characters and normalizes the
+// tag names
+func (t *TaskTemplate) Sanitize() {
+	t.Name = strings.TrimSpace(sanitizeText(t.Name))
+	t.TitlePattern = strings.TrimSpace(sanitizeText(t.TitlePattern))
+	t.Summary = strings.TrimSpace(sanitizeText(t.Summary))
+	for i := range t.Checklist {
+		t.Checklist[i].Text = strings.TrimSpace(sanitizeText(t.Checklist[i].Text))
+		t.Checklist[i].Note = strings.TrimSpace(sanitizeText(t.Checklist[i].Note))
+	}
+	t.Tags = NormalizeTags(t.Tags)
+}
+
+func (t *TaskTemplate) Validate() error {
+	if t.Name == "" {
+		return ErrEmptyTemplateName
+	}
+	if utf8.RuneCountInString(t.Name) > MaxTemplateNameLength {
+		return ErrTemplateNameTooLong
+	}
+	if t.TitlePattern == "" {
+		return ErrEmptyTitlePattern
+	}
+	if utf8.RuneCountInString(t.TitlePattern) > MaxTitleLength {
+		return ErrTitlePatternTooLong
+	}
+	if utf8.RuneCountInString(t.Summary) > MaxSummaryLength {
+		return ErrSummaryTooLong
+	}
+	if t.EstimatedMinutes < 0 || t.EstimatedMinutes > MaxTemplateEstimatedMinutes {
+		return ErrInvalidEstimatedDuration
+	}
+	if len(t.Checklist) > MaxChecklistItems {
+		return ErrTooManyChecklistItems
+	}
+	for _, item := range t.ChecklistItems() {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+	return ValidateTaskTags(t.Tags)
+}
+
+// RenderTitle fills the placeholders of the title pattern. {{date}} defaults
+// to the day the task is performed; every other variable must be given.
+func (t *TaskTemplate) RenderTitle(variables map[string]string, performedAt time.Time) (string, error) {
+	missing := false
+	title := placeholderPattern.ReplaceAllStringFunc(t.TitlePattern, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := variables[name]; ok {
+			return strings.TrimSpace(sanitizeText(value))
+		}
+		if name == "date" {
+			return performedAt.Format("2006-01-02")
+		}
+		missing = true
+		return placeholder
+	})
+	if missing {
+		return "", ErrMissingTemplateVariable
+	}
+	return title, nil
+}
+
+// ChecklistItems returns the template checklist as open items for a new task
+func (t *TaskTemplate) ChecklistItems() []*TaskChecklistItem {
+	items := make([]*TaskChecklistItem, 0, len(t.Checklist))
+	for _, item := range t.Checklist {
+		items = append(items, &TaskChecklistItem{
+			Text:     item.Text,
+			Required: item.Required,
+			Note:     item.Note,
+		})
+	}
+	return items
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskTemplate_Validate(t *testing.T) {
+	valid := func() TaskTemplate {
+		return TaskTemplate{
+			Name:         "HVAC filter replacement",
+			TitlePattern: "Replace HVAC filters - {{site}}",
+			Summary:      "Replaced the air filters.",
+			Checklist:    []TaskTemplateChecklistItem{{Text: "Replace air filters", Required: true}},
+			Tags:         []string{"hvac"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(*TaskTemplate)
+		wantErr error
+	}{
+		{name: "valid template", change: func(t *TaskTemplate) {}},
+		{name: "empty summary is allowed", change: func(t *TaskTemplate) { t.Summary = "" }},
+		{name: "empty name", change: func(t *TaskTemplate) { t.Name = "" }, wantErr: ErrEmptyTemplateName},
+		{name: "empty title pattern", change: func(t *TaskTemplate) { t.TitlePattern = "" }, wantErr: ErrEmptyTitlePattern},
+		{name: "title pattern too long", change: func(t *TaskTemplate) { t.TitlePattern = strings.Repeat("a", MaxTitleLength+1) }, wantErr: ErrTitlePatternTooLong},
+		{name: "negative duration", change: func(t *TaskTemplate) { t.EstimatedMinutes = -1 }, wantErr: ErrInvalidEstimatedDuration},
+		{name: "empty checklist item", change: func(t *TaskTemplate) { t.Checklist[0].Text = "" }, wantErr: ErrEmptyChecklistItem},
+		{name: "invalid tag", change: func(t *TaskTemplate) { t.Tags = []string{"HVAC!"} }, wantErr: ErrInvalidTagName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := valid()
+			tt.change(&template)
+			assert.Equal(t, tt.wantErr, template.Validate())
+		})
+	}
+}
+
+func TestTaskTemplate_RenderTitle(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		pattern   string
+		variables map[string]string
+		want      string
+		wantErr   error
+	}{
+		{name: "no placeholders", pattern: "Monthly inspection", want: "Monthly inspection"},
+		{name: "variables and date", pattern: "Replace filters - {{site}} ({{ date }})", variables: map[string]string{"site": " Lisbon office "}, want: "Replace filters - Lisbon office (2024-03-20)"},
+		{name: "date can be overridden", pattern: "Inspection {{date}}", variables: map[string]string{"date": "Q1"}, want: "Inspection Q1"},
+		{name: "missing variable", pattern: "Replace filters - {{site}}", wantErr: ErrMissingTemplateVariable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := TaskTemplate{TitlePattern: tt.pattern}
+			got, err := template.RenderTitle(tt.variables, performedAt)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
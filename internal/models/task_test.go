@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -12,6 +13,8 @@ func TestTask_Validate(t *testing.T) {
 		Title        string
 		Summary      string
 		PerformedAt  time.Time
+		Status       string
+		Priority     string
 		CreatedAt    time.Time
 		UpdatedAt    time.Time
 	}
@@ -26,6 +29,8 @@ func TestTask_Validate(t *testing.T) {
 				Title:       "Fix air conditioning",
 				Summary:     "Replaced filters and recharged coolant",
 				PerformedAt: time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC),
+				Status:      TaskStatusCompleted,
+				Priority:    TaskPriorityNormal,
 			},
 			wantErr: false,
 		},
@@ -65,6 +70,26 @@ func TestTask_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "multibyte title at the character limit",
+			fields: fields{
+				Title:       strings.Repeat("ç", 255),
+				Summary:     strings.Repeat("日", 2500),
+				PerformedAt: time.Now(),
+				Status:      TaskStatusCompleted,
+				Priority:    TaskPriorityNormal,
+			},
+			wantErr: false,
+		},
+		{
+			name: "multibyte title over the character limit",
+			fields: fields{
+				Title:       strings.Repeat("ç", 256),
+				Summary:     "Valid summary",
+				PerformedAt: time.Now(),
+			},
+			wantErr: true,
+		},
 		{
 			name: "performed_at before min date",
 			fields: fields{
@@ -83,12 +108,55 @@ func TestTask_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "open task",
+			fields: fields{
+				Title:       "Valid title",
+				Summary:     "Valid summary",
+				PerformedAt: time.Now(),
+				Status:      TaskStatusOpen,
+				Priority:    TaskPriorityNormal,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown status",
+			fields: fields{
+				Title:       "Valid title",
+				Summary:     "Valid summary",
+				PerformedAt: time.Now(),
+				Status:      "in_progress",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown priority",
+			fields: fields{
+				Title:       "Valid title",
+				Summary:     "Valid summary",
+				PerformedAt: time.Now(),
+				Status:      TaskStatusOpen,
+				Priority:    "critical",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing status",
+			fields: fields{
+				Title:       "Valid title",
+				Summary:     "Valid summary",
+				PerformedAt: time.Now(),
+			},
+			wantErr: true,
+		},
 		{
 			name: "title and summary with leading/trailing spaces",
 			fields: fields{
 				Title:       "   Valid title   ",
 				Summary:     "   Valid summary   ",
 				PerformedAt: time.Now(),
+				Status:      TaskStatusCompleted,
+				Priority:    TaskPriorityNormal,
 			},
 			wantErr: false,
 		},
@@ -101,6 +169,8 @@ func TestTask_Validate(t *testing.T) {
 				Title:        tt.fields.Title,
 				Summary:      tt.fields.Summary,
 				PerformedAt:  tt.fields.PerformedAt,
+				Status:       tt.fields.Status,
+				Priority:     tt.fields.Priority,
 				CreatedAt:    tt.fields.CreatedAt,
 				UpdatedAt:    tt.fields.UpdatedAt,
 			}
@@ -128,13 +198,13 @@ func TestTask_Sanitize(t *testing.T) {
 		wantSummary string
 	}{
 		{
-			name: "sanitize html in title and summary",
+			name: "html is kept as raw text",
 			fields: fields{
 				Title:   "<script>alert('x')</script>",
 				Summary: "<b>bold</b> & <i>italic</i>",
 			},
-			wantTitle:   "&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;",
-			wantSummary: "&lt;b&gt;bold&lt;/b&gt; &amp; &lt;i&gt;italic&lt;/i&gt;",
+			wantTitle:   "<script>alert('x')</script>",
+			wantSummary: "<b>bold</b> & <i>italic</i>",
 		},
 		{
 			name: "sanitize with no html",
@@ -146,13 +216,31 @@ func TestTask_Sanitize(t *testing.T) {
 			wantSummary: "Normal Summary",
 		},
 		{
-			name: "sanitize with special chars",
+			name: "sanitizing twice does not change the text",
 			fields: fields{
-				Title:   "5 > 3 & 2 < 4",
+				Title:   "5 > 3 &amp; 2 < 4",
 				Summary: "\"quote\" & 'single quote'",
 			},
-			wantTitle:   "5 &gt; 3 &amp; 2 &lt; 4",
-			wantSummary: "&quot;quote&quot; &amp; &#39;single quote&#39;",
+			wantTitle:   "5 > 3 &amp; 2 < 4",
+			wantSummary: "\"quote\" & 'single quote'",
+		},
+		{
+			name: "control characters are dropped, line breaks kept",
+			fields: fields{
+				Title:   "Fix\x00 AC\x1b",
+				Summary: "Line one\nLine two\tend",
+			},
+			wantTitle:   "Fix AC",
+			wantSummary: "Line one\nLine two\tend",
+		},
+		{
+			name: "invalid utf-8 is replaced",
+			fields: fields{
+				Title:   "Caf\xe9",
+				Summary: "ok",
+			},
+			wantTitle:   "Caf\uFFFD",
+			wantSummary: "ok",
 		},
 		{
 			name: "sanitize empty fields",
@@ -176,6 +264,12 @@ func TestTask_Sanitize(t *testing.T) {
 				UpdatedAt:    tt.fields.UpdatedAt,
 			}
 			tr.Sanitize()
+			if tr.Title != tt.wantTitle {
+				t.Errorf("Task.Sanitize() title = %q, want %q", tr.Title, tt.wantTitle)
+			}
+			if tr.Summary != tt.wantSummary {
+				t.Errorf("Task.Sanitize() summary = %q, want %q", tr.Summary, tt.wantSummary)
+			}
 		})
 	}
 }
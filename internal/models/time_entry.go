@@ -0,0 +1,174 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTimeEntryNoteLength is counted in characters
+const MaxTimeEntryNoteLength = 500
+
+var (
+	ErrTimeEntryNoteTooLong  = errors.New("time entry note exceeds maximum length of 500 characters")
+	ErrInvalidTimeEntryRange = errors.New("ended_at must be after started_at")
+	ErrTimeEntryInFuture     = errors.New("time entries cannot start or end in the future")
+)
+
+// TimeEntry is time a technician spent on a task. A running timer has no end
+// yet; its duration grows until it is stopped.
+// @Description Time spent on a task
+type TimeEntry struct {
+	// @Description The unique identifier of the entry
+	ID int64 `json:"id" example:"1"`
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description The ID of the technician who did the work
+	TechnicianID int64 `json:"technician_id" example:"2"`
+	// @Description When the work started
+	StartedAt time.Time `json:"started_at" example:"2024-03-20T13:00:00Z"`
+	// @Description When the work ended, absent while the timer runs
+	EndedAt *time.Time `json:"ended_at,omitempty" example:"2024-03-20T14:30:00Z"`
+	// @Description Seconds spent, up to now while the timer runs
+	DurationSeconds int64 `json:"duration_seconds" example:"5400"`
+	// @Description Whether the timer is still running
+	Running bool `json:"running" example:"false"`
+	// @Description Optional remark on the work done
+	Note string `json:"note" example:"Replaced the compressor"`
+	// @Description When the entry was recorded
+	CreatedAt time.Time `json:"created_at" example:"2024-03-20T13:00:00Z"`
+}
+
+// Sanitize removes invalid UTF-8 and control characters from the note
+func (e *TimeEntry) Sanitize() {
+	e.Note = strings.TrimSpace(sanitizeText(e.Note))
+}
+
+// Validate checks the note and that the entry does not go past now
+func (e *TimeEntry) Validate(now time.Time) error {
+	if utf8.RuneCountInString(e.Note) > MaxTimeEntryNoteLength {
+		return ErrTimeEntryNoteTooLong
+	}
+	if e.StartedAt.After(now) {
+		return ErrTimeEntryInFuture
+	}
+	if e.EndedAt != nil {
+		if !e.EndedAt.After(e.StartedAt) {
+			return ErrInvalidTimeEntryRange
+		}
+		if e.EndedAt.After(now) {
+			return ErrTimeEntryInFuture
+		}
+	}
+	return nil
+}
+
+// UpdateDuration fills Running and DurationSeconds, counting a running timer
+// up to now. A timer stopped within the second it started counts as one
+// second, as the time report does.
+func (e *TimeEntry) UpdateDuration(now time.Time) {
+	end := now
+	var minimum int64
+	e.Running = e.EndedAt == nil
+	if !e.Running {
+		end = *e.EndedAt
+		minimum = 1
+	}
+	e.DurationSeconds = max(int64(end.Sub(e.StartedAt)/time.Second), minimum)
+}
+
+// TaskTimeEntries is the time spent on a task
+// @Description Time entries of a task
+type TaskTimeEntries struct {
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description Seconds spent on the task by every technician, running timers included
+	TotalSeconds int64 `json:"total_seconds" example:"9000"`
+	// @Description The entries, oldest first
+	Entries []*TimeEntry `json:"entries"`
+}
+
+// NewTaskTimeEntries computes the duration of each entry as of now and their
+// total
+func NewTaskTimeEntries(taskID int64, entries []*TimeEntry, now time.Time) *TaskTimeEntries {
+	result := &TaskTimeEntries{TaskID: taskID, Entries: entries}
+	for _, entry := range entries {
+		entry.UpdateDuration(now)
+		result.TotalSeconds += entry.DurationSeconds
+	}
+	return result
+}
+
+// TimeReportQuery selects the time spent in [From, To); entries crossing a
+// bound only count for their part inside the period. TechnicianID restricts
+// the report to one technician; 0 reports on everyone.
+type TimeReportQuery struct {
+	From         time.Time
+	To           time.Time
+	TechnicianID int64
+}
+
+func (q *TimeReportQuery) Validate() error {
+	if !q.From.Before(q.To) {
+		return ErrInvalidReportPeriod
+	}
+	return nil
+}
+
+// TaskTime is the time a technician spent on a task in a period
+// @Description Time spent by a technician on a task
+type TaskTime struct {
+	// @Description The ID of the task
+	TaskID int64 `json:"task_id" example:"1"`
+	// @Description Title of the task
+	Title string `json:"title" example:"Fix air conditioning"`
+	// @Description The ID of the technician
+	TechnicianID int64 `json:"technician_id" example:"2"`
+	// @Description Name of the technician
+	TechnicianName string `json:"technician_name" example:"Sarah Johnson"`
+	// @Description Seconds spent in the period
+	Seconds int64 `json:"seconds" example:"5400"`
+}
+
+// TechnicianTime is the time a technician spent on every task in a period
+// @Description Time spent by a technician
+type TechnicianTime struct {
+	// @Description The ID of the technician
+	TechnicianID int64 `json:"technician_id" example:"2"`
+	// @Description Name of the technician
+	TechnicianName string `json:"technician_name" example:"Sarah Johnson"`
+	// @Description Seconds spent in the period
+	Seconds int64 `json:"seconds" example:"28800"`
+}
+
+// TimeReport is the time spent in a period, per technician and per task
+// @Description Time tracking report
+type TimeReport struct {
+	// @Description Start of the period (inclusive)
+	From time.Time `json:"from" example:"2024-03-01T00:00:00Z"`
+	// @Description End of the period (exclusive)
+	To time.Time `json:"to" example:"2024-04-01T00:00:00Z"`
+	// @Description Seconds spent by every technician in the report together
+	TotalSeconds int64 `json:"total_seconds" example:"57600"`
+	// @Description Totals per technician
+	Technicians []*TechnicianTime `json:"technicians"`
+	// @Description Time per task and technician
+	Tasks []*TaskTime `json:"tasks"`
+}
+
+// NewTimeReport totals the time of each technician. The rows are expected
+// grouped by technician, as the repository returns them.
+func NewTimeReport(query TimeReportQuery, rows []*TaskTime) *TimeReport {
+	report := &TimeReport{From: query.From, To: query.To, Technicians: []*TechnicianTime{}, Tasks: rows}
+	var current *TechnicianTime
+	for _, row := range rows {
+		if current == nil || current.TechnicianID != row.TechnicianID {
+			current = &TechnicianTime{TechnicianID: row.TechnicianID, TechnicianName: row.TechnicianName}
+			report.Technicians = append(report.Technicians, current)
+		}
+		current.Seconds += row.Seconds
+		report.TotalSeconds += row.Seconds
+	}
+	return report
+}
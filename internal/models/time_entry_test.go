@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntry_Validate(t *testing.T) {
+	now := time.Date(2024, 3, 20, 15, 0, 0, 0, time.UTC)
+	start := now.Add(-2 * time.Hour)
+	end := now.Add(-time.Hour)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		entry   TimeEntry
+		wantErr error
+	}{
+		{name: "finished entry", entry: TimeEntry{StartedAt: start, EndedAt: &end, Note: "Replaced the compressor"}},
+		{name: "running timer", entry: TimeEntry{StartedAt: start}},
+		{name: "note too long", entry: TimeEntry{StartedAt: start, Note: strings.Repeat("é", MaxTimeEntryNoteLength+1)}, wantErr: ErrTimeEntryNoteTooLong},
+		{name: "ends before it starts", entry: TimeEntry{StartedAt: end, EndedAt: &start}, wantErr: ErrInvalidTimeEntryRange},
+		{name: "empty entry", entry: TimeEntry{StartedAt: start, EndedAt: &start}, wantErr: ErrInvalidTimeEntryRange},
+		{name: "starts in the future", entry: TimeEntry{StartedAt: future}, wantErr: ErrTimeEntryInFuture},
+		{name: "ends in the future", entry: TimeEntry{StartedAt: start, EndedAt: &future}, wantErr: ErrTimeEntryInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.entry.Validate(now))
+		})
+	}
+}
+
+func TestNewTaskTimeEntries(t *testing.T) {
+	now := time.Date(2024, 3, 20, 15, 0, 0, 0, time.UTC)
+	end := now.Add(-2 * time.Hour)
+
+	entries := NewTaskTimeEntries(1, []*TimeEntry{
+		{StartedAt: now.Add(-3 * time.Hour), EndedAt: &end},
+		{StartedAt: now.Add(-30 * time.Minute)},
+	}, now)
+
+	assert.Equal(t, int64(3600), entries.Entries[0].DurationSeconds)
+	assert.False(t, entries.Entries[0].Running)
+	assert.Equal(t, int64(1800), entries.Entries[1].DurationSeconds)
+	assert.True(t, entries.Entries[1].Running)
+	assert.Equal(t, int64(5400), entries.TotalSeconds)
+}
+
+func TestTimeEntry_UpdateDurationStoppedWithinASecond(t *testing.T) {
+	startedAt := time.Date(2024, 3, 20, 15, 0, 0, 0, time.UTC)
+	entry := &TimeEntry{StartedAt: startedAt, EndedAt: &startedAt}
+
+	entry.UpdateDuration(startedAt.Add(time.Hour))
+
+	assert.False(t, entry.Running)
+	assert.Equal(t, int64(1), entry.DurationSeconds)
+}
+
+func TestNewTimeReport(t *testing.T) {
+	query := TimeReportQuery{
+		From: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	report := NewTimeReport(query, []*TaskTime{
+		{TaskID: 1, TechnicianID: 3, TechnicianName: "Mike Wilson", Seconds: 3600},
+		{TaskID: 4, TechnicianID: 3, TechnicianName: "Mike Wilson", Seconds: 1800},
+		{TaskID: 2, TechnicianID: 2, TechnicianName: "Sarah Johnson", Seconds: 600},
+	})
+
+	assert.Equal(t, int64(6000), report.TotalSeconds)
+	assert.Equal(t, []*TechnicianTime{
+		{TechnicianID: 3, TechnicianName: "Mike Wilson", Seconds: 5400},
+		{TechnicianID: 2, TechnicianName: "Sarah Johnson", Seconds: 600},
+	}, report.Technicians)
+	assert.Len(t, report.Tasks, 3)
+
+	empty := NewTimeReport(query, []*TaskTime{})
+	assert.Empty(t, empty.Technicians)
+	assert.Zero(t, empty.TotalSeconds)
+}
+
+func TestTimeReportQuery_Validate(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, (&TimeReportQuery{From: from, To: from.Add(time.Hour)}).Validate())
+	assert.Equal(t, ErrInvalidReportPeriod, (&TimeReportQuery{From: from, To: from}).Validate())
+}
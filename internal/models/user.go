@@ -15,6 +15,9 @@ type User struct {
 	Email        string    `json:"email"`
 	PasswordHash string    `json:"-"`
 	Role         UserRole  `json:"role"`
+	ManagerID    *int64    `json:"manager_id,omitempty"`
+	Locale       string    `json:"locale"`
+	Timezone     string    `json:"timezone"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -0,0 +1,23 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrVersionConflict is returned when a row changed since the caller read it
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrDuplicate is returned when a row would break a unique key
+	ErrDuplicate = errors.New("duplicate entry")
+	// ErrCycle is returned when a link would close a cycle
+	ErrCycle = errors.New("cycle")
+	// ErrOverlap is returned when a period would overlap another one
+	ErrOverlap = errors.New("overlap")
+	// ErrInsufficientStock is returned when a movement would take the stock
+	// of a part below zero
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrChecklistOpen is returned when a task would be completed while
+	// required checklist items are not done
+	ErrChecklistOpen = errors.New("checklist open")
+	// ErrBlocked is returned when a task would be completed while tasks it
+	// depends on are not
+	ErrBlocked = errors.New("blocked")
+)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"sword-challenge/internal/models"
+	"time"
 )
 
 type UserRepository interface {
@@ -14,18 +15,290 @@ type UserRepository interface {
 }
 
 type TaskRepository interface {
+	// Create saves the task with its tags and task.ChecklistItems and sets
+	// task.ID. It returns ErrDuplicate when the occurrence of a recurring task
+	// already has a task.
 	Create(ctx context.Context, task *models.Task) error
 	GetByID(ctx context.Context, id int64) (*models.Task, error)
 	GetByTechnicianID(ctx context.Context, technicianID int64) ([]*models.Task, error)
-	GetLastInsertTask(ctx context.Context) (*models.Task, error)
 	GetAll(ctx context.Context) ([]*models.Task, error)
-	Update(ctx context.Context, task *models.Task) error
+	// GetByTags returns active tasks with any of the tags, or all of them when
+	// matchAll is set; technicianID 0 means every technician
+	GetByTags(ctx context.Context, technicianID int64, tags []string, matchAll bool) ([]*models.Task, error)
+	// Update saves the task; its tags and location are replaced unless
+	// task.Tags and task.Location are nil. Completing a task fails with
+	// ErrChecklistOpen while required checklist items are open, and with
+	// ErrBlocked while tasks it depends on are.
+	Update(ctx context.Context, task *models.Task, editorID int64) error
 	Delete(ctx context.Context, id int64) error
+	GetDeletedByID(ctx context.Context, id int64) (*models.Task, error)
+	GetDeleted(ctx context.Context) ([]*models.Task, error)
+	Restore(ctx context.Context, id int64) error
+	// PurgeDeleted returns the number of tasks removed and the blob keys of
+	// their attachments, which the caller must delete from the blob store
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, []string, error)
+	// GetUnblockedDependents returns the open tasks depending on the task
+	// whose prerequisites are now all completed or deleted
+	GetUnblockedDependents(ctx context.Context, id int64) ([]*models.Task, error)
+	// GetByAssetID returns the active tasks performed on the asset, most
+	// recent first; technicianID 0 means every technician
+	GetByAssetID(ctx context.Context, assetID int64, technicianID int64) ([]*models.Task, error)
+	// ReviewLocation marks the location of the task as reviewed by the
+	// manager; ErrVersionConflict means it is not waiting for review
+	ReviewLocation(ctx context.Context, taskID int64, reviewerID int64) error
+	// PurgeLocations clears the coordinates of up to limit task locations
+	// recorded before the given time and returns how many were cleared
+	PurgeLocations(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+type TaskRevisionRepository interface {
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskRevision, error)
+	GetByRevision(ctx context.Context, taskID int64, revision int) (*models.TaskRevision, error)
+}
+
+type TaskAttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.TaskAttachment) error
+	GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskAttachment, error)
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskAttachment, error)
+	// MarkProcessed switches the attachment to its stripped blob and saves the
+	// size, checksum, dimensions and capture time of the processed image
+	MarkProcessed(ctx context.Context, attachment *models.TaskAttachment) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type TaskChecklistRepository interface {
+	// Create appends the item to the checklist, then moves it to item.Position
+	// when that is set
+	Create(ctx context.Context, item *models.TaskChecklistItem) error
+	GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskChecklistItem, error)
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskChecklistItem, error)
+	Count(ctx context.Context, taskID int64) (int, error)
+	// Update saves the item and moves it to item.Position, shifting the items
+	// in between
+	Update(ctx context.Context, item *models.TaskChecklistItem) error
+	// Delete removes the item and closes the gap it leaves
+	Delete(ctx context.Context, item *models.TaskChecklistItem) error
+}
+
+type TaskDependencyRepository interface {
+	// Create returns ErrDuplicate when the dependency exists and ErrCycle when
+	// the prerequisite already depends on the task, directly or not
+	Create(ctx context.Context, dependency *models.TaskDependency, createdBy int64) error
+	// Delete returns false when the dependency does not exist
+	Delete(ctx context.Context, dependency *models.TaskDependency) (bool, error)
+	// GetGraph returns the active tasks the task depends on, directly or not,
+	// and those depending on it, with the dependencies between them
+	GetGraph(ctx context.Context, taskID int64) (*models.TaskDependencyGraph, error)
+}
+
+type TaskTemplateRepository interface {
+	// Create saves the template as its revision 1
+	Create(ctx context.Context, template *models.TaskTemplate) error
+	// GetByID returns the current revision of an active template
+	GetByID(ctx context.Context, id int64) (*models.TaskTemplate, error)
+	GetAll(ctx context.Context) ([]*models.TaskTemplate, error)
+	// GetRevision returns any revision, also of deleted templates
+	GetRevision(ctx context.Context, id int64, revision int) (*models.TaskTemplate, error)
+	// Update saves the template as a new revision; template.Revision holds
+	// its number on success
+	Update(ctx context.Context, template *models.TaskTemplate) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type RecurringTaskRepository interface {
+	Create(ctx context.Context, recurringTask *models.RecurringTask) error
+	GetByID(ctx context.Context, id int64) (*models.RecurringTask, error)
+	GetAll(ctx context.Context) ([]*models.RecurringTask, error)
+	GetByTechnicianID(ctx context.Context, technicianID int64) ([]*models.RecurringTask, error)
+	Update(ctx context.Context, recurringTask *models.RecurringTask) error
+	// SetGeneratedUntil records that the occurrences up to until have tasks
+	SetGeneratedUntil(ctx context.Context, id int64, until time.Time) error
+	// Delete removes the definition; the tasks it generated are kept
+	Delete(ctx context.Context, id int64) error
+	// GetScheduledTasks returns the active tasks generated for occurrences
+	// after the given time
+	GetScheduledTasks(ctx context.Context, id int64, after time.Time) ([]*models.RecurringOccurrence, error)
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	GetByID(ctx context.Context, id int64) (*models.Tag, error)
+	GetAll(ctx context.Context) ([]*models.Tag, error)
+	GetByNames(ctx context.Context, names []string) ([]*models.Tag, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id int64) error
+	// CountTasks returns every tag with its number of active tasks;
+	// technicianID 0 counts the tasks of every technician
+	CountTasks(ctx context.Context, technicianID int64) ([]*models.TagCount, error)
+}
+
+// SLARepository stores the SLA policies and tracks which escalation of each
+// task has been notified
+type SLARepository interface {
+	// CreatePolicy returns ErrDuplicate when the tag already has a policy
+	CreatePolicy(ctx context.Context, policy *models.SLAPolicy) error
+	GetPolicyByID(ctx context.Context, id int64) (*models.SLAPolicy, error)
+	GetPolicies(ctx context.Context) ([]*models.SLAPolicy, error)
+	// GetPoliciesByTags returns the policies of any of the tags, strictest first
+	GetPoliciesByTags(ctx context.Context, tags []string) ([]*models.SLAPolicy, error)
+	// UpdatePolicy returns ErrDuplicate when the tag already has another policy
+	UpdatePolicy(ctx context.Context, policy *models.SLAPolicy) error
+	DeletePolicy(ctx context.Context, id int64) error
+	// GetEscalations returns up to limit open tasks that reached an escalation
+	// level at now that has not been notified yet
+	GetEscalations(ctx context.Context, now time.Time, limit int) ([]*models.SLAEscalation, error)
+	// Escalate moves a task from one escalation level to the next; it returns
+	// false when the task is no longer at level from, e.g. another replica
+	// escalated it or it was completed
+	Escalate(ctx context.Context, taskID int64, from string, to string) (bool, error)
+	// GetReport returns the SLA compliance of each technician with tasks due
+	// in the query period
+	GetReport(ctx context.Context, query models.SLAReportQuery, now time.Time) ([]*models.SLACompliance, error)
+}
+
+type TaskCommentRepository interface {
+	Create(ctx context.Context, comment *models.TaskComment) error
+	GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskComment, error)
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskComment, error)
+	Update(ctx context.Context, comment *models.TaskComment) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// CustomerRepository stores customers. Deleted customers are hidden but kept
+// for the history of their sites.
+type CustomerRepository interface {
+	Create(ctx context.Context, customer *models.Customer) error
+	GetByID(ctx context.Context, id int64) (*models.Customer, error)
+	// List returns the customers by name
+	List(ctx context.Context) ([]*models.Customer, error)
+	Update(ctx context.Context, customer *models.Customer) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// SiteRepository stores the sites of customers. Deleted sites are hidden but
+// stay linked to the tasks performed there.
+type SiteRepository interface {
+	Create(ctx context.Context, site *models.Site) error
+	GetByID(ctx context.Context, id int64) (*models.Site, error)
+	// List returns the sites by name; customerID 0 means every customer
+	List(ctx context.Context, customerID int64) ([]*models.Site, error)
+	// CountByCustomerID returns the number of sites of the customer
+	CountByCustomerID(ctx context.Context, customerID int64) (int, error)
+	Update(ctx context.Context, site *models.Site) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// AssetRepository stores the assets installed on sites. Deleted assets are
+// hidden but stay linked to the tasks performed on them.
+type AssetRepository interface {
+	// Create returns ErrDuplicate when another asset of the model has the
+	// serial number
+	Create(ctx context.Context, asset *models.Asset) error
+	GetByID(ctx context.Context, id int64) (*models.Asset, error)
+	// List returns the assets by name; siteID 0 means every site
+	List(ctx context.Context, siteID int64) ([]*models.Asset, error)
+	// CountBySiteID returns the number of assets installed on the site
+	CountBySiteID(ctx context.Context, siteID int64) (int, error)
+	// Update returns ErrDuplicate when another asset of the model has the
+	// serial number
+	Update(ctx context.Context, asset *models.Asset) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// PartRepository stores the parts catalog and its stock ledger
+type PartRepository interface {
+	// Create returns ErrDuplicate when another part has the SKU
+	Create(ctx context.Context, part *models.Part) error
+	GetByID(ctx context.Context, id int64) (*models.Part, error)
+	// List returns the parts by name, only those at or below their low stock
+	// threshold when lowStockOnly is set
+	List(ctx context.Context, lowStockOnly bool) ([]*models.Part, error)
+	// Update changes the catalog fields, not the stock; it returns
+	// ErrDuplicate when another part has the SKU
+	Update(ctx context.Context, part *models.Part) error
+	// Delete removes the part from the catalog; its ledger is kept
+	Delete(ctx context.Context, id int64) error
+	// MoveStock applies movement.Quantity to the stock of the part and records
+	// the movement in the ledger, atomically. It returns the part with its new
+	// stock, or ErrInsufficientStock when the stock would go below zero.
+	MoveStock(ctx context.Context, movement *models.StockMovement) (*models.Part, error)
+	// GetMovements returns up to limit movements of the part, newest first,
+	// older than the cursor movement when cursor is not 0
+	GetMovements(ctx context.Context, partID int64, cursor int64, limit int) ([]*models.StockMovement, error)
+}
+
+// TaskMaterialRepository stores the parts used on tasks. Recording and
+// removing materials move the stock in the same transaction.
+type TaskMaterialRepository interface {
+	// Create records the material and takes its quantity out of stock. It
+	// returns the part with its new stock, or ErrInsufficientStock.
+	Create(ctx context.Context, material *models.TaskMaterial) (*models.Part, error)
+	GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskMaterial, error)
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskMaterial, error)
+	// Delete removes the material and puts its quantity back in stock; it
+	// returns false when the material was already removed
+	Delete(ctx context.Context, material *models.TaskMaterial, deletedBy int64) (bool, error)
+}
+
+// TimeEntryRepository stores the time technicians spend on tasks. A
+// technician has at most one running timer and their entries never overlap.
+type TimeEntryRepository interface {
+	// Create returns ErrOverlap when the entry overlaps another entry of the
+	// technician, a running timer included
+	Create(ctx context.Context, entry *models.TimeEntry) error
+	GetByID(ctx context.Context, taskID int64, id int64) (*models.TimeEntry, error)
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TimeEntry, error)
+	// GetRunning returns the running timer of the technician on the task, or
+	// nil when there is none
+	GetRunning(ctx context.Context, taskID int64, technicianID int64) (*models.TimeEntry, error)
+	// Stop ends a running timer; it returns false when it was already stopped
+	Stop(ctx context.Context, id int64, endedAt time.Time) (bool, error)
+	Delete(ctx context.Context, id int64) error
+	// GetReport returns the time each technician spent on each task in the
+	// query period, running timers counting up to now
+	GetReport(ctx context.Context, query models.TimeReportQuery, now time.Time) ([]*models.TaskTime, error)
+}
+
+// TaskSearchRepository finds tasks by text, best matches first. The MySQL
+// implementation uses a FULLTEXT index; a dedicated search engine can be
+// plugged in by implementing this interface.
+type TaskSearchRepository interface {
+	Search(ctx context.Context, query models.TaskSearchQuery) ([]*models.TaskSearchHit, error)
+}
+
+// QueueRepository serves the work queue: open tasks nobody has claimed, by
+// priority, due date and age
+type QueueRepository interface {
+	// GetQueue returns up to limit queued tasks; technicianID 0 means every
+	// technician
+	GetQueue(ctx context.Context, technicianID int64, limit int) ([]*models.Task, error)
+	// ClaimNext claims the first queued task of the technician for them. It
+	// returns nil when their queue is empty; concurrent calls never get the
+	// same task.
+	ClaimNext(ctx context.Context, technicianID int64) (*models.Task, error)
+	// Release puts a task the technician claimed back in their queue. It
+	// returns nil when the task is not claimed by them.
+	Release(ctx context.Context, taskID int64, technicianID int64) (*models.Task, error)
 }
 
 type NotificationRepository interface {
 	Create(ctx context.Context, notification *models.Notification) error
-	GetUnread(ctx context.Context) ([]*models.Notification, error)
+	// CreateAll creates the notifications in one transaction: all or none
+	CreateAll(ctx context.Context, notifications []*models.Notification) error
+	GetByID(ctx context.Context, id int64) (*models.Notification, error)
+	List(ctx context.Context, filter models.NotificationFilter) ([]*models.Notification, error)
+	// CountUnread counts the unread notifications of a recipient, plus the
+	// shared ones when includeShared is set
+	CountUnread(ctx context.Context, recipientID int64, includeShared bool) (int64, error)
 	MarkAsRead(ctx context.Context, id int64) error
 	Delete(ctx context.Context, id int64) error
+	PurgeRead(ctx context.Context, before time.Time, limit int, archive bool) (int64, error)
+}
+
+// Locker provides named locks shared by every replica, used to elect a single
+// leader for background jobs
+type Locker interface {
+	// TryLock acquires the lock without waiting. When acquired is true the
+	// caller must call release once done.
+	TryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
 }
@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type assetRepository struct {
+	query tasks.Queries
+}
+
+func NewAssetRepository(db *sql.DB) repository.AssetRepository {
+	return &assetRepository{query: *tasks.New(db)}
+}
+
+func (r *assetRepository) Create(ctx context.Context, asset *models.Asset) error {
+	id, err := r.query.CreateAsset(ctx, tasks.CreateAssetParams{
+		SiteID:       asset.SiteID,
+		Name:         asset.Name,
+		SerialNumber: asset.SerialNumber,
+		Model:        asset.Model,
+		InstalledOn:  toNullTime(asset.InstallDate()),
+	})
+	if err != nil {
+		return translateDuplicate(err)
+	}
+	asset.ID = id
+	return nil
+}
+
+func (r *assetRepository) GetByID(ctx context.Context, id int64) (*models.Asset, error) {
+	asset, err := r.query.GetAsset(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAssetModel(asset), nil
+}
+
+func (r *assetRepository) List(ctx context.Context, siteID int64) ([]*models.Asset, error) {
+	rows, err := r.query.GetAssets(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]*models.Asset, 0, len(rows))
+	for _, asset := range rows {
+		assets = append(assets, toAssetModel(asset))
+	}
+	return assets, nil
+}
+
+func (r *assetRepository) CountBySiteID(ctx context.Context, siteID int64) (int, error) {
+	count, err := r.query.CountAssetsBySiteID(ctx, siteID)
+	return int(count), err
+}
+
+func (r *assetRepository) Update(ctx context.Context, asset *models.Asset) error {
+	err := r.query.UpdateAsset(ctx, tasks.UpdateAssetParams{
+		SiteID:       asset.SiteID,
+		Name:         asset.Name,
+		SerialNumber: asset.SerialNumber,
+		Model:        asset.Model,
+		InstalledOn:  toNullTime(asset.InstallDate()),
+		ID:           asset.ID,
+	})
+	return translateDuplicate(err)
+}
+
+func (r *assetRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteAsset(ctx, id)
+}
+
+func toAssetModel(asset tasks.Asset) *models.Asset {
+	a := &models.Asset{
+		ID:           asset.ID,
+		SiteID:       asset.SiteID,
+		Name:         asset.Name,
+		SerialNumber: asset.SerialNumber,
+		Model:        asset.Model,
+		CreatedAt:    asset.CreatedAt.Time,
+		UpdatedAt:    asset.UpdatedAt.Time,
+	}
+	if asset.InstalledOn.Valid {
+		a.InstalledOn = asset.InstalledOn.Time.Format(models.DateLayout)
+	}
+	return a
+}
@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type customerRepository struct {
+	query tasks.Queries
+}
+
+func NewCustomerRepository(db *sql.DB) repository.CustomerRepository {
+	return &customerRepository{query: *tasks.New(db)}
+}
+
+func (r *customerRepository) Create(ctx context.Context, customer *models.Customer) error {
+	id, err := r.query.CreateCustomer(ctx, tasks.CreateCustomerParams{
+		Name:  customer.Name,
+		Email: customer.Email,
+		Phone: customer.Phone,
+	})
+	if err != nil {
+		return err
+	}
+	customer.ID = id
+	return nil
+}
+
+func (r *customerRepository) GetByID(ctx context.Context, id int64) (*models.Customer, error) {
+	customer, err := r.query.GetCustomer(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toCustomerModel(customer), nil
+}
+
+func (r *customerRepository) List(ctx context.Context) ([]*models.Customer, error) {
+	rows, err := r.query.GetCustomers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	customers := make([]*models.Customer, 0, len(rows))
+	for _, customer := range rows {
+		customers = append(customers, toCustomerModel(customer))
+	}
+	return customers, nil
+}
+
+func (r *customerRepository) Update(ctx context.Context, customer *models.Customer) error {
+	return r.query.UpdateCustomer(ctx, tasks.UpdateCustomerParams{
+		Name:  customer.Name,
+		Email: customer.Email,
+		Phone: customer.Phone,
+		ID:    customer.ID,
+	})
+}
+
+func (r *customerRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteCustomer(ctx, id)
+}
+
+func toCustomerModel(customer tasks.Customer) *models.Customer {
+	return &models.Customer{
+		ID:        customer.ID,
+		Name:      customer.Name,
+		Email:     customer.Email,
+		Phone:     customer.Phone,
+		CreatedAt: customer.CreatedAt.Time,
+		UpdatedAt: customer.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,43 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sword-challenge/internal/repository"
+)
+
+type lockRepository struct {
+	db *sql.DB
+}
+
+func NewLockRepository(db *sql.DB) repository.Locker {
+	return &lockRepository{db: db}
+}
+
+// TryLock uses MySQL named locks. A named lock belongs to the session that took
+// it, so a dedicated connection is held until the lock is released.
+func (r *lockRepository) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, 0)`, name).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if acquired.Int64 != 1 {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, name); err != nil {
+			log.Printf("Error releasing lock %s: %v", name, err)
+		}
+		conn.Close()
+	}
+	return release, true, nil
+}
@@ -3,42 +3,104 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"sword-challenge/internal/models"
 	"sword-challenge/internal/repository"
 	"sword-challenge/internal/repository/mysql/notifications"
+	"time"
 )
 
 type notificationRepository struct {
+	db    *sql.DB
 	query notifications.Queries
 }
 
 func NewNotificationRepository(db *sql.DB) repository.NotificationRepository {
-	return &notificationRepository{query: *notifications.New(db)}
+	return &notificationRepository{db: db, query: *notifications.New(db)}
 }
 
 func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
-	return r.query.Create(ctx, notifications.CreateParams{
-		TaskID:  notification.TaskID,
-		Message: notification.Message,
+	return createNotification(ctx, &r.query, notification)
+}
+
+func (r *notificationRepository) CreateAll(ctx context.Context, all []*models.Notification) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	for _, notification := range all {
+		if err := createNotification(ctx, query, notification); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func createNotification(ctx context.Context, query *notifications.Queries, notification *models.Notification) error {
+	var params json.RawMessage
+	if notification.Params != nil {
+		var err error
+		if params, err = json.Marshal(notification.Params); err != nil {
+			return err
+		}
+	}
+	var recipientID sql.NullInt64
+	if notification.RecipientID != nil {
+		recipientID = sql.NullInt64{Int64: *notification.RecipientID, Valid: true}
+	}
+	return query.Create(ctx, notifications.CreateParams{
+		TaskID:      notification.TaskID,
+		RecipientID: recipientID,
+		Message:     notification.Message,
+		TemplateKey: sql.NullString{String: notification.TemplateKey, Valid: notification.TemplateKey != ""},
+		Params:      params,
 	})
 }
 
-func (r *notificationRepository) GetUnread(ctx context.Context) ([]*models.Notification, error) {
-	allNotifications, err := r.query.GetUnread(ctx)
+func (r *notificationRepository) GetByID(ctx context.Context, id int64) (*models.Notification, error) {
+	notification, err := r.query.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationModel(notification)
+}
+
+func (r *notificationRepository) List(ctx context.Context, filter models.NotificationFilter) ([]*models.Notification, error) {
+	allNotifications, err := r.query.List(ctx, notifications.ListParams{
+		RecipientID:   sql.NullInt64{Int64: filter.RecipientID, Valid: true},
+		IncludeShared: filter.IncludeShared,
+		Status:        filter.Status,
+		TaskID:        filter.TaskID,
+		Cursor:        filter.Cursor,
+		Limit:         int32(filter.Limit),
+	})
 	if err != nil {
 		return nil, err
 	}
 	notifications := make([]*models.Notification, 0, len(allNotifications))
 	for _, notification := range allNotifications {
-		notifications = append(notifications, &models.Notification{
-			ID:      notification.ID,
-			TaskID:  notification.TaskID,
-			Message: notification.Message,
-		})
+		n, err := toNotificationModel(notification)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
 	}
 	return notifications, nil
 }
 
+func (r *notificationRepository) CountUnread(ctx context.Context, recipientID int64, includeShared bool) (int64, error) {
+	return r.query.CountUnread(ctx, notifications.CountUnreadParams{
+		RecipientID:   sql.NullInt64{Int64: recipientID, Valid: true},
+		IncludeShared: includeShared,
+	})
+}
+
 func (r *notificationRepository) MarkAsRead(ctx context.Context, id int64) error {
 	return r.query.MarkAsRead(ctx, id)
 }
@@ -46,3 +108,59 @@ func (r *notificationRepository) MarkAsRead(ctx context.Context, id int64) error
 func (r *notificationRepository) Delete(ctx context.Context, id int64) error {
 	return r.query.Delete(ctx, id)
 }
+
+// PurgeRead removes up to limit read notifications created before the given
+// time, copying them to notifications_archive first when archive is set. Each
+// call runs in its own transaction so locks are held for a single batch only.
+func (r *notificationRepository) PurgeRead(ctx context.Context, before time.Time, limit int, archive bool) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	ids, err := query.GetReadIDsBefore(ctx, notifications.GetReadIDsBeforeParams{
+		CreatedAt: sql.NullTime{Time: before, Valid: true},
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if archive {
+		if err := query.ArchiveByIDs(ctx, ids); err != nil {
+			return 0, err
+		}
+	}
+	purged, err := query.DeleteByIDs(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	return purged, tx.Commit()
+}
+
+func toNotificationModel(notification notifications.Notification) (*models.Notification, error) {
+	var params map[string]string
+	if len(notification.Params) > 0 {
+		if err := json.Unmarshal(notification.Params, &params); err != nil {
+			return nil, err
+		}
+	}
+	n := &models.Notification{
+		ID:          notification.ID,
+		TaskID:      notification.TaskID,
+		Message:     notification.Message,
+		TemplateKey: notification.TemplateKey.String,
+		Params:      params,
+		IsRead:      notification.IsRead.Bool,
+		CreatedAt:   notification.CreatedAt.Time,
+	}
+	if notification.RecipientID.Valid {
+		n.RecipientID = &notification.RecipientID.Int64
+	}
+	return n, nil
+}
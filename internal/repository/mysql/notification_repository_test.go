@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRepository_CreateAll(t *testing.T) {
+	mentions := func() []*models.Notification {
+		author := &models.User{ID: 1, Name: "Alice"}
+		var all []*models.Notification
+		for _, recipientID := range []int64{2, 3, 4} {
+			notification, err := models.NewCommentMentionNotification(10, 20, author, recipientID)
+			require.NoError(t, err)
+			all = append(all, notification)
+		}
+		return all
+	}
+
+	t.Run("creates every notification", func(t *testing.T) {
+		fake := newFakeDB(map[string]int64{})
+		db := sql.OpenDB(fake)
+		defer db.Close()
+
+		require.NoError(t, NewNotificationRepository(db).CreateAll(context.Background(), mentions()))
+		assert.Len(t, fake.committed, 3)
+	})
+
+	t.Run("creates none when one fails", func(t *testing.T) {
+		fake := newFakeDB(map[string]int64{})
+		fake.fail = func(n int, statement fakeStatement) error {
+			if n == 2 {
+				return errors.New("connection reset")
+			}
+			return nil
+		}
+		db := sql.OpenDB(fake)
+		defer db.Close()
+
+		assert.Error(t, NewNotificationRepository(db).CreateAll(context.Background(), mentions()))
+		assert.Empty(t, fake.committed)
+	})
+}
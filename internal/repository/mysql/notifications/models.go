@@ -6,12 +6,242 @@ package notifications
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
 )
 
+type TasksPriority string
+
+const (
+	TasksPriorityLow    TasksPriority = "low"
+	TasksPriorityNormal TasksPriority = "normal"
+	TasksPriorityHigh   TasksPriority = "high"
+	TasksPriorityUrgent TasksPriority = "urgent"
+)
+
+func (e *TasksPriority) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TasksPriority(s)
+	case string:
+		*e = TasksPriority(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TasksPriority: %T", src)
+	}
+	return nil
+}
+
+type NullTasksPriority struct {
+	TasksPriority TasksPriority
+	Valid         bool // Valid is true if TasksPriority is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTasksPriority) Scan(value interface{}) error {
+	if value == nil {
+		ns.TasksPriority, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TasksPriority.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTasksPriority) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TasksPriority), nil
+}
+
+type TasksSlaEscalation string
+
+const (
+	TasksSlaEscalationNone     TasksSlaEscalation = "none"
+	TasksSlaEscalationAtRisk   TasksSlaEscalation = "at_risk"
+	TasksSlaEscalationBreached TasksSlaEscalation = "breached"
+)
+
+func (e *TasksSlaEscalation) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TasksSlaEscalation(s)
+	case string:
+		*e = TasksSlaEscalation(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TasksSlaEscalation: %T", src)
+	}
+	return nil
+}
+
+type NullTasksSlaEscalation struct {
+	TasksSlaEscalation TasksSlaEscalation
+	Valid              bool // Valid is true if TasksSlaEscalation is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTasksSlaEscalation) Scan(value interface{}) error {
+	if value == nil {
+		ns.TasksSlaEscalation, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TasksSlaEscalation.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTasksSlaEscalation) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TasksSlaEscalation), nil
+}
+
+type TasksStatus string
+
+const (
+	TasksStatusOpen      TasksStatus = "open"
+	TasksStatusCompleted TasksStatus = "completed"
+)
+
+func (e *TasksStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TasksStatus(s)
+	case string:
+		*e = TasksStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TasksStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTasksStatus struct {
+	TasksStatus TasksStatus
+	Valid       bool // Valid is true if TasksStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTasksStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TasksStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TasksStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTasksStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TasksStatus), nil
+}
+
+type UsersRole string
+
+const (
+	UsersRoleManager    UsersRole = "manager"
+	UsersRoleTechnician UsersRole = "technician"
+)
+
+func (e *UsersRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UsersRole(s)
+	case string:
+		*e = UsersRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UsersRole: %T", src)
+	}
+	return nil
+}
+
+type NullUsersRole struct {
+	UsersRole UsersRole
+	Valid     bool // Valid is true if UsersRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUsersRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.UsersRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UsersRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUsersRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UsersRole), nil
+}
+
 type Notification struct {
-	ID        int64
-	TaskID    int64
-	Message   string
-	IsRead    sql.NullBool
-	CreatedAt sql.NullTime
+	ID          int64
+	TaskID      int64
+	RecipientID sql.NullInt64
+	Message     string
+	TemplateKey sql.NullString
+	Params      json.RawMessage
+	IsRead      sql.NullBool
+	CreatedAt   sql.NullTime
+}
+
+type NotificationsArchive struct {
+	ID          int64
+	TaskID      int64
+	RecipientID sql.NullInt64
+	Message     string
+	TemplateKey sql.NullString
+	Params      json.RawMessage
+	IsRead      sql.NullBool
+	CreatedAt   sql.NullTime
+	ArchivedAt  sql.NullTime
+}
+
+type Task struct {
+	ID               int64
+	TechnicianID     int64
+	Title            string
+	Summary          string
+	PerformedAt      time.Time
+	Status           TasksStatus
+	Priority         TasksPriority
+	TemplateID       sql.NullInt64
+	TemplateRevision sql.NullInt32
+	RecurringTaskID  sql.NullInt64
+	ScheduledFor     sql.NullTime
+	DueAt            sql.NullTime
+	AtRiskAt         sql.NullTime
+	SlaPolicyID      sql.NullInt64
+	SlaEscalation    TasksSlaEscalation
+	CompletedAt      sql.NullTime
+	ClaimedBy        sql.NullInt64
+	ClaimedAt        sql.NullTime
+	SiteID           sql.NullInt64
+	AssetID          sql.NullInt64
+	Version          int32
+	CreatedAt        sql.NullTime
+	UpdatedAt        sql.NullTime
+	DeletedAt        sql.NullTime
+}
+
+type User struct {
+	ID           int64
+	Name         string
+	Email        string
+	PasswordHash string
+	Role         UsersRole
+	ManagerID    sql.NullInt64
+	Locale       string
+	Timezone     string
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
 }
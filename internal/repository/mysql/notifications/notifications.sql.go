@@ -7,20 +7,72 @@ package notifications
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
 )
 
+const archiveByIDs = `-- name: ArchiveByIDs :exec
+INSERT INTO notifications_archive (id, task_id, recipient_id, message, template_key, params, is_read, created_at)
+SELECT id, task_id, recipient_id, message, template_key, params, is_read, created_at FROM notifications
+WHERE notifications.id IN (/*SLICE:ids*/?)
+`
+
+func (q *Queries) ArchiveByIDs(ctx context.Context, ids []int64) error {
+	sql := archiveByIDs
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		sql = strings.Replace(sql, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		sql = strings.Replace(sql, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	_, err := q.db.ExecContext(ctx, sql, queryParams...)
+	return err
+}
+
+const countUnread = `-- name: CountUnread :one
+SELECT COUNT(*) FROM notifications
+WHERE is_read = 0
+  AND (recipient_id = ? OR (? = 1 AND recipient_id IS NULL))
+  AND EXISTS (SELECT 1 FROM tasks WHERE tasks.id = notifications.task_id AND tasks.deleted_at IS NULL)
+`
+
+type CountUnreadParams struct {
+	RecipientID   sql.NullInt64
+	IncludeShared interface{}
+}
+
+func (q *Queries) CountUnread(ctx context.Context, arg CountUnreadParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnread, arg.RecipientID, arg.IncludeShared)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const create = `-- name: Create :exec
-INSERT INTO notifications (task_id, message)
-VALUES (?, ?)
+INSERT INTO notifications (task_id, recipient_id, message, template_key, params)
+VALUES (?, ?, ?, ?, ?)
 `
 
 type CreateParams struct {
-	TaskID  int64
-	Message string
+	TaskID      int64
+	RecipientID sql.NullInt64
+	Message     string
+	TemplateKey sql.NullString
+	Params      json.RawMessage
 }
 
 func (q *Queries) Create(ctx context.Context, arg CreateParams) error {
-	_, err := q.db.ExecContext(ctx, create, arg.TaskID, arg.Message)
+	_, err := q.db.ExecContext(ctx, create,
+		arg.TaskID,
+		arg.RecipientID,
+		arg.Message,
+		arg.TemplateKey,
+		arg.Params,
+	)
 	return err
 }
 
@@ -33,6 +85,28 @@ func (q *Queries) Delete(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteByIDs = `-- name: DeleteByIDs :execrows
+DELETE FROM notifications WHERE id IN (/*SLICE:ids*/?)
+`
+
+func (q *Queries) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	sql := deleteByIDs
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		sql = strings.Replace(sql, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		sql = strings.Replace(sql, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	result, err := q.db.ExecContext(ctx, sql, queryParams...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteByTaskID = `-- name: DeleteByTaskID :exec
 DELETE FROM notifications WHERE task_id = ?
 `
@@ -43,7 +117,7 @@ func (q *Queries) DeleteByTaskID(ctx context.Context, taskID int64) error {
 }
 
 const getAll = `-- name: GetAll :many
-SELECT id, task_id, message, is_read, created_at FROM notifications
+SELECT id, task_id, recipient_id, message, template_key, params, is_read, created_at FROM notifications
 `
 
 func (q *Queries) GetAll(ctx context.Context) ([]Notification, error) {
@@ -58,7 +132,10 @@ func (q *Queries) GetAll(ctx context.Context) ([]Notification, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.TaskID,
+			&i.RecipientID,
 			&i.Message,
+			&i.TemplateKey,
+			&i.Params,
 			&i.IsRead,
 			&i.CreatedAt,
 		); err != nil {
@@ -76,7 +153,9 @@ func (q *Queries) GetAll(ctx context.Context) ([]Notification, error) {
 }
 
 const getByID = `-- name: GetByID :one
-SELECT id, task_id, message, is_read, created_at FROM notifications WHERE id = ?
+SELECT id, task_id, recipient_id, message, template_key, params, is_read, created_at FROM notifications
+WHERE id = ?
+  AND EXISTS (SELECT 1 FROM tasks WHERE tasks.id = notifications.task_id AND tasks.deleted_at IS NULL)
 `
 
 func (q *Queries) GetByID(ctx context.Context, id int64) (Notification, error) {
@@ -85,7 +164,10 @@ func (q *Queries) GetByID(ctx context.Context, id int64) (Notification, error) {
 	err := row.Scan(
 		&i.ID,
 		&i.TaskID,
+		&i.RecipientID,
 		&i.Message,
+		&i.TemplateKey,
+		&i.Params,
 		&i.IsRead,
 		&i.CreatedAt,
 	)
@@ -93,7 +175,7 @@ func (q *Queries) GetByID(ctx context.Context, id int64) (Notification, error) {
 }
 
 const getByTaskID = `-- name: GetByTaskID :many
-SELECT id, task_id, message, is_read, created_at FROM notifications WHERE task_id = ?
+SELECT id, task_id, recipient_id, message, template_key, params, is_read, created_at FROM notifications WHERE task_id = ?
 `
 
 func (q *Queries) GetByTaskID(ctx context.Context, taskID int64) ([]Notification, error) {
@@ -108,7 +190,10 @@ func (q *Queries) GetByTaskID(ctx context.Context, taskID int64) ([]Notification
 		if err := rows.Scan(
 			&i.ID,
 			&i.TaskID,
+			&i.RecipientID,
 			&i.Message,
+			&i.TemplateKey,
+			&i.Params,
 			&i.IsRead,
 			&i.CreatedAt,
 		); err != nil {
@@ -125,12 +210,73 @@ func (q *Queries) GetByTaskID(ctx context.Context, taskID int64) ([]Notification
 	return items, nil
 }
 
-const getUnread = `-- name: GetUnread :many
-SELECT id, task_id, message, is_read, created_at FROM notifications WHERE is_read = 0
+const getReadIDsBefore = `-- name: GetReadIDsBefore :many
+SELECT id FROM notifications
+WHERE is_read = 1 AND created_at < ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetReadIDsBeforeParams struct {
+	CreatedAt sql.NullTime
+	Limit     int32
+}
+
+func (q *Queries) GetReadIDsBefore(ctx context.Context, arg GetReadIDsBeforeParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getReadIDsBefore, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const list = `-- name: List :many
+SELECT id, task_id, recipient_id, message, template_key, params, is_read, created_at FROM notifications
+WHERE (recipient_id = ? OR (? = 1 AND recipient_id IS NULL))
+  AND (? = 'all' OR is_read = (? = 'read'))
+  AND (? = 0 OR task_id = ?)
+  AND (? = 0 OR id < ?)
+  AND EXISTS (SELECT 1 FROM tasks WHERE tasks.id = notifications.task_id AND tasks.deleted_at IS NULL)
+ORDER BY id DESC
+LIMIT ?
 `
 
-func (q *Queries) GetUnread(ctx context.Context) ([]Notification, error) {
-	rows, err := q.db.QueryContext(ctx, getUnread)
+type ListParams struct {
+	RecipientID   sql.NullInt64
+	IncludeShared interface{}
+	Status        interface{}
+	TaskID        int64
+	Cursor        int64
+	Limit         int32
+}
+
+func (q *Queries) List(ctx context.Context, arg ListParams) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, list,
+		arg.RecipientID,
+		arg.IncludeShared,
+		arg.Status,
+		arg.Status,
+		arg.TaskID,
+		arg.TaskID,
+		arg.Cursor,
+		arg.Cursor,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +287,10 @@ func (q *Queries) GetUnread(ctx context.Context) ([]Notification, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.TaskID,
+			&i.RecipientID,
 			&i.Message,
+			&i.TemplateKey,
+			&i.Params,
 			&i.IsRead,
 			&i.CreatedAt,
 		); err != nil {
@@ -0,0 +1,173 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type partRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewPartRepository(db *sql.DB) repository.PartRepository {
+	return &partRepository{db: db, query: *tasks.New(db)}
+}
+
+func (r *partRepository) Create(ctx context.Context, part *models.Part) error {
+	id, err := r.query.CreatePart(ctx, tasks.CreatePartParams{
+		Sku:               part.SKU,
+		Name:              part.Name,
+		Unit:              part.Unit,
+		LowStockThreshold: int32(part.LowStockThreshold),
+	})
+	if err != nil {
+		return translateDuplicate(err)
+	}
+	part.ID = id
+	return nil
+}
+
+func (r *partRepository) GetByID(ctx context.Context, id int64) (*models.Part, error) {
+	part, err := r.query.GetPart(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toPartModel(part), nil
+}
+
+func (r *partRepository) List(ctx context.Context, lowStockOnly bool) ([]*models.Part, error) {
+	rows, err := r.query.GetParts(ctx, lowStockOnly)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*models.Part, 0, len(rows))
+	for _, part := range rows {
+		parts = append(parts, toPartModel(part))
+	}
+	return parts, nil
+}
+
+func (r *partRepository) Update(ctx context.Context, part *models.Part) error {
+	err := r.query.UpdatePart(ctx, tasks.UpdatePartParams{
+		Sku:               part.SKU,
+		Name:              part.Name,
+		Unit:              part.Unit,
+		LowStockThreshold: int32(part.LowStockThreshold),
+		ID:                part.ID,
+	})
+	return translateDuplicate(err)
+}
+
+func (r *partRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeletePart(ctx, id)
+}
+
+func (r *partRepository) MoveStock(ctx context.Context, movement *models.StockMovement) (*models.Part, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	part, err := moveStock(ctx, r.query.WithTx(tx), movement)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return part, nil
+}
+
+func (r *partRepository) GetMovements(ctx context.Context, partID int64, cursor int64, limit int) ([]*models.StockMovement, error) {
+	rows, err := r.query.GetStockMovements(ctx, tasks.GetStockMovementsParams{
+		PartID: partID,
+		Cursor: cursor,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	movements := make([]*models.StockMovement, 0, len(rows))
+	for _, row := range rows {
+		movements = append(movements, toStockMovementModel(row))
+	}
+	return movements, nil
+}
+
+// moveStock locks the part row, so concurrent movements of the same part are
+// applied one after the other, then updates the stock and appends the
+// movement to the ledger. It must run in a transaction.
+func moveStock(ctx context.Context, query *tasks.Queries, movement *models.StockMovement) (*models.Part, error) {
+	row, err := query.LockPart(ctx, movement.PartID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := int(row.Stock) + movement.Quantity
+	if balance < 0 {
+		return nil, repository.ErrInsufficientStock
+	}
+	if err := query.SetPartStock(ctx, tasks.SetPartStockParams{Stock: int32(balance), ID: row.ID}); err != nil {
+		return nil, err
+	}
+
+	id, err := query.CreateStockMovement(ctx, tasks.CreateStockMovementParams{
+		PartID:    movement.PartID,
+		TaskID:    toNullInt64(movement.TaskID),
+		Quantity:  int32(movement.Quantity),
+		Balance:   int32(balance),
+		Reason:    tasks.StockMovementsReason(movement.Reason),
+		Note:      movement.Note,
+		CreatedBy: toNullInt64(movement.CreatedBy),
+	})
+	if err != nil {
+		return nil, err
+	}
+	movement.ID = id
+	movement.Balance = balance
+
+	row.Stock = int32(balance)
+	return toPartModel(row), nil
+}
+
+func toPartModel(part tasks.Part) *models.Part {
+	p := &models.Part{
+		ID:                part.ID,
+		SKU:               part.Sku,
+		Name:              part.Name,
+		Unit:              part.Unit,
+		Stock:             int(part.Stock),
+		LowStockThreshold: int(part.LowStockThreshold),
+		CreatedAt:         part.CreatedAt.Time,
+		UpdatedAt:         part.UpdatedAt.Time,
+	}
+	p.UpdateLowStock()
+	return p
+}
+
+func toStockMovementModel(movement tasks.StockMovement) *models.StockMovement {
+	m := &models.StockMovement{
+		ID:        movement.ID,
+		PartID:    movement.PartID,
+		Quantity:  int(movement.Quantity),
+		Balance:   int(movement.Balance),
+		Reason:    string(movement.Reason),
+		Note:      movement.Note,
+		CreatedAt: movement.CreatedAt.Time,
+	}
+	if movement.TaskID.Valid {
+		m.TaskID = &movement.TaskID.Int64
+	}
+	if movement.CreatedBy.Valid {
+		m.CreatedBy = &movement.CreatedBy.Int64
+	}
+	return m
+}
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+// queueRepository orders the queue with "priority DESC": priority is an ENUM,
+// which MySQL sorts by declaration order, so urgent tasks come first
+type queueRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewQueueRepository(db *sql.DB) repository.QueueRepository {
+	return &queueRepository{db: db, query: *tasks.New(db)}
+}
+
+func (r *queueRepository) GetQueue(ctx context.Context, technicianID int64, limit int) ([]*models.Task, error) {
+	queued, err := r.query.GetQueue(ctx, tasks.GetQueueParams{
+		TechnicianID: technicianID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	taskModels := toTaskModels(queued)
+	if err := loadTaskDetails(ctx, &r.query, taskModels); err != nil {
+		return nil, err
+	}
+	return taskModels, nil
+}
+
+// ClaimNext locks the first queued task, skipping rows other transactions
+// are claiming, and marks it claimed in the same transaction
+func (r *queueRepository) ClaimNext(ctx context.Context, technicianID int64) (*models.Task, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	id, err := query.GetNextQueuedTaskID(ctx, technicianID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := query.ClaimTask(ctx, tasks.ClaimTaskParams{
+		ClaimedBy: sql.NullInt64{Int64: technicianID, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claimed == 0 {
+		return nil, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.getTask(ctx, id)
+}
+
+func (r *queueRepository) Release(ctx context.Context, taskID int64, technicianID int64) (*models.Task, error) {
+	released, err := r.query.ReleaseTask(ctx, tasks.ReleaseTaskParams{
+		ID:        taskID,
+		ClaimedBy: sql.NullInt64{Int64: technicianID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if released == 0 {
+		return nil, nil
+	}
+	return r.getTask(ctx, taskID)
+}
+
+func (r *queueRepository) getTask(ctx context.Context, id int64) (*models.Task, error) {
+	task, err := r.query.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	taskModel := toTaskModel(task)
+	if err := loadTaskDetails(ctx, &r.query, []*models.Task{taskModel}); err != nil {
+		return nil, err
+	}
+	return taskModel, nil
+}
@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+	"time"
+)
+
+type recurringTaskRepository struct {
+	query tasks.Queries
+}
+
+func NewRecurringTaskRepository(db *sql.DB) repository.RecurringTaskRepository {
+	return &recurringTaskRepository{query: *tasks.New(db)}
+}
+
+func (r *recurringTaskRepository) Create(ctx context.Context, recurringTask *models.RecurringTask) error {
+	id, err := r.query.CreateRecurringTask(ctx, tasks.CreateRecurringTaskParams{
+		TechnicianID: recurringTask.TechnicianID,
+		Title:        recurringTask.Title,
+		Summary:      recurringTask.Summary,
+		TemplateID:   toNullInt64(recurringTask.TemplateID),
+		Rrule:        recurringTask.RRule,
+		StartsAt:     recurringTask.StartsAt,
+		Timezone:     recurringTask.Timezone,
+		CreatedBy:    recurringTask.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+	recurringTask.ID = id
+	return nil
+}
+
+func (r *recurringTaskRepository) GetByID(ctx context.Context, id int64) (*models.RecurringTask, error) {
+	recurringTask, err := r.query.GetRecurringTask(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRecurringTaskModel(recurringTask), nil
+}
+
+func (r *recurringTaskRepository) GetAll(ctx context.Context) ([]*models.RecurringTask, error) {
+	rows, err := r.query.GetRecurringTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toRecurringTaskModels(rows), nil
+}
+
+func (r *recurringTaskRepository) GetByTechnicianID(ctx context.Context, technicianID int64) ([]*models.RecurringTask, error) {
+	rows, err := r.query.GetRecurringTasksByTechnicianID(ctx, technicianID)
+	if err != nil {
+		return nil, err
+	}
+	return toRecurringTaskModels(rows), nil
+}
+
+func (r *recurringTaskRepository) Update(ctx context.Context, recurringTask *models.RecurringTask) error {
+	return r.query.UpdateRecurringTask(ctx, tasks.UpdateRecurringTaskParams{
+		TechnicianID: recurringTask.TechnicianID,
+		Title:        recurringTask.Title,
+		Summary:      recurringTask.Summary,
+		TemplateID:   toNullInt64(recurringTask.TemplateID),
+		Rrule:        recurringTask.RRule,
+		StartsAt:     recurringTask.StartsAt,
+		Timezone:     recurringTask.Timezone,
+		ID:           recurringTask.ID,
+	})
+}
+
+func (r *recurringTaskRepository) SetGeneratedUntil(ctx context.Context, id int64, until time.Time) error {
+	return r.query.SetRecurringTaskGeneratedUntil(ctx, tasks.SetRecurringTaskGeneratedUntilParams{
+		GeneratedUntil: sql.NullTime{Time: until, Valid: true},
+		ID:             id,
+	})
+}
+
+func (r *recurringTaskRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteRecurringTask(ctx, id)
+}
+
+func (r *recurringTaskRepository) GetScheduledTasks(ctx context.Context, id int64, after time.Time) ([]*models.RecurringOccurrence, error) {
+	rows, err := r.query.GetScheduledTasks(ctx, tasks.GetScheduledTasksParams{
+		RecurringTaskID: sql.NullInt64{Int64: id, Valid: true},
+		ScheduledFor:    sql.NullTime{Time: after, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	occurrences := make([]*models.RecurringOccurrence, 0, len(rows))
+	for _, row := range rows {
+		taskID := row.ID
+		occurrences = append(occurrences, &models.RecurringOccurrence{
+			ScheduledFor: row.ScheduledFor.Time,
+			TaskID:       &taskID,
+		})
+	}
+	return occurrences, nil
+}
+
+func toNullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+func toRecurringTaskModel(recurringTask tasks.RecurringTask) *models.RecurringTask {
+	r := &models.RecurringTask{
+		ID:           recurringTask.ID,
+		TechnicianID: recurringTask.TechnicianID,
+		Title:        recurringTask.Title,
+		Summary:      recurringTask.Summary,
+		RRule:        recurringTask.Rrule,
+		StartsAt:     recurringTask.StartsAt,
+		Timezone:     recurringTask.Timezone,
+		CreatedBy:    recurringTask.CreatedBy,
+		CreatedAt:    recurringTask.CreatedAt.Time,
+		UpdatedAt:    recurringTask.UpdatedAt.Time,
+	}
+	if recurringTask.TemplateID.Valid {
+		r.TemplateID = &recurringTask.TemplateID.Int64
+	}
+	if recurringTask.GeneratedUntil.Valid {
+		r.GeneratedUntil = &recurringTask.GeneratedUntil.Time
+	}
+	return r
+}
+
+func toRecurringTaskModels(rows []tasks.RecurringTask) []*models.RecurringTask {
+	recurringTasks := make([]*models.RecurringTask, 0, len(rows))
+	for _, row := range rows {
+		recurringTasks = append(recurringTasks, toRecurringTaskModel(row))
+	}
+	return recurringTasks
+}
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type siteRepository struct {
+	query tasks.Queries
+}
+
+func NewSiteRepository(db *sql.DB) repository.SiteRepository {
+	return &siteRepository{query: *tasks.New(db)}
+}
+
+func (r *siteRepository) Create(ctx context.Context, site *models.Site) error {
+	id, err := r.query.CreateSite(ctx, tasks.CreateSiteParams{
+		CustomerID:     site.CustomerID,
+		Name:           site.Name,
+		Address:        site.Address,
+		City:           site.City,
+		PostalCode:     site.PostalCode,
+		Country:        site.Country,
+		Latitude:       toNullFloat64(site.Latitude),
+		Longitude:      toNullFloat64(site.Longitude),
+		GeofenceRadius: int32(site.GeofenceRadius),
+	})
+	if err != nil {
+		return err
+	}
+	site.ID = id
+	return nil
+}
+
+func (r *siteRepository) GetByID(ctx context.Context, id int64) (*models.Site, error) {
+	site, err := r.query.GetSite(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toSiteModel(site), nil
+}
+
+func (r *siteRepository) List(ctx context.Context, customerID int64) ([]*models.Site, error) {
+	rows, err := r.query.GetSites(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	sites := make([]*models.Site, 0, len(rows))
+	for _, site := range rows {
+		sites = append(sites, toSiteModel(site))
+	}
+	return sites, nil
+}
+
+func (r *siteRepository) CountByCustomerID(ctx context.Context, customerID int64) (int, error) {
+	count, err := r.query.CountSitesByCustomerID(ctx, customerID)
+	return int(count), err
+}
+
+func (r *siteRepository) Update(ctx context.Context, site *models.Site) error {
+	return r.query.UpdateSite(ctx, tasks.UpdateSiteParams{
+		Name:           site.Name,
+		Address:        site.Address,
+		City:           site.City,
+		PostalCode:     site.PostalCode,
+		Country:        site.Country,
+		Latitude:       toNullFloat64(site.Latitude),
+		Longitude:      toNullFloat64(site.Longitude),
+		GeofenceRadius: int32(site.GeofenceRadius),
+		ID:             site.ID,
+	})
+}
+
+func (r *siteRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteSite(ctx, id)
+}
+
+// toNullFloat64 maps an optional number to a nullable column
+func toNullFloat64(v *float64) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *v, Valid: true}
+}
+
+func toSiteModel(site tasks.Site) *models.Site {
+	s := &models.Site{
+		ID:             site.ID,
+		CustomerID:     site.CustomerID,
+		Name:           site.Name,
+		Address:        site.Address,
+		City:           site.City,
+		PostalCode:     site.PostalCode,
+		Country:        site.Country,
+		GeofenceRadius: int(site.GeofenceRadius),
+		CreatedAt:      site.CreatedAt.Time,
+		UpdatedAt:      site.UpdatedAt.Time,
+	}
+	if site.Latitude.Valid && site.Longitude.Valid {
+		s.Latitude = &site.Latitude.Float64
+		s.Longitude = &site.Longitude.Float64
+	}
+	return s
+}
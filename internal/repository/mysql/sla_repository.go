@@ -0,0 +1,160 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+	"time"
+)
+
+type slaRepository struct {
+	query tasks.Queries
+}
+
+func NewSLARepository(db *sql.DB) repository.SLARepository {
+	return &slaRepository{query: *tasks.New(db)}
+}
+
+func (r *slaRepository) CreatePolicy(ctx context.Context, policy *models.SLAPolicy) error {
+	id, err := r.query.CreateSLAPolicy(ctx, tasks.CreateSLAPolicyParams{
+		Name:              policy.Name,
+		TagID:             policy.TagID,
+		ResolutionMinutes: int32(policy.ResolutionMinutes),
+		AtRiskMinutes:     int32(policy.AtRiskMinutes),
+	})
+	if err != nil {
+		return translateDuplicate(err)
+	}
+	policy.ID = id
+	return nil
+}
+
+func (r *slaRepository) GetPolicyByID(ctx context.Context, id int64) (*models.SLAPolicy, error) {
+	row, err := r.query.GetSLAPolicy(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toSLAPolicyModel(tasks.GetSLAPoliciesRow(row)), nil
+}
+
+func (r *slaRepository) GetPolicies(ctx context.Context) ([]*models.SLAPolicy, error) {
+	rows, err := r.query.GetSLAPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*models.SLAPolicy, 0, len(rows))
+	for _, row := range rows {
+		policies = append(policies, toSLAPolicyModel(row))
+	}
+	return policies, nil
+}
+
+func (r *slaRepository) GetPoliciesByTags(ctx context.Context, tags []string) ([]*models.SLAPolicy, error) {
+	if len(tags) == 0 {
+		return []*models.SLAPolicy{}, nil
+	}
+	rows, err := r.query.GetSLAPoliciesByTagNames(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*models.SLAPolicy, 0, len(rows))
+	for _, row := range rows {
+		policies = append(policies, toSLAPolicyModel(tasks.GetSLAPoliciesRow(row)))
+	}
+	return policies, nil
+}
+
+func (r *slaRepository) UpdatePolicy(ctx context.Context, policy *models.SLAPolicy) error {
+	err := r.query.UpdateSLAPolicy(ctx, tasks.UpdateSLAPolicyParams{
+		Name:              policy.Name,
+		TagID:             policy.TagID,
+		ResolutionMinutes: int32(policy.ResolutionMinutes),
+		AtRiskMinutes:     int32(policy.AtRiskMinutes),
+		ID:                policy.ID,
+	})
+	return translateDuplicate(err)
+}
+
+// DeletePolicy removes the policy; tasks keep the due date it gave them
+func (r *slaRepository) DeletePolicy(ctx context.Context, id int64) error {
+	return r.query.DeleteSLAPolicy(ctx, id)
+}
+
+func (r *slaRepository) GetEscalations(ctx context.Context, now time.Time, limit int) ([]*models.SLAEscalation, error) {
+	rows, err := r.query.GetEscalationCandidates(ctx, tasks.GetEscalationCandidatesParams{
+		Now:   sql.NullTime{Time: now, Valid: true},
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	escalations := make([]*models.SLAEscalation, 0, len(rows))
+	for _, row := range rows {
+		escalation := &models.SLAEscalation{
+			TaskID:       row.ID,
+			TechnicianID: row.TechnicianID,
+			Title:        row.Title,
+			DueAt:        row.DueAt.Time,
+			Level:        string(row.SlaEscalation),
+		}
+		if row.AtRiskAt.Valid {
+			escalation.AtRiskAt = &row.AtRiskAt.Time
+		}
+		escalations = append(escalations, escalation)
+	}
+	return escalations, nil
+}
+
+func (r *slaRepository) Escalate(ctx context.Context, taskID int64, from string, to string) (bool, error) {
+	updated, err := r.query.SetTaskEscalation(ctx, tasks.SetTaskEscalationParams{
+		Escalation:        tasks.TasksSlaEscalation(to),
+		ID:                taskID,
+		CurrentEscalation: tasks.TasksSlaEscalation(from),
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated == 1, nil
+}
+
+func (r *slaRepository) GetReport(ctx context.Context, query models.SLAReportQuery, now time.Time) ([]*models.SLACompliance, error) {
+	rows, err := r.query.GetSLAReport(ctx, tasks.GetSLAReportParams{
+		Now:          sql.NullTime{Time: now, Valid: true},
+		FromTime:     sql.NullTime{Time: query.From, Valid: true},
+		ToTime:       sql.NullTime{Time: query.To, Valid: true},
+		TechnicianID: query.TechnicianID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	technicians := make([]*models.SLACompliance, 0, len(rows))
+	for _, row := range rows {
+		technicians = append(technicians, &models.SLACompliance{
+			TechnicianID:   row.TechnicianID,
+			TechnicianName: row.Name,
+			Total:          row.Total,
+			Met:            row.Met,
+			Missed:         row.Missed,
+			Pending:        row.Pending,
+		})
+	}
+	return technicians, nil
+}
+
+func toSLAPolicyModel(row tasks.GetSLAPoliciesRow) *models.SLAPolicy {
+	return &models.SLAPolicy{
+		ID:                row.ID,
+		Name:              row.Name,
+		Tag:               row.Tag,
+		TagID:             row.TagID,
+		ResolutionMinutes: int(row.ResolutionMinutes),
+		AtRiskMinutes:     int(row.AtRiskMinutes),
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+}
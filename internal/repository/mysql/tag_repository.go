@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+const (
+	// errDuplicateEntry is the MySQL error number of a unique key violation
+	errDuplicateEntry = 1062
+	// errDeadlock is the MySQL error number of a transaction rolled back to
+	// break a deadlock
+	errDeadlock = 1213
+)
+
+type tagRepository struct {
+	query tasks.Queries
+}
+
+func NewTagRepository(db *sql.DB) repository.TagRepository {
+	return &tagRepository{query: *tasks.New(db)}
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	id, err := r.query.CreateTag(ctx, tasks.CreateTagParams{
+		Name:        tag.Name,
+		Category:    tag.Category,
+		Description: tag.Description,
+	})
+	if err != nil {
+		return translateDuplicate(err)
+	}
+	tag.ID = id
+	return nil
+}
+
+func (r *tagRepository) GetByID(ctx context.Context, id int64) (*models.Tag, error) {
+	tag, err := r.query.GetTag(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTagModel(tag), nil
+}
+
+func (r *tagRepository) GetAll(ctx context.Context) ([]*models.Tag, error) {
+	rows, err := r.query.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toTagModels(rows), nil
+}
+
+func (r *tagRepository) GetByNames(ctx context.Context, names []string) ([]*models.Tag, error) {
+	if len(names) == 0 {
+		return []*models.Tag{}, nil
+	}
+	rows, err := r.query.GetTagsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	return toTagModels(rows), nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	err := r.query.UpdateTag(ctx, tasks.UpdateTagParams{
+		Name:        tag.Name,
+		Category:    tag.Category,
+		Description: tag.Description,
+		ID:          tag.ID,
+	})
+	return translateDuplicate(err)
+}
+
+func (r *tagRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteTag(ctx, id)
+}
+
+func (r *tagRepository) CountTasks(ctx context.Context, technicianID int64) ([]*models.TagCount, error) {
+	rows, err := r.query.CountTasksByTag(ctx, tasks.CountTasksByTagParams{TechnicianID: technicianID})
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]*models.TagCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, &models.TagCount{
+			TagID:     row.ID,
+			Name:      row.Name,
+			Category:  row.Category,
+			TaskCount: row.TaskCount,
+		})
+	}
+	return counts, nil
+}
+
+// translateDuplicate maps a unique key violation to repository.ErrDuplicate
+func translateDuplicate(err error) error {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry {
+		return repository.ErrDuplicate
+	}
+	return err
+}
+
+func toTagModel(tag tasks.Tag) *models.Tag {
+	return &models.Tag{
+		ID:          tag.ID,
+		Name:        tag.Name,
+		Category:    tag.Category,
+		Description: tag.Description,
+		CreatedAt:   tag.CreatedAt.Time,
+	}
+}
+
+func toTagModels(rows []tasks.Tag) []*models.Tag {
+	tags := make([]*models.Tag, 0, len(rows))
+	for _, tag := range rows {
+		tags = append(tags, toTagModel(tag))
+	}
+	return tags
+}
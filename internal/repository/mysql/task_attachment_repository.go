@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type taskAttachmentRepository struct {
+	query tasks.Queries
+}
+
+func NewTaskAttachmentRepository(db *sql.DB) repository.TaskAttachmentRepository {
+	return &taskAttachmentRepository{query: *tasks.New(db)}
+}
+
+func (r *taskAttachmentRepository) Create(ctx context.Context, attachment *models.TaskAttachment) error {
+	id, err := r.query.CreateAttachment(ctx, tasks.CreateAttachmentParams{
+		TaskID:         attachment.TaskID,
+		UploaderID:     attachment.UploaderID,
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		SizeBytes:      attachment.Size,
+		ChecksumSha256: attachment.Checksum,
+		StorageKey:     attachment.StorageKey,
+	})
+	if err != nil {
+		return err
+	}
+	attachment.ID = id
+	return nil
+}
+
+func (r *taskAttachmentRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskAttachment, error) {
+	attachment, err := r.query.GetAttachment(ctx, tasks.GetAttachmentParams{ID: id, TaskID: taskID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTaskAttachmentModel(attachment), nil
+}
+
+func (r *taskAttachmentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskAttachment, error) {
+	rows, err := r.query.GetAttachmentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]*models.TaskAttachment, 0, len(rows))
+	for _, attachment := range rows {
+		attachments = append(attachments, toTaskAttachmentModel(attachment))
+	}
+	return attachments, nil
+}
+
+func (r *taskAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteAttachment(ctx, id)
+}
+
+func (r *taskAttachmentRepository) MarkProcessed(ctx context.Context, attachment *models.TaskAttachment) error {
+	params := tasks.MarkAttachmentProcessedParams{
+		StorageKey:     attachment.StorageKey,
+		SizeBytes:      attachment.Size,
+		ChecksumSha256: attachment.Checksum,
+		Width:          sql.NullInt32{Int32: int32(attachment.Width), Valid: attachment.Width > 0},
+		Height:         sql.NullInt32{Int32: int32(attachment.Height), Valid: attachment.Height > 0},
+		ID:             attachment.ID,
+	}
+	if attachment.CapturedAt != nil {
+		params.CapturedAt = sql.NullTime{Time: *attachment.CapturedAt, Valid: true}
+	}
+	return r.query.MarkAttachmentProcessed(ctx, params)
+}
+
+func toTaskAttachmentModel(attachment tasks.TaskAttachment) *models.TaskAttachment {
+	a := &models.TaskAttachment{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		UploaderID:  attachment.UploaderID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.SizeBytes,
+		Checksum:    attachment.ChecksumSha256,
+		StorageKey:  attachment.StorageKey,
+		Width:       int(attachment.Width.Int32),
+		Height:      int(attachment.Height.Int32),
+		CreatedAt:   attachment.CreatedAt.Time,
+	}
+	if attachment.CapturedAt.Valid {
+		a.CapturedAt = &attachment.CapturedAt.Time
+	}
+	if attachment.ProcessedAt.Valid {
+		a.ProcessedAt = &attachment.ProcessedAt.Time
+	}
+	a.SetThumbnails()
+	return a
+}
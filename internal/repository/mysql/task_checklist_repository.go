@@ -0,0 +1,201 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type taskChecklistRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewTaskChecklistRepository(db *sql.DB) repository.TaskChecklistRepository {
+	return &taskChecklistRepository{db: db, query: *tasks.New(db)}
+}
+
+// Create appends the item and, when item.Position is set, moves it there in
+// the same transaction. Checklist changes bump the version of the task, as
+// its checklist progress is part of it.
+func (r *taskChecklistRepository) Create(ctx context.Context, item *models.TaskChecklistItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	id, err := query.CreateChecklistItem(ctx, tasks.CreateChecklistItemParams{
+		TaskID:   item.TaskID,
+		Text:     item.Text,
+		Required: item.Required,
+		Note:     item.Note,
+	})
+	if err != nil {
+		return err
+	}
+
+	created, err := query.GetChecklistItem(ctx, tasks.GetChecklistItemParams{ID: id, TaskID: item.TaskID})
+	if err != nil {
+		return err
+	}
+	if err := moveChecklistItem(ctx, query, created, item.Position); err != nil {
+		return err
+	}
+	if err := query.BumpVersion(ctx, item.TaskID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	item.ID = id
+	return nil
+}
+
+func (r *taskChecklistRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskChecklistItem, error) {
+	item, err := r.query.GetChecklistItem(ctx, tasks.GetChecklistItemParams{ID: id, TaskID: taskID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTaskChecklistItemModel(item), nil
+}
+
+func (r *taskChecklistRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskChecklistItem, error) {
+	rows, err := r.query.GetChecklistItemsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*models.TaskChecklistItem, 0, len(rows))
+	for _, item := range rows {
+		items = append(items, toTaskChecklistItemModel(item))
+	}
+	return items, nil
+}
+
+func (r *taskChecklistRepository) Count(ctx context.Context, taskID int64) (int, error) {
+	count, err := r.query.CountChecklistItems(ctx, taskID)
+	return int(count), err
+}
+
+// Update saves the item and moves it to item.Position in one transaction
+func (r *taskChecklistRepository) Update(ctx context.Context, item *models.TaskChecklistItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	params := tasks.UpdateChecklistItemParams{
+		Text:     item.Text,
+		Required: item.Required,
+		Done:     item.Done,
+		Note:     item.Note,
+		ID:       item.ID,
+	}
+	if item.CompletedBy != nil {
+		params.CompletedBy = sql.NullInt64{Int64: *item.CompletedBy, Valid: true}
+	}
+	if item.CompletedAt != nil {
+		params.CompletedAt = sql.NullTime{Time: *item.CompletedAt, Valid: true}
+	}
+	if err := query.UpdateChecklistItem(ctx, params); err != nil {
+		return err
+	}
+
+	current, err := query.GetChecklistItem(ctx, tasks.GetChecklistItemParams{ID: item.ID, TaskID: item.TaskID})
+	if err != nil {
+		return err
+	}
+	if err := moveChecklistItem(ctx, query, current, item.Position); err != nil {
+		return err
+	}
+	if err := query.BumpVersion(ctx, item.TaskID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the item and shifts the items after it up by one
+func (r *taskChecklistRepository) Delete(ctx context.Context, item *models.TaskChecklistItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	if err := query.DeleteChecklistItem(ctx, item.ID); err != nil {
+		return err
+	}
+	if err := query.ShiftChecklistItemsUp(ctx, tasks.ShiftChecklistItemsUpParams{
+		TaskID:       item.TaskID,
+		FromPosition: int32(item.Position),
+		ToPosition:   math.MaxInt32,
+	}); err != nil {
+		return err
+	}
+	if err := query.BumpVersion(ctx, item.TaskID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// moveChecklistItem places item at position, shifting the items in between
+// by one. A position of 0 leaves the item where it is.
+func moveChecklistItem(ctx context.Context, query *tasks.Queries, item tasks.TaskChecklistItem, position int) error {
+	to := int32(position)
+	if to == 0 || to == item.Position {
+		return nil
+	}
+
+	var err error
+	if to < item.Position {
+		err = query.ShiftChecklistItemsDown(ctx, tasks.ShiftChecklistItemsDownParams{
+			TaskID:       item.TaskID,
+			FromPosition: to,
+			ToPosition:   item.Position,
+		})
+	} else {
+		err = query.ShiftChecklistItemsUp(ctx, tasks.ShiftChecklistItemsUpParams{
+			TaskID:       item.TaskID,
+			FromPosition: item.Position,
+			ToPosition:   to,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return query.SetChecklistItemPosition(ctx, tasks.SetChecklistItemPositionParams{Position: to, ID: item.ID})
+}
+
+func toTaskChecklistItemModel(item tasks.TaskChecklistItem) *models.TaskChecklistItem {
+	i := &models.TaskChecklistItem{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		Position:  int(item.Position),
+		Text:      item.Text,
+		Required:  item.Required,
+		Done:      item.Done,
+		Note:      item.Note,
+		CreatedAt: item.CreatedAt.Time,
+		UpdatedAt: item.UpdatedAt.Time,
+	}
+	if item.CompletedBy.Valid {
+		i.CompletedBy = &item.CompletedBy.Int64
+	}
+	if item.CompletedAt.Valid {
+		i.CompletedAt = &item.CompletedAt.Time
+	}
+	return i
+}
@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type taskCommentRepository struct {
+	query tasks.Queries
+}
+
+func NewTaskCommentRepository(db *sql.DB) repository.TaskCommentRepository {
+	return &taskCommentRepository{query: *tasks.New(db)}
+}
+
+func (r *taskCommentRepository) Create(ctx context.Context, comment *models.TaskComment) error {
+	id, err := r.query.CreateComment(ctx, tasks.CreateCommentParams{
+		TaskID:   comment.TaskID,
+		AuthorID: comment.AuthorID,
+		Body:     comment.Body,
+	})
+	if err != nil {
+		return err
+	}
+	comment.ID = id
+	return nil
+}
+
+func (r *taskCommentRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskComment, error) {
+	comment, err := r.query.GetComment(ctx, tasks.GetCommentParams{ID: id, TaskID: taskID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTaskCommentModel(comment), nil
+}
+
+func (r *taskCommentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskComment, error) {
+	rows, err := r.query.GetCommentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]*models.TaskComment, 0, len(rows))
+	for _, comment := range rows {
+		comments = append(comments, toTaskCommentModel(comment))
+	}
+	return comments, nil
+}
+
+func (r *taskCommentRepository) Update(ctx context.Context, comment *models.TaskComment) error {
+	return r.query.UpdateComment(ctx, tasks.UpdateCommentParams{
+		Body: comment.Body,
+		ID:   comment.ID,
+	})
+}
+
+func (r *taskCommentRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteComment(ctx, id)
+}
+
+func toTaskCommentModel(comment tasks.TaskComment) *models.TaskComment {
+	c := &models.TaskComment{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.Time,
+	}
+	if comment.EditedAt.Valid {
+		c.EditedAt = &comment.EditedAt.Time
+	}
+	return c
+}
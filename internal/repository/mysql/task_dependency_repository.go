@@ -0,0 +1,144 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+type taskDependencyRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewTaskDependencyRepository(db *sql.DB) repository.TaskDependencyRepository {
+	return &taskDependencyRepository{db: db, query: *tasks.New(db)}
+}
+
+// Create checks for a cycle and inserts the dependency in a serializable
+// transaction: the cycle check takes shared locks on the dependencies it
+// walks, so two concurrent links closing a cycle together cannot both commit.
+// One of them is rolled back as a deadlock instead; it is retried once, and
+// then sees the other link and reports the cycle.
+func (r *taskDependencyRepository) Create(ctx context.Context, dependency *models.TaskDependency, createdBy int64) error {
+	err := r.create(ctx, dependency, createdBy)
+	if isDeadlock(err) {
+		err = r.create(ctx, dependency, createdBy)
+	}
+	return err
+}
+
+func (r *taskDependencyRepository) create(ctx context.Context, dependency *models.TaskDependency, createdBy int64) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	prerequisites, err := query.GetPrerequisiteIDs(ctx, dependency.DependsOnID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(prerequisites, dependency.TaskID) {
+		return repository.ErrCycle
+	}
+
+	if err := query.CreateTaskDependency(ctx, tasks.CreateTaskDependencyParams{
+		TaskID:      dependency.TaskID,
+		DependsOnID: dependency.DependsOnID,
+		CreatedBy:   sql.NullInt64{Int64: createdBy, Valid: true},
+	}); err != nil {
+		return translateDuplicate(err)
+	}
+	// The task is now blocked by its prerequisite
+	if err := query.BumpVersion(ctx, dependency.TaskID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// isDeadlock reports whether err rolled the transaction back to break a
+// deadlock
+func isDeadlock(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDeadlock
+}
+
+// Delete removes the dependency and bumps the version of the task, which is
+// no longer blocked by the prerequisite, in the same transaction
+func (r *taskDependencyRepository) Delete(ctx context.Context, dependency *models.TaskDependency) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	deleted, err := query.DeleteTaskDependency(ctx, tasks.DeleteTaskDependencyParams{
+		TaskID:      dependency.TaskID,
+		DependsOnID: dependency.DependsOnID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if deleted == 0 {
+		return false, nil
+	}
+	if err := query.BumpVersion(ctx, dependency.TaskID); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetGraph leaves out tasks in the trash and the dependencies touching them
+func (r *taskDependencyRepository) GetGraph(ctx context.Context, taskID int64) (*models.TaskDependencyGraph, error) {
+	edges, err := r.query.GetDependencyGraphEdges(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int64{taskID}
+	for _, edge := range edges {
+		ids = append(ids, edge.TaskID, edge.DependsOnID)
+	}
+	slices.Sort(ids)
+	nodes, err := r.query.GetDependencyNodes(ctx, slices.Compact(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &models.TaskDependencyGraph{
+		TaskID: taskID,
+		Nodes:  make([]*models.TaskDependencyNode, 0, len(nodes)),
+		Edges:  make([]*models.TaskDependency, 0, len(edges)),
+	}
+	active := make(map[int64]bool, len(nodes))
+	for _, node := range nodes {
+		active[node.ID] = true
+		graph.Nodes = append(graph.Nodes, &models.TaskDependencyNode{
+			ID:           node.ID,
+			TechnicianID: node.TechnicianID,
+			Title:        node.Title,
+			Status:       string(node.Status),
+		})
+	}
+	for _, edge := range edges {
+		if active[edge.TaskID] && active[edge.DependsOnID] {
+			graph.Edges = append(graph.Edges, &models.TaskDependency{
+				TaskID:      edge.TaskID,
+				DependsOnID: edge.DependsOnID,
+			})
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"sword-challenge/internal/models"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDependencyRepository_CreateRetriesDeadlock(t *testing.T) {
+	fake := newFakeDB(map[string]int64{})
+	// A concurrent link closing a cycle makes MySQL roll back the first try
+	fake.fail = func(n int, statement fakeStatement) error {
+		if n == 1 {
+			return &mysqldriver.MySQLError{Number: errDeadlock, Message: "Deadlock found when trying to get lock"}
+		}
+		return nil
+	}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	err := NewTaskDependencyRepository(db).Create(context.Background(), &models.TaskDependency{TaskID: 1, DependsOnID: 2}, 3)
+
+	require.NoError(t, err)
+	require.Len(t, fake.committed, 2)
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, statementArgs(fake.committed[0]))
+	// The linked task gets a new version along with the dependency
+	assert.Contains(t, fake.committed[1].query, "version = version + 1")
+	assert.Equal(t, []interface{}{int64(1)}, statementArgs(fake.committed[1]))
+}
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type taskMaterialRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewTaskMaterialRepository(db *sql.DB) repository.TaskMaterialRepository {
+	return &taskMaterialRepository{db: db, query: *tasks.New(db)}
+}
+
+func (r *taskMaterialRepository) Create(ctx context.Context, material *models.TaskMaterial) (*models.Part, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	part, err := moveStock(ctx, query, &models.StockMovement{
+		PartID:    material.PartID,
+		TaskID:    &material.TaskID,
+		Quantity:  -material.Quantity,
+		Reason:    models.StockReasonConsumption,
+		Note:      material.Note,
+		CreatedBy: material.CreatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := query.CreateTaskMaterial(ctx, tasks.CreateTaskMaterialParams{
+		TaskID:    material.TaskID,
+		PartID:    material.PartID,
+		Quantity:  int32(material.Quantity),
+		Note:      material.Note,
+		CreatedBy: toNullInt64(material.CreatedBy),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	material.ID = id
+	material.SKU = part.SKU
+	material.Name = part.Name
+	material.Unit = part.Unit
+	return part, nil
+}
+
+func (r *taskMaterialRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskMaterial, error) {
+	row, err := r.query.GetTaskMaterial(ctx, tasks.GetTaskMaterialParams{ID: id, TaskID: taskID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTaskMaterialModel(tasks.GetTaskMaterialsByTaskIDRow(row)), nil
+}
+
+func (r *taskMaterialRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskMaterial, error) {
+	rows, err := r.query.GetTaskMaterialsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	materials := make([]*models.TaskMaterial, 0, len(rows))
+	for _, row := range rows {
+		materials = append(materials, toTaskMaterialModel(row))
+	}
+	return materials, nil
+}
+
+// Delete only puts the quantity back when it removed the row, so a material
+// removed twice concurrently is returned to stock once
+func (r *taskMaterialRepository) Delete(ctx context.Context, material *models.TaskMaterial, deletedBy int64) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	deleted, err := query.DeleteTaskMaterial(ctx, material.ID)
+	if err != nil {
+		return false, err
+	}
+	if deleted == 0 {
+		return false, nil
+	}
+
+	if _, err := moveStock(ctx, query, &models.StockMovement{
+		PartID:    material.PartID,
+		TaskID:    &material.TaskID,
+		Quantity:  material.Quantity,
+		Reason:    models.StockReasonReturn,
+		CreatedBy: &deletedBy,
+	}); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func toTaskMaterialModel(row tasks.GetTaskMaterialsByTaskIDRow) *models.TaskMaterial {
+	m := &models.TaskMaterial{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		PartID:    row.PartID,
+		SKU:       row.Sku,
+		Name:      row.Name,
+		Unit:      row.Unit,
+		Quantity:  int(row.Quantity),
+		Note:      row.Note,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.CreatedBy.Valid {
+		m.CreatedBy = &row.CreatedBy.Int64
+	}
+	return m
+}
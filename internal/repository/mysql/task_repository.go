@@ -6,55 +6,102 @@ import (
 	"sword-challenge/internal/models"
 	"sword-challenge/internal/repository"
 	"sword-challenge/internal/repository/mysql/tasks"
+	"time"
 )
 
 type taskRepository struct {
+	db    *sql.DB
 	query tasks.Queries
 }
 
 func NewTaskRepository(db *sql.DB) repository.TaskRepository {
-	return &taskRepository{query: *tasks.New(db)}
+	return &taskRepository{db: db, query: *tasks.New(db)}
 }
 
+// Create inserts the task, its tags, its checklist, its location and its
+// first revision in one transaction
 func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
-	return r.query.Create(ctx, tasks.CreateParams{
-		TechnicianID: task.TechnicianID,
-		Title:        task.Title,
-		Summary:      task.Summary,
-		PerformedAt:  task.PerformedAt,
-	})
-}
-
-func (r *taskRepository) GetLastInsertTask(ctx context.Context) (*models.Task, error) {
-	task, err := r.query.GetLastInsertTask(ctx)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &models.Task{
-		ID:           task.ID,
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	params := tasks.CreateParams{
 		TechnicianID: task.TechnicianID,
 		Title:        task.Title,
 		Summary:      task.Summary,
 		PerformedAt:  task.PerformedAt,
-		CreatedAt:    task.CreatedAt.Time,
-		UpdatedAt:    task.UpdatedAt.Time,
-	}, nil
+		Status:       tasks.TasksStatus(task.Status),
+		Priority:     tasks.TasksPriority(task.Priority),
+	}
+	if task.Template != nil {
+		params.TemplateID = sql.NullInt64{Int64: task.Template.ID, Valid: true}
+		params.TemplateRevision = sql.NullInt32{Int32: int32(task.Template.Revision), Valid: true}
+	}
+	if task.RecurringTaskID != nil && task.ScheduledFor != nil {
+		params.RecurringTaskID = sql.NullInt64{Int64: *task.RecurringTaskID, Valid: true}
+		params.ScheduledFor = sql.NullTime{Time: *task.ScheduledFor, Valid: true}
+	}
+	params.DueAt = toNullTime(task.DueAt)
+	params.AtRiskAt = toNullTime(task.AtRiskAt)
+	params.SlaPolicyID = toNullInt64(task.SLAPolicyID)
+	params.SiteID = toNullInt64(task.SiteID)
+	params.AssetID = toNullInt64(task.AssetID)
+	id, err := query.Create(ctx, params)
+	if err != nil {
+		return translateDuplicate(err)
+	}
+
+	if len(task.Tags) > 0 {
+		if err := query.AddTaskTags(ctx, tasks.AddTaskTagsParams{TaskID: id, Names: task.Tags}); err != nil {
+			return err
+		}
+	}
+
+	// The revision records the tags, so it is written once they are
+	if err := query.CreateRevision(ctx, tasks.CreateRevisionParams{
+		TaskID:   id,
+		EditorID: task.TechnicianID,
+		Action:   tasks.TaskRevisionsActionCreate,
+	}); err != nil {
+		return err
+	}
+
+	for _, item := range task.ChecklistItems {
+		if _, err := query.CreateChecklistItem(ctx, tasks.CreateChecklistItemParams{
+			TaskID:   id,
+			Text:     item.Text,
+			Required: item.Required,
+			Note:     item.Note,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if task.Location != nil {
+		if err := saveTaskLocation(ctx, query, id, task.Location); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	task.ID = id
+	return nil
 }
 
 func (r *taskRepository) GetByID(ctx context.Context, id int64) (*models.Task, error) {
 	task, err := r.query.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &models.Task{
-		ID:           task.ID,
-		TechnicianID: task.TechnicianID,
-		Title:        task.Title,
-		Summary:      task.Summary,
-		PerformedAt:  task.PerformedAt,
-		CreatedAt:    task.CreatedAt.Time,
-		UpdatedAt:    task.UpdatedAt.Time,
-	}, nil
+	return r.withDetails(ctx, toTaskModel(task))
 }
 
 func (r *taskRepository) GetByTechnicianID(ctx context.Context, technicianID int64) ([]*models.Task, error) {
@@ -62,16 +109,7 @@ func (r *taskRepository) GetByTechnicianID(ctx context.Context, technicianID int
 	if err != nil {
 		return nil, err
 	}
-	tasks := make([]*models.Task, 0, len(tallTasks))
-	for _, task := range tallTasks {
-		tasks = append(tasks, &models.Task{
-			ID:           task.ID,
-			TechnicianID: task.TechnicianID,
-			Title:        task.Title,
-			Summary:      task.Summary,
-		})
-	}
-	return tasks, nil
+	return r.withDetailsAll(ctx, toTaskModels(tallTasks))
 }
 
 func (r *taskRepository) GetAll(ctx context.Context) ([]*models.Task, error) {
@@ -79,27 +117,499 @@ func (r *taskRepository) GetAll(ctx context.Context) ([]*models.Task, error) {
 	if err != nil {
 		return nil, err
 	}
-	tasks := make([]*models.Task, 0, len(tallTasks))
-	for _, task := range tallTasks {
-		tasks = append(tasks, &models.Task{
-			ID:           task.ID,
-			TechnicianID: task.TechnicianID,
-			Title:        task.Title,
-			Summary:      task.Summary,
-		})
+	return r.withDetailsAll(ctx, toTaskModels(tallTasks))
+}
+
+// GetByTags returns the active tasks with any of the tags, or all of them when
+// matchAll is set. A technicianID of 0 means every technician.
+func (r *taskRepository) GetByTags(ctx context.Context, technicianID int64, tags []string, matchAll bool) ([]*models.Task, error) {
+	minMatches := 1
+	if matchAll {
+		minMatches = len(tags)
 	}
-	return tasks, nil
+	tallTasks, err := r.query.GetByTags(ctx, tasks.GetByTagsParams{
+		TechnicianID: technicianID,
+		Names:        tags,
+		MinMatches:   minMatches,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withDetailsAll(ctx, toTaskModels(tallTasks))
+}
+
+// GetByAssetID returns the active tasks performed on the asset, most recent
+// first. A technicianID of 0 means every technician.
+func (r *taskRepository) GetByAssetID(ctx context.Context, assetID int64, technicianID int64) ([]*models.Task, error) {
+	tallTasks, err := r.query.GetByAssetID(ctx, tasks.GetByAssetIDParams{
+		AssetID:      sql.NullInt64{Int64: assetID, Valid: true},
+		TechnicianID: technicianID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withDetailsAll(ctx, toTaskModels(tallTasks))
 }
 
-func (r *taskRepository) Update(ctx context.Context, task *models.Task) error {
-	return r.query.Update(ctx, tasks.UpdateParams{
+// Update writes the task if its version still matches task.Version and
+// records the new version as a revision in the same transaction. Tags and the
+// location are replaced unless task.Tags and task.Location are nil. On success
+// task.Version holds the new version; otherwise ErrVersionConflict is
+// returned, or ErrChecklistOpen or ErrBlocked when the task would be
+// completed with required checklist items or prerequisites open.
+func (r *taskRepository) Update(ctx context.Context, task *models.Task, editorID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	if task.Status == models.TaskStatusCompleted {
+		if err := checkCompletable(ctx, query, task); err != nil {
+			return err
+		}
+	}
+
+	updated, err := query.Update(ctx, tasks.UpdateParams{
 		ID:          task.ID,
 		Title:       task.Title,
 		Summary:     task.Summary,
 		PerformedAt: task.PerformedAt,
+		Status:      tasks.TasksStatus(task.Status),
+		Priority:    tasks.TasksPriority(task.Priority),
+		SiteID:      toNullInt64(task.SiteID),
+		AssetID:     toNullInt64(task.AssetID),
+		DueAt:       toNullTime(task.DueAt),
+		AtRiskAt:    toNullTime(task.AtRiskAt),
+		Version:     int32(task.Version),
 	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return repository.ErrVersionConflict
+	}
+
+	if task.Tags != nil {
+		if err := query.DeleteTaskTags(ctx, task.ID); err != nil {
+			return err
+		}
+		if len(task.Tags) > 0 {
+			if err := query.AddTaskTags(ctx, tasks.AddTaskTagsParams{TaskID: task.ID, Names: task.Tags}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The revision records the tags, so it is written once they are
+	if err := query.CreateRevision(ctx, tasks.CreateRevisionParams{
+		TaskID:   task.ID,
+		EditorID: editorID,
+		Action:   tasks.TaskRevisionsActionUpdate,
+	}); err != nil {
+		return err
+	}
+
+	if task.Location != nil {
+		if err := saveTaskLocation(ctx, query, task.ID, task.Location); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	task.Version++
+	return nil
 }
 
+// checkCompletable locks the task and, unless it is already completed, makes
+// sure its required checklist items and the tasks it depends on are done. The
+// rows read stay locked until the transaction ends, so none can be reopened
+// before the task is saved.
+func checkCompletable(ctx context.Context, query *tasks.Queries, task *models.Task) error {
+	current, err := query.LockTask(ctx, task.ID)
+	if err == sql.ErrNoRows || (err == nil && int(current.Version) != task.Version) {
+		return repository.ErrVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	if current.Status == tasks.TasksStatusCompleted {
+		return nil
+	}
+
+	open, err := query.CountOpenRequiredItems(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return repository.ErrChecklistOpen
+	}
+
+	prerequisites, err := query.CountOpenPrerequisites(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if prerequisites > 0 {
+		return repository.ErrBlocked
+	}
+	return nil
+}
+
+// Delete moves the task to the trash; it can be restored until it is purged
 func (r *taskRepository) Delete(ctx context.Context, id int64) error {
 	return r.query.Delete(ctx, id)
 }
+
+func (r *taskRepository) GetDeletedByID(ctx context.Context, id int64) (*models.Task, error) {
+	task, err := r.query.GetDeletedByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withDetails(ctx, toTaskModel(task))
+}
+
+func (r *taskRepository) GetDeleted(ctx context.Context) ([]*models.Task, error) {
+	tallTasks, err := r.query.GetDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withDetailsAll(ctx, toTaskModels(tallTasks))
+}
+
+func (r *taskRepository) Restore(ctx context.Context, id int64) error {
+	return r.query.Restore(ctx, id)
+}
+
+// PurgeDeleted permanently removes up to limit tasks trashed before the given
+// time, together with their notifications and attachment rows (ON DELETE
+// CASCADE). The attachment blob keys are returned so the files can be removed.
+func (r *taskRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, []string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	ids, err := query.GetDeletedIDsBefore(ctx, tasks.GetDeletedIDsBeforeParams{
+		DeletedAt: sql.NullTime{Time: before, Valid: true},
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(ids) == 0 {
+		return 0, nil, nil
+	}
+
+	keys, err := query.GetAttachmentKeysByTaskIDs(ctx, ids)
+	if err != nil {
+		return 0, nil, err
+	}
+	purged, err := query.PurgeByIDs(ctx, ids)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, err
+	}
+	return purged, keys, nil
+}
+
+func (r *taskRepository) GetUnblockedDependents(ctx context.Context, id int64) ([]*models.Task, error) {
+	rows, err := r.query.GetUnblockedDependents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dependents := make([]*models.Task, 0, len(rows))
+	for _, row := range rows {
+		dependents = append(dependents, &models.Task{
+			ID:           row.ID,
+			TechnicianID: row.TechnicianID,
+			Title:        row.Title,
+			Status:       string(row.Status),
+		})
+	}
+	return dependents, nil
+}
+
+// ReviewLocation bumps the version of the task along with the review, as the
+// review is part of the task
+func (r *taskRepository) ReviewLocation(ctx context.Context, taskID int64, reviewerID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	reviewed, err := query.ReviewTaskLocation(ctx, tasks.ReviewTaskLocationParams{
+		ReviewedBy: sql.NullInt64{Int64: reviewerID, Valid: true},
+		TaskID:     taskID,
+	})
+	if err != nil {
+		return err
+	}
+	if reviewed == 0 {
+		return repository.ErrVersionConflict
+	}
+	if err := query.BumpVersion(ctx, taskID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PurgeLocations clears the coordinates of up to limit task locations
+// recorded before the given time, oldest first. The geofence outcome and
+// distance are kept.
+func (r *taskRepository) PurgeLocations(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.query.ClearTaskLocationCoordinates(ctx, tasks.ClearTaskLocationCoordinatesParams{
+		RecordedAt: before,
+		Limit:      int32(limit),
+	})
+}
+
+func (r *taskRepository) withDetails(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := loadTaskDetails(ctx, &r.query, []*models.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *taskRepository) withDetailsAll(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	if err := loadTaskDetails(ctx, &r.query, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// loadTaskDetails fills the tags, checklist progress, blocking prerequisites
+// and location of each task
+func loadTaskDetails(ctx context.Context, query *tasks.Queries, taskModels []*models.Task) error {
+	if err := loadTaskTags(ctx, query, taskModels); err != nil {
+		return err
+	}
+	if err := loadChecklistProgress(ctx, query, taskModels); err != nil {
+		return err
+	}
+	if err := loadBlockingTasks(ctx, query, taskModels); err != nil {
+		return err
+	}
+	return loadTaskLocations(ctx, query, taskModels)
+}
+
+// loadTaskTags fills the Tags of each task with a single query
+func loadTaskTags(ctx context.Context, query *tasks.Queries, taskModels []*models.Task) error {
+	if len(taskModels) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*models.Task, len(taskModels))
+	ids := make([]int64, 0, len(taskModels))
+	for _, task := range taskModels {
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	rows, err := query.GetTagNamesByTaskIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if task, ok := byID[row.TaskID]; ok {
+			task.Tags = append(task.Tags, row.Name)
+		}
+	}
+	return nil
+}
+
+// loadChecklistProgress fills the Checklist of each task with a single query;
+// tasks without checklist items keep a zero progress
+func loadChecklistProgress(ctx context.Context, query *tasks.Queries, taskModels []*models.Task) error {
+	if len(taskModels) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*models.Task, len(taskModels))
+	ids := make([]int64, 0, len(taskModels))
+	for _, task := range taskModels {
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	rows, err := query.GetChecklistProgressByTaskIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if task, ok := byID[row.TaskID]; ok {
+			task.Checklist = models.NewChecklistProgress(int(row.Total), int(row.Done), int(row.RequiredOpen))
+		}
+	}
+	return nil
+}
+
+// loadBlockingTasks fills the BlockedBy of each task with a single query
+func loadBlockingTasks(ctx context.Context, query *tasks.Queries, taskModels []*models.Task) error {
+	if len(taskModels) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*models.Task, len(taskModels))
+	ids := make([]int64, 0, len(taskModels))
+	for _, task := range taskModels {
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	rows, err := query.GetBlockingTaskIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if task, ok := byID[row.TaskID]; ok {
+			task.BlockedBy = append(task.BlockedBy, row.DependsOnID)
+		}
+	}
+	return nil
+}
+
+// loadTaskLocations fills the Location of each task with a single query
+func loadTaskLocations(ctx context.Context, query *tasks.Queries, taskModels []*models.Task) error {
+	if len(taskModels) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*models.Task, len(taskModels))
+	ids := make([]int64, 0, len(taskModels))
+	for _, task := range taskModels {
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	rows, err := query.GetTaskLocationsByTaskIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if task, ok := byID[row.TaskID]; ok {
+			task.Location = toTaskLocationModel(row)
+		}
+	}
+	return nil
+}
+
+// saveTaskLocation saves the location of the task, keeping its review unless
+// the coordinates changed
+func saveTaskLocation(ctx context.Context, query *tasks.Queries, taskID int64, location *models.TaskLocation) error {
+	params := tasks.SaveTaskLocationParams{
+		TaskID:     taskID,
+		Latitude:   toNullFloat64(location.Latitude),
+		Longitude:  toNullFloat64(location.Longitude),
+		Accuracy:   toNullFloat64(location.Accuracy),
+		RecordedAt: location.RecordedAt,
+		Geofence:   tasks.TaskLocationsGeofence(location.Geofence),
+	}
+	if location.Distance != nil {
+		params.Distance = sql.NullInt32{Int32: int32(*location.Distance), Valid: true}
+	}
+	return query.SaveTaskLocation(ctx, params)
+}
+
+func toTaskLocationModel(row tasks.TaskLocation) *models.TaskLocation {
+	l := &models.TaskLocation{
+		RecordedAt: row.RecordedAt,
+		Geofence:   string(row.Geofence),
+	}
+	if row.Latitude.Valid && row.Longitude.Valid {
+		l.Latitude = &row.Latitude.Float64
+		l.Longitude = &row.Longitude.Float64
+	}
+	if row.Accuracy.Valid {
+		l.Accuracy = &row.Accuracy.Float64
+	}
+	if row.Distance.Valid {
+		distance := int(row.Distance.Int32)
+		l.Distance = &distance
+	}
+	if row.ReviewedBy.Valid {
+		l.ReviewedBy = &row.ReviewedBy.Int64
+	}
+	if row.ReviewedAt.Valid {
+		l.ReviewedAt = &row.ReviewedAt.Time
+	}
+	return l
+}
+
+func toTaskModel(task tasks.Task) *models.Task {
+	t := &models.Task{
+		ID:           task.ID,
+		TechnicianID: task.TechnicianID,
+		Title:        task.Title,
+		Summary:      task.Summary,
+		PerformedAt:  task.PerformedAt,
+		Status:       string(task.Status),
+		Priority:     string(task.Priority),
+		Version:      int(task.Version),
+		CreatedAt:    task.CreatedAt.Time,
+		UpdatedAt:    task.UpdatedAt.Time,
+		Tags:         []string{},
+	}
+	if task.TemplateID.Valid {
+		t.Template = &models.TaskTemplateRef{ID: task.TemplateID.Int64, Revision: int(task.TemplateRevision.Int32)}
+	}
+	if task.RecurringTaskID.Valid {
+		t.RecurringTaskID = &task.RecurringTaskID.Int64
+	}
+	if task.ScheduledFor.Valid {
+		t.ScheduledFor = &task.ScheduledFor.Time
+	}
+	if task.DueAt.Valid {
+		t.DueAt = &task.DueAt.Time
+	}
+	if task.AtRiskAt.Valid {
+		t.AtRiskAt = &task.AtRiskAt.Time
+	}
+	if task.SlaPolicyID.Valid {
+		t.SLAPolicyID = &task.SlaPolicyID.Int64
+	}
+	if task.CompletedAt.Valid {
+		t.CompletedAt = &task.CompletedAt.Time
+	}
+	if task.ClaimedBy.Valid {
+		t.ClaimedBy = &task.ClaimedBy.Int64
+	}
+	if task.ClaimedAt.Valid {
+		t.ClaimedAt = &task.ClaimedAt.Time
+	}
+	if task.SiteID.Valid {
+		t.SiteID = &task.SiteID.Int64
+	}
+	if task.AssetID.Valid {
+		t.AssetID = &task.AssetID.Int64
+	}
+	if task.DeletedAt.Valid {
+		t.DeletedAt = &task.DeletedAt.Time
+	}
+	t.RenderSummary()
+	t.UpdateSLAStatus(time.Now())
+	return t
+}
+
+// toNullTime maps an optional time to a nullable column
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func toTaskModels(tallTasks []tasks.Task) []*models.Task {
+	tasks := make([]*models.Task, 0, len(tallTasks))
+	for _, task := range tallTasks {
+		tasks = append(tasks, toTaskModel(task))
+	}
+	return tasks
+}
@@ -0,0 +1,280 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatement is a statement run on fakeDB
+type fakeStatement struct {
+	query string
+	args  []driver.NamedValue
+}
+
+// fakeDB is an in-memory database/sql driver that gives every table its own
+// auto-increment counter, like MySQL. Statements are only recorded once their
+// transaction commits. When fail is set, the statement it returns an error for
+// fails. Queries return the row rows gives for them, or none.
+type fakeDB struct {
+	mu        sync.Mutex
+	nextID    map[string]int64
+	committed []fakeStatement
+	queried   []string
+	executed  int
+	fail      func(n int, statement fakeStatement) error
+	rows      func(query string) []driver.Value
+}
+
+func newFakeDB(firstIDs map[string]int64) *fakeDB {
+	return &fakeDB{nextID: firstIDs}
+}
+
+var insertTable = regexp.MustCompile(`(?is)^\s*(?:--[^\n]*\n\s*)*(?:INSERT|REPLACE)\s+INTO\s+(\w+)`)
+
+func (db *fakeDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{db: db}, nil
+}
+
+func (db *fakeDB) Driver() driver.Driver {
+	return db
+}
+
+func (db *fakeDB) Open(name string) (driver.Conn, error) {
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db      *fakeDB
+	pending []fakeStatement
+	inTx    bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeDB does not prepare statements")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	return c, nil
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Begin()
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	statement := fakeStatement{query: query, args: args}
+	c.db.executed++
+	if c.db.fail != nil {
+		if err := c.db.fail(c.db.executed, statement); err != nil {
+			return nil, err
+		}
+	}
+
+	var id int64
+	if match := insertTable.FindStringSubmatch(query); match != nil {
+		id = c.db.nextID[match[1]]
+		c.db.nextID[match[1]] = id + 1
+	}
+	if c.inTx {
+		c.pending = append(c.pending, statement)
+	} else {
+		c.db.committed = append(c.db.committed, statement)
+	}
+	return fakeResult{id: id}, nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.queried = append(c.db.queried, query)
+	rows := &fakeRows{}
+	if c.db.rows != nil {
+		if row := c.db.rows(query); row != nil {
+			rows.values = [][]driver.Value{row}
+		}
+	}
+	return rows, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.committed = append(c.db.committed, c.pending...)
+	c.pending, c.inTx = nil, false
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.pending, c.inTx = nil, false
+	return nil
+}
+
+// fakeResult reports the ID of the row inserted by a statement
+type fakeResult struct {
+	id int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) {
+	return r.id, nil
+}
+
+func (r fakeResult) RowsAffected() (int64, error) {
+	return 1, nil
+}
+
+// fakeRows returns values; columns are only counted, never named
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	return make([]string, len(r.values[0]))
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestTaskRepository_Create(t *testing.T) {
+	// Each table counts from elsewhere, so taking the ID of the revision or
+	// of a checklist item inserted after the task for the task's would show
+	fake := newFakeDB(map[string]int64{
+		"tasks":                7,
+		"task_revisions":       40,
+		"task_checklist_items": 90,
+	})
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	latitude, longitude := 38.7223, -9.1393
+	task := &models.Task{
+		TechnicianID: 1,
+		Title:        "Replace compressor",
+		Summary:      "Replaced the compressor of the rooftop chiller",
+		PerformedAt:  time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC),
+		Status:       models.TaskStatusOpen,
+		Priority:     models.TaskPriorityNormal,
+		Tags:         []string{"hvac"},
+		ChecklistItems: []*models.TaskChecklistItem{
+			{Text: "Isolate power", Required: true},
+			{Text: "Check refrigerant"},
+		},
+		Location: &models.TaskLocation{Latitude: &latitude, Longitude: &longitude, Geofence: models.GeofenceUnverified},
+	}
+
+	require.NoError(t, NewTaskRepository(db).Create(context.Background(), task))
+
+	// The task, its revision, tag, two checklist items and location, in one
+	// transaction, all pointing at the task
+	assert.Equal(t, int64(7), task.ID)
+	require.Len(t, fake.committed, 6)
+	for _, statement := range fake.committed[1:] {
+		assert.Contains(t, statementArgs(statement), int64(7), statement.query)
+	}
+	for _, statement := range fake.committed {
+		assert.NotRegexp(t, `(?i)LAST_INSERT_ID`, statement.query)
+	}
+}
+
+func statementArgs(statement fakeStatement) []interface{} {
+	args := make([]interface{}, 0, len(statement.args))
+	for _, arg := range statement.args {
+		args = append(args, arg.Value)
+	}
+	return args
+}
+
+func TestTaskRepository_UpdateCompleting(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		version       int64
+		requiredOpen  int64
+		prerequisites int64
+		expectedError error
+	}{
+		{name: "required items and prerequisites done", status: "open", version: 3},
+		{name: "required items open", status: "open", version: 3, requiredOpen: 1, expectedError: repository.ErrChecklistOpen},
+		{name: "prerequisites open", status: "open", version: 3, prerequisites: 2, expectedError: repository.ErrBlocked},
+		{name: "already completed", status: "completed", version: 3, requiredOpen: 1},
+		{name: "stale version", status: "open", version: 4, expectedError: repository.ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(map[string]int64{})
+			fake.rows = func(query string) []driver.Value {
+				switch {
+				case regexp.MustCompile(`name: LockTask\b`).MatchString(query):
+					return []driver.Value{[]byte(tt.status), tt.version}
+				case regexp.MustCompile(`name: CountOpenRequiredItems\b`).MatchString(query):
+					return []driver.Value{tt.requiredOpen}
+				case regexp.MustCompile(`name: CountOpenPrerequisites\b`).MatchString(query):
+					return []driver.Value{tt.prerequisites}
+				}
+				return nil
+			}
+			db := sql.OpenDB(fake)
+			defer db.Close()
+
+			task := &models.Task{
+				ID:          1,
+				Title:       "Replace compressor",
+				Summary:     "Replaced the compressor of the rooftop chiller",
+				PerformedAt: time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC),
+				Status:      models.TaskStatusCompleted,
+				Priority:    models.TaskPriorityNormal,
+				Version:     3,
+			}
+			err := NewTaskRepository(db).Update(context.Background(), task, 1)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				assert.Empty(t, fake.committed)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, fake.committed)
+			// Checked with locking reads in the transaction saving the task
+			assert.Regexp(t, `FOR UPDATE`, fake.queried[0])
+			if tt.status == "open" {
+				assert.Regexp(t, `FOR SHARE`, fake.queried[1])
+				assert.Regexp(t, `FOR SHARE`, fake.queried[2])
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+// taskRevisionRepository reads revisions; they are written by taskRepository
+// in the same transaction as the task itself
+type taskRevisionRepository struct {
+	query tasks.Queries
+}
+
+func NewTaskRevisionRepository(db *sql.DB) repository.TaskRevisionRepository {
+	return &taskRevisionRepository{query: *tasks.New(db)}
+}
+
+func (r *taskRevisionRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskRevision, error) {
+	allRevisions, err := r.query.GetRevisionsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*models.TaskRevision, 0, len(allRevisions))
+	for _, revision := range allRevisions {
+		revisions = append(revisions, toTaskRevisionModel(revision))
+	}
+	return revisions, nil
+}
+
+func (r *taskRevisionRepository) GetByRevision(ctx context.Context, taskID int64, revision int) (*models.TaskRevision, error) {
+	taskRevision, err := r.query.GetRevision(ctx, tasks.GetRevisionParams{
+		TaskID:   taskID,
+		Revision: int32(revision),
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTaskRevisionModel(taskRevision), nil
+}
+
+func toTaskRevisionModel(revision tasks.TaskRevision) *models.TaskRevision {
+	r := &models.TaskRevision{
+		ID:          revision.ID,
+		TaskID:      revision.TaskID,
+		Revision:    int(revision.Revision),
+		EditorID:    revision.EditorID,
+		Action:      string(revision.Action),
+		Title:       revision.Title,
+		Summary:     revision.Summary,
+		PerformedAt: revision.PerformedAt,
+		Status:      string(revision.Status),
+		Priority:    string(revision.Priority.TaskRevisionsPriority),
+		CreatedAt:   revision.CreatedAt.Time,
+	}
+	if revision.DueAt.Valid {
+		r.DueAt = &revision.DueAt.Time
+	}
+	if revision.Tags.Valid {
+		r.Tags = []string{}
+		if revision.Tags.String != "" {
+			r.Tags = strings.Split(revision.Tags.String, ",")
+		}
+	}
+	if revision.SiteID.Valid {
+		r.SiteID = &revision.SiteID.Int64
+	}
+	if revision.AssetID.Valid {
+		r.AssetID = &revision.AssetID.Int64
+	}
+	return r
+}
@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+// MATCH ... AGAINST parameters are not understood by sqlc, so the search
+// statements are written by hand. Both use the title_summary FULLTEXT index.
+const (
+	searchTasks = `SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at,
+  MATCH (title, summary) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+FROM tasks
+WHERE deleted_at IS NULL AND MATCH (title, summary) AGAINST (? IN NATURAL LANGUAGE MODE)
+ORDER BY score DESC, id DESC
+LIMIT ?`
+
+	searchTasksByTechnicianID = `SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at,
+  MATCH (title, summary) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+FROM tasks
+WHERE technician_id = ? AND deleted_at IS NULL AND MATCH (title, summary) AGAINST (? IN NATURAL LANGUAGE MODE)
+ORDER BY score DESC, id DESC
+LIMIT ?`
+)
+
+type taskSearchRepository struct {
+	db *sql.DB
+}
+
+func NewTaskSearchRepository(db *sql.DB) repository.TaskSearchRepository {
+	return &taskSearchRepository{db: db}
+}
+
+func (r *taskSearchRepository) Search(ctx context.Context, query models.TaskSearchQuery) ([]*models.TaskSearchHit, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if query.TechnicianID != 0 {
+		rows, err = r.db.QueryContext(ctx, searchTasksByTechnicianID, query.Query, query.TechnicianID, query.Query, query.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, searchTasks, query.Query, query.Query, query.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []*models.TaskSearchHit{}
+	for rows.Next() {
+		var (
+			task  tasks.Task
+			score float64
+		)
+		if err := rows.Scan(
+			&task.ID,
+			&task.TechnicianID,
+			&task.Title,
+			&task.Summary,
+			&task.PerformedAt,
+			&task.Status,
+			&task.Priority,
+			&task.TemplateID,
+			&task.TemplateRevision,
+			&task.RecurringTaskID,
+			&task.ScheduledFor,
+			&task.DueAt,
+			&task.AtRiskAt,
+			&task.SlaPolicyID,
+			&task.SlaEscalation,
+			&task.CompletedAt,
+			&task.ClaimedBy,
+			&task.ClaimedAt,
+			&task.SiteID,
+			&task.AssetID,
+			&task.Version,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.DeletedAt,
+			&score,
+		); err != nil {
+			return nil, err
+		}
+		hits = append(hits, &models.TaskSearchHit{Task: toTaskModel(task), Score: score})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	found := make([]*models.Task, 0, len(hits))
+	for _, hit := range hits {
+		found = append(found, hit.Task)
+	}
+	if err := loadTaskDetails(ctx, tasks.New(r.db), found); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
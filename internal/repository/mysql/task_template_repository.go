@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+)
+
+type taskTemplateRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewTaskTemplateRepository(db *sql.DB) repository.TaskTemplateRepository {
+	return &taskTemplateRepository{db: db, query: *tasks.New(db)}
+}
+
+// Create inserts the template and its first revision in one transaction
+func (r *taskTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	id, err := query.CreateTaskTemplate(ctx)
+	if err != nil {
+		return err
+	}
+	if err := createTaskTemplateRevision(ctx, query, id, template); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	template.ID = id
+	template.Revision = 1
+	return nil
+}
+
+func (r *taskTemplateRepository) GetByID(ctx context.Context, id int64) (*models.TaskTemplate, error) {
+	revision, err := r.query.GetTaskTemplate(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTaskTemplateModel(revision)
+}
+
+func (r *taskTemplateRepository) GetAll(ctx context.Context) ([]*models.TaskTemplate, error) {
+	rows, err := r.query.GetTaskTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*models.TaskTemplate, 0, len(rows))
+	for _, row := range rows {
+		template, err := toTaskTemplateModel(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (r *taskTemplateRepository) GetRevision(ctx context.Context, id int64, revision int) (*models.TaskTemplate, error) {
+	row, err := r.query.GetTaskTemplateRevision(ctx, tasks.GetTaskTemplateRevisionParams{
+		TemplateID: id,
+		Revision:   int32(revision),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTaskTemplateModel(row)
+}
+
+// Update bumps the template revision and writes the new revision in one
+// transaction; earlier revisions are kept for the tasks created from them
+func (r *taskTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	updated, err := query.BumpTaskTemplateRevision(ctx, template.ID)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return sql.ErrNoRows
+	}
+	if err := createTaskTemplateRevision(ctx, query, template.ID, template); err != nil {
+		return err
+	}
+
+	current, err := query.GetTaskTemplate(ctx, template.ID)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	template.Revision = int(current.Revision)
+	return nil
+}
+
+// Delete hides the template; its revisions stay for the tasks created from it
+func (r *taskTemplateRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteTaskTemplate(ctx, id)
+}
+
+// createTaskTemplateRevision writes the template content as the template's
+// current revision number
+func createTaskTemplateRevision(ctx context.Context, query *tasks.Queries, id int64, template *models.TaskTemplate) error {
+	checklist := template.Checklist
+	if checklist == nil {
+		checklist = []models.TaskTemplateChecklistItem{}
+	}
+	checklistJSON, err := json.Marshal(checklist)
+	if err != nil {
+		return err
+	}
+	tags := template.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	return query.CreateTaskTemplateRevision(ctx, tasks.CreateTaskTemplateRevisionParams{
+		Name:             template.Name,
+		TitlePattern:     template.TitlePattern,
+		Summary:          template.Summary,
+		Checklist:        checklistJSON,
+		Tags:             tagsJSON,
+		EstimatedMinutes: int32(template.EstimatedMinutes),
+		EditorID:         template.EditorID,
+		TemplateID:       id,
+	})
+}
+
+func toTaskTemplateModel(revision tasks.TaskTemplateRevision) (*models.TaskTemplate, error) {
+	template := &models.TaskTemplate{
+		ID:               revision.TemplateID,
+		Revision:         int(revision.Revision),
+		Name:             revision.Name,
+		TitlePattern:     revision.TitlePattern,
+		Summary:          revision.Summary,
+		EstimatedMinutes: int(revision.EstimatedMinutes),
+		EditorID:         revision.EditorID,
+		CreatedAt:        revision.CreatedAt.Time,
+	}
+	if err := json.Unmarshal(revision.Checklist, &template.Checklist); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(revision.Tags, &template.Tags); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
@@ -7,10 +7,355 @@ package tasks
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
+type StockMovementsReason string
+
+const (
+	StockMovementsReasonRestock     StockMovementsReason = "restock"
+	StockMovementsReasonConsumption StockMovementsReason = "consumption"
+	StockMovementsReasonReturn      StockMovementsReason = "return"
+	StockMovementsReasonAdjustment  StockMovementsReason = "adjustment"
+)
+
+func (e *StockMovementsReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StockMovementsReason(s)
+	case string:
+		*e = StockMovementsReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StockMovementsReason: %T", src)
+	}
+	return nil
+}
+
+type NullStockMovementsReason struct {
+	StockMovementsReason StockMovementsReason
+	Valid                bool // Valid is true if StockMovementsReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStockMovementsReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.StockMovementsReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StockMovementsReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStockMovementsReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StockMovementsReason), nil
+}
+
+type TaskLocationsGeofence string
+
+const (
+	TaskLocationsGeofenceInside     TaskLocationsGeofence = "inside"
+	TaskLocationsGeofenceOutside    TaskLocationsGeofence = "outside"
+	TaskLocationsGeofenceUnverified TaskLocationsGeofence = "unverified"
+)
+
+func (e *TaskLocationsGeofence) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TaskLocationsGeofence(s)
+	case string:
+		*e = TaskLocationsGeofence(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TaskLocationsGeofence: %T", src)
+	}
+	return nil
+}
+
+type NullTaskLocationsGeofence struct {
+	TaskLocationsGeofence TaskLocationsGeofence
+	Valid                 bool // Valid is true if TaskLocationsGeofence is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTaskLocationsGeofence) Scan(value interface{}) error {
+	if value == nil {
+		ns.TaskLocationsGeofence, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TaskLocationsGeofence.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTaskLocationsGeofence) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TaskLocationsGeofence), nil
+}
+
+type TaskRevisionsAction string
+
+const (
+	TaskRevisionsActionCreate TaskRevisionsAction = "create"
+	TaskRevisionsActionUpdate TaskRevisionsAction = "update"
+)
+
+func (e *TaskRevisionsAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TaskRevisionsAction(s)
+	case string:
+		*e = TaskRevisionsAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TaskRevisionsAction: %T", src)
+	}
+	return nil
+}
+
+type NullTaskRevisionsAction struct {
+	TaskRevisionsAction TaskRevisionsAction
+	Valid               bool // Valid is true if TaskRevisionsAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTaskRevisionsAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.TaskRevisionsAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TaskRevisionsAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTaskRevisionsAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TaskRevisionsAction), nil
+}
+
+type TaskRevisionsPriority string
+
+const (
+	TaskRevisionsPriorityLow    TaskRevisionsPriority = "low"
+	TaskRevisionsPriorityNormal TaskRevisionsPriority = "normal"
+	TaskRevisionsPriorityHigh   TaskRevisionsPriority = "high"
+	TaskRevisionsPriorityUrgent TaskRevisionsPriority = "urgent"
+)
+
+func (e *TaskRevisionsPriority) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TaskRevisionsPriority(s)
+	case string:
+		*e = TaskRevisionsPriority(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TaskRevisionsPriority: %T", src)
+	}
+	return nil
+}
+
+type NullTaskRevisionsPriority struct {
+	TaskRevisionsPriority TaskRevisionsPriority
+	Valid                 bool // Valid is true if TaskRevisionsPriority is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTaskRevisionsPriority) Scan(value interface{}) error {
+	if value == nil {
+		ns.TaskRevisionsPriority, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TaskRevisionsPriority.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTaskRevisionsPriority) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TaskRevisionsPriority), nil
+}
+
+type TaskRevisionsStatus string
+
+const (
+	TaskRevisionsStatusOpen      TaskRevisionsStatus = "open"
+	TaskRevisionsStatusCompleted TaskRevisionsStatus = "completed"
+)
+
+func (e *TaskRevisionsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TaskRevisionsStatus(s)
+	case string:
+		*e = TaskRevisionsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TaskRevisionsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTaskRevisionsStatus struct {
+	TaskRevisionsStatus TaskRevisionsStatus
+	Valid               bool // Valid is true if TaskRevisionsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTaskRevisionsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TaskRevisionsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TaskRevisionsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTaskRevisionsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TaskRevisionsStatus), nil
+}
+
+type TasksPriority string
+
+const (
+	TasksPriorityLow    TasksPriority = "low"
+	TasksPriorityNormal TasksPriority = "normal"
+	TasksPriorityHigh   TasksPriority = "high"
+	TasksPriorityUrgent TasksPriority = "urgent"
+)
+
+func (e *TasksPriority) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TasksPriority(s)
+	case string:
+		*e = TasksPriority(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TasksPriority: %T", src)
+	}
+	return nil
+}
+
+type NullTasksPriority struct {
+	TasksPriority TasksPriority
+	Valid         bool // Valid is true if TasksPriority is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTasksPriority) Scan(value interface{}) error {
+	if value == nil {
+		ns.TasksPriority, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TasksPriority.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTasksPriority) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TasksPriority), nil
+}
+
+type TasksSlaEscalation string
+
+const (
+	TasksSlaEscalationNone     TasksSlaEscalation = "none"
+	TasksSlaEscalationAtRisk   TasksSlaEscalation = "at_risk"
+	TasksSlaEscalationBreached TasksSlaEscalation = "breached"
+)
+
+func (e *TasksSlaEscalation) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TasksSlaEscalation(s)
+	case string:
+		*e = TasksSlaEscalation(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TasksSlaEscalation: %T", src)
+	}
+	return nil
+}
+
+type NullTasksSlaEscalation struct {
+	TasksSlaEscalation TasksSlaEscalation
+	Valid              bool // Valid is true if TasksSlaEscalation is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTasksSlaEscalation) Scan(value interface{}) error {
+	if value == nil {
+		ns.TasksSlaEscalation, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TasksSlaEscalation.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTasksSlaEscalation) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TasksSlaEscalation), nil
+}
+
+type TasksStatus string
+
+const (
+	TasksStatusOpen      TasksStatus = "open"
+	TasksStatusCompleted TasksStatus = "completed"
+)
+
+func (e *TasksStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TasksStatus(s)
+	case string:
+		*e = TasksStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TasksStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTasksStatus struct {
+	TasksStatus TasksStatus
+	Valid       bool // Valid is true if TasksStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTasksStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TasksStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TasksStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTasksStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TasksStatus), nil
+}
+
 type UsersRole string
 
 const (
@@ -53,14 +398,253 @@ func (ns NullUsersRole) Value() (driver.Value, error) {
 	return string(ns.UsersRole), nil
 }
 
+type Asset struct {
+	ID                 int64
+	SiteID             int64
+	Name               string
+	SerialNumber       string
+	Model              string
+	InstalledOn        sql.NullTime
+	ActiveSerialNumber sql.NullString
+	CreatedAt          sql.NullTime
+	UpdatedAt          sql.NullTime
+	DeletedAt          sql.NullTime
+}
+
+type Customer struct {
+	ID        int64
+	Name      string
+	Email     string
+	Phone     string
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+	DeletedAt sql.NullTime
+}
+
+type Part struct {
+	ID                int64
+	Sku               string
+	Name              string
+	Unit              string
+	Stock             int32
+	LowStockThreshold int32
+	ActiveSku         sql.NullString
+	CreatedAt         sql.NullTime
+	UpdatedAt         sql.NullTime
+	DeletedAt         sql.NullTime
+}
+
+type RecurringTask struct {
+	ID             int64
+	TechnicianID   int64
+	Title          string
+	Summary        string
+	TemplateID     sql.NullInt64
+	Rrule          string
+	StartsAt       time.Time
+	Timezone       string
+	GeneratedUntil sql.NullTime
+	CreatedBy      int64
+	CreatedAt      sql.NullTime
+	UpdatedAt      sql.NullTime
+}
+
+type Site struct {
+	ID             int64
+	CustomerID     int64
+	Name           string
+	Address        string
+	City           string
+	PostalCode     string
+	Country        string
+	Latitude       sql.NullFloat64
+	Longitude      sql.NullFloat64
+	GeofenceRadius int32
+	CreatedAt      sql.NullTime
+	UpdatedAt      sql.NullTime
+	DeletedAt      sql.NullTime
+}
+
+type SlaPolicy struct {
+	ID                int64
+	Name              string
+	TagID             int64
+	ResolutionMinutes int32
+	AtRiskMinutes     int32
+	CreatedAt         sql.NullTime
+	UpdatedAt         sql.NullTime
+}
+
+type StockMovement struct {
+	ID        int64
+	PartID    int64
+	TaskID    sql.NullInt64
+	Quantity  int32
+	Balance   int32
+	Reason    StockMovementsReason
+	Note      string
+	CreatedBy sql.NullInt64
+	CreatedAt sql.NullTime
+}
+
+type Tag struct {
+	ID          int64
+	Name        string
+	Category    string
+	Description string
+	CreatedAt   sql.NullTime
+}
+
 type Task struct {
-	ID           int64
-	TechnicianID int64
-	Title        string
-	Summary      string
-	PerformedAt  time.Time
-	CreatedAt    sql.NullTime
-	UpdatedAt    sql.NullTime
+	ID               int64
+	TechnicianID     int64
+	Title            string
+	Summary          string
+	PerformedAt      time.Time
+	Status           TasksStatus
+	Priority         TasksPriority
+	TemplateID       sql.NullInt64
+	TemplateRevision sql.NullInt32
+	RecurringTaskID  sql.NullInt64
+	ScheduledFor     sql.NullTime
+	DueAt            sql.NullTime
+	AtRiskAt         sql.NullTime
+	SlaPolicyID      sql.NullInt64
+	SlaEscalation    TasksSlaEscalation
+	CompletedAt      sql.NullTime
+	ClaimedBy        sql.NullInt64
+	ClaimedAt        sql.NullTime
+	SiteID           sql.NullInt64
+	AssetID          sql.NullInt64
+	Version          int32
+	CreatedAt        sql.NullTime
+	UpdatedAt        sql.NullTime
+	DeletedAt        sql.NullTime
+}
+
+type TaskAttachment struct {
+	ID             int64
+	TaskID         int64
+	UploaderID     int64
+	FileName       string
+	ContentType    string
+	SizeBytes      int64
+	ChecksumSha256 string
+	StorageKey     string
+	Width          sql.NullInt32
+	Height         sql.NullInt32
+	CapturedAt     sql.NullTime
+	ProcessedAt    sql.NullTime
+	CreatedAt      sql.NullTime
+}
+
+type TaskChecklistItem struct {
+	ID          int64
+	TaskID      int64
+	Position    int32
+	Text        string
+	Required    bool
+	Done        bool
+	Note        string
+	CompletedBy sql.NullInt64
+	CompletedAt sql.NullTime
+	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
+}
+
+type TaskComment struct {
+	ID        int64
+	TaskID    int64
+	AuthorID  int64
+	Body      string
+	CreatedAt sql.NullTime
+	EditedAt  sql.NullTime
+}
+
+type TaskDependency struct {
+	TaskID      int64
+	DependsOnID int64
+	CreatedBy   sql.NullInt64
+	CreatedAt   sql.NullTime
+}
+
+type TaskLocation struct {
+	TaskID     int64
+	Latitude   sql.NullFloat64
+	Longitude  sql.NullFloat64
+	Accuracy   sql.NullFloat64
+	RecordedAt time.Time
+	Geofence   TaskLocationsGeofence
+	Distance   sql.NullInt32
+	ReviewedBy sql.NullInt64
+	ReviewedAt sql.NullTime
+}
+
+type TaskMaterial struct {
+	ID        int64
+	TaskID    int64
+	PartID    int64
+	Quantity  int32
+	Note      string
+	CreatedBy sql.NullInt64
+	CreatedAt sql.NullTime
+}
+
+type TaskRevision struct {
+	ID          int64
+	TaskID      int64
+	Revision    int32
+	EditorID    int64
+	Action      TaskRevisionsAction
+	Title       string
+	Summary     string
+	PerformedAt time.Time
+	Status      TaskRevisionsStatus
+	Priority    NullTaskRevisionsPriority
+	DueAt       sql.NullTime
+	Tags        sql.NullString
+	SiteID      sql.NullInt64
+	AssetID     sql.NullInt64
+	CreatedAt   sql.NullTime
+}
+
+type TaskTag struct {
+	TaskID int64
+	TagID  int64
+}
+
+type TaskTemplate struct {
+	ID        int64
+	Revision  int32
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+	DeletedAt sql.NullTime
+}
+
+type TaskTemplateRevision struct {
+	ID               int64
+	TemplateID       int64
+	Revision         int32
+	Name             string
+	TitlePattern     string
+	Summary          string
+	Checklist        json.RawMessage
+	Tags             json.RawMessage
+	EstimatedMinutes int32
+	EditorID         int64
+	CreatedAt        sql.NullTime
+}
+
+type TimeEntry struct {
+	ID                  int64
+	TaskID              int64
+	TechnicianID        int64
+	StartedAt           time.Time
+	EndedAt             sql.NullTime
+	Note                string
+	RunningTechnicianID sql.NullInt64
+	CreatedAt           sql.NullTime
+	UpdatedAt           sql.NullTime
 }
 
 type User struct {
@@ -69,6 +653,9 @@ type User struct {
 	Email        string
 	PasswordHash string
 	Role         UsersRole
+	ManagerID    sql.NullInt64
+	Locale       string
+	Timezone     string
 	CreatedAt    sql.NullTime
 	UpdatedAt    sql.NullTime
 }
@@ -7,33 +7,71 @@ package tasks
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"time"
 )
 
-const create = `-- name: Create :exec
-INSERT INTO tasks (technician_id, title, summary, performed_at)
-VALUES (?, ?, ?, ?)
+const bumpVersion = `-- name: BumpVersion :exec
+UPDATE tasks SET version = version + 1 WHERE id = ?
+`
+
+// For changes to what a task shows that are not written by Update, such as
+// its checklist, so clients holding the old version see it changed
+func (q *Queries) BumpVersion(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, bumpVersion, id)
+	return err
+}
+
+const create = `-- name: Create :execlastid
+INSERT INTO tasks (technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, site_id, asset_id, completed_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, IF(status = 'completed', CURRENT_TIMESTAMP, NULL))
 `
 
 type CreateParams struct {
-	TechnicianID int64
-	Title        string
-	Summary      string
-	PerformedAt  time.Time
+	TechnicianID     int64
+	Title            string
+	Summary          string
+	PerformedAt      time.Time
+	Status           TasksStatus
+	Priority         TasksPriority
+	TemplateID       sql.NullInt64
+	TemplateRevision sql.NullInt32
+	RecurringTaskID  sql.NullInt64
+	ScheduledFor     sql.NullTime
+	DueAt            sql.NullTime
+	AtRiskAt         sql.NullTime
+	SlaPolicyID      sql.NullInt64
+	SiteID           sql.NullInt64
+	AssetID          sql.NullInt64
 }
 
-func (q *Queries) Create(ctx context.Context, arg CreateParams) error {
-	_, err := q.db.ExecContext(ctx, create,
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, create,
 		arg.TechnicianID,
 		arg.Title,
 		arg.Summary,
 		arg.PerformedAt,
+		arg.Status,
+		arg.Priority,
+		arg.TemplateID,
+		arg.TemplateRevision,
+		arg.RecurringTaskID,
+		arg.ScheduledFor,
+		arg.DueAt,
+		arg.AtRiskAt,
+		arg.SlaPolicyID,
+		arg.SiteID,
+		arg.AssetID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
 }
 
 const delete = `-- name: Delete :exec
-DELETE FROM tasks WHERE id = ?
+UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) Delete(ctx context.Context, id int64) error {
@@ -42,7 +80,7 @@ func (q *Queries) Delete(ctx context.Context, id int64) error {
 }
 
 const getAll = `-- name: GetAll :many
-SELECT id, technician_id, title, summary, performed_at, created_at, updated_at FROM tasks
+SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at FROM tasks WHERE deleted_at IS NULL
 `
 
 func (q *Queries) GetAll(ctx context.Context) ([]Task, error) {
@@ -60,8 +98,85 @@ func (q *Queries) GetAll(ctx context.Context) ([]Task, error) {
 			&i.Title,
 			&i.Summary,
 			&i.PerformedAt,
+			&i.Status,
+			&i.Priority,
+			&i.TemplateID,
+			&i.TemplateRevision,
+			&i.RecurringTaskID,
+			&i.ScheduledFor,
+			&i.DueAt,
+			&i.AtRiskAt,
+			&i.SlaPolicyID,
+			&i.SlaEscalation,
+			&i.CompletedAt,
+			&i.ClaimedBy,
+			&i.ClaimedAt,
+			&i.SiteID,
+			&i.AssetID,
+			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getByAssetID = `-- name: GetByAssetID :many
+SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at FROM tasks
+WHERE asset_id = ? AND deleted_at IS NULL
+  AND (? = 0 OR technician_id = ?)
+ORDER BY performed_at DESC, id DESC
+`
+
+type GetByAssetIDParams struct {
+	AssetID      sql.NullInt64
+	TechnicianID int64
+}
+
+func (q *Queries) GetByAssetID(ctx context.Context, arg GetByAssetIDParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getByAssetID, arg.AssetID, arg.TechnicianID, arg.TechnicianID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.TechnicianID,
+			&i.Title,
+			&i.Summary,
+			&i.PerformedAt,
+			&i.Status,
+			&i.Priority,
+			&i.TemplateID,
+			&i.TemplateRevision,
+			&i.RecurringTaskID,
+			&i.ScheduledFor,
+			&i.DueAt,
+			&i.AtRiskAt,
+			&i.SlaPolicyID,
+			&i.SlaEscalation,
+			&i.CompletedAt,
+			&i.ClaimedBy,
+			&i.ClaimedAt,
+			&i.SiteID,
+			&i.AssetID,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -77,7 +192,7 @@ func (q *Queries) GetAll(ctx context.Context) ([]Task, error) {
 }
 
 const getByID = `-- name: GetByID :one
-SELECT id, technician_id, title, summary, performed_at, created_at, updated_at FROM tasks WHERE id = ?
+SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at FROM tasks WHERE id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) GetByID(ctx context.Context, id int64) (Task, error) {
@@ -89,14 +204,31 @@ func (q *Queries) GetByID(ctx context.Context, id int64) (Task, error) {
 		&i.Title,
 		&i.Summary,
 		&i.PerformedAt,
+		&i.Status,
+		&i.Priority,
+		&i.TemplateID,
+		&i.TemplateRevision,
+		&i.RecurringTaskID,
+		&i.ScheduledFor,
+		&i.DueAt,
+		&i.AtRiskAt,
+		&i.SlaPolicyID,
+		&i.SlaEscalation,
+		&i.CompletedAt,
+		&i.ClaimedBy,
+		&i.ClaimedAt,
+		&i.SiteID,
+		&i.AssetID,
+		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getByTechnicianID = `-- name: GetByTechnicianID :many
-SELECT id, technician_id, title, summary, performed_at, created_at, updated_at FROM tasks WHERE technician_id = ?
+SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at FROM tasks WHERE technician_id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) GetByTechnicianID(ctx context.Context, technicianID int64) ([]Task, error) {
@@ -114,8 +246,77 @@ func (q *Queries) GetByTechnicianID(ctx context.Context, technicianID int64) ([]
 			&i.Title,
 			&i.Summary,
 			&i.PerformedAt,
+			&i.Status,
+			&i.Priority,
+			&i.TemplateID,
+			&i.TemplateRevision,
+			&i.RecurringTaskID,
+			&i.ScheduledFor,
+			&i.DueAt,
+			&i.AtRiskAt,
+			&i.SlaPolicyID,
+			&i.SlaEscalation,
+			&i.CompletedAt,
+			&i.ClaimedBy,
+			&i.ClaimedAt,
+			&i.SiteID,
+			&i.AssetID,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeleted = `-- name: GetDeleted :many
+SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at FROM tasks WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC
+`
+
+func (q *Queries) GetDeleted(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.TechnicianID,
+			&i.Title,
+			&i.Summary,
+			&i.PerformedAt,
+			&i.Status,
+			&i.Priority,
+			&i.TemplateID,
+			&i.TemplateRevision,
+			&i.RecurringTaskID,
+			&i.ScheduledFor,
+			&i.DueAt,
+			&i.AtRiskAt,
+			&i.SlaPolicyID,
+			&i.SlaEscalation,
+			&i.CompletedAt,
+			&i.ClaimedBy,
+			&i.ClaimedAt,
+			&i.SiteID,
+			&i.AssetID,
+			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -130,12 +331,12 @@ func (q *Queries) GetByTechnicianID(ctx context.Context, technicianID int64) ([]
 	return items, nil
 }
 
-const getLastInsertTask = `-- name: GetLastInsertTask :one
-SELECT id, technician_id, title, summary, performed_at, created_at, updated_at FROM tasks WHERE id = LAST_INSERT_ID()
+const getDeletedByID = `-- name: GetDeletedByID :one
+SELECT id, technician_id, title, summary, performed_at, status, priority, template_id, template_revision, recurring_task_id, scheduled_for, due_at, at_risk_at, sla_policy_id, sla_escalation, completed_at, claimed_by, claimed_at, site_id, asset_id, version, created_at, updated_at, deleted_at FROM tasks WHERE id = ? AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) GetLastInsertTask(ctx context.Context) (Task, error) {
-	row := q.db.QueryRowContext(ctx, getLastInsertTask)
+func (q *Queries) GetDeletedByID(ctx context.Context, id int64) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedByID, id)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -143,14 +344,66 @@ func (q *Queries) GetLastInsertTask(ctx context.Context) (Task, error) {
 		&i.Title,
 		&i.Summary,
 		&i.PerformedAt,
+		&i.Status,
+		&i.Priority,
+		&i.TemplateID,
+		&i.TemplateRevision,
+		&i.RecurringTaskID,
+		&i.ScheduledFor,
+		&i.DueAt,
+		&i.AtRiskAt,
+		&i.SlaPolicyID,
+		&i.SlaEscalation,
+		&i.CompletedAt,
+		&i.ClaimedBy,
+		&i.ClaimedAt,
+		&i.SiteID,
+		&i.AssetID,
+		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const getDeletedIDsBefore = `-- name: GetDeletedIDsBefore :many
+SELECT id FROM tasks
+WHERE deleted_at IS NOT NULL AND deleted_at < ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetDeletedIDsBeforeParams struct {
+	DeletedAt sql.NullTime
+	Limit     int32
+}
+
+func (q *Queries) GetDeletedIDsBefore(ctx context.Context, arg GetDeletedIDsBeforeParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedIDsBefore, arg.DeletedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLastInsertUser = `-- name: GetLastInsertUser :one
-SELECT id, name, email, password_hash, role, created_at, updated_at FROM users WHERE id = LAST_INSERT_ID()
+SELECT id, name, email, password_hash, role, manager_id, locale, timezone, created_at, updated_at FROM users WHERE id = LAST_INSERT_ID()
 `
 
 func (q *Queries) GetLastInsertUser(ctx context.Context) (User, error) {
@@ -162,29 +415,101 @@ func (q *Queries) GetLastInsertUser(ctx context.Context) (User, error) {
 		&i.Email,
 		&i.PasswordHash,
 		&i.Role,
+		&i.ManagerID,
+		&i.Locale,
+		&i.Timezone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const update = `-- name: Update :exec
-UPDATE tasks SET title = ?, summary = ?, performed_at = ? WHERE id = ?
+const lockTask = `-- name: LockTask :one
+SELECT status, version FROM tasks WHERE id = ? AND deleted_at IS NULL FOR UPDATE
+`
+
+type LockTaskRow struct {
+	Status  TasksStatus
+	Version int32
+}
+
+func (q *Queries) LockTask(ctx context.Context, id int64) (LockTaskRow, error) {
+	row := q.db.QueryRowContext(ctx, lockTask, id)
+	var i LockTaskRow
+	err := row.Scan(&i.Status, &i.Version)
+	return i, err
+}
+
+const purgeByIDs = `-- name: PurgeByIDs :execrows
+DELETE FROM tasks WHERE id IN (/*SLICE:ids*/?) AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeByIDs(ctx context.Context, ids []int64) (int64, error) {
+	sql := purgeByIDs
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		sql = strings.Replace(sql, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		sql = strings.Replace(sql, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	result, err := q.db.ExecContext(ctx, sql, queryParams...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restore = `-- name: Restore :exec
+UPDATE tasks SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) Restore(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, restore, id)
+	return err
+}
+
+const update = `-- name: Update :execrows
+UPDATE tasks SET title = ?, summary = ?, performed_at = ?, status = ?, priority = ?, site_id = ?, asset_id = ?,
+  completed_at = IF(status = 'completed', COALESCE(completed_at, CURRENT_TIMESTAMP), NULL),
+  sla_escalation = IF(due_at <=> ?, sla_escalation, 'none'),
+  due_at = ?, at_risk_at = ?, version = version + 1
+WHERE id = ? AND version = ? AND deleted_at IS NULL
 `
 
 type UpdateParams struct {
 	Title       string
 	Summary     string
 	PerformedAt time.Time
+	Status      TasksStatus
+	Priority    TasksPriority
+	SiteID      sql.NullInt64
+	AssetID     sql.NullInt64
+	DueAt       sql.NullTime
+	AtRiskAt    sql.NullTime
 	ID          int64
+	Version     int32
 }
 
-func (q *Queries) Update(ctx context.Context, arg UpdateParams) error {
-	_, err := q.db.ExecContext(ctx, update,
+func (q *Queries) Update(ctx context.Context, arg UpdateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, update,
 		arg.Title,
 		arg.Summary,
 		arg.PerformedAt,
+		arg.Status,
+		arg.Priority,
+		arg.SiteID,
+		arg.AssetID,
+		arg.DueAt,
+		arg.DueAt,
+		arg.AtRiskAt,
 		arg.ID,
+		arg.Version,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
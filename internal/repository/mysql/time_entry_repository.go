@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/internal/repository/mysql/tasks"
+	"time"
+)
+
+type timeEntryRepository struct {
+	db    *sql.DB
+	query tasks.Queries
+}
+
+func NewTimeEntryRepository(db *sql.DB) repository.TimeEntryRepository {
+	return &timeEntryRepository{db: db, query: *tasks.New(db)}
+}
+
+// Create locks the technician's row before looking for overlaps, so two
+// entries of the same technician are never checked and inserted concurrently.
+// The unique key on running timers backs the check up.
+func (r *timeEntryRepository) Create(ctx context.Context, entry *models.TimeEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := r.query.WithTx(tx)
+	if _, err := query.LockUser(ctx, entry.TechnicianID); err != nil {
+		return err
+	}
+
+	endedAt := toNullTime(entry.EndedAt)
+	overlapping, err := query.CountOverlappingTimeEntries(ctx, tasks.CountOverlappingTimeEntriesParams{
+		TechnicianID: entry.TechnicianID,
+		EndedAt:      endedAt,
+		StartedAt:    entry.StartedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return repository.ErrOverlap
+	}
+
+	id, err := query.CreateTimeEntry(ctx, tasks.CreateTimeEntryParams{
+		TaskID:       entry.TaskID,
+		TechnicianID: entry.TechnicianID,
+		StartedAt:    entry.StartedAt,
+		EndedAt:      endedAt,
+		Note:         entry.Note,
+	})
+	if err != nil {
+		if errors.Is(translateDuplicate(err), repository.ErrDuplicate) {
+			return repository.ErrOverlap
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	entry.ID = id
+	return nil
+}
+
+func (r *timeEntryRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TimeEntry, error) {
+	entry, err := r.query.GetTimeEntry(ctx, tasks.GetTimeEntryParams{ID: id, TaskID: taskID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTimeEntryModel(entry), nil
+}
+
+func (r *timeEntryRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TimeEntry, error) {
+	rows, err := r.query.GetTimeEntriesByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*models.TimeEntry, 0, len(rows))
+	for _, entry := range rows {
+		entries = append(entries, toTimeEntryModel(entry))
+	}
+	return entries, nil
+}
+
+func (r *timeEntryRepository) GetRunning(ctx context.Context, taskID int64, technicianID int64) (*models.TimeEntry, error) {
+	entry, err := r.query.GetRunningTimeEntry(ctx, tasks.GetRunningTimeEntryParams{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTimeEntryModel(entry), nil
+}
+
+func (r *timeEntryRepository) Stop(ctx context.Context, id int64, endedAt time.Time) (bool, error) {
+	stopped, err := r.query.StopTimeEntry(ctx, tasks.StopTimeEntryParams{
+		EndedAt: sql.NullTime{Time: endedAt, Valid: true},
+		ID:      id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return stopped > 0, nil
+}
+
+func (r *timeEntryRepository) Delete(ctx context.Context, id int64) error {
+	return r.query.DeleteTimeEntry(ctx, id)
+}
+
+func (r *timeEntryRepository) GetReport(ctx context.Context, query models.TimeReportQuery, now time.Time) ([]*models.TaskTime, error) {
+	rows, err := r.query.GetTimeReport(ctx, tasks.GetTimeReportParams{
+		FromTime:     sql.NullTime{Time: query.From, Valid: true},
+		Now:          sql.NullTime{Time: now, Valid: true},
+		ToTime:       sql.NullTime{Time: query.To, Valid: true},
+		TechnicianID: query.TechnicianID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	times := make([]*models.TaskTime, 0, len(rows))
+	for _, row := range rows {
+		times = append(times, &models.TaskTime{
+			TaskID:         row.TaskID,
+			Title:          row.Title,
+			TechnicianID:   row.TechnicianID,
+			TechnicianName: row.Name,
+			Seconds:        row.Seconds,
+		})
+	}
+	return times, nil
+}
+
+func toTimeEntryModel(entry tasks.TimeEntry) *models.TimeEntry {
+	e := &models.TimeEntry{
+		ID:           entry.ID,
+		TaskID:       entry.TaskID,
+		TechnicianID: entry.TechnicianID,
+		StartedAt:    entry.StartedAt,
+		Note:         entry.Note,
+		CreatedAt:    entry.CreatedAt.Time,
+	}
+	if entry.EndedAt.Valid {
+		e.EndedAt = &entry.EndedAt.Time
+	}
+	return e
+}
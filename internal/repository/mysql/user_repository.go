@@ -17,10 +17,18 @@ func NewUserRepository(db *sql.DB) repository.UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (name, email, password_hash, role)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO users (name, email, password_hash, role, manager_id, locale, timezone)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
-	result, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.PasswordHash, user.Role)
+	result, err := r.db.ExecContext(ctx, query,
+		user.Name,
+		user.Email,
+		user.PasswordHash,
+		user.Role,
+		user.ManagerID,
+		userLocale(user),
+		userTimezone(user),
+	)
 	if err != nil {
 		return err
 	}
@@ -35,7 +43,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
 	query := `
-		SELECT id, name, email, password_hash, role, created_at, updated_at
+		SELECT id, name, email, password_hash, role, manager_id, locale, timezone, created_at, updated_at
 		FROM users
 		WHERE id = ?
 	`
@@ -46,6 +54,9 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
+		&user.ManagerID,
+		&user.Locale,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -60,7 +71,7 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, name, email, password_hash, role, created_at, updated_at
+		SELECT id, name, email, password_hash, role, manager_id, locale, timezone, created_at, updated_at
 		FROM users
 		WHERE email = ?
 	`
@@ -71,6 +82,9 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
+		&user.ManagerID,
+		&user.Locale,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -86,7 +100,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
-		SET name = ?, email = ?, password_hash = ?, role = ?
+		SET name = ?, email = ?, password_hash = ?, role = ?, manager_id = ?, locale = ?, timezone = ?
 		WHERE id = ?
 	`
 	_, err := r.db.ExecContext(ctx, query,
@@ -94,6 +108,9 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 		user.Email,
 		user.PasswordHash,
 		user.Role,
+		user.ManagerID,
+		userLocale(user),
+		userTimezone(user),
 		user.ID,
 	)
 	return err
@@ -104,3 +121,19 @@ func (r *userRepository) Delete(ctx context.Context, id int64) error {
 	_, err := r.db.ExecContext(ctx, query, id)
 	return err
 }
+
+// userLocale returns the user's locale or the column default
+func userLocale(user *models.User) string {
+	if user.Locale == "" {
+		return "en"
+	}
+	return user.Locale
+}
+
+// userTimezone returns the user's timezone or the column default
+func userTimezone(user *models.User) string {
+	if user.Timezone == "" {
+		return "UTC"
+	}
+	return user.Timezone
+}
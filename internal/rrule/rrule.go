@@ -0,0 +1,475 @@
+// Package rrule parses iCalendar (RFC 5545) recurrence rules and expands them
+// into occurrences. It supports the day-based subset used for maintenance
+// schedules: FREQ=DAILY, WEEKLY, MONTHLY or YEARLY with INTERVAL, COUNT,
+// UNTIL, WKST, BYDAY (with ordinals such as 1MO or -1FR), BYMONTHDAY, BYMONTH
+// and BYSETPOS. Every occurrence happens at the time of day of the start.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the unit of a rule's period
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencies = map[string]Frequency{
+	"DAILY":   Daily,
+	"WEEKLY":  Weekly,
+	"MONTHLY": Monthly,
+	"YEARLY":  Yearly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// maxPeriods bounds the expansion of rules that rarely or never match, e.g.
+// the 30th of February
+const maxPeriods = 100000
+
+var (
+	ErrInvalidRule     = errors.New("invalid recurrence rule")
+	ErrUnsupportedRule = errors.New("unsupported recurrence rule part")
+)
+
+// WeekdayNum is a BYDAY entry: a weekday, optionally the Nth (or Nth from
+// the end when negative) of the month or year
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is a parsed RRULE
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	WeekStart  time.Weekday
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+}
+
+// Parse reads a rule such as "FREQ=MONTHLY;BYDAY=1MO", with or without the
+// "RRULE:" prefix
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, ErrInvalidRule
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" || seen[name] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			freq, known := frequencies[value]
+			if !known {
+				return nil, fmt.Errorf("%w: FREQ=%s", ErrUnsupportedRule, value)
+			}
+			rule.Freq = freq
+		case "INTERVAL":
+			rule.Interval, err = parseInt(value, 1, 1000)
+		case "COUNT":
+			rule.Count, err = parseInt(value, 1, 10000)
+		case "UNTIL":
+			rule.Until, err = parseUntil(value)
+		case "WKST":
+			day, known := weekdays[value]
+			if !known {
+				err = fmt.Errorf("%w: WKST=%s", ErrInvalidRule, value)
+			}
+			rule.WeekStart = day
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(value, 31)
+		case "BYMONTH":
+			var months []int
+			if months, err = parseIntList(value, 12); err == nil {
+				for _, month := range months {
+					if month < 0 {
+						return nil, fmt.Errorf("%w: BYMONTH=%s", ErrInvalidRule, value)
+					}
+					rule.ByMonth = append(rule.ByMonth, time.Month(month))
+				}
+			}
+		case "BYSETPOS":
+			rule.BySetPos, err = parseIntList(value, 366)
+		case "BYSECOND", "BYMINUTE", "BYHOUR", "BYYEARDAY", "BYWEEKNO":
+			err = fmt.Errorf("%w: %s", ErrUnsupportedRule, name)
+		default:
+			err = fmt.Errorf("%w: %s", ErrInvalidRule, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !seen["FREQ"] {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if seen["COUNT"] && seen["UNTIL"] {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRule)
+	}
+	if len(rule.BySetPos) > 0 && len(rule.ByDay) == 0 && len(rule.ByMonthDay) == 0 && len(rule.ByMonth) == 0 {
+		return nil, fmt.Errorf("%w: BYSETPOS needs another BY part", ErrInvalidRule)
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly && rule.Freq != Yearly {
+			return nil, fmt.Errorf("%w: numbered BYDAY needs FREQ=MONTHLY or YEARLY", ErrInvalidRule)
+		}
+		if day.N != 0 && rule.Freq == Monthly && (day.N > 5 || day.N < -5) {
+			return nil, fmt.Errorf("%w: a month has at most 5 of each weekday", ErrInvalidRule)
+		}
+	}
+	return rule, nil
+}
+
+// String formats the rule back to RRULE syntax, without the prefix
+func (r *Rule) String() string {
+	names := map[Frequency]string{Daily: "DAILY", Weekly: "WEEKLY", Monthly: "MONTHLY", Yearly: "YEARLY"}
+	parts := []string{"FREQ=" + names[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayName(r.WeekStart))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			if day.N != 0 {
+				days = append(days, strconv.Itoa(day.N)+weekdayName(day.Day))
+			} else {
+				days = append(days, weekdayName(day.Day))
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, 0, len(r.ByMonth))
+		for _, month := range r.ByMonth {
+			months = append(months, int(month))
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns up to limit occurrences t with after < t <= before, in
+// order. The rule is expanded from start, which fixes the time of day, the
+// location and, for COUNT, the first occurrence; start itself is an
+// occurrence only if it matches the rule.
+func (r *Rule) Between(start, after, before time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	emitted := 0
+	for period := 0; period < maxPeriods && len(occurrences) < limit; period++ {
+		candidates, periodStart := r.expand(start, period*r.Interval)
+		if periodStart.After(before) || (!r.Until.IsZero() && periodStart.After(r.Until)) {
+			break
+		}
+		for _, t := range candidates {
+			if t.Before(start) {
+				continue
+			}
+			if t.After(before) || (!r.Until.IsZero() && t.After(r.Until)) {
+				return occurrences
+			}
+			emitted++
+			if r.Count > 0 && emitted > r.Count {
+				return occurrences
+			}
+			if t.After(after) {
+				occurrences = append(occurrences, t)
+				if len(occurrences) == limit {
+					return occurrences
+				}
+			}
+		}
+	}
+	return occurrences
+}
+
+// expand returns the sorted occurrences of the period offset periods after
+// the one containing start, and the first day of that period
+func (r *Rule) expand(start time.Time, offset int) ([]time.Time, time.Time) {
+	year, month, day := start.Date()
+	loc := start.Location()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, start.Hour(), start.Minute(), start.Second(), 0, loc)
+	}
+
+	var days []time.Time
+	var periodStart time.Time
+	switch r.Freq {
+	case Daily:
+		periodStart = at(year, month, day+offset)
+		days = []time.Time{periodStart}
+	case Weekly:
+		back := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		periodStart = at(year, month, day-back+7*offset)
+		for i := 0; i < 7; i++ {
+			days = append(days, at(year, month, day-back+7*offset+i))
+		}
+		if len(r.ByDay) == 0 {
+			days = filterDays(days, func(t time.Time) bool { return t.Weekday() == start.Weekday() })
+		}
+	case Monthly:
+		periodStart = at(year, month+time.Month(offset), 1)
+		days = r.expandMonth(periodStart, day, at)
+	case Yearly:
+		periodStart = at(year+offset, time.January, 1)
+		months := r.ByMonth
+		if len(months) == 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			months = []time.Month{month}
+		}
+		if len(months) == 0 && len(r.ByDay) > 0 && len(r.ByMonthDay) == 0 {
+			// Weekdays of the whole year, e.g. the last Friday of the year
+			days = expandByDay(daysOf(periodStart, at(year+offset+1, time.January, 1)), r.ByDay)
+			break
+		}
+		if len(months) == 0 {
+			months = allMonths()
+		}
+		for _, m := range months {
+			days = append(days, r.expandMonth(at(year+offset, m, 1), day, at)...)
+		}
+	}
+
+	if len(r.ByMonth) > 0 {
+		days = filterDays(days, func(t time.Time) bool { return slices.Contains(r.ByMonth, t.Month()) })
+	}
+	if r.Freq != Monthly && r.Freq != Yearly {
+		if len(r.ByMonthDay) > 0 {
+			days = filterDays(days, func(t time.Time) bool { return matchesMonthDay(t, r.ByMonthDay) })
+		}
+		if len(r.ByDay) > 0 {
+			days = filterDays(days, func(t time.Time) bool { return matchesWeekday(t, r.ByDay) })
+		}
+	}
+
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	days = slices.CompactFunc(days, func(a, b time.Time) bool { return a.Equal(b) })
+	if len(r.BySetPos) > 0 {
+		days = selectPositions(days, r.BySetPos)
+	}
+	return days, periodStart
+}
+
+// expandMonth returns the days of the month starting at first selected by
+// BYMONTHDAY and BYDAY, or the start day of the month when neither is set
+func (r *Rule) expandMonth(first time.Time, startDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	year, month, _ := first.Date()
+	all := daysOf(first, at(year, month+1, 1))
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		days := filterDays(all, func(t time.Time) bool { return matchesMonthDay(t, r.ByMonthDay) })
+		if len(r.ByDay) > 0 {
+			days = filterDays(days, func(t time.Time) bool { return matchesWeekday(t, r.ByDay) })
+		}
+		return days
+	case len(r.ByDay) > 0:
+		return expandByDay(all, r.ByDay)
+	default:
+		// Months without the start day (e.g. the 31st) are skipped
+		return filterDays(all, func(t time.Time) bool { return t.Day() == startDay })
+	}
+}
+
+// expandByDay selects the BYDAY days of a month or year; numbered entries
+// pick the Nth matching weekday from the start or, when negative, the end
+func expandByDay(all []time.Time, byDay []WeekdayNum) []time.Time {
+	var days []time.Time
+	for _, wd := range byDay {
+		matching := filterDays(all, func(t time.Time) bool { return t.Weekday() == wd.Day })
+		switch {
+		case wd.N == 0:
+			days = append(days, matching...)
+		case wd.N > 0 && wd.N <= len(matching):
+			days = append(days, matching[wd.N-1])
+		case wd.N < 0 && -wd.N <= len(matching):
+			days = append(days, matching[len(matching)+wd.N])
+		}
+	}
+	return days
+}
+
+func daysOf(from, to time.Time) []time.Time {
+	var days []time.Time
+	year, month, day := from.Date()
+	for i := 0; ; i++ {
+		t := time.Date(year, month, day+i, from.Hour(), from.Minute(), from.Second(), 0, from.Location())
+		if !t.Before(to) {
+			return days
+		}
+		days = append(days, t)
+	}
+}
+
+func filterDays(days []time.Time, keep func(time.Time) bool) []time.Time {
+	kept := make([]time.Time, 0, len(days))
+	for _, t := range days {
+		if keep(t) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+func matchesMonthDay(t time.Time, monthDays []int) bool {
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range monthDays {
+		if d == t.Day() || (d < 0 && last+d+1 == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesWeekday(t time.Time, byDay []WeekdayNum) bool {
+	for _, wd := range byDay {
+		if wd.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// selectPositions keeps the BYSETPOS entries of a period's sorted days
+func selectPositions(days []time.Time, positions []int) []time.Time {
+	var selected []time.Time
+	for _, pos := range positions {
+		switch {
+		case pos > 0 && pos <= len(days):
+			selected = append(selected, days[pos-1])
+		case pos < 0 && -pos <= len(days):
+			selected = append(selected, days[len(days)+pos])
+		}
+	}
+	slices.SortFunc(selected, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(selected, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+func allMonths() []time.Month {
+	months := make([]time.Month, 0, 12)
+	for m := time.January; m <= time.December; m++ {
+		months = append(months, m)
+	}
+	return months
+}
+
+func parseInt(value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%w: %s is not between %d and %d", ErrInvalidRule, value, min, max)
+	}
+	return n, nil
+}
+
+// parseIntList reads comma-separated numbers between -max and max, except 0
+func parseIntList(value string, max int) ([]int, error) {
+	var list []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n > max || n < -max {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRule, value)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRule, value)
+		}
+		day, known := weekdays[item[len(item)-2:]]
+		if !known {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRule, value)
+		}
+		wd := WeekdayNum{Day: day}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRule, value)
+			}
+			wd.N = n
+		}
+		days = append(days, wd)
+	}
+	return days, nil
+}
+
+// parseUntil reads a UTC date-time (20240320T143000Z) or a date (20240320),
+// which includes the whole day
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL=%s", ErrInvalidRule, value)
+}
+
+func weekdayName(day time.Weekday) string {
+	for name, d := range weekdays {
+		if d == day {
+			return name
+		}
+	}
+	return ""
+}
+
+func joinInts(values []int) string {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, strconv.Itoa(v))
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,178 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr error
+	}{
+		{name: "monthly by numbered weekday", rule: "RRULE:FREQ=MONTHLY;BYDAY=1MO", want: "FREQ=MONTHLY;BYDAY=1MO"},
+		{name: "every 90 days", rule: "freq=daily;interval=90", want: "FREQ=DAILY;INTERVAL=90"},
+		{name: "until a date", rule: "FREQ=WEEKLY;BYDAY=MO,TH;UNTIL=20241231", want: "FREQ=WEEKLY;UNTIL=20241231T235959Z;BYDAY=MO,TH"},
+		{name: "last weekday of the month", rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", want: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{name: "missing FREQ", rule: "INTERVAL=2", wantErr: ErrInvalidRule},
+		{name: "hourly is not supported", rule: "FREQ=HOURLY", wantErr: ErrUnsupportedRule},
+		{name: "BYHOUR is not supported", rule: "FREQ=DAILY;BYHOUR=9", wantErr: ErrUnsupportedRule},
+		{name: "COUNT with UNTIL", rule: "FREQ=DAILY;COUNT=3;UNTIL=20241231", wantErr: ErrInvalidRule},
+		{name: "numbered weekday on a weekly rule", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: ErrInvalidRule},
+		{name: "sixth Monday of a month", rule: "FREQ=MONTHLY;BYDAY=6MO", wantErr: ErrInvalidRule},
+		{name: "day zero", rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: ErrInvalidRule},
+		{name: "repeated part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: ErrInvalidRule},
+		{name: "empty", rule: "", wantErr: ErrInvalidRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && rule.String() != tt.want {
+				t.Errorf("String() = %q, want %q", rule.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		rule   string
+		start  time.Time
+		after  time.Time
+		before time.Time
+		limit  int
+		want   []time.Time
+	}{
+		{
+			name:   "every first Monday",
+			rule:   "FREQ=MONTHLY;BYDAY=1MO",
+			start:  day(2024, 1, 1),
+			after:  day(2024, 1, 1).Add(-time.Second),
+			before: day(2024, 12, 31),
+			limit:  3,
+			want:   []time.Time{day(2024, 1, 1), day(2024, 2, 5), day(2024, 3, 4)},
+		},
+		{
+			name:   "every 90 days",
+			rule:   "FREQ=DAILY;INTERVAL=90",
+			start:  day(2024, 1, 1),
+			after:  day(2024, 1, 1),
+			before: day(2024, 12, 31),
+			limit:  10,
+			want:   []time.Time{day(2024, 3, 31), day(2024, 6, 29), day(2024, 9, 27), day(2024, 12, 26)},
+		},
+		{
+			name:   "weekly on two days with a count",
+			rule:   "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			start:  day(2024, 3, 5),
+			after:  time.Time{},
+			before: day(2024, 12, 31),
+			limit:  10,
+			want:   []time.Time{day(2024, 3, 5), day(2024, 3, 7), day(2024, 3, 12)},
+		},
+		{
+			name:   "count is kept when starting later",
+			rule:   "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			start:  day(2024, 3, 5),
+			after:  day(2024, 3, 6),
+			before: day(2024, 12, 31),
+			limit:  10,
+			want:   []time.Time{day(2024, 3, 7), day(2024, 3, 12)},
+		},
+		{
+			name:   "months without the 31st are skipped",
+			rule:   "FREQ=MONTHLY",
+			start:  day(2024, 1, 31),
+			after:  time.Time{},
+			before: day(2024, 6, 1),
+			limit:  10,
+			want:   []time.Time{day(2024, 1, 31), day(2024, 3, 31), day(2024, 5, 31)},
+		},
+		{
+			name:   "last day of the month",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:  day(2024, 1, 1),
+			after:  time.Time{},
+			before: day(2024, 4, 1),
+			limit:  10,
+			want:   []time.Time{day(2024, 1, 31), day(2024, 2, 29), day(2024, 3, 31)},
+		},
+		{
+			name:   "last weekday of the month",
+			rule:   "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			start:  day(2024, 3, 1),
+			after:  time.Time{},
+			before: day(2024, 7, 1),
+			limit:  10,
+			want:   []time.Time{day(2024, 3, 29), day(2024, 4, 30), day(2024, 5, 31), day(2024, 6, 28)},
+		},
+		{
+			name:   "yearly in March and September",
+			rule:   "FREQ=YEARLY;BYMONTH=3,9;BYMONTHDAY=15",
+			start:  day(2024, 1, 1),
+			after:  time.Time{},
+			before: day(2025, 12, 31),
+			limit:  3,
+			want:   []time.Time{day(2024, 3, 15), day(2024, 9, 15), day(2025, 3, 15)},
+		},
+		{
+			name:   "last Friday of the year",
+			rule:   "FREQ=YEARLY;BYDAY=-1FR",
+			start:  day(2024, 1, 1),
+			after:  time.Time{},
+			before: day(2025, 12, 31),
+			limit:  10,
+			want:   []time.Time{day(2024, 12, 27), day(2025, 12, 26)},
+		},
+		{
+			name:   "until stops the rule",
+			rule:   "FREQ=DAILY;UNTIL=20240103",
+			start:  day(2024, 1, 1),
+			after:  time.Time{},
+			before: day(2024, 12, 31),
+			limit:  10,
+			want:   []time.Time{day(2024, 1, 1), day(2024, 1, 2), day(2024, 1, 3)},
+		},
+		{
+			name:   "local time of day is kept across daylight saving time",
+			rule:   "FREQ=WEEKLY",
+			start:  time.Date(2024, 3, 25, 9, 0, 0, 0, lisbon).AddDate(0, 0, -7),
+			after:  time.Time{},
+			before: time.Date(2024, 4, 1, 9, 0, 0, 0, lisbon),
+			limit:  10,
+			want:   []time.Time{time.Date(2024, 3, 18, 9, 0, 0, 0, lisbon), time.Date(2024, 3, 25, 9, 0, 0, 0, lisbon), time.Date(2024, 4, 1, 9, 0, 0, 0, lisbon)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got := rule.Between(tt.start, tt.after, tt.before, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Between()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
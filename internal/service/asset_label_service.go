@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sword-challenge/config"
+	"sword-challenge/internal/labels"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+var ErrInvalidLabel = errors.New("label token is not valid")
+
+// AssetLabelService makes the QR code labels of assets and resolves scanned
+// labels. Managers print labels; everyone can scan them.
+type AssetLabelService struct {
+	assetService *AssetService
+	assetRepo    repository.AssetRepository
+	siteRepo     repository.SiteRepository
+	signer       *labels.Signer
+	baseURL      string
+}
+
+func NewAssetLabelService(
+	assetService *AssetService,
+	assetRepo repository.AssetRepository,
+	siteRepo repository.SiteRepository,
+	signer *labels.Signer,
+	cfg config.AssetLabels,
+) *AssetLabelService {
+	return &AssetLabelService{
+		assetService: assetService,
+		assetRepo:    assetRepo,
+		siteRepo:     siteRepo,
+		signer:       signer,
+		baseURL:      cfg.BaseURL,
+	}
+}
+
+// GetLabel renders the label of an asset as a size x size pixels PNG or as
+// an SVG; size is ignored for SVG
+func (s *AssetLabelService) GetLabel(ctx context.Context, assetID int64, format string, size int, userID int64) ([]byte, error) {
+	if err := requireManager(ctx, s.assetService.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if size == 0 {
+		size = models.DefaultLabelSize
+	}
+	if !models.IsValidLabelFormat(format) || size < models.MinLabelSize || size > models.MaxLabelSize {
+		return nil, ErrInvalidInput
+	}
+
+	asset, err := s.assetService.getAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if format == models.LabelFormatSVG {
+		return labels.SVG(s.link(asset.ID))
+	}
+	return labels.PNG(s.link(asset.ID), size)
+}
+
+// GetLabelSheet renders the labels of the assets of a site, or of every asset
+// when siteID is 0, as a printable PDF
+func (s *AssetLabelService) GetLabelSheet(ctx context.Context, siteID int64, userID int64) ([]byte, error) {
+	if err := requireManager(ctx, s.assetService.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if siteID < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	// Labels carry the name of the site so they go on the right equipment
+	var sites []*models.Site
+	if siteID != 0 {
+		site, err := s.siteRepo.GetByID(ctx, siteID)
+		if err != nil {
+			return nil, err
+		}
+		if site == nil {
+			return nil, ErrNotFound
+		}
+		sites = []*models.Site{site}
+	} else {
+		var err error
+		if sites, err = s.siteRepo.List(ctx, 0); err != nil {
+			return nil, err
+		}
+	}
+	siteNames := make(map[int64]string, len(sites))
+	for _, site := range sites {
+		siteNames[site.ID] = site.Name
+	}
+
+	assets, err := s.assetRepo.List(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	if len(assets) == 0 {
+		return nil, ErrNotFound
+	}
+	if len(assets) > models.MaxLabelSheetAssets {
+		return nil, ErrInvalidInput
+	}
+
+	sheet := make([]labels.Label, 0, len(assets))
+	for _, asset := range assets {
+		lines := []string{asset.Name, "S/N " + asset.SerialNumber}
+		if asset.Model != "" {
+			lines = append(lines, asset.Model)
+		}
+		lines = append(lines, siteNames[asset.SiteID])
+		sheet = append(sheet, labels.Label{Content: s.link(asset.ID), Lines: lines})
+	}
+	return labels.Sheet(sheet)
+}
+
+// Resolve returns the asset of a scanned label with its site and open tasks
+func (s *AssetLabelService) Resolve(ctx context.Context, token string, userID int64) (*models.AssetScan, error) {
+	assetID, err := s.signer.Parse(token)
+	if err != nil {
+		return nil, ErrInvalidLabel
+	}
+
+	// The history only holds the tasks the user can see
+	tasks, err := s.assetService.GetHistory(ctx, assetID, userID)
+	if err != nil {
+		return nil, err
+	}
+	openTasks := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status == models.TaskStatusOpen {
+			openTasks = append(openTasks, task)
+		}
+	}
+
+	asset, err := s.assetService.getAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	site, err := s.siteRepo.GetByID(ctx, asset.SiteID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AssetScan{Asset: asset, Site: site, OpenTasks: openTasks}, nil
+}
+
+// link returns the deep link encoded in the label of the asset
+func (s *AssetLabelService) link(assetID int64) string {
+	return s.baseURL + "?token=" + url.QueryEscape(s.signer.Token(assetID))
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/labels"
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssetLabelService_Resolve(t *testing.T) {
+	signer := labels.NewSigner([]byte("a-label-secret-of-at-least-32-bytes"))
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+
+	tests := []struct {
+		name          string
+		token         string
+		asset         *models.Asset
+		expectedTasks []int64
+		expectedError error
+	}{
+		{
+			name:          "open tasks of the technician",
+			token:         signer.Token(7),
+			asset:         &models.Asset{ID: 7, SiteID: 1},
+			expectedTasks: []int64{5},
+		},
+		{
+			name:          "forged token",
+			token:         "7.AAAAAAAAAAAAAAAAAAAAAA",
+			expectedError: ErrInvalidLabel,
+		},
+		{
+			name:          "deleted asset",
+			token:         signer.Token(7),
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockAssetRepo := new(MockAssetRepository)
+			mockSiteRepo := new(MockSiteRepository)
+			mockTaskRepo := new(MockTaskRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, technician.ID).Return(technician, nil)
+			if tt.asset != nil {
+				mockAssetRepo.On("GetByID", mock.Anything, int64(7)).Return(tt.asset, nil)
+			} else {
+				mockAssetRepo.On("GetByID", mock.Anything, int64(7)).Return(nil, nil)
+			}
+			mockSiteRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Site{ID: 1, Name: "Lisbon headquarters"}, nil)
+			mockTaskRepo.On("GetByAssetID", mock.Anything, int64(7), technician.ID).Return([]*models.Task{
+				{ID: 5, Status: models.TaskStatusOpen},
+				{ID: 3, Status: models.TaskStatusCompleted},
+			}, nil)
+
+			assetService := NewAssetService(mockAssetRepo, mockSiteRepo, mockTaskRepo, mockUserRepo)
+			service := NewAssetLabelService(assetService, mockAssetRepo, mockSiteRepo, signer, config.AssetLabels{BaseURL: "https://app.example/scan"})
+			scan, err := service.Resolve(context.Background(), tt.token, technician.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.asset, scan.Asset)
+				assert.Equal(t, "Lisbon headquarters", scan.Site.Name)
+				var taskIDs []int64
+				for _, task := range scan.OpenTasks {
+					taskIDs = append(taskIDs, task.ID)
+				}
+				assert.Equal(t, tt.expectedTasks, taskIDs)
+			}
+		})
+	}
+}
+
+func TestAssetLabelService_GetLabel(t *testing.T) {
+	signer := labels.NewSigner([]byte("a-label-secret-of-at-least-32-bytes"))
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		format         string
+		size           int
+		expectedPrefix []byte
+		expectedError  error
+	}{
+		{name: "png", user: manager, format: models.LabelFormatPNG, expectedPrefix: []byte("\x89PNG")},
+		{name: "svg", user: manager, format: models.LabelFormatSVG, expectedPrefix: []byte("<svg")},
+		{name: "unknown format", user: manager, format: "gif", expectedError: ErrInvalidInput},
+		{name: "too large", user: manager, format: models.LabelFormatPNG, size: models.MaxLabelSize + 1, expectedError: ErrInvalidInput},
+		{name: "technicians do not print labels", user: technician, format: models.LabelFormatPNG, expectedError: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockAssetRepo := new(MockAssetRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockAssetRepo.On("GetByID", mock.Anything, int64(7)).Return(&models.Asset{ID: 7, SiteID: 1}, nil)
+
+			assetService := NewAssetService(mockAssetRepo, new(MockSiteRepository), new(MockTaskRepository), mockUserRepo)
+			service := NewAssetLabelService(assetService, mockAssetRepo, new(MockSiteRepository), signer, config.AssetLabels{BaseURL: "https://app.example/scan"})
+			label, err := service.GetLabel(context.Background(), 7, tt.format, tt.size, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.True(t, bytes.HasPrefix(label, tt.expectedPrefix))
+			}
+		})
+	}
+}
+
+func TestAssetLabelService_GetLabelSheet(t *testing.T) {
+	signer := labels.NewSigner([]byte("a-label-secret-of-at-least-32-bytes"))
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	mockUserRepo := new(MockUserRepository)
+	mockAssetRepo := new(MockAssetRepository)
+	mockSiteRepo := new(MockSiteRepository)
+	mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+	mockSiteRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Site{ID: 1, Name: "Lisbon headquarters"}, nil)
+	mockSiteRepo.On("GetByID", mock.Anything, int64(2)).Return(&models.Site{ID: 2, Name: "Porto warehouse"}, nil)
+	mockAssetRepo.On("List", mock.Anything, int64(1)).Return([]*models.Asset{
+		{ID: 7, SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", Model: "Carrier 30RB-160"},
+	}, nil)
+	mockAssetRepo.On("List", mock.Anything, int64(2)).Return([]*models.Asset{}, nil)
+
+	assetService := NewAssetService(mockAssetRepo, mockSiteRepo, new(MockTaskRepository), mockUserRepo)
+	service := NewAssetLabelService(assetService, mockAssetRepo, mockSiteRepo, signer, config.AssetLabels{BaseURL: "https://app.example/scan"})
+
+	sheet, err := service.GetLabelSheet(context.Background(), 1, manager.ID)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(sheet, []byte("%PDF-")))
+
+	// A site without assets has no sheet to print
+	_, err = service.GetLabelSheet(context.Background(), 2, manager.ID)
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"time"
+)
+
+var ErrAssetExists = errors.New("another asset of the model has the serial number")
+
+// AssetService manages the assets installed on sites and their maintenance
+// history. Everyone reads assets; only managers change them. Managers see
+// every task performed on an asset, technicians only their own.
+type AssetService struct {
+	assetRepo repository.AssetRepository
+	siteRepo  repository.SiteRepository
+	taskRepo  repository.TaskRepository
+	userRepo  repository.UserRepository
+}
+
+func NewAssetService(
+	assetRepo repository.AssetRepository,
+	siteRepo repository.SiteRepository,
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+) *AssetService {
+	return &AssetService{
+		assetRepo: assetRepo,
+		siteRepo:  siteRepo,
+		taskRepo:  taskRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetAssets returns the assets of a site; siteID 0 means every site
+func (s *AssetService) GetAssets(ctx context.Context, siteID int64, userID int64) ([]*models.Asset, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if siteID < 0 {
+		return nil, ErrInvalidInput
+	}
+	return s.assetRepo.List(ctx, siteID)
+}
+
+func (s *AssetService) GetAsset(ctx context.Context, id int64, userID int64) (*models.Asset, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.getAsset(ctx, id)
+}
+
+func (s *AssetService) CreateAsset(ctx context.Context, asset *models.Asset, userID int64) (*models.Asset, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if err := s.saveAsset(ctx, asset, s.assetRepo.Create); err != nil {
+		return nil, err
+	}
+	return s.getAsset(ctx, asset.ID)
+}
+
+// UpdateAsset changes an asset; moving it to another site keeps its history
+func (s *AssetService) UpdateAsset(ctx context.Context, asset *models.Asset, userID int64) (*models.Asset, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getAsset(ctx, asset.ID); err != nil {
+		return nil, err
+	}
+	if err := s.saveAsset(ctx, asset, s.assetRepo.Update); err != nil {
+		return nil, err
+	}
+	return s.getAsset(ctx, asset.ID)
+}
+
+// DeleteAsset removes an asset. The tasks performed on it keep the link, so
+// its history stays readable.
+func (s *AssetService) DeleteAsset(ctx context.Context, id int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getAsset(ctx, id); err != nil {
+		return err
+	}
+	return s.assetRepo.Delete(ctx, id)
+}
+
+// GetHistory returns the tasks performed on the asset, most recent first
+func (s *AssetService) GetHistory(ctx context.Context, id int64, userID int64) ([]*models.Task, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getAsset(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// Technicians can only see their own tasks
+	var technicianID int64
+	if user.IsTechnician() {
+		technicianID = userID
+	}
+	return s.taskRepo.GetByAssetID(ctx, id, technicianID)
+}
+
+// saveAsset validates the asset and its site before writing it with save
+func (s *AssetService) saveAsset(ctx context.Context, asset *models.Asset, save func(context.Context, *models.Asset) error) error {
+	// Sanitize input
+	asset.Sanitize()
+
+	// Validate input
+	if err := asset.Validate(time.Now()); err != nil {
+		return ErrInvalidInput
+	}
+	site, err := s.siteRepo.GetByID(ctx, asset.SiteID)
+	if err != nil {
+		return err
+	}
+	if site == nil {
+		return ErrUnknownSite
+	}
+
+	if err := save(ctx, asset); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return ErrAssetExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *AssetService) getAsset(ctx context.Context, id int64) (*models.Asset, error) {
+	asset, err := s.assetRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, ErrNotFound
+	}
+	return asset, nil
+}
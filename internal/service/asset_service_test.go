@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAssetRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssetRepository) Create(ctx context.Context, asset *models.Asset) error {
+	args := m.Called(ctx, asset)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) GetByID(ctx context.Context, id int64) (*models.Asset, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) List(ctx context.Context, siteID int64) ([]*models.Asset, error) {
+	args := m.Called(ctx, siteID)
+	return args.Get(0).([]*models.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) CountBySiteID(ctx context.Context, siteID int64) (int, error) {
+	args := m.Called(ctx, siteID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAssetRepository) Update(ctx context.Context, asset *models.Asset) error {
+	args := m.Called(ctx, asset)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestAssetService_CreateAsset(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	tests := []struct {
+		name          string
+		asset         *models.Asset
+		setupMocks    func(*MockAssetRepository, *MockSiteRepository)
+		expectedError error
+	}{
+		{
+			name:  "manager adds an asset",
+			asset: &models.Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: " CH-2019-0042 ", Model: "Carrier 30RB-160", InstalledOn: "2019-06-01"},
+			setupMocks: func(ar *MockAssetRepository, sr *MockSiteRepository) {
+				sr.On("GetByID", mock.Anything, int64(1)).Return(&models.Site{ID: 1}, nil)
+				ar.On("Create", mock.Anything, mock.MatchedBy(func(a *models.Asset) bool { return a.SerialNumber == "CH-2019-0042" })).
+					Run(func(args mock.Arguments) { args.Get(1).(*models.Asset).ID = 7 }).Return(nil)
+				ar.On("GetByID", mock.Anything, int64(7)).Return(&models.Asset{ID: 7}, nil)
+			},
+		},
+		{
+			name:          "installed in the future",
+			asset:         &models.Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", InstalledOn: "2999-01-01"},
+			setupMocks:    func(ar *MockAssetRepository, sr *MockSiteRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:  "unknown site",
+			asset: &models.Asset{SiteID: 9, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042"},
+			setupMocks: func(ar *MockAssetRepository, sr *MockSiteRepository) {
+				sr.On("GetByID", mock.Anything, int64(9)).Return(nil, nil)
+			},
+			expectedError: ErrUnknownSite,
+		},
+		{
+			name:  "serial number already used for the model",
+			asset: &models.Asset{SiteID: 1, Name: "Rooftop chiller 1", SerialNumber: "CH-2019-0042", Model: "Carrier 30RB-160"},
+			setupMocks: func(ar *MockAssetRepository, sr *MockSiteRepository) {
+				sr.On("GetByID", mock.Anything, int64(1)).Return(&models.Site{ID: 1}, nil)
+				ar.On("Create", mock.Anything, mock.Anything).Return(repository.ErrDuplicate)
+			},
+			expectedError: ErrAssetExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockAssetRepo := new(MockAssetRepository)
+			mockSiteRepo := new(MockSiteRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+			tt.setupMocks(mockAssetRepo, mockSiteRepo)
+
+			service := NewAssetService(mockAssetRepo, mockSiteRepo, new(MockTaskRepository), mockUserRepo)
+			asset, err := service.CreateAsset(context.Background(), tt.asset, manager.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, int64(7), asset.ID)
+			}
+			mockAssetRepo.AssertExpectations(t)
+			mockSiteRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssetService_GetHistory(t *testing.T) {
+	tests := []struct {
+		name                 string
+		user                 *models.User
+		expectedTechnicianID int64
+	}{
+		{name: "managers see every task", user: &models.User{ID: 1, Role: models.RoleManager}},
+		{name: "technicians see their own tasks", user: &models.User{ID: 2, Role: models.RoleTechnician}, expectedTechnicianID: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockAssetRepo := new(MockAssetRepository)
+			mockTaskRepo := new(MockTaskRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockAssetRepo.On("GetByID", mock.Anything, int64(7)).Return(&models.Asset{ID: 7}, nil)
+			mockTaskRepo.On("GetByAssetID", mock.Anything, int64(7), tt.expectedTechnicianID).
+				Return([]*models.Task{{ID: 4}, {ID: 2}}, nil)
+
+			service := NewAssetService(mockAssetRepo, new(MockSiteRepository), mockTaskRepo, mockUserRepo)
+			tasks, err := service.GetHistory(context.Background(), 7, tt.user.ID)
+
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 2)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+var (
+	ErrCustomerHasSites = errors.New("customer has sites")
+	ErrSiteHasAssets    = errors.New("site has assets")
+	ErrUnknownCustomer  = errors.New("customer does not exist")
+)
+
+// CustomerService manages customers and their sites. Everyone reads them;
+// only managers change them. Deleted customers and sites stay linked to the
+// tasks performed there.
+type CustomerService struct {
+	customerRepo repository.CustomerRepository
+	siteRepo     repository.SiteRepository
+	assetRepo    repository.AssetRepository
+	userRepo     repository.UserRepository
+}
+
+func NewCustomerService(
+	customerRepo repository.CustomerRepository,
+	siteRepo repository.SiteRepository,
+	assetRepo repository.AssetRepository,
+	userRepo repository.UserRepository,
+) *CustomerService {
+	return &CustomerService{
+		customerRepo: customerRepo,
+		siteRepo:     siteRepo,
+		assetRepo:    assetRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (s *CustomerService) GetCustomers(ctx context.Context, userID int64) ([]*models.Customer, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.customerRepo.List(ctx)
+}
+
+func (s *CustomerService) GetCustomer(ctx context.Context, id int64, userID int64) (*models.Customer, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.getCustomer(ctx, id)
+}
+
+func (s *CustomerService) CreateCustomer(ctx context.Context, customer *models.Customer, userID int64) (*models.Customer, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	customer.Sanitize()
+
+	// Validate input
+	if err := customer.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.customerRepo.Create(ctx, customer); err != nil {
+		return nil, err
+	}
+	return s.getCustomer(ctx, customer.ID)
+}
+
+func (s *CustomerService) UpdateCustomer(ctx context.Context, customer *models.Customer, userID int64) (*models.Customer, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getCustomer(ctx, customer.ID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	customer.Sanitize()
+
+	// Validate input
+	if err := customer.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.customerRepo.Update(ctx, customer); err != nil {
+		return nil, err
+	}
+	return s.getCustomer(ctx, customer.ID)
+}
+
+// DeleteCustomer removes a customer once its sites are deleted
+func (s *CustomerService) DeleteCustomer(ctx context.Context, id int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getCustomer(ctx, id); err != nil {
+		return err
+	}
+
+	sites, err := s.siteRepo.CountByCustomerID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sites > 0 {
+		return ErrCustomerHasSites
+	}
+	return s.customerRepo.Delete(ctx, id)
+}
+
+// GetSites returns the sites of a customer; customerID 0 means every customer
+func (s *CustomerService) GetSites(ctx context.Context, customerID int64, userID int64) ([]*models.Site, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if customerID < 0 {
+		return nil, ErrInvalidInput
+	}
+	return s.siteRepo.List(ctx, customerID)
+}
+
+func (s *CustomerService) GetSite(ctx context.Context, id int64, userID int64) (*models.Site, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.getSite(ctx, id)
+}
+
+func (s *CustomerService) CreateSite(ctx context.Context, site *models.Site, userID int64) (*models.Site, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	site.Sanitize()
+	if site.GeofenceRadius == 0 {
+		site.GeofenceRadius = models.DefaultGeofenceRadius
+	}
+
+	// Validate input
+	if err := site.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+	customer, err := s.customerRepo.GetByID(ctx, site.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, ErrUnknownCustomer
+	}
+
+	if err := s.siteRepo.Create(ctx, site); err != nil {
+		return nil, err
+	}
+	return s.getSite(ctx, site.ID)
+}
+
+// UpdateSite changes the address, coordinates and geofence of a site; a site
+// cannot move to another customer
+func (s *CustomerService) UpdateSite(ctx context.Context, site *models.Site, userID int64) (*models.Site, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	existingSite, err := s.getSite(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	site.CustomerID = existingSite.CustomerID
+
+	// Sanitize input
+	site.Sanitize()
+	if site.GeofenceRadius == 0 {
+		site.GeofenceRadius = existingSite.GeofenceRadius
+	}
+
+	// Validate input
+	if err := site.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.siteRepo.Update(ctx, site); err != nil {
+		return nil, err
+	}
+	return s.getSite(ctx, site.ID)
+}
+
+// DeleteSite removes a site once its assets are deleted
+func (s *CustomerService) DeleteSite(ctx context.Context, id int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getSite(ctx, id); err != nil {
+		return err
+	}
+
+	assets, err := s.assetRepo.CountBySiteID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if assets > 0 {
+		return ErrSiteHasAssets
+	}
+	return s.siteRepo.Delete(ctx, id)
+}
+
+func (s *CustomerService) getCustomer(ctx context.Context, id int64) (*models.Customer, error) {
+	customer, err := s.customerRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, ErrNotFound
+	}
+	return customer, nil
+}
+
+func (s *CustomerService) getSite(ctx context.Context, id int64) (*models.Site, error) {
+	site, err := s.siteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if site == nil {
+		return nil, ErrNotFound
+	}
+	return site, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerRepository) Create(ctx context.Context, customer *models.Customer) error {
+	args := m.Called(ctx, customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetByID(ctx context.Context, id int64) (*models.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) List(ctx context.Context) ([]*models.Customer, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) Update(ctx context.Context, customer *models.Customer) error {
+	args := m.Called(ctx, customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockSiteRepository struct {
+	mock.Mock
+}
+
+func (m *MockSiteRepository) Create(ctx context.Context, site *models.Site) error {
+	args := m.Called(ctx, site)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) GetByID(ctx context.Context, id int64) (*models.Site, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) List(ctx context.Context, customerID int64) ([]*models.Site, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*models.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) CountByCustomerID(ctx context.Context, customerID int64) (int, error) {
+	args := m.Called(ctx, customerID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSiteRepository) Update(ctx context.Context, site *models.Site) error {
+	args := m.Called(ctx, site)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCustomerService_CreateSite(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+	latitude, longitude := 38.7197, -9.1453
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		site          *models.Site
+		setupMocks    func(*MockCustomerRepository, *MockSiteRepository)
+		expectedError error
+	}{
+		{
+			name: "manager adds a site",
+			user: manager,
+			site: &models.Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Country: "pt", Latitude: &latitude, Longitude: &longitude},
+			setupMocks: func(cr *MockCustomerRepository, sr *MockSiteRepository) {
+				cr.On("GetByID", mock.Anything, int64(1)).Return(&models.Customer{ID: 1}, nil)
+				sr.On("Create", mock.Anything, mock.MatchedBy(func(s *models.Site) bool { return s.Country == "PT" })).
+					Run(func(args mock.Arguments) { args.Get(1).(*models.Site).ID = 3 }).Return(nil)
+				sr.On("GetByID", mock.Anything, int64(3)).Return(&models.Site{ID: 3}, nil)
+			},
+		},
+		{
+			name:          "technicians cannot add sites",
+			user:          technician,
+			site:          &models.Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110"},
+			setupMocks:    func(cr *MockCustomerRepository, sr *MockSiteRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "latitude without longitude",
+			user:          manager,
+			site:          &models.Site{CustomerID: 1, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110", Latitude: &latitude},
+			setupMocks:    func(cr *MockCustomerRepository, sr *MockSiteRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name: "unknown customer",
+			user: manager,
+			site: &models.Site{CustomerID: 9, Name: "Lisbon headquarters", Address: "Avenida da Liberdade 110"},
+			setupMocks: func(cr *MockCustomerRepository, sr *MockSiteRepository) {
+				cr.On("GetByID", mock.Anything, int64(9)).Return(nil, nil)
+			},
+			expectedError: ErrUnknownCustomer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockCustomerRepo := new(MockCustomerRepository)
+			mockSiteRepo := new(MockSiteRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			tt.setupMocks(mockCustomerRepo, mockSiteRepo)
+
+			service := NewCustomerService(mockCustomerRepo, mockSiteRepo, new(MockAssetRepository), mockUserRepo)
+			site, err := service.CreateSite(context.Background(), tt.site, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, int64(3), site.ID)
+			}
+			mockCustomerRepo.AssertExpectations(t)
+			mockSiteRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCustomerService_Delete(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	mockUserRepo := new(MockUserRepository)
+	mockCustomerRepo := new(MockCustomerRepository)
+	mockSiteRepo := new(MockSiteRepository)
+	mockAssetRepo := new(MockAssetRepository)
+	mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+	mockCustomerRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Customer{ID: 1}, nil)
+	mockSiteRepo.On("CountByCustomerID", mock.Anything, int64(1)).Return(1, nil)
+	mockSiteRepo.On("GetByID", mock.Anything, int64(3)).Return(&models.Site{ID: 3, CustomerID: 1}, nil)
+	mockAssetRepo.On("CountBySiteID", mock.Anything, int64(3)).Return(0, nil)
+	mockSiteRepo.On("Delete", mock.Anything, int64(3)).Return(nil)
+
+	service := NewCustomerService(mockCustomerRepo, mockSiteRepo, mockAssetRepo, mockUserRepo)
+
+	// The customer keeps its site, so it cannot be deleted yet
+	assert.Equal(t, ErrCustomerHasSites, service.DeleteCustomer(context.Background(), 1, manager.ID))
+	assert.NoError(t, service.DeleteSite(context.Background(), 3, manager.ID))
+	mockCustomerRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockSiteRepo.AssertExpectations(t)
+}
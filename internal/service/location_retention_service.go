@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sword-challenge/config"
+	"sword-challenge/internal/repository"
+	"time"
+)
+
+// locationsPurged counts task locations whose coordinates were cleared by the
+// retention job, exposed on /debug/vars
+var locationsPurged = expvar.NewInt("task_locations_purged_total")
+
+type LocationRetentionService struct {
+	taskRepo  repository.TaskRepository
+	retention config.LocationRetention
+}
+
+func NewLocationRetentionService(
+	taskRepo repository.TaskRepository,
+	retention config.LocationRetention,
+) *LocationRetentionService {
+	return &LocationRetentionService{
+		taskRepo:  taskRepo,
+		retention: retention,
+	}
+}
+
+// Purge clears the coordinates of task locations older than the retention
+// window, one batch at a time, and returns how many were cleared. Whether the
+// task was inside the geofence and how far away it was are kept.
+func (s *LocationRetentionService) Purge(ctx context.Context) (int64, error) {
+	if s.retention.MaxAge <= 0 || s.retention.BatchSize <= 0 {
+		return 0, ErrInvalidInput
+	}
+
+	before := time.Now().Add(-s.retention.MaxAge)
+	var total int64
+	for {
+		purged, err := s.taskRepo.PurgeLocations(ctx, before, s.retention.BatchSize)
+		total += purged
+		locationsPurged.Add(purged)
+		if err != nil {
+			return total, err
+		}
+		if purged < int64(s.retention.BatchSize) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+
+	log.Printf("Cleared the coordinates of %d task locations recorded before %s", total, before.Format(time.RFC3339))
+	return total, nil
+}
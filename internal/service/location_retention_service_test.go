@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sword-challenge/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLocationRetentionService_Purge(t *testing.T) {
+	retention := config.LocationRetention{MaxAge: 24 * time.Hour, BatchSize: 2}
+	beforeCutoff := mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-23 * time.Hour))
+	})
+
+	tests := []struct {
+		name          string
+		retention     config.LocationRetention
+		setupMocks    func(*MockTaskRepository)
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			name:      "success - runs batches until a short one",
+			retention: retention,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("PurgeLocations", mock.Anything, beforeCutoff, 2).Return(int64(2), nil).Once()
+				tr.On("PurgeLocations", mock.Anything, beforeCutoff, 2).Return(int64(1), nil).Once()
+			},
+			expectedTotal: 3,
+		},
+		{
+			name:      "error - repository error",
+			retention: retention,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("PurgeLocations", mock.Anything, beforeCutoff, 2).Return(int64(0), errors.New("repository error")).Once()
+			},
+			expectedErr: errors.New("repository error"),
+		},
+		{
+			name:        "error - invalid retention",
+			retention:   config.LocationRetention{BatchSize: 2},
+			setupMocks:  func(tr *MockTaskRepository) {},
+			expectedErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			tt.setupMocks(mockTaskRepo)
+
+			service := NewLocationRetentionService(mockTaskRepo, tt.retention)
+			total, err := service.Purge(context.Background())
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedTotal, total)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sword-challenge/config"
+	"sword-challenge/internal/repository"
+	"time"
+)
+
+// notificationsPurged counts notifications removed by the retention job,
+// exposed on /debug/vars
+var notificationsPurged = expvar.NewInt("notifications_purged_total")
+
+type NotificationRetentionService struct {
+	notificationRepo repository.NotificationRepository
+	retention        config.NotificationRetention
+}
+
+func NewNotificationRetentionService(
+	notificationRepo repository.NotificationRepository,
+	retention config.NotificationRetention,
+) *NotificationRetentionService {
+	return &NotificationRetentionService{
+		notificationRepo: notificationRepo,
+		retention:        retention,
+	}
+}
+
+// Purge removes read notifications older than the retention age, one batch at
+// a time, and returns how many were removed
+func (s *NotificationRetentionService) Purge(ctx context.Context) (int64, error) {
+	if s.retention.MaxAge <= 0 || s.retention.BatchSize <= 0 {
+		return 0, ErrInvalidInput
+	}
+
+	before := time.Now().Add(-s.retention.MaxAge)
+	var total int64
+	for {
+		purged, err := s.notificationRepo.PurgeRead(ctx, before, s.retention.BatchSize, s.retention.Archive)
+		total += purged
+		notificationsPurged.Add(purged)
+		if err != nil {
+			return total, err
+		}
+		if purged < int64(s.retention.BatchSize) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+
+	log.Printf("Purged %d read notifications older than %s", total, before.Format(time.RFC3339))
+	return total, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sword-challenge/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationRetentionService_Purge(t *testing.T) {
+	retention := config.NotificationRetention{MaxAge: 24 * time.Hour, BatchSize: 2, Archive: true}
+	beforeCutoff := mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-23 * time.Hour))
+	})
+
+	tests := []struct {
+		name          string
+		retention     config.NotificationRetention
+		setupMocks    func(*MockNotificationRepository)
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			name:      "success - runs batches until a short one",
+			retention: retention,
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("PurgeRead", mock.Anything, beforeCutoff, 2, true).Return(int64(2), nil).Twice()
+				nr.On("PurgeRead", mock.Anything, beforeCutoff, 2, true).Return(int64(1), nil).Once()
+			},
+			expectedTotal: 5,
+		},
+		{
+			name:      "success - nothing to purge",
+			retention: retention,
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("PurgeRead", mock.Anything, beforeCutoff, 2, true).Return(int64(0), nil).Once()
+			},
+			expectedTotal: 0,
+		},
+		{
+			name:      "error - repository error keeps the partial count",
+			retention: retention,
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("PurgeRead", mock.Anything, beforeCutoff, 2, true).Return(int64(2), nil).Once()
+				nr.On("PurgeRead", mock.Anything, beforeCutoff, 2, true).Return(int64(0), errors.New("repository error")).Once()
+			},
+			expectedTotal: 2,
+			expectedErr:   errors.New("repository error"),
+		},
+		{
+			name:          "error - invalid retention",
+			retention:     config.NotificationRetention{BatchSize: 2},
+			setupMocks:    func(nr *MockNotificationRepository) {},
+			expectedTotal: 0,
+			expectedErr:   ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotifRepo := new(MockNotificationRepository)
+			tt.setupMocks(mockNotifRepo)
+
+			service := NewNotificationRetentionService(mockNotifRepo, tt.retention)
+			total, err := service.Purge(context.Background())
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedTotal, total)
+			mockNotifRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -21,7 +21,7 @@ func NewNotificationService(
 	}
 }
 
-func (s *NotificationService) GetUnreadNotifications(ctx context.Context, userID int64) ([]*models.Notification, error) {
+func (s *NotificationService) ListNotifications(ctx context.Context, filter models.NotificationFilter, userID int64) (*models.NotificationPage, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -30,12 +30,66 @@ func (s *NotificationService) GetUnreadNotifications(ctx context.Context, userID
 		return nil, ErrNotFound
 	}
 
-	// Only managers can view notifications
-	if !user.IsManager() {
+	if err := filter.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	// Everyone sees the notifications addressed to them; managers also see
+	// the shared ones
+	filter.RecipientID = user.ID
+	filter.IncludeShared = user.IsManager()
+
+	// Fetch one extra row to know whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	notifications, err := s.notificationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.NotificationPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		page.NextCursor = page.Notifications[limit-1].ID
+	}
+
+	page.UnreadCount, err = s.notificationRepo.CountUnread(ctx, filter.RecipientID, filter.IncludeShared)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notification := range page.Notifications {
+		if err := notification.Localize(user.Locale, user.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (s *NotificationService) GetNotification(ctx context.Context, notificationID int64, userID int64) (*models.Notification, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if notification == nil {
+		return nil, ErrNotFound
+	}
+	if !notification.VisibleTo(user) {
 		return nil, ErrUnauthorized
 	}
 
-	return s.notificationRepo.GetUnread(ctx)
+	if err := notification.Localize(user.Locale, user.Timezone); err != nil {
+		return nil, err
+	}
+	return notification, nil
 }
 
 func (s *NotificationService) MarkAsRead(ctx context.Context, notificationID int64, userID int64) error {
@@ -47,8 +101,15 @@ func (s *NotificationService) MarkAsRead(ctx context.Context, notificationID int
 		return ErrNotFound
 	}
 
-	// Only managers can mark notifications as read
-	if !user.IsManager() {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return err
+	}
+	if notification == nil {
+		return ErrNotFound
+	}
+	// Only the readers of a notification can mark it as read
+	if !notification.VisibleTo(user) {
 		return ErrUnauthorized
 	}
 
@@ -22,14 +22,32 @@ func (m *MockNotificationRepository) Create(ctx context.Context, notification *m
 	return args.Error(0)
 }
 
-func (m *MockNotificationRepository) GetUnread(ctx context.Context) ([]*models.Notification, error) {
-	args := m.Called(ctx)
+func (m *MockNotificationRepository) CreateAll(ctx context.Context, notifications []*models.Notification) error {
+	args := m.Called(ctx, notifications)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByID(ctx context.Context, id int64) (*models.Notification, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) List(ctx context.Context, filter models.NotificationFilter) ([]*models.Notification, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Notification), args.Error(1)
 }
 
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, recipientID int64, includeShared bool) (int64, error) {
+	args := m.Called(ctx, recipientID, includeShared)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockNotificationRepository) MarkAsRead(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -40,56 +58,99 @@ func (m *MockNotificationRepository) Delete(ctx context.Context, id int64) error
 	return args.Error(0)
 }
 
-func TestNotificationService_GetUnreadNotifications(t *testing.T) {
+func (m *MockNotificationRepository) PurgeRead(ctx context.Context, before time.Time, limit int, archive bool) (int64, error) {
+	args := m.Called(ctx, before, limit, archive)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestNotificationService_MarkAsRead(t *testing.T) {
+	technicianID := int64(1)
+	otherID := int64(5)
 	tests := []struct {
 		name           string
+		notificationID int64
 		userID         int64
 		mockUser       *models.User
 		mockUserErr    error
-		mockNotifs     []*models.Notification
-		mockNotifsErr  error
-		expectedNotifs []*models.Notification
+		mockNotif      *models.Notification
+		mockNotifErr   error
+		expectMark     bool
 		expectedErr    error
 	}{
 		{
-			name:   "success - manager gets notifications",
-			userID: 1,
+			name:           "success - manager marks notification as read",
+			notificationID: 1,
+			userID:         1,
 			mockUser: &models.User{
 				ID:   1,
 				Role: models.RoleManager,
 			},
-			mockNotifs: []*models.Notification{
-				{ID: 1, TaskID: 1, Message: "Test notification", IsRead: false, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
-			},
-			expectedNotifs: []*models.Notification{
-				{ID: 1, TaskID: 1, Message: "Test notification", IsRead: false, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			mockNotif:  &models.Notification{ID: 1},
+			expectMark: true,
+		},
+		{
+			name:           "success - technician marks their mention as read",
+			notificationID: 1,
+			userID:         1,
+			mockUser: &models.User{
+				ID:   1,
+				Role: models.RoleTechnician,
 			},
+			mockNotif:  &models.Notification{ID: 1, RecipientID: &technicianID},
+			expectMark: true,
 		},
 		{
-			name:        "error - user not found",
-			userID:      1,
-			mockUser:    nil,
-			mockUserErr: nil,
+			name:           "error - user not found",
+			notificationID: 1,
+			userID:         1,
+			mockUser:       nil,
+			mockUserErr:    nil,
+			expectedErr:    ErrNotFound,
+		},
+		{
+			name:           "error - notification not found",
+			notificationID: 1,
+			userID:         1,
+			mockUser: &models.User{
+				ID:   1,
+				Role: models.RoleManager,
+			},
 			expectedErr: ErrNotFound,
 		},
 		{
-			name:   "error - user not manager",
-			userID: 1,
+			name:           "error - technician cannot mark shared notifications",
+			notificationID: 1,
+			userID:         1,
 			mockUser: &models.User{
 				ID:   1,
 				Role: models.RoleTechnician,
 			},
+			mockNotif:   &models.Notification{ID: 1},
 			expectedErr: ErrUnauthorized,
 		},
 		{
-			name:   "error - repository error",
-			userID: 1,
+			name:           "error - manager cannot mark another user's mention",
+			notificationID: 1,
+			userID:         1,
 			mockUser: &models.User{
 				ID:   1,
 				Role: models.RoleManager,
 			},
-			mockNotifsErr: errors.New("repository error"),
-			expectedErr:   errors.New("repository error"),
+			mockNotif:   &models.Notification{ID: 1, RecipientID: &otherID},
+			expectedErr: ErrUnauthorized,
+		},
+		{
+			name:           "error - repository error",
+			notificationID: 1,
+			userID:         1,
+			mockUser: &models.User{
+				ID:   1,
+				Role: models.RoleManager,
+			},
+			mockNotif:    &models.Notification{ID: 1},
+			mockNotifErr: errors.New("repository error"),
+			expectMark:   true,
+			expectedErr:  errors.New("repository error"),
 		},
 	}
 
@@ -101,24 +162,25 @@ func TestNotificationService_GetUnreadNotifications(t *testing.T) {
 
 			// Setup expectations
 			mockUserRepo.On("GetByID", mock.Anything, tt.userID).Return(tt.mockUser, tt.mockUserErr)
-			if tt.mockUser != nil && tt.mockUser.Role == models.RoleManager {
-				mockNotifRepo.On("GetUnread", mock.Anything).Return(tt.mockNotifs, tt.mockNotifsErr)
+			if tt.mockUser != nil {
+				mockNotifRepo.On("GetByID", mock.Anything, tt.notificationID).Return(tt.mockNotif, nil)
+			}
+			if tt.expectMark {
+				mockNotifRepo.On("MarkAsRead", mock.Anything, tt.notificationID).Return(tt.mockNotifErr)
 			}
 
 			// Create service
 			service := NewNotificationService(mockNotifRepo, mockUserRepo)
 
 			// Execute
-			notifs, err := service.GetUnreadNotifications(context.Background(), tt.userID)
+			err := service.MarkAsRead(context.Background(), tt.notificationID, tt.userID)
 
 			// Assert
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedErr, err)
-				assert.Nil(t, notifs)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedNotifs, notifs)
 			}
 
 			// Verify all expectations were met
@@ -128,83 +190,159 @@ func TestNotificationService_GetUnreadNotifications(t *testing.T) {
 	}
 }
 
-func TestNotificationService_MarkAsRead(t *testing.T) {
+func TestNotificationService_ListNotifications(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
 	tests := []struct {
-		name           string
-		notificationID int64
-		userID         int64
-		mockUser       *models.User
-		mockUserErr    error
-		mockNotifErr   error
-		expectedErr    error
+		name         string
+		filter       models.NotificationFilter
+		mockUser     *models.User
+		setupMocks   func(*MockNotificationRepository)
+		expectedPage *models.NotificationPage
+		expectedErr  error
 	}{
 		{
-			name:           "success - manager marks notification as read",
-			notificationID: 1,
-			userID:         1,
-			mockUser: &models.User{
-				ID:   1,
-				Role: models.RoleManager,
+			name:     "success - last page has no cursor",
+			filter:   models.NotificationFilter{Status: models.NotificationStatusAll, Limit: 2},
+			mockUser: manager,
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("List", mock.Anything, models.NotificationFilter{Status: models.NotificationStatusAll, Limit: 3, RecipientID: 1, IncludeShared: true}).
+					Return([]*models.Notification{{ID: 5}, {ID: 4, IsRead: true}}, nil)
+				nr.On("CountUnread", mock.Anything, int64(1), true).Return(int64(1), nil)
+			},
+			expectedPage: &models.NotificationPage{
+				Notifications: []*models.Notification{{ID: 5}, {ID: 4, IsRead: true}},
+				UnreadCount:   1,
 			},
 		},
 		{
-			name:           "error - user not found",
-			notificationID: 1,
-			userID:         1,
-			mockUser:       nil,
-			mockUserErr:    nil,
-			expectedErr:    ErrNotFound,
+			name:     "success - defaults and next cursor",
+			filter:   models.NotificationFilter{TaskID: 7, Limit: 2},
+			mockUser: manager,
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("List", mock.Anything, models.NotificationFilter{Status: models.NotificationStatusUnread, TaskID: 7, Limit: 3, RecipientID: 1, IncludeShared: true}).
+					Return([]*models.Notification{{ID: 9}, {ID: 8}, {ID: 3}}, nil)
+				nr.On("CountUnread", mock.Anything, int64(1), true).Return(int64(3), nil)
+			},
+			expectedPage: &models.NotificationPage{
+				Notifications: []*models.Notification{{ID: 9}, {ID: 8}},
+				NextCursor:    8,
+				UnreadCount:   3,
+			},
 		},
 		{
-			name:           "error - user not manager",
-			notificationID: 1,
-			userID:         1,
-			mockUser: &models.User{
-				ID:   1,
-				Role: models.RoleTechnician,
+			name:        "error - invalid status",
+			filter:      models.NotificationFilter{Status: "archived"},
+			mockUser:    manager,
+			setupMocks:  func(nr *MockNotificationRepository) {},
+			expectedErr: ErrInvalidInput,
+		},
+		{
+			name:        "error - limit too large",
+			filter:      models.NotificationFilter{Limit: models.MaxNotificationLimit + 1},
+			mockUser:    manager,
+			setupMocks:  func(nr *MockNotificationRepository) {},
+			expectedErr: ErrInvalidInput,
+		},
+		{
+			name:     "success - technician only sees notifications addressed to them",
+			mockUser: &models.User{ID: 1, Role: models.RoleTechnician},
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("List", mock.Anything, models.NotificationFilter{Status: models.NotificationStatusUnread, Limit: models.DefaultNotificationLimit + 1, RecipientID: 1}).
+					Return([]*models.Notification{}, nil)
+				nr.On("CountUnread", mock.Anything, int64(1), false).Return(int64(0), nil)
 			},
-			expectedErr: ErrUnauthorized,
+			expectedPage: &models.NotificationPage{Notifications: []*models.Notification{}},
 		},
 		{
-			name:           "error - repository error",
-			notificationID: 1,
-			userID:         1,
-			mockUser: &models.User{
-				ID:   1,
-				Role: models.RoleManager,
+			name:     "success - messages rendered in the user's locale and timezone",
+			mockUser: &models.User{ID: 1, Role: models.RoleManager, Locale: "pt-BR", Timezone: "America/Sao_Paulo"},
+			setupMocks: func(nr *MockNotificationRepository) {
+				nr.On("List", mock.Anything, models.NotificationFilter{Status: models.NotificationStatusUnread, Limit: models.DefaultNotificationLimit + 1, RecipientID: 1, IncludeShared: true}).
+					Return([]*models.Notification{
+						{ID: 1, TaskID: 1, Message: "The tech John Doe performed the task on 2024-03-20 14:30:00", TemplateKey: "task_performed", Params: map[string]string{"tech_name": "John Doe", "performed_at": "2024-03-20T14:30:00Z"}},
+					}, nil)
+				nr.On("CountUnread", mock.Anything, int64(1), true).Return(int64(1), nil)
+			},
+			expectedPage: &models.NotificationPage{
+				Notifications: []*models.Notification{
+					{ID: 1, TaskID: 1, Message: "O técnico John Doe realizou a tarefa em 20/03/2024 11:30:00", TemplateKey: "task_performed", Params: map[string]string{"tech_name": "John Doe", "performed_at": "2024-03-20T14:30:00Z"}},
+				},
+				UnreadCount: 1,
 			},
-			mockNotifErr: errors.New("repository error"),
-			expectedErr:  errors.New("repository error"),
+		},
+		{
+			name:        "error - user not found",
+			setupMocks:  func(nr *MockNotificationRepository) {},
+			expectedErr: ErrNotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Setup mocks
 			mockUserRepo := new(MockUserRepository)
 			mockNotifRepo := new(MockNotificationRepository)
 
-			// Setup expectations
-			mockUserRepo.On("GetByID", mock.Anything, tt.userID).Return(tt.mockUser, tt.mockUserErr)
-			if tt.mockUser != nil && tt.mockUser.Role == models.RoleManager {
-				mockNotifRepo.On("MarkAsRead", mock.Anything, tt.notificationID).Return(tt.mockNotifErr)
-			}
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(tt.mockUser, nil)
+			tt.setupMocks(mockNotifRepo)
 
-			// Create service
 			service := NewNotificationService(mockNotifRepo, mockUserRepo)
+			page, err := service.ListNotifications(context.Background(), tt.filter, 1)
 
-			// Execute
-			err := service.MarkAsRead(context.Background(), tt.notificationID, tt.userID)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedPage, page)
+			mockUserRepo.AssertExpectations(t)
+			mockNotifRepo.AssertExpectations(t)
+		})
+	}
+}
 
-			// Assert
-			if tt.expectedErr != nil {
-				assert.Error(t, err)
-				assert.Equal(t, tt.expectedErr, err)
-			} else {
-				assert.NoError(t, err)
-			}
+func TestNotificationService_GetNotification(t *testing.T) {
+	recipientID := int64(1)
+	tests := []struct {
+		name          string
+		mockUser      *models.User
+		mockNotif     *models.Notification
+		expectedNotif *models.Notification
+		expectedErr   error
+	}{
+		{
+			name:          "success - manager gets read notification",
+			mockUser:      &models.User{ID: 1, Role: models.RoleManager},
+			mockNotif:     &models.Notification{ID: 2, TaskID: 1, Message: "Test notification", IsRead: true},
+			expectedNotif: &models.Notification{ID: 2, TaskID: 1, Message: "Test notification", IsRead: true},
+		},
+		{
+			name:          "success - technician gets their mention",
+			mockUser:      &models.User{ID: 1, Role: models.RoleTechnician},
+			mockNotif:     &models.Notification{ID: 2, TaskID: 1, RecipientID: &recipientID, Message: "Test notification"},
+			expectedNotif: &models.Notification{ID: 2, TaskID: 1, RecipientID: &recipientID, Message: "Test notification"},
+		},
+		{
+			name:        "error - notification not found",
+			mockUser:    &models.User{ID: 1, Role: models.RoleManager},
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:        "error - technician cannot read shared notifications",
+			mockUser:    &models.User{ID: 1, Role: models.RoleTechnician},
+			mockNotif:   &models.Notification{ID: 2, TaskID: 1, Message: "Test notification"},
+			expectedErr: ErrUnauthorized,
+		},
+	}
 
-			// Verify all expectations were met
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockNotifRepo := new(MockNotificationRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(tt.mockUser, nil)
+			mockNotifRepo.On("GetByID", mock.Anything, int64(2)).Return(tt.mockNotif, nil)
+
+			service := NewNotificationService(mockNotifRepo, mockUserRepo)
+			notif, err := service.GetNotification(context.Background(), 2, 1)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedNotif, notif)
 			mockUserRepo.AssertExpectations(t)
 			mockNotifRepo.AssertExpectations(t)
 		})
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+var (
+	ErrPartExists        = errors.New("another part has the sku")
+	ErrInsufficientStock = errors.New("not enough parts in stock")
+	ErrUnknownPart       = errors.New("the part is not in the catalog")
+)
+
+// PartService manages the parts catalog and its stock. Everyone reads the
+// catalog; only managers change it, restock, adjust the stock and read the
+// ledger. Technicians take parts out of stock through the materials of their
+// tasks.
+type PartService struct {
+	partRepo repository.PartRepository
+	userRepo repository.UserRepository
+}
+
+func NewPartService(partRepo repository.PartRepository, userRepo repository.UserRepository) *PartService {
+	return &PartService{
+		partRepo: partRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *PartService) GetParts(ctx context.Context, lowStockOnly bool, userID int64) ([]*models.Part, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.partRepo.List(ctx, lowStockOnly)
+}
+
+func (s *PartService) GetPart(ctx context.Context, id int64, userID int64) (*models.Part, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.getPart(ctx, id)
+}
+
+// CreatePart adds a part to the catalog with no stock; restock it to receive
+// parts
+func (s *PartService) CreatePart(ctx context.Context, part *models.Part, userID int64) (*models.Part, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	part.Sanitize()
+
+	// Validate input
+	if err := part.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.partRepo.Create(ctx, part); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrPartExists
+		}
+		return nil, err
+	}
+	return s.getPart(ctx, part.ID)
+}
+
+// UpdatePart changes the catalog fields of a part; the stock only changes
+// through movements
+func (s *PartService) UpdatePart(ctx context.Context, part *models.Part, userID int64) (*models.Part, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getPart(ctx, part.ID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	part.Sanitize()
+
+	// Validate input
+	if err := part.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.partRepo.Update(ctx, part); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrPartExists
+		}
+		return nil, err
+	}
+	return s.getPart(ctx, part.ID)
+}
+
+// DeletePart removes a part from the catalog. Its ledger and the materials
+// already recorded on tasks are kept.
+func (s *PartService) DeletePart(ctx context.Context, id int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getPart(ctx, id); err != nil {
+		return err
+	}
+	return s.partRepo.Delete(ctx, id)
+}
+
+// MoveStock restocks a part or corrects its stock, e.g. after a count, and
+// returns the part with its new stock
+func (s *PartService) MoveStock(ctx context.Context, movement *models.StockMovement, userID int64) (*models.Part, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getPart(ctx, movement.PartID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	movement.Sanitize()
+	movement.TaskID = nil
+	movement.CreatedBy = &userID
+
+	// Validate input
+	if err := movement.ValidateAdjustment(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	part, err := s.partRepo.MoveStock(ctx, movement)
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, err
+	}
+	return part, nil
+}
+
+// GetMovements returns a page of the part's ledger, newest first
+func (s *PartService) GetMovements(ctx context.Context, partID int64, cursor int64, limit int, userID int64) (*models.StockMovementPage, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if limit == 0 {
+		limit = models.DefaultStockMovementLimit
+	}
+	if limit < 1 || limit > models.MaxStockMovementLimit || cursor < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.getPart(ctx, partID); err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra movement to know whether there is a next page
+	movements, err := s.partRepo.GetMovements(ctx, partID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.StockMovementPage{Movements: movements}
+	if len(movements) > limit {
+		page.Movements = movements[:limit]
+		page.NextCursor = page.Movements[limit-1].ID
+	}
+	return page, nil
+}
+
+func (s *PartService) getPart(ctx context.Context, id int64) (*models.Part, error) {
+	part, err := s.partRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if part == nil {
+		return nil, ErrNotFound
+	}
+	return part, nil
+}
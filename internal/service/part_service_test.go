@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPartRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartRepository) Create(ctx context.Context, part *models.Part) error {
+	args := m.Called(ctx, part)
+	return args.Error(0)
+}
+
+func (m *MockPartRepository) GetByID(ctx context.Context, id int64) (*models.Part, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Part), args.Error(1)
+}
+
+func (m *MockPartRepository) List(ctx context.Context, lowStockOnly bool) ([]*models.Part, error) {
+	args := m.Called(ctx, lowStockOnly)
+	return args.Get(0).([]*models.Part), args.Error(1)
+}
+
+func (m *MockPartRepository) Update(ctx context.Context, part *models.Part) error {
+	args := m.Called(ctx, part)
+	return args.Error(0)
+}
+
+func (m *MockPartRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPartRepository) MoveStock(ctx context.Context, movement *models.StockMovement) (*models.Part, error) {
+	args := m.Called(ctx, movement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Part), args.Error(1)
+}
+
+func (m *MockPartRepository) GetMovements(ctx context.Context, partID int64, cursor int64, limit int) ([]*models.StockMovement, error) {
+	args := m.Called(ctx, partID, cursor, limit)
+	return args.Get(0).([]*models.StockMovement), args.Error(1)
+}
+
+func TestPartService_CreatePart(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		part          *models.Part
+		setupMocks    func(*MockPartRepository)
+		expectedError error
+	}{
+		{
+			name: "manager adds a part",
+			user: manager,
+			part: &models.Part{SKU: " FLT-MERV13 ", Name: "MERV 13 air filter", Unit: "pcs", LowStockThreshold: 6},
+			setupMocks: func(pr *MockPartRepository) {
+				pr.On("Create", mock.Anything, mock.MatchedBy(func(p *models.Part) bool { return p.SKU == "FLT-MERV13" })).
+					Run(func(args mock.Arguments) { args.Get(1).(*models.Part).ID = 4 }).Return(nil)
+				pr.On("GetByID", mock.Anything, int64(4)).Return(&models.Part{ID: 4, SKU: "FLT-MERV13"}, nil)
+			},
+		},
+		{
+			name:          "technicians cannot add parts",
+			user:          technician,
+			part:          &models.Part{SKU: "FLT-MERV13", Name: "MERV 13 air filter", Unit: "pcs"},
+			setupMocks:    func(pr *MockPartRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "invalid sku",
+			user:          manager,
+			part:          &models.Part{SKU: "FLT MERV13", Name: "MERV 13 air filter", Unit: "pcs"},
+			setupMocks:    func(pr *MockPartRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name: "sku already used",
+			user: manager,
+			part: &models.Part{SKU: "FLT-MERV13", Name: "MERV 13 air filter", Unit: "pcs"},
+			setupMocks: func(pr *MockPartRepository) {
+				pr.On("Create", mock.Anything, mock.Anything).Return(repository.ErrDuplicate)
+			},
+			expectedError: ErrPartExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockPartRepo := new(MockPartRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			tt.setupMocks(mockPartRepo)
+
+			service := NewPartService(mockPartRepo, mockUserRepo)
+			part, err := service.CreatePart(context.Background(), tt.part, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, int64(4), part.ID)
+			}
+			mockPartRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPartService_MoveStock(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	tests := []struct {
+		name          string
+		movement      *models.StockMovement
+		setupMocks    func(*MockPartRepository)
+		expectedStock int
+		expectedError error
+	}{
+		{
+			name:     "restock",
+			movement: &models.StockMovement{PartID: 1, Quantity: 10, Reason: models.StockReasonRestock, Note: "PO 2024-117"},
+			setupMocks: func(pr *MockPartRepository) {
+				pr.On("MoveStock", mock.Anything, mock.MatchedBy(func(m *models.StockMovement) bool {
+					return m.Quantity == 10 && *m.CreatedBy == manager.ID && m.TaskID == nil
+				})).Return(&models.Part{ID: 1, Stock: 14}, nil)
+			},
+			expectedStock: 14,
+		},
+		{
+			name:          "consumption is recorded through tasks",
+			movement:      &models.StockMovement{PartID: 1, Quantity: -1, Reason: models.StockReasonConsumption},
+			setupMocks:    func(pr *MockPartRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:     "adjustment below zero",
+			movement: &models.StockMovement{PartID: 1, Quantity: -10, Reason: models.StockReasonAdjustment},
+			setupMocks: func(pr *MockPartRepository) {
+				pr.On("MoveStock", mock.Anything, mock.Anything).Return(nil, repository.ErrInsufficientStock)
+			},
+			expectedError: ErrInsufficientStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockPartRepo := new(MockPartRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+			mockPartRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Part{ID: 1, Stock: 4}, nil)
+			tt.setupMocks(mockPartRepo)
+
+			service := NewPartService(mockPartRepo, mockUserRepo)
+			part, err := service.MoveStock(context.Background(), tt.movement, manager.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.expectedStock, part.Stock)
+			}
+			mockPartRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPartService_GetMovements(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	mockUserRepo := new(MockUserRepository)
+	mockPartRepo := new(MockPartRepository)
+	mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+	mockPartRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Part{ID: 1}, nil)
+	mockPartRepo.On("GetMovements", mock.Anything, int64(1), int64(0), 3).Return([]*models.StockMovement{
+		{ID: 9}, {ID: 8}, {ID: 7},
+	}, nil)
+
+	service := NewPartService(mockPartRepo, mockUserRepo)
+	page, err := service.GetMovements(context.Background(), 1, 0, 2, manager.ID)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Movements, 2)
+	assert.Equal(t, int64(8), page.NextCursor)
+
+	_, err = service.GetMovements(context.Background(), 1, 0, models.MaxStockMovementLimit+1, manager.ID)
+	assert.Equal(t, ErrInvalidInput, err)
+}
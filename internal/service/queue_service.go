@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+var (
+	ErrQueueEmpty     = errors.New("no open task to claim")
+	ErrTaskNotClaimed = errors.New("task is not claimed by the technician")
+)
+
+// Limits of the work queue listing
+const (
+	DefaultQueueLimit = 20
+	MaxQueueLimit     = 100
+)
+
+// QueueService serves the dispatcher work queue: the open tasks nobody has
+// claimed yet, urgent first, then by due date and age. Tasks are assigned to
+// a technician when they are created, so each technician claims from their
+// own queue; there is no shared pool.
+type QueueService struct {
+	queueRepo repository.QueueRepository
+	userRepo  repository.UserRepository
+}
+
+func NewQueueService(
+	queueRepo repository.QueueRepository,
+	userRepo repository.UserRepository,
+) *QueueService {
+	return &QueueService{
+		queueRepo: queueRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetQueue returns up to limit queued tasks. Managers see every technician's
+// queue, technicians their own.
+func (s *QueueService) GetQueue(ctx context.Context, limit int, userID int64) ([]*models.Task, error) {
+	if limit == 0 {
+		limit = DefaultQueueLimit
+	}
+	if limit < 1 || limit > MaxQueueLimit {
+		return nil, ErrInvalidInput
+	}
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same visibility rules as GetTasks: technicians only see their own tasks
+	var technicianID int64
+	if user.IsTechnician() {
+		technicianID = userID
+	}
+	return s.queueRepo.GetQueue(ctx, technicianID, limit)
+}
+
+// ClaimNext claims the first task of the technician's queue. Concurrent
+// claims, e.g. from two devices, never get the same task.
+func (s *QueueService) ClaimNext(ctx context.Context, userID int64) (*models.Task, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsTechnician() {
+		return nil, ErrUnauthorized
+	}
+
+	task, err := s.queueRepo.ClaimNext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrQueueEmpty
+	}
+	return task, nil
+}
+
+// Release gives up a claim of the technician, putting the task back in their
+// queue
+func (s *QueueService) Release(ctx context.Context, taskID int64, userID int64) (*models.Task, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsTechnician() {
+		return nil, ErrUnauthorized
+	}
+
+	task, err := s.queueRepo.Release(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotClaimed
+	}
+	return task, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQueueRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueueRepository) GetQueue(ctx context.Context, technicianID int64, limit int) ([]*models.Task, error) {
+	args := m.Called(ctx, technicianID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+func (m *MockQueueRepository) ClaimNext(ctx context.Context, technicianID int64) (*models.Task, error) {
+	args := m.Called(ctx, technicianID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockQueueRepository) Release(ctx context.Context, taskID int64, technicianID int64) (*models.Task, error) {
+	args := m.Called(ctx, taskID, technicianID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func TestQueueService_GetQueue(t *testing.T) {
+	queued := []*models.Task{{ID: 1, TechnicianID: 2, Status: models.TaskStatusOpen, Priority: models.TaskPriorityUrgent}}
+
+	tests := []struct {
+		name                 string
+		user                 *models.User
+		limit                int
+		expectedTechnicianID int64
+		expectedLimit        int
+		expectedError        error
+	}{
+		{
+			name:                 "manager sees every queue",
+			user:                 &models.User{ID: 1, Role: models.RoleManager},
+			expectedTechnicianID: 0,
+			expectedLimit:        DefaultQueueLimit,
+		},
+		{
+			name:                 "technician sees only their queue",
+			user:                 &models.User{ID: 2, Role: models.RoleTechnician},
+			limit:                5,
+			expectedTechnicianID: 2,
+			expectedLimit:        5,
+		},
+		{
+			name:          "limit over the maximum",
+			user:          &models.User{ID: 2, Role: models.RoleTechnician},
+			limit:         MaxQueueLimit + 1,
+			expectedError: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueueRepo := new(MockQueueRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			if tt.expectedError == nil {
+				mockQueueRepo.On("GetQueue", mock.Anything, tt.expectedTechnicianID, tt.expectedLimit).Return(queued, nil)
+			}
+
+			service := NewQueueService(mockQueueRepo, mockUserRepo)
+			tasks, err := service.GetQueue(context.Background(), tt.limit, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, queued, tasks)
+			}
+			mockQueueRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQueueService_ClaimNext(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *models.User
+		setupMocks    func(*MockQueueRepository)
+		expectedError error
+	}{
+		{
+			name: "technician claims the first task of their queue",
+			user: &models.User{ID: 2, Role: models.RoleTechnician},
+			setupMocks: func(qr *MockQueueRepository) {
+				claimedBy := int64(2)
+				qr.On("ClaimNext", mock.Anything, int64(2)).Return(&models.Task{ID: 7, TechnicianID: 2, ClaimedBy: &claimedBy}, nil)
+			},
+		},
+		{
+			name: "empty queue",
+			user: &models.User{ID: 2, Role: models.RoleTechnician},
+			setupMocks: func(qr *MockQueueRepository) {
+				qr.On("ClaimNext", mock.Anything, int64(2)).Return(nil, nil)
+			},
+			expectedError: ErrQueueEmpty,
+		},
+		{
+			name:          "unauthorized - managers do not claim tasks",
+			user:          &models.User{ID: 1, Role: models.RoleManager},
+			setupMocks:    func(qr *MockQueueRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueueRepo := new(MockQueueRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			tt.setupMocks(mockQueueRepo)
+
+			service := NewQueueService(mockQueueRepo, mockUserRepo)
+			task, err := service.ClaimNext(context.Background(), tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.user.ID, *task.ClaimedBy)
+			} else {
+				assert.Nil(t, task)
+			}
+			mockQueueRepo.AssertExpectations(t)
+		})
+	}
+
+}
+
+func TestQueueService_Release(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *models.User
+		setupMocks    func(*MockQueueRepository)
+		expectedError error
+	}{
+		{
+			name: "technician releases their claim",
+			user: &models.User{ID: 2, Role: models.RoleTechnician},
+			setupMocks: func(qr *MockQueueRepository) {
+				qr.On("Release", mock.Anything, int64(7), int64(2)).Return(&models.Task{ID: 7, TechnicianID: 2, Version: 3}, nil)
+			},
+		},
+		{
+			name: "task not claimed by the technician",
+			user: &models.User{ID: 2, Role: models.RoleTechnician},
+			setupMocks: func(qr *MockQueueRepository) {
+				qr.On("Release", mock.Anything, int64(7), int64(2)).Return(nil, nil)
+			},
+			expectedError: ErrTaskNotClaimed,
+		},
+		{
+			name:          "unauthorized - managers do not claim tasks",
+			user:          &models.User{ID: 1, Role: models.RoleManager},
+			setupMocks:    func(qr *MockQueueRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueueRepo := new(MockQueueRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			tt.setupMocks(mockQueueRepo)
+
+			service := NewQueueService(mockQueueRepo, mockUserRepo)
+			task, err := service.Release(context.Background(), 7, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Nil(t, task.ClaimedBy)
+			} else {
+				assert.Nil(t, task)
+			}
+			mockQueueRepo.AssertExpectations(t)
+		})
+	}
+}
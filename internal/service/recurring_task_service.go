@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"time"
+)
+
+// recurringTasksCreated counts the tasks created by the recurring task job,
+// exposed on /debug/vars
+var recurringTasksCreated = expvar.NewInt("recurring_tasks_created_total")
+
+// Limits of the upcoming occurrences listing
+const (
+	DefaultOccurrenceLimit = 10
+	MaxOccurrenceLimit     = 100
+)
+
+// occurrenceWindow is how far ahead upcoming occurrences are looked up, so a
+// rule that no longer matches cannot be expanded forever
+const occurrenceWindow = 5 * 365 * 24 * time.Hour
+
+// RecurringTaskService manages recurring task definitions and creates their
+// occurrences as tasks. Managers define them; technicians see their own.
+type RecurringTaskService struct {
+	recurringRepo repository.RecurringTaskRepository
+	taskService   *TaskService
+	userRepo      repository.UserRepository
+	templateRepo  repository.TaskTemplateRepository
+	recurring     config.RecurringTasks
+}
+
+func NewRecurringTaskService(
+	recurringRepo repository.RecurringTaskRepository,
+	taskService *TaskService,
+	userRepo repository.UserRepository,
+	templateRepo repository.TaskTemplateRepository,
+	recurring config.RecurringTasks,
+) *RecurringTaskService {
+	return &RecurringTaskService{
+		recurringRepo: recurringRepo,
+		taskService:   taskService,
+		userRepo:      userRepo,
+		templateRepo:  templateRepo,
+		recurring:     recurring,
+	}
+}
+
+func (s *RecurringTaskService) GetRecurringTasks(ctx context.Context, userID int64) ([]*models.RecurringTask, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Technicians can only see their own recurring tasks
+	if user.IsTechnician() {
+		return s.recurringRepo.GetByTechnicianID(ctx, userID)
+	}
+	return s.recurringRepo.GetAll(ctx)
+}
+
+func (s *RecurringTaskService) GetRecurringTask(ctx context.Context, id int64, userID int64) (*models.RecurringTask, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recurringTask, err := s.getRecurringTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Technicians can only see their own recurring tasks
+	if user.IsTechnician() && recurringTask.TechnicianID != userID {
+		return nil, ErrUnauthorized
+	}
+	return recurringTask, nil
+}
+
+func (s *RecurringTaskService) CreateRecurringTask(ctx context.Context, recurringTask *models.RecurringTask, userID int64) (*models.RecurringTask, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkRecurringTask(ctx, recurringTask); err != nil {
+		return nil, err
+	}
+
+	recurringTask.CreatedBy = userID
+	if err := s.recurringRepo.Create(ctx, recurringTask); err != nil {
+		return nil, err
+	}
+	return s.getRecurringTask(ctx, recurringTask.ID)
+}
+
+// UpdateRecurringTask changes the definition. Tasks already created are kept;
+// the new rule applies to the occurrences that have no task yet.
+func (s *RecurringTaskService) UpdateRecurringTask(ctx context.Context, recurringTask *models.RecurringTask, userID int64) (*models.RecurringTask, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getRecurringTask(ctx, recurringTask.ID); err != nil {
+		return nil, err
+	}
+	if err := s.checkRecurringTask(ctx, recurringTask); err != nil {
+		return nil, err
+	}
+
+	if err := s.recurringRepo.Update(ctx, recurringTask); err != nil {
+		return nil, err
+	}
+	return s.getRecurringTask(ctx, recurringTask.ID)
+}
+
+// DeleteRecurringTask stops the schedule; the tasks it created are kept
+func (s *RecurringTaskService) DeleteRecurringTask(ctx context.Context, id int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getRecurringTask(ctx, id); err != nil {
+		return err
+	}
+	return s.recurringRepo.Delete(ctx, id)
+}
+
+// GetOccurrences returns the next occurrences of a recurring task, with the
+// task created for each one the scheduler has already reached. A limit of 0
+// means DefaultOccurrenceLimit.
+func (s *RecurringTaskService) GetOccurrences(ctx context.Context, id int64, limit int, userID int64) ([]*models.RecurringOccurrence, error) {
+	if limit == 0 {
+		limit = DefaultOccurrenceLimit
+	}
+	if limit < 1 || limit > MaxOccurrenceLimit {
+		return nil, ErrInvalidInput
+	}
+	recurringTask, err := s.GetRecurringTask(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	times, err := recurringTask.Occurrences(now, now.Add(occurrenceWindow), limit)
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := s.recurringRepo.GetScheduledTasks(ctx, id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]*models.RecurringOccurrence, 0, len(times))
+	for _, t := range times {
+		occurrence := &models.RecurringOccurrence{ScheduledFor: t}
+		for _, task := range scheduled {
+			if task.ScheduledFor.Equal(t) {
+				occurrence.TaskID = task.TaskID
+				break
+			}
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// Generate creates the tasks of every occurrence up to the configured horizon
+// and returns how many were created. Each definition resumes where the last
+// run stopped; occurrences that already have a task are skipped, so running
+// it again or on several replicas creates no duplicates.
+func (s *RecurringTaskService) Generate(ctx context.Context) (int, error) {
+	if s.recurring.Horizon <= 0 || s.recurring.BatchSize <= 0 {
+		return 0, ErrInvalidInput
+	}
+
+	recurringTasks, err := s.recurringRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	total := 0
+	for _, recurringTask := range recurringTasks {
+		created, err := s.generate(ctx, recurringTask, now)
+		total += created
+		recurringTasksCreated.Add(int64(created))
+		if err != nil {
+			// A broken definition must not hold back the others
+			log.Printf("Error generating tasks of recurring task %d: %v", recurringTask.ID, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Created %d tasks from recurring tasks", total)
+	}
+	return total, nil
+}
+
+// generate creates the tasks of one definition and moves its watermark
+func (s *RecurringTaskService) generate(ctx context.Context, recurringTask *models.RecurringTask, now time.Time) (int, error) {
+	// Occurrences before the definition was first reached are not backfilled
+	after := now
+	if recurringTask.GeneratedUntil != nil {
+		after = *recurringTask.GeneratedUntil
+	}
+	until := now.Add(s.recurring.Horizon)
+	if !until.After(after) {
+		return 0, nil
+	}
+
+	times, err := recurringTask.Occurrences(after, until, s.recurring.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	// Resume after the last occurrence when the batch is full
+	if len(times) == s.recurring.BatchSize {
+		until = times[len(times)-1]
+	}
+
+	var template *models.TaskTemplate
+	if recurringTask.TemplateID != nil {
+		// A deleted template no longer contributes to new tasks
+		if template, err = s.templateRepo.GetByID(ctx, *recurringTask.TemplateID); err != nil {
+			return 0, err
+		}
+	}
+
+	created := 0
+	for _, t := range times {
+		task := newRecurringTaskOccurrence(recurringTask, template, t)
+		// Occurrences are due the SLA's resolution time after they are scheduled
+		err := s.taskService.createTask(ctx, task, t)
+		if errors.Is(err, repository.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		created++
+	}
+
+	return created, s.recurringRepo.SetGeneratedUntil(ctx, recurringTask.ID, until)
+}
+
+// newRecurringTaskOccurrence builds the open task of one occurrence
+func newRecurringTaskOccurrence(recurringTask *models.RecurringTask, template *models.TaskTemplate, scheduledFor time.Time) *models.Task {
+	task := &models.Task{
+		TechnicianID:    recurringTask.TechnicianID,
+		Title:           recurringTask.Title,
+		Summary:         recurringTask.Summary,
+		PerformedAt:     scheduledFor,
+		Status:          models.TaskStatusOpen,
+		Priority:        models.TaskPriorityNormal,
+		RecurringTaskID: &recurringTask.ID,
+		ScheduledFor:    &scheduledFor,
+	}
+	if template != nil {
+		task.Template = &models.TaskTemplateRef{ID: template.ID, Revision: template.Revision}
+		task.Tags = template.Tags
+		task.ChecklistItems = template.ChecklistItems()
+		if task.Summary == "" {
+			task.Summary = template.Summary
+		}
+	}
+	if task.Summary == "" {
+		task.Summary = task.Title
+	}
+	return task
+}
+
+// checkRecurringTask sanitizes and validates a definition and makes sure it
+// is assigned to a technician and its template exists
+func (s *RecurringTaskService) checkRecurringTask(ctx context.Context, recurringTask *models.RecurringTask) error {
+	// Sanitize input
+	recurringTask.Sanitize()
+
+	// Validate input
+	if err := recurringTask.Validate(); err != nil {
+		return ErrInvalidInput
+	}
+	technician, err := s.userRepo.GetByID(ctx, recurringTask.TechnicianID)
+	if err != nil {
+		return err
+	}
+	if technician == nil || !technician.IsTechnician() {
+		return ErrInvalidInput
+	}
+	if recurringTask.TemplateID != nil {
+		template, err := s.templateRepo.GetByID(ctx, *recurringTask.TemplateID)
+		if err != nil {
+			return err
+		}
+		if template == nil {
+			return ErrInvalidInput
+		}
+	}
+	return nil
+}
+
+func (s *RecurringTaskService) getRecurringTask(ctx context.Context, id int64) (*models.RecurringTask, error) {
+	recurringTask, err := s.recurringRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recurringTask == nil {
+		return nil, ErrNotFound
+	}
+	return recurringTask, nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecurringTaskRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecurringTaskRepository) Create(ctx context.Context, recurringTask *models.RecurringTask) error {
+	args := m.Called(ctx, recurringTask)
+	return args.Error(0)
+}
+
+func (m *MockRecurringTaskRepository) GetByID(ctx context.Context, id int64) (*models.RecurringTask, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RecurringTask), args.Error(1)
+}
+
+func (m *MockRecurringTaskRepository) GetAll(ctx context.Context) ([]*models.RecurringTask, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RecurringTask), args.Error(1)
+}
+
+func (m *MockRecurringTaskRepository) GetByTechnicianID(ctx context.Context, technicianID int64) ([]*models.RecurringTask, error) {
+	args := m.Called(ctx, technicianID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RecurringTask), args.Error(1)
+}
+
+func (m *MockRecurringTaskRepository) Update(ctx context.Context, recurringTask *models.RecurringTask) error {
+	args := m.Called(ctx, recurringTask)
+	return args.Error(0)
+}
+
+func (m *MockRecurringTaskRepository) SetGeneratedUntil(ctx context.Context, id int64, until time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
+func (m *MockRecurringTaskRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRecurringTaskRepository) GetScheduledTasks(ctx context.Context, id int64, after time.Time) ([]*models.RecurringOccurrence, error) {
+	args := m.Called(ctx, id, after)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RecurringOccurrence), args.Error(1)
+}
+
+func TestRecurringTaskService_CreateRecurringTask(t *testing.T) {
+	valid := func() *models.RecurringTask {
+		return &models.RecurringTask{
+			TechnicianID: 2,
+			Title:        "Monthly HVAC inspection",
+			Summary:      "Inspect the rooftop units.",
+			RRule:        "FREQ=MONTHLY;BYDAY=1MO",
+			StartsAt:     time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		role          models.UserRole
+		recurringTask func() *models.RecurringTask
+		assignee      *models.User
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name:          "manager schedules a task for a technician",
+			role:          models.RoleManager,
+			recurringTask: valid,
+			assignee:      &models.User{ID: 2, Role: models.RoleTechnician},
+			expectCreate:  true,
+		},
+		{
+			name: "invalid rrule",
+			role: models.RoleManager,
+			recurringTask: func() *models.RecurringTask {
+				r := valid()
+				r.RRule = "FREQ=HOURLY"
+				return r
+			},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "assignee must be a technician",
+			role:          models.RoleManager,
+			recurringTask: valid,
+			assignee:      &models.User{ID: 2, Role: models.RoleManager},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "technicians cannot schedule tasks",
+			role:          models.RoleTechnician,
+			recurringTask: valid,
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRecurringRepo := new(MockRecurringTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: tt.role}, nil)
+			if tt.assignee != nil {
+				mockUserRepo.On("GetByID", mock.Anything, int64(2)).Return(tt.assignee, nil)
+			}
+			if tt.expectCreate {
+				mockRecurringRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.RecurringTask) bool {
+					return r.CreatedBy == 1 && r.Timezone == "UTC"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.RecurringTask).ID = 7
+				}).Return(nil)
+				mockRecurringRepo.On("GetByID", mock.Anything, int64(7)).Return(&models.RecurringTask{ID: 7}, nil)
+			}
+
+			service := NewRecurringTaskService(mockRecurringRepo, newTestTaskService(taskServiceDeps{}), mockUserRepo, new(MockTaskTemplateRepository), config.RecurringTasks{})
+			_, err := service.CreateRecurringTask(context.Background(), tt.recurringTask(), 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRecurringRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringTaskService_GetRecurringTask(t *testing.T) {
+	recurringTask := &models.RecurringTask{ID: 7, TechnicianID: 2}
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedError error
+	}{
+		{name: "manager sees any recurring task", user: &models.User{ID: 1, Role: models.RoleManager}},
+		{name: "technician sees their own", user: &models.User{ID: 2, Role: models.RoleTechnician}},
+		{name: "technician cannot see others", user: &models.User{ID: 3, Role: models.RoleTechnician}, expectedError: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRecurringRepo := new(MockRecurringTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockRecurringRepo.On("GetByID", mock.Anything, int64(7)).Return(recurringTask, nil)
+
+			service := NewRecurringTaskService(mockRecurringRepo, newTestTaskService(taskServiceDeps{}), mockUserRepo, new(MockTaskTemplateRepository), config.RecurringTasks{})
+			_, err := service.GetRecurringTask(context.Background(), 7, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestRecurringTaskService_Generate(t *testing.T) {
+	templateID := int64(5)
+	template := &models.TaskTemplate{
+		ID:        5,
+		Revision:  2,
+		Summary:   "Replaced the air filters.",
+		Checklist: []models.TaskTemplateChecklistItem{{Text: "Replace air filters", Required: true}},
+		Tags:      []string{"hvac"},
+	}
+
+	// A daily rule starting in an hour has three occurrences in a three day horizon
+	startsAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	recurring := config.RecurringTasks{Horizon: 72 * time.Hour, BatchSize: 100}
+
+	t.Run("creates open tasks ahead of time", func(t *testing.T) {
+		mockRecurringRepo := new(MockRecurringTaskRepository)
+		mockTaskRepo := new(MockTaskRepository)
+		mockTemplateRepo := new(MockTaskTemplateRepository)
+		mockSLARepo := new(MockSLARepository)
+
+		mockRecurringRepo.On("GetAll", mock.Anything).Return([]*models.RecurringTask{{
+			ID:           7,
+			TechnicianID: 2,
+			Title:        "Daily filter check",
+			TemplateID:   &templateID,
+			RRule:        "FREQ=DAILY",
+			StartsAt:     startsAt,
+			Timezone:     "UTC",
+		}}, nil)
+		mockTemplateRepo.On("GetByID", mock.Anything, templateID).Return(template, nil)
+		mockSLARepo.On("GetPoliciesByTags", mock.Anything, []string{"hvac"}).
+			Return([]*models.SLAPolicy{{ID: 3, Tag: "hvac", ResolutionMinutes: 480, AtRiskMinutes: 60}}, nil)
+		mockTaskRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.TechnicianID == 2 &&
+				task.Status == models.TaskStatusOpen &&
+				*task.RecurringTaskID == 7 &&
+				task.ScheduledFor.Equal(task.PerformedAt) &&
+				task.Summary == template.Summary &&
+				task.Template.Revision == 2 &&
+				len(task.ChecklistItems) == 1 &&
+				*task.SLAPolicyID == 3 &&
+				task.DueAt.Equal(task.ScheduledFor.Add(8*time.Hour))
+		})).Return(nil).Times(3)
+		mockRecurringRepo.On("SetGeneratedUntil", mock.Anything, int64(7), mock.Anything).Return(nil)
+
+		mockBroker := messaging.NewMockBroker()
+		taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, slaRepo: mockSLARepo, broker: mockBroker})
+		service := NewRecurringTaskService(mockRecurringRepo, taskService, new(MockUserRepository), mockTemplateRepo, recurring)
+		created, err := service.Generate(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, created)
+		mockTaskRepo.AssertExpectations(t)
+		mockRecurringRepo.AssertExpectations(t)
+		// Like the tasks technicians create, each occurrence is announced
+		assert.Eventually(t, func() bool { return len(mockBroker.GetMessages()) == 3 }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, int64(2), mockBroker.GetMessages()[0].TechnicianID)
+	})
+
+	t.Run("skips occurrences that already have a task", func(t *testing.T) {
+		mockRecurringRepo := new(MockRecurringTaskRepository)
+		mockTaskRepo := new(MockTaskRepository)
+
+		generatedUntil := startsAt.Add(24 * time.Hour)
+		mockRecurringRepo.On("GetAll", mock.Anything).Return([]*models.RecurringTask{{
+			ID:             7,
+			TechnicianID:   2,
+			Title:          "Daily filter check",
+			Summary:        "Check the filters.",
+			RRule:          "FREQ=DAILY",
+			StartsAt:       startsAt,
+			Timezone:       "UTC",
+			GeneratedUntil: &generatedUntil,
+		}}, nil)
+		// Only the occurrence after the watermark is attempted; another
+		// replica already created it
+		mockTaskRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.ScheduledFor.Equal(startsAt.Add(48 * time.Hour))
+		})).Return(repository.ErrDuplicate).Once()
+		mockRecurringRepo.On("SetGeneratedUntil", mock.Anything, int64(7), mock.Anything).Return(nil)
+
+		service := NewRecurringTaskService(mockRecurringRepo, newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo}), new(MockUserRepository), new(MockTaskTemplateRepository), recurring)
+		created, err := service.Generate(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		mockTaskRepo.AssertExpectations(t)
+		mockRecurringRepo.AssertExpectations(t)
+	})
+}
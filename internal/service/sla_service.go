@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+	"time"
+)
+
+var ErrSLAPolicyExists = errors.New("the tag already has an SLA policy")
+
+// slaEscalations counts the escalations published by the SLA evaluator,
+// exposed on /debug/vars
+var slaEscalations = expvar.NewInt("sla_escalations_total")
+
+// SLAService manages the SLA policies, escalates tasks that are at risk or
+// breached and reports SLA compliance. Everyone reads the policies; only
+// managers change them.
+type SLAService struct {
+	slaRepo       repository.SLARepository
+	tagRepo       repository.TagRepository
+	userRepo      repository.UserRepository
+	messageBroker messaging.MessageBroker
+	sla           config.SLA
+}
+
+func NewSLAService(
+	slaRepo repository.SLARepository,
+	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+	messageBroker messaging.MessageBroker,
+	sla config.SLA,
+) *SLAService {
+	return &SLAService{
+		slaRepo:       slaRepo,
+		tagRepo:       tagRepo,
+		userRepo:      userRepo,
+		messageBroker: messageBroker,
+		sla:           sla,
+	}
+}
+
+func (s *SLAService) GetPolicies(ctx context.Context, userID int64) ([]*models.SLAPolicy, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.slaRepo.GetPolicies(ctx)
+}
+
+func (s *SLAService) GetPolicy(ctx context.Context, id int64, userID int64) (*models.SLAPolicy, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.getPolicy(ctx, id)
+}
+
+// CreatePolicy gives the tasks of a tag a resolution time. It applies to the
+// tasks created from now on.
+func (s *SLAService) CreatePolicy(ctx context.Context, policy *models.SLAPolicy, userID int64) (*models.SLAPolicy, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	if err := s.slaRepo.CreatePolicy(ctx, policy); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrSLAPolicyExists
+		}
+		return nil, err
+	}
+	return s.getPolicy(ctx, policy.ID)
+}
+
+// UpdatePolicy changes a policy; tasks keep the due date it already gave them
+func (s *SLAService) UpdatePolicy(ctx context.Context, policy *models.SLAPolicy, userID int64) (*models.SLAPolicy, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getPolicy(ctx, policy.ID); err != nil {
+		return nil, err
+	}
+	if err := s.checkPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	if err := s.slaRepo.UpdatePolicy(ctx, policy); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrSLAPolicyExists
+		}
+		return nil, err
+	}
+	return s.getPolicy(ctx, policy.ID)
+}
+
+// DeletePolicy removes a policy; tasks keep the due date it gave them
+func (s *SLAService) DeletePolicy(ctx context.Context, id int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getPolicy(ctx, id); err != nil {
+		return err
+	}
+	return s.slaRepo.DeletePolicy(ctx, id)
+}
+
+// GetReport returns the SLA compliance of the tasks due in the query period.
+// Technicians only get their own figures.
+func (s *SLAService) GetReport(ctx context.Context, query models.SLAReportQuery, userID int64) (*models.SLAReport, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if err := query.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	// Technicians can only see their own compliance
+	query.TechnicianID = 0
+	if user.IsTechnician() {
+		query.TechnicianID = userID
+	}
+
+	technicians, err := s.slaRepo.GetReport(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return models.NewSLAReport(query, technicians), nil
+}
+
+// Evaluate escalates every open task that became at risk or breached its due
+// date and returns how many escalations were published. Each level is
+// published once per task: the level is recorded before publishing, and only
+// by the run that moved the task to it.
+func (s *SLAService) Evaluate(ctx context.Context) (int, error) {
+	if s.sla.BatchSize <= 0 {
+		return 0, ErrInvalidInput
+	}
+
+	now := time.Now()
+	total := 0
+	for {
+		escalations, err := s.slaRepo.GetEscalations(ctx, now, s.sla.BatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		escalated := 0
+		for _, escalation := range escalations {
+			published, err := s.escalate(ctx, escalation, now)
+			if err != nil {
+				return total, err
+			}
+			if published {
+				escalated++
+			}
+		}
+		total += escalated
+		slaEscalations.Add(int64(escalated))
+
+		// Stop on the last batch, or when another replica took over the batch
+		if len(escalations) < s.sla.BatchSize || escalated == 0 {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Escalated %d tasks past their SLA", total)
+	}
+	return total, nil
+}
+
+// escalate records the level the task reached and publishes it
+func (s *SLAService) escalate(ctx context.Context, escalation *models.SLAEscalation, now time.Time) (bool, error) {
+	level := escalation.Next(now)
+	if level == escalation.Level {
+		return false, nil
+	}
+
+	moved, err := s.slaRepo.Escalate(ctx, escalation.TaskID, escalation.Level, level)
+	if err != nil || !moved {
+		return false, err
+	}
+
+	if err := s.messageBroker.PublishTaskEscalated(ctx, escalation.TaskID, escalation.TechnicianID, level, escalation.Title, escalation.DueAt); err != nil {
+		// Put the task back so the next run publishes it again
+		if _, revertErr := s.slaRepo.Escalate(ctx, escalation.TaskID, level, escalation.Level); revertErr != nil {
+			log.Printf("Error reverting the escalation of task %d: %v", escalation.TaskID, revertErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// checkPolicy sanitizes and validates a policy and resolves its tag
+func (s *SLAService) checkPolicy(ctx context.Context, policy *models.SLAPolicy) error {
+	// Sanitize input
+	policy.Sanitize()
+
+	// Validate input
+	if err := policy.Validate(); err != nil {
+		return ErrInvalidInput
+	}
+	tags, err := s.tagRepo.GetByNames(ctx, []string{policy.Tag})
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return ErrUnknownTags
+	}
+	policy.TagID = tags[0].ID
+	return nil
+}
+
+func (s *SLAService) getPolicy(ctx context.Context, id int64) (*models.SLAPolicy, error) {
+	policy, err := s.slaRepo.GetPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, ErrNotFound
+	}
+	return policy, nil
+}
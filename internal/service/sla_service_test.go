@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSLARepository struct {
+	mock.Mock
+}
+
+func (m *MockSLARepository) CreatePolicy(ctx context.Context, policy *models.SLAPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockSLARepository) GetPolicyByID(ctx context.Context, id int64) (*models.SLAPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SLAPolicy), args.Error(1)
+}
+
+func (m *MockSLARepository) GetPolicies(ctx context.Context) ([]*models.SLAPolicy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SLAPolicy), args.Error(1)
+}
+
+func (m *MockSLARepository) GetPoliciesByTags(ctx context.Context, tags []string) ([]*models.SLAPolicy, error) {
+	args := m.Called(ctx, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SLAPolicy), args.Error(1)
+}
+
+func (m *MockSLARepository) UpdatePolicy(ctx context.Context, policy *models.SLAPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockSLARepository) DeletePolicy(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSLARepository) GetEscalations(ctx context.Context, now time.Time, limit int) ([]*models.SLAEscalation, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SLAEscalation), args.Error(1)
+}
+
+func (m *MockSLARepository) Escalate(ctx context.Context, taskID int64, from string, to string) (bool, error) {
+	args := m.Called(ctx, taskID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSLARepository) GetReport(ctx context.Context, query models.SLAReportQuery, now time.Time) ([]*models.SLACompliance, error) {
+	args := m.Called(ctx, query, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SLACompliance), args.Error(1)
+}
+
+func TestSLAService_CreatePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          models.UserRole
+		policy        *models.SLAPolicy
+		setupMocks    func(*MockSLARepository, *MockTagRepository)
+		expectedError error
+	}{
+		{
+			name:   "manager creates a policy for a tag",
+			role:   models.RoleManager,
+			policy: &models.SLAPolicy{Name: "Corrective", Tag: " Corrective ", ResolutionMinutes: 1440, AtRiskMinutes: 240},
+			setupMocks: func(sr *MockSLARepository, tr *MockTagRepository) {
+				tr.On("GetByNames", mock.Anything, []string{"corrective"}).Return([]*models.Tag{{ID: 4, Name: "corrective"}}, nil)
+				sr.On("CreatePolicy", mock.Anything, mock.MatchedBy(func(policy *models.SLAPolicy) bool {
+					return policy.TagID == 4 && policy.Tag == "corrective"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.SLAPolicy).ID = 1
+				}).Return(nil)
+				sr.On("GetPolicyByID", mock.Anything, int64(1)).Return(&models.SLAPolicy{ID: 1, Tag: "corrective"}, nil)
+			},
+		},
+		{
+			name:          "unauthorized - technician cannot create policies",
+			role:          models.RoleTechnician,
+			policy:        &models.SLAPolicy{Name: "Corrective", Tag: "corrective", ResolutionMinutes: 1440},
+			setupMocks:    func(sr *MockSLARepository, tr *MockTagRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "at-risk window longer than the resolution time",
+			role:          models.RoleManager,
+			policy:        &models.SLAPolicy{Name: "Corrective", Tag: "corrective", ResolutionMinutes: 60, AtRiskMinutes: 60},
+			setupMocks:    func(sr *MockSLARepository, tr *MockTagRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:   "unknown tag",
+			role:   models.RoleManager,
+			policy: &models.SLAPolicy{Name: "Corrective", Tag: "corrective", ResolutionMinutes: 1440},
+			setupMocks: func(sr *MockSLARepository, tr *MockTagRepository) {
+				tr.On("GetByNames", mock.Anything, []string{"corrective"}).Return([]*models.Tag{}, nil)
+			},
+			expectedError: ErrUnknownTags,
+		},
+		{
+			name:   "tag already has a policy",
+			role:   models.RoleManager,
+			policy: &models.SLAPolicy{Name: "Corrective", Tag: "corrective", ResolutionMinutes: 1440},
+			setupMocks: func(sr *MockSLARepository, tr *MockTagRepository) {
+				tr.On("GetByNames", mock.Anything, []string{"corrective"}).Return([]*models.Tag{{ID: 4, Name: "corrective"}}, nil)
+				sr.On("CreatePolicy", mock.Anything, mock.Anything).Return(repository.ErrDuplicate)
+			},
+			expectedError: ErrSLAPolicyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSLARepo := new(MockSLARepository)
+			mockTagRepo := new(MockTagRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: tt.role}, nil)
+			tt.setupMocks(mockSLARepo, mockTagRepo)
+
+			service := NewSLAService(mockSLARepo, mockTagRepo, mockUserRepo, messaging.NewMockBroker(), config.SLA{})
+			policy, err := service.CreatePolicy(context.Background(), tt.policy, 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, policy)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), policy.ID)
+			}
+			mockSLARepo.AssertExpectations(t)
+			mockTagRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSLAService_GetReport(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("technicians only get their own figures", func(t *testing.T) {
+		mockSLARepo := new(MockSLARepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetByID", mock.Anything, int64(2)).Return(&models.User{ID: 2, Role: models.RoleTechnician}, nil)
+		mockSLARepo.On("GetReport", mock.Anything, models.SLAReportQuery{From: from, To: to, TechnicianID: 2}, mock.Anything).
+			Return([]*models.SLACompliance{{TechnicianID: 2, Total: 4, Met: 3, Missed: 1}}, nil)
+
+		service := NewSLAService(mockSLARepo, new(MockTagRepository), mockUserRepo, messaging.NewMockBroker(), config.SLA{})
+		report, err := service.GetReport(context.Background(), models.SLAReportQuery{From: from, To: to, TechnicianID: 3}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), report.Overall.Total)
+		assert.InDelta(t, 75.0, *report.Overall.CompliancePercent, 0.001)
+		mockSLARepo.AssertExpectations(t)
+	})
+
+	t.Run("empty period", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleManager}, nil)
+
+		service := NewSLAService(new(MockSLARepository), new(MockTagRepository), mockUserRepo, messaging.NewMockBroker(), config.SLA{})
+		_, err := service.GetReport(context.Background(), models.SLAReportQuery{From: to, To: from}, 1)
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestSLAService_Evaluate(t *testing.T) {
+	now := time.Now()
+	atRiskAt := now.Add(-time.Hour)
+
+	t.Run("escalates each task once to the level it reached", func(t *testing.T) {
+		mockSLARepo := new(MockSLARepository)
+		broker := messaging.NewMockBroker()
+		mockSLARepo.On("GetEscalations", mock.Anything, mock.Anything, 10).Return([]*models.SLAEscalation{
+			{TaskID: 1, TechnicianID: 2, Title: "Fix AC", DueAt: now.Add(time.Hour), AtRiskAt: &atRiskAt, Level: models.SLAEscalationNone},
+			{TaskID: 2, TechnicianID: 2, Title: "Fix boiler", DueAt: now.Add(-time.Minute), AtRiskAt: &atRiskAt, Level: models.SLAEscalationAtRisk},
+			{TaskID: 3, TechnicianID: 3, Title: "Fix pump", DueAt: now.Add(-time.Minute), Level: models.SLAEscalationNone},
+		}, nil).Once()
+		mockSLARepo.On("Escalate", mock.Anything, int64(1), models.SLAEscalationNone, models.SLAEscalationAtRisk).Return(true, nil)
+		mockSLARepo.On("Escalate", mock.Anything, int64(2), models.SLAEscalationAtRisk, models.SLAEscalationBreached).Return(true, nil)
+		// Another replica escalated task 3 first
+		mockSLARepo.On("Escalate", mock.Anything, int64(3), models.SLAEscalationNone, models.SLAEscalationBreached).Return(false, nil)
+
+		service := NewSLAService(mockSLARepo, new(MockTagRepository), new(MockUserRepository), broker, config.SLA{BatchSize: 10})
+		escalated, err := service.Evaluate(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, escalated)
+		messages := broker.GetEscalationMessages()
+		if assert.Len(t, messages, 2) {
+			assert.Equal(t, int64(1), messages[0].TaskID)
+			assert.Equal(t, models.SLAEscalationAtRisk, messages[0].Level)
+			assert.Equal(t, int64(2), messages[1].TaskID)
+			assert.Equal(t, models.SLAEscalationBreached, messages[1].Level)
+		}
+		mockSLARepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		service := NewSLAService(new(MockSLARepository), new(MockTagRepository), new(MockUserRepository), messaging.NewMockBroker(), config.SLA{})
+		_, err := service.Evaluate(context.Background())
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
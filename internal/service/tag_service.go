@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+var ErrTagExists = errors.New("a tag with this name already exists")
+
+// TagService manages the tag vocabulary. Everyone can read it; only managers
+// can change it.
+type TagService struct {
+	tagRepo  repository.TagRepository
+	userRepo repository.UserRepository
+}
+
+func NewTagService(
+	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+) *TagService {
+	return &TagService{
+		tagRepo:  tagRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *TagService) GetTags(ctx context.Context, userID int64) ([]*models.Tag, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetAll(ctx)
+}
+
+// CountTasks returns every tag with its number of tasks; technicians only
+// count their own tasks
+func (s *TagService) CountTasks(ctx context.Context, userID int64) ([]*models.TagCount, error) {
+	user, err := getUser(ctx, s.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var technicianID int64
+	if user.IsTechnician() {
+		technicianID = userID
+	}
+	return s.tagRepo.CountTasks(ctx, technicianID)
+}
+
+func (s *TagService) CreateTag(ctx context.Context, tag *models.Tag, userID int64) (*models.Tag, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	tag.Sanitize()
+
+	// Validate input
+	if err := tag.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrTagExists
+		}
+		return nil, err
+	}
+	return s.tagRepo.GetByID(ctx, tag.ID)
+}
+
+// UpdateTag renames or recategorizes a tag; tasks keep it under its new name
+func (s *TagService) UpdateTag(ctx context.Context, tag *models.Tag, userID int64) (*models.Tag, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.tagRepo.GetByID(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+
+	// Sanitize input
+	tag.Sanitize()
+
+	// Validate input
+	if err := tag.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrTagExists
+		}
+		return nil, err
+	}
+	tag.CreatedAt = existing.CreatedAt
+	return tag, nil
+}
+
+// DeleteTag removes a tag from the vocabulary and from every task
+func (s *TagService) DeleteTag(ctx context.Context, tagID int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+
+	tag, err := s.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		return err
+	}
+	if tag == nil {
+		return ErrNotFound
+	}
+	return s.tagRepo.Delete(ctx, tagID)
+}
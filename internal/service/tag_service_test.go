@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) GetByID(ctx context.Context, id int64) (*models.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetAll(ctx context.Context) ([]*models.Tag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByNames(ctx context.Context, names []string) ([]*models.Tag, error) {
+	args := m.Called(ctx, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) CountTasks(ctx context.Context, technicianID int64) ([]*models.TagCount, error) {
+	args := m.Called(ctx, technicianID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TagCount), args.Error(1)
+}
+
+func TestTagService_CreateTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          models.UserRole
+		tag           *models.Tag
+		setupMocks    func(*MockTagRepository)
+		expectedError error
+	}{
+		{
+			name: "manager creates a normalized tag",
+			role: models.RoleManager,
+			tag:  &models.Tag{Name: " HVAC ", Category: "Discipline"},
+			setupMocks: func(tr *MockTagRepository) {
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(tag *models.Tag) bool {
+					return tag.Name == "hvac" && tag.Category == "discipline"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.Tag).ID = 7
+				}).Return(nil)
+				tr.On("GetByID", mock.Anything, int64(7)).Return(&models.Tag{ID: 7, Name: "hvac", Category: "discipline"}, nil)
+			},
+		},
+		{
+			name:          "unauthorized - technician cannot create tags",
+			role:          models.RoleTechnician,
+			tag:           &models.Tag{Name: "hvac"},
+			setupMocks:    func(tr *MockTagRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "invalid tag name",
+			role:          models.RoleManager,
+			tag:           &models.Tag{Name: "air conditioning"},
+			setupMocks:    func(tr *MockTagRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name: "duplicate tag name",
+			role: models.RoleManager,
+			tag:  &models.Tag{Name: "hvac"},
+			setupMocks: func(tr *MockTagRepository) {
+				tr.On("Create", mock.Anything, mock.Anything).Return(repository.ErrDuplicate)
+			},
+			expectedError: ErrTagExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTagRepo := new(MockTagRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: tt.role}, nil)
+			tt.setupMocks(mockTagRepo)
+
+			service := NewTagService(mockTagRepo, mockUserRepo)
+			tag, err := service.CreateTag(context.Background(), tt.tag, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, "hvac", tag.Name)
+			}
+			mockTagRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_CountTasks(t *testing.T) {
+	counts := []*models.TagCount{{TagID: 1, Name: "hvac", Category: "discipline", TaskCount: 2}}
+
+	tests := []struct {
+		name                 string
+		user                 *models.User
+		expectedTechnicianID int64
+	}{
+		{
+			name:                 "manager counts every task",
+			user:                 &models.User{ID: 1, Role: models.RoleManager},
+			expectedTechnicianID: 0,
+		},
+		{
+			name:                 "technician counts only their tasks",
+			user:                 &models.User{ID: 2, Role: models.RoleTechnician},
+			expectedTechnicianID: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTagRepo := new(MockTagRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTagRepo.On("CountTasks", mock.Anything, tt.expectedTechnicianID).Return(counts, nil)
+
+			service := NewTagService(mockTagRepo, mockUserRepo)
+			result, err := service.CountTasks(context.Background(), tt.user.ID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, counts, result)
+			mockTagRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_DeleteTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          models.UserRole
+		setupMocks    func(*MockTagRepository)
+		expectedError error
+	}{
+		{
+			name: "manager deletes a tag",
+			role: models.RoleManager,
+			setupMocks: func(tr *MockTagRepository) {
+				tr.On("GetByID", mock.Anything, int64(3)).Return(&models.Tag{ID: 3, Name: "hvac"}, nil)
+				tr.On("Delete", mock.Anything, int64(3)).Return(nil)
+			},
+		},
+		{
+			name: "tag not found",
+			role: models.RoleManager,
+			setupMocks: func(tr *MockTagRepository) {
+				tr.On("GetByID", mock.Anything, int64(3)).Return(nil, nil)
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "unauthorized - technician cannot delete tags",
+			role:          models.RoleTechnician,
+			setupMocks:    func(tr *MockTagRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTagRepo := new(MockTagRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: tt.role}, nil)
+			tt.setupMocks(mockTagRepo)
+
+			service := NewTagService(mockTagRepo, mockUserRepo)
+			err := service.DeleteTag(context.Background(), 3, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTagRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+	"sword-challenge/pkg/storage"
+)
+
+var (
+	ErrFileTooLarge         = errors.New("file exceeds the maximum attachment size")
+	ErrUnsupportedMediaType = errors.New("file type is not allowed")
+)
+
+type TaskAttachmentService struct {
+	taskService    *TaskService
+	userRepo       repository.UserRepository
+	attachmentRepo repository.TaskAttachmentRepository
+	blobStore      storage.BlobStore
+	messageBroker  messaging.MessageBroker
+	limits         config.AttachmentLimits
+}
+
+func NewTaskAttachmentService(
+	taskService *TaskService,
+	userRepo repository.UserRepository,
+	attachmentRepo repository.TaskAttachmentRepository,
+	blobStore storage.BlobStore,
+	messageBroker messaging.MessageBroker,
+	limits config.AttachmentLimits,
+) *TaskAttachmentService {
+	return &TaskAttachmentService{
+		taskService:    taskService,
+		userRepo:       userRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		messageBroker:  messageBroker,
+		limits:         limits,
+	}
+}
+
+// MaxSize is the largest file accepted by Upload, in bytes
+func (s *TaskAttachmentService) MaxSize() int64 {
+	return s.limits.MaxSize
+}
+
+func (s *TaskAttachmentService) GetAttachments(ctx context.Context, taskID int64, userID int64) ([]*models.TaskAttachment, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.attachmentRepo.GetByTaskID(ctx, taskID)
+}
+
+// Upload stores a file for the task. The MIME type is detected from the
+// content; the name sent by the client is only kept for display.
+func (s *TaskAttachmentService) Upload(ctx context.Context, taskID int64, fileName string, file io.ReadSeeker, userID int64) (*models.TaskAttachment, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Read the content once to check the limits and compute the checksum
+	hash := sha256.New()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	head = head[:n]
+	hash.Write(head)
+	rest, err := io.Copy(hash, io.LimitReader(file, s.limits.MaxSize))
+	if err != nil {
+		return nil, err
+	}
+	size := int64(n) + rest
+	if size == 0 {
+		return nil, ErrInvalidInput
+	}
+	if size > s.limits.MaxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !slices.Contains(s.limits.AllowedTypes, contentType) {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	key, err := newAttachmentKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &models.TaskAttachment{
+		TaskID:      taskID,
+		UploaderID:  userID,
+		FileName:    models.SanitizeFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}
+
+	if err := s.blobStore.Put(ctx, key, io.LimitReader(file, size), size, contentType, attachment.Checksum); err != nil {
+		return nil, err
+	}
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		if delErr := s.blobStore.Delete(ctx, key); delErr != nil {
+			log.Printf("Error deleting attachment blob %s: %v", key, delErr)
+		}
+		return nil, err
+	}
+
+	// Images are made into thumbnails and stripped of metadata in the background
+	if attachment.IsImage() {
+		if err := s.messageBroker.PublishAttachmentStored(ctx, taskID, attachment.ID); err != nil {
+			log.Printf("Error publishing attachment %d: %v", attachment.ID, err)
+		}
+	}
+	return attachment, nil
+}
+
+// OpenAttachment returns the attachment metadata and its content; the caller
+// must close the reader
+func (s *TaskAttachmentService) OpenAttachment(ctx context.Context, taskID int64, attachmentID int64, userID int64) (*models.TaskAttachment, io.ReadCloser, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment == nil {
+		return nil, nil, ErrNotFound
+	}
+
+	content, err := s.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// OpenThumbnail returns the attachment metadata and one of its JPEG
+// thumbnails; the caller must close the reader. Attachments that are not
+// processed images have no thumbnails.
+func (s *TaskAttachmentService) OpenThumbnail(ctx context.Context, taskID int64, attachmentID int64, size string, userID int64) (*models.TaskAttachment, io.ReadCloser, error) {
+	if _, ok := models.ThumbnailSizes[size]; !ok {
+		return nil, nil, ErrInvalidInput
+	}
+
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment == nil || len(attachment.Thumbnails) == 0 {
+		return nil, nil, ErrNotFound
+	}
+
+	content, err := s.blobStore.Get(ctx, models.ThumbnailKey(attachment.StorageKey, size))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment; only its uploader or a manager can
+// delete it
+func (s *TaskAttachmentService) DeleteAttachment(ctx context.Context, taskID int64, attachmentID int64, userID int64) error {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrNotFound
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment == nil {
+		return ErrNotFound
+	}
+	if !user.IsManager() && attachment.UploaderID != userID {
+		return ErrUnauthorized
+	}
+
+	if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		return err
+	}
+	// The row is gone; a file that fails to delete is only an orphan
+	for _, key := range models.AttachmentBlobKeys(attachment.StorageKey) {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			log.Printf("Error deleting attachment blob %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// newAttachmentKey returns a random blob key under the task's prefix
+func newAttachmentKey(taskID int64) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(b)), nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/pkg/messaging"
+	"sword-challenge/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskAttachmentRepository) Create(ctx context.Context, attachment *models.TaskAttachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *MockTaskAttachmentRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskAttachment, error) {
+	args := m.Called(ctx, taskID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskAttachment), args.Error(1)
+}
+
+func (m *MockTaskAttachmentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskAttachment, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TaskAttachment), args.Error(1)
+}
+
+func (m *MockTaskAttachmentRepository) MarkProcessed(ctx context.Context, attachment *models.TaskAttachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *MockTaskAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestTaskAttachmentService_Upload(t *testing.T) {
+	limits := config.AttachmentLimits{MaxSize: 64, AllowedTypes: []string{"image/png", "application/pdf"}}
+	png := append(append([]byte{}, pngHeader...), []byte("pixels")...)
+	pngSum := sha256.Sum256(png)
+
+	tests := []struct {
+		name          string
+		userID        int64
+		content       []byte
+		setupMocks    func(*MockTaskAttachmentRepository)
+		expectedError error
+		expectStored  bool
+	}{
+		{
+			name:    "stores the file with detected type and checksum",
+			userID:  1,
+			content: png,
+			setupMocks: func(ar *MockTaskAttachmentRepository) {
+				ar.On("Create", mock.Anything, mock.MatchedBy(func(a *models.TaskAttachment) bool {
+					return a.ContentType == "image/png" && a.Size == int64(len(png)) &&
+						a.Checksum == hex.EncodeToString(pngSum[:]) && a.FileName == "after.png" && a.UploaderID == 1
+				})).Return(nil)
+			},
+			expectStored: true,
+		},
+		{
+			name:          "file too large",
+			userID:        1,
+			content:       append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 64)...),
+			setupMocks:    func(ar *MockTaskAttachmentRepository) {},
+			expectedError: ErrFileTooLarge,
+		},
+		{
+			name:          "type not allowed",
+			userID:        1,
+			content:       []byte("<html><script>alert(1)</script></html>"),
+			setupMocks:    func(ar *MockTaskAttachmentRepository) {},
+			expectedError: ErrUnsupportedMediaType,
+		},
+		{
+			name:          "empty file",
+			userID:        1,
+			content:       []byte{},
+			setupMocks:    func(ar *MockTaskAttachmentRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "technician cannot attach to another technician's task",
+			userID:        3,
+			content:       png,
+			setupMocks:    func(ar *MockTaskAttachmentRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAttachmentRepo := new(MockTaskAttachmentRepository)
+			blobStore := storage.NewMemoryStore()
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.userID).Return(&models.User{ID: tt.userID, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 1}, nil)
+			tt.setupMocks(mockAttachmentRepo)
+
+			broker := messaging.NewMockBroker()
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: broker})
+			service := NewTaskAttachmentService(taskService, mockUserRepo, mockAttachmentRepo, blobStore, broker, limits)
+			attachment, err := service.Upload(context.Background(), 1, "../after.png", bytes.NewReader(tt.content), tt.userID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectStored {
+				r, err := blobStore.Get(context.Background(), attachment.StorageKey)
+				assert.NoError(t, err)
+				stored, _ := io.ReadAll(r)
+				assert.Equal(t, tt.content, stored)
+				assert.Equal(t, []messaging.AttachmentStoredMessage{{TaskID: 1, AttachmentID: attachment.ID}}, broker.GetAttachmentMessages())
+			} else {
+				assert.Empty(t, blobStore.Keys())
+				assert.Empty(t, broker.GetAttachmentMessages())
+			}
+			mockAttachmentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskAttachmentService_DeleteAttachment(t *testing.T) {
+	attachment := &models.TaskAttachment{ID: 7, TaskID: 1, UploaderID: 1, StorageKey: "tasks/1/abc"}
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		setupMocks    func(*MockTaskAttachmentRepository)
+		expectedError error
+		expectDeleted bool
+	}{
+		{
+			name: "uploader deletes their attachment",
+			user: &models.User{ID: 1, Role: models.RoleTechnician},
+			setupMocks: func(ar *MockTaskAttachmentRepository) {
+				ar.On("GetByID", mock.Anything, int64(1), int64(7)).Return(attachment, nil)
+				ar.On("Delete", mock.Anything, int64(7)).Return(nil)
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "manager deletes any attachment",
+			user: &models.User{ID: 2, Role: models.RoleManager},
+			setupMocks: func(ar *MockTaskAttachmentRepository) {
+				ar.On("GetByID", mock.Anything, int64(1), int64(7)).Return(&models.TaskAttachment{ID: 7, TaskID: 1, UploaderID: 5, StorageKey: "tasks/1/abc"}, nil)
+				ar.On("Delete", mock.Anything, int64(7)).Return(nil)
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "attachment not found",
+			user: &models.User{ID: 1, Role: models.RoleTechnician},
+			setupMocks: func(ar *MockTaskAttachmentRepository) {
+				ar.On("GetByID", mock.Anything, int64(1), int64(7)).Return(nil, nil)
+			},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAttachmentRepo := new(MockTaskAttachmentRepository)
+			blobStore := storage.NewMemoryStore()
+			blobStore.Put(context.Background(), "tasks/1/abc", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png", "")
+			blobStore.Put(context.Background(), "tasks/1/abc.small", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/jpeg", "")
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 1}, nil)
+			tt.setupMocks(mockAttachmentRepo)
+
+			broker := messaging.NewMockBroker()
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: broker})
+			service := NewTaskAttachmentService(taskService, mockUserRepo, mockAttachmentRepo, blobStore, broker, config.AttachmentLimits{})
+			err := service.DeleteAttachment(context.Background(), 1, 7, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectDeleted, len(blobStore.Keys()) == 0)
+			mockAttachmentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskAttachmentService_OpenThumbnail(t *testing.T) {
+	processedAt := time.Now()
+	processed := &models.TaskAttachment{ID: 7, TaskID: 1, ContentType: "image/jpeg", StorageKey: "tasks/1/abc", ProcessedAt: &processedAt}
+	processed.SetThumbnails()
+	pending := &models.TaskAttachment{ID: 8, TaskID: 1, ContentType: "image/jpeg", StorageKey: "tasks/1/def"}
+
+	tests := []struct {
+		name          string
+		attachmentID  int64
+		size          string
+		setupMocks    func(*MockTaskAttachmentRepository)
+		expectedError error
+	}{
+		{
+			name:         "returns the thumbnail of a processed image",
+			attachmentID: 7,
+			size:         "small",
+			setupMocks: func(ar *MockTaskAttachmentRepository) {
+				ar.On("GetByID", mock.Anything, int64(1), int64(7)).Return(processed, nil)
+			},
+		},
+		{
+			name:          "unknown size",
+			attachmentID:  7,
+			size:          "huge",
+			setupMocks:    func(ar *MockTaskAttachmentRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:         "image not processed yet",
+			attachmentID: 8,
+			size:         "small",
+			setupMocks: func(ar *MockTaskAttachmentRepository) {
+				ar.On("GetByID", mock.Anything, int64(1), int64(8)).Return(pending, nil)
+			},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAttachmentRepo := new(MockTaskAttachmentRepository)
+			blobStore := storage.NewMemoryStore()
+			blobStore.Put(context.Background(), "tasks/1/abc.small", bytes.NewReader([]byte("thumb")), 5, "image/jpeg", "")
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 1}, nil)
+			tt.setupMocks(mockAttachmentRepo)
+
+			broker := messaging.NewMockBroker()
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: broker})
+			service := NewTaskAttachmentService(taskService, mockUserRepo, mockAttachmentRepo, blobStore, broker, config.AttachmentLimits{})
+			_, content, err := service.OpenThumbnail(context.Background(), 1, tt.attachmentID, tt.size, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				data, _ := io.ReadAll(content)
+				content.Close()
+				assert.Equal(t, []byte("thumb"), data)
+			}
+			mockAttachmentRepo.AssertExpectations(t)
+		})
+	}
+}
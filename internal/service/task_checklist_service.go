@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"time"
+)
+
+// TaskChecklistService manages the checklist items of tasks. Anyone who can
+// see a task (its technician, managers) can edit its checklist.
+type TaskChecklistService struct {
+	taskService   *TaskService
+	checklistRepo repository.TaskChecklistRepository
+}
+
+func NewTaskChecklistService(
+	taskService *TaskService,
+	checklistRepo repository.TaskChecklistRepository,
+) *TaskChecklistService {
+	return &TaskChecklistService{
+		taskService:   taskService,
+		checklistRepo: checklistRepo,
+	}
+}
+
+func (s *TaskChecklistService) GetItems(ctx context.Context, taskID int64, userID int64) ([]*models.TaskChecklistItem, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.GetByTaskID(ctx, taskID)
+}
+
+// CreateItem adds an open item to the checklist, at item.Position or at the
+// end when it is 0
+func (s *TaskChecklistService) CreateItem(ctx context.Context, taskID int64, item *models.TaskChecklistItem, userID int64) (*models.TaskChecklistItem, error) {
+	// Same visibility rules as the task itself
+	task, err := s.taskService.GetTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	item.Sanitize()
+
+	// Validate input
+	if err := item.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	count, err := s.checklistRepo.Count(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= models.MaxChecklistItems {
+		return nil, ErrInvalidInput
+	}
+
+	// A completed task cannot get a required item it has not done
+	item.Done = false
+	if task.Status == models.TaskStatusCompleted && item.BlocksCompletion() {
+		return nil, ErrChecklistOpen
+	}
+
+	item.TaskID = taskID
+	item.Position = min(item.Position, count+1)
+	if err := s.checklistRepo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+
+	created, err := s.checklistRepo.GetByID(ctx, taskID, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, ErrNotFound
+	}
+	return created, nil
+}
+
+// UpdateItem changes an item; marking it done records the user and time.
+// A position of 0 keeps the item where it is.
+func (s *TaskChecklistService) UpdateItem(ctx context.Context, taskID int64, update *models.TaskChecklistItem, userID int64) (*models.TaskChecklistItem, error) {
+	// Same visibility rules as the task itself
+	task, err := s.taskService.GetTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.checklistRepo.GetByID(ctx, taskID, update.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+
+	item := *existing
+	item.Text = update.Text
+	item.Note = update.Note
+	item.Required = update.Required
+	item.Position = update.Position
+	item.SetDone(update.Done, userID, time.Now().UTC())
+
+	// Sanitize input
+	item.Sanitize()
+
+	// Validate input
+	if err := item.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	// A completed task must keep its required items done; reopen it first
+	if task.Status == models.TaskStatusCompleted && item.BlocksCompletion() {
+		return nil, ErrChecklistOpen
+	}
+
+	count, err := s.checklistRepo.Count(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	item.Position = min(item.Position, count)
+	if err := s.checklistRepo.Update(ctx, &item); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.checklistRepo.GetByID(ctx, taskID, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrNotFound
+	}
+	return updated, nil
+}
+
+func (s *TaskChecklistService) DeleteItem(ctx context.Context, taskID int64, itemID int64, userID int64) error {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	item, err := s.checklistRepo.GetByID(ctx, taskID, itemID)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return ErrNotFound
+	}
+
+	return s.checklistRepo.Delete(ctx, item)
+}
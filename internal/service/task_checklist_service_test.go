@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskChecklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskChecklistRepository) Create(ctx context.Context, item *models.TaskChecklistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockTaskChecklistRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskChecklistItem, error) {
+	args := m.Called(ctx, taskID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskChecklistItem, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistRepository) Count(ctx context.Context, taskID int64) (int, error) {
+	args := m.Called(ctx, taskID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskChecklistRepository) Update(ctx context.Context, item *models.TaskChecklistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockTaskChecklistRepository) Delete(ctx context.Context, item *models.TaskChecklistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func TestTaskChecklistService_CreateItem(t *testing.T) {
+	owner := &models.User{ID: 2, Role: models.RoleTechnician}
+
+	tests := []struct {
+		name          string
+		status        string
+		item          *models.TaskChecklistItem
+		setupMocks    func(*MockTaskChecklistRepository)
+		expectedError error
+	}{
+		{
+			name:   "required item is appended to an open task",
+			status: models.TaskStatusOpen,
+			item:   &models.TaskChecklistItem{Text: " Replace air filters ", Required: true, Position: 9},
+			setupMocks: func(cr *MockTaskChecklistRepository) {
+				cr.On("Count", mock.Anything, int64(1)).Return(2, nil)
+				cr.On("Create", mock.Anything, mock.MatchedBy(func(i *models.TaskChecklistItem) bool {
+					return i.TaskID == 1 && i.Text == "Replace air filters" && i.Position == 3 && !i.Done
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TaskChecklistItem).ID = 10
+				}).Return(nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(&models.TaskChecklistItem{ID: 10, TaskID: 1}, nil)
+			},
+		},
+		{
+			name:   "completed task cannot get a required item",
+			status: models.TaskStatusCompleted,
+			item:   &models.TaskChecklistItem{Text: "Replace air filters", Required: true},
+			setupMocks: func(cr *MockTaskChecklistRepository) {
+				cr.On("Count", mock.Anything, int64(1)).Return(2, nil)
+			},
+			expectedError: ErrChecklistOpen,
+		},
+		{
+			name:   "full checklist",
+			status: models.TaskStatusOpen,
+			item:   &models.TaskChecklistItem{Text: "One more"},
+			setupMocks: func(cr *MockTaskChecklistRepository) {
+				cr.On("Count", mock.Anything, int64(1)).Return(models.MaxChecklistItems, nil)
+			},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "empty text",
+			status:        models.TaskStatusOpen,
+			item:          &models.TaskChecklistItem{Text: "   "},
+			setupMocks:    func(cr *MockTaskChecklistRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockChecklistRepo := new(MockTaskChecklistRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2, Status: tt.status}, nil)
+			tt.setupMocks(mockChecklistRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskChecklistService(taskService, mockChecklistRepo)
+			_, err := service.CreateItem(context.Background(), 1, tt.item, owner.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockChecklistRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskChecklistService_UpdateItem(t *testing.T) {
+	owner := &models.User{ID: 2, Role: models.RoleTechnician}
+	completedBy := int64(1)
+
+	tests := []struct {
+		name          string
+		status        string
+		existing      *models.TaskChecklistItem
+		update        *models.TaskChecklistItem
+		setupMocks    func(*MockTaskChecklistRepository)
+		expectedError error
+	}{
+		{
+			name:     "ticking an item off records who did it",
+			status:   models.TaskStatusOpen,
+			existing: &models.TaskChecklistItem{ID: 10, TaskID: 1, Position: 1, Text: "Replace air filters", Required: true},
+			update:   &models.TaskChecklistItem{ID: 10, Text: "Replace air filters", Required: true, Done: true},
+			setupMocks: func(cr *MockTaskChecklistRepository) {
+				cr.On("Count", mock.Anything, int64(1)).Return(3, nil)
+				cr.On("Update", mock.Anything, mock.MatchedBy(func(i *models.TaskChecklistItem) bool {
+					return i.Done && i.CompletedBy != nil && *i.CompletedBy == 2 && i.CompletedAt != nil && i.Position == 0
+				})).Return(nil)
+			},
+		},
+		{
+			name:     "ticking a done item again keeps its completion",
+			status:   models.TaskStatusCompleted,
+			existing: &models.TaskChecklistItem{ID: 10, TaskID: 1, Position: 1, Text: "Replace air filters", Required: true, Done: true, CompletedBy: &completedBy},
+			update:   &models.TaskChecklistItem{ID: 10, Text: "Replace air filters", Required: true, Done: true, Position: 5},
+			setupMocks: func(cr *MockTaskChecklistRepository) {
+				cr.On("Count", mock.Anything, int64(1)).Return(3, nil)
+				cr.On("Update", mock.Anything, mock.MatchedBy(func(i *models.TaskChecklistItem) bool {
+					return *i.CompletedBy == 1 && i.Position == 3
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "required item of a completed task cannot be reopened",
+			status:        models.TaskStatusCompleted,
+			existing:      &models.TaskChecklistItem{ID: 10, TaskID: 1, Position: 1, Text: "Replace air filters", Required: true, Done: true, CompletedBy: &completedBy},
+			update:        &models.TaskChecklistItem{ID: 10, Text: "Replace air filters", Required: true},
+			setupMocks:    func(cr *MockTaskChecklistRepository) {},
+			expectedError: ErrChecklistOpen,
+		},
+		{
+			name:          "unknown item",
+			status:        models.TaskStatusOpen,
+			update:        &models.TaskChecklistItem{ID: 10, Text: "Replace air filters"},
+			setupMocks:    func(cr *MockTaskChecklistRepository) {},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockChecklistRepo := new(MockTaskChecklistRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2, Status: tt.status}, nil)
+			if tt.existing != nil {
+				mockChecklistRepo.On("GetByID", mock.Anything, int64(1), int64(10)).Return(tt.existing, nil)
+			} else {
+				mockChecklistRepo.On("GetByID", mock.Anything, int64(1), int64(10)).Return(nil, nil)
+			}
+			tt.setupMocks(mockChecklistRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskChecklistService(taskService, mockChecklistRepo)
+			_, err := service.UpdateItem(context.Background(), 1, tt.update, owner.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockChecklistRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+)
+
+type TaskCommentService struct {
+	taskService   *TaskService
+	userRepo      repository.UserRepository
+	commentRepo   repository.TaskCommentRepository
+	messageBroker messaging.MessageBroker
+}
+
+func NewTaskCommentService(
+	taskService *TaskService,
+	userRepo repository.UserRepository,
+	commentRepo repository.TaskCommentRepository,
+	messageBroker messaging.MessageBroker,
+) *TaskCommentService {
+	return &TaskCommentService{
+		taskService:   taskService,
+		userRepo:      userRepo,
+		commentRepo:   commentRepo,
+		messageBroker: messageBroker,
+	}
+}
+
+func (s *TaskCommentService) GetComments(ctx context.Context, taskID int64, userID int64) ([]*models.TaskComment, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.GetByTaskID(ctx, taskID)
+}
+
+// CreateComment adds a comment to the task and notifies the users it mentions
+func (s *TaskCommentService) CreateComment(ctx context.Context, taskID int64, comment *models.TaskComment, userID int64) (*models.TaskComment, error) {
+	// Same visibility rules as the task itself
+	task, err := s.taskService.GetTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	comment.Sanitize()
+
+	// Validate input
+	if err := comment.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	comment.TaskID = taskID
+	comment.AuthorID = userID
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(ctx, task, comment, nil)
+
+	created, err := s.commentRepo.GetByID(ctx, taskID, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, ErrNotFound
+	}
+	return created, nil
+}
+
+// UpdateComment changes the body of a comment; only its author can edit it.
+// Users mentioned for the first time are notified.
+func (s *TaskCommentService) UpdateComment(ctx context.Context, taskID int64, commentID int64, body string, userID int64) (*models.TaskComment, error) {
+	// Same visibility rules as the task itself
+	task, err := s.taskService.GetTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.commentRepo.GetByID(ctx, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+	if existing.AuthorID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	comment := &models.TaskComment{ID: existing.ID, TaskID: taskID, AuthorID: userID, Body: body}
+
+	// Sanitize input
+	comment.Sanitize()
+
+	// Validate input
+	if err := comment.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(ctx, task, comment, existing.Mentions())
+
+	updated, err := s.commentRepo.GetByID(ctx, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrNotFound
+	}
+	return updated, nil
+}
+
+// DeleteComment removes a comment; only its author or a manager can delete it
+func (s *TaskCommentService) DeleteComment(ctx context.Context, taskID int64, commentID int64, userID int64) error {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrNotFound
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, taskID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		return ErrNotFound
+	}
+	if !user.IsManager() && comment.AuthorID != userID {
+		return ErrUnauthorized
+	}
+
+	return s.commentRepo.Delete(ctx, comment.ID)
+}
+
+// notifyMentions publishes a notification request for every user mentioned in
+// the comment and not in alreadyNotified. Unknown emails, the author and
+// users who cannot see the task are skipped, so a mention never reveals the
+// task to someone outside it.
+func (s *TaskCommentService) notifyMentions(ctx context.Context, task *models.Task, comment *models.TaskComment, alreadyNotified []string) {
+	var recipientIDs []int64
+	for _, email := range comment.Mentions() {
+		if slices.Contains(alreadyNotified, email) {
+			continue
+		}
+
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			log.Printf("Error resolving mention %s: %v", email, err)
+			continue
+		}
+		if user == nil || user.ID == comment.AuthorID || slices.Contains(recipientIDs, user.ID) {
+			continue
+		}
+		if user.IsTechnician() && task.TechnicianID != user.ID {
+			continue
+		}
+		recipientIDs = append(recipientIDs, user.ID)
+	}
+	if len(recipientIDs) == 0 {
+		return
+	}
+
+	if err := s.messageBroker.PublishCommentMentioned(ctx, task.ID, comment.ID, comment.AuthorID, recipientIDs); err != nil {
+		log.Printf("Error publishing mentions of comment %d: %v", comment.ID, err)
+	}
+}
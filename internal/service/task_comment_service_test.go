@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/pkg/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskCommentRepository) Create(ctx context.Context, comment *models.TaskComment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockTaskCommentRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskComment, error) {
+	args := m.Called(ctx, taskID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskCommentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskComment, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskCommentRepository) Update(ctx context.Context, comment *models.TaskComment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockTaskCommentRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestTaskCommentService_CreateComment(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager, Email: "john.smith@company.com"}
+	owner := &models.User{ID: 2, Role: models.RoleTechnician, Email: "sarah.j@company.com"}
+	otherTech := &models.User{ID: 3, Role: models.RoleTechnician, Email: "mike.w@company.com"}
+
+	tests := []struct {
+		name               string
+		user               *models.User
+		body               string
+		setupMocks         func(*MockUserRepository, *MockTaskCommentRepository)
+		expectedError      error
+		expectedRecipients []int64
+	}{
+		{
+			name: "manager mentions the task's technician",
+			user: manager,
+			body: "@sarah.j@company.com was the filter replaced too?",
+			setupMocks: func(ur *MockUserRepository, cr *MockTaskCommentRepository) {
+				ur.On("GetByEmail", mock.Anything, "sarah.j@company.com").Return(owner, nil)
+				cr.On("Create", mock.Anything, mock.MatchedBy(func(c *models.TaskComment) bool {
+					return c.TaskID == 1 && c.AuthorID == 1
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TaskComment).ID = 10
+				}).Return(nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(&models.TaskComment{ID: 10, TaskID: 1, AuthorID: 1}, nil)
+			},
+			expectedRecipients: []int64{2},
+		},
+		{
+			name: "users who cannot see the task, unknown emails and the author are not notified",
+			user: manager,
+			body: "@mike.w@company.com @nobody@company.com @john.smith@company.com",
+			setupMocks: func(ur *MockUserRepository, cr *MockTaskCommentRepository) {
+				ur.On("GetByEmail", mock.Anything, "mike.w@company.com").Return(otherTech, nil)
+				ur.On("GetByEmail", mock.Anything, "nobody@company.com").Return(nil, nil)
+				ur.On("GetByEmail", mock.Anything, "john.smith@company.com").Return(manager, nil)
+				cr.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TaskComment).ID = 10
+				}).Return(nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(&models.TaskComment{ID: 10}, nil)
+			},
+		},
+		{
+			name:          "empty comment",
+			user:          owner,
+			body:          "   ",
+			setupMocks:    func(ur *MockUserRepository, cr *MockTaskCommentRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "technician cannot comment on another technician's task",
+			user:          otherTech,
+			body:          "Hello",
+			setupMocks:    func(ur *MockUserRepository, cr *MockTaskCommentRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockCommentRepo := new(MockTaskCommentRepository)
+			broker := messaging.NewMockBroker()
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2}, nil)
+			tt.setupMocks(mockUserRepo, mockCommentRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: broker})
+			service := NewTaskCommentService(taskService, mockUserRepo, mockCommentRepo, broker)
+			_, err := service.CreateComment(context.Background(), 1, &models.TaskComment{Body: tt.body}, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedRecipients != nil {
+				assert.Equal(t, []messaging.CommentMentionedMessage{
+					{TaskID: 1, CommentID: 10, AuthorID: tt.user.ID, RecipientIDs: tt.expectedRecipients},
+				}, broker.GetMentionMessages())
+			} else {
+				assert.Empty(t, broker.GetMentionMessages())
+			}
+			mockUserRepo.AssertExpectations(t)
+			mockCommentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskCommentService_UpdateComment(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager, Email: "john.smith@company.com"}
+	owner := &models.User{ID: 2, Role: models.RoleTechnician, Email: "sarah.j@company.com"}
+	existing := &models.TaskComment{ID: 10, TaskID: 1, AuthorID: 2, Body: "@john.smith@company.com done"}
+
+	tests := []struct {
+		name          string
+		userID        int64
+		body          string
+		setupMocks    func(*MockUserRepository, *MockTaskCommentRepository)
+		expectedError error
+		expectNotify  bool
+	}{
+		{
+			name:   "author edits; only new mentions are notified",
+			userID: 2,
+			body:   "@john.smith@company.com done, and the filter too",
+			setupMocks: func(ur *MockUserRepository, cr *MockTaskCommentRepository) {
+				ur.On("GetByID", mock.Anything, int64(2)).Return(owner, nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(existing, nil)
+				cr.On("Update", mock.Anything, mock.MatchedBy(func(c *models.TaskComment) bool {
+					return c.ID == 10 && c.Body == "@john.smith@company.com done, and the filter too"
+				})).Return(nil)
+			},
+		},
+		{
+			name:   "newly mentioned manager is notified",
+			userID: 2,
+			body:   "@john.smith@company.com @JOHN.SMITH@company.com done",
+			setupMocks: func(ur *MockUserRepository, cr *MockTaskCommentRepository) {
+				ur.On("GetByID", mock.Anything, int64(2)).Return(owner, nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(&models.TaskComment{ID: 10, TaskID: 1, AuthorID: 2, Body: "done"}, nil)
+				cr.On("Update", mock.Anything, mock.Anything).Return(nil)
+				ur.On("GetByEmail", mock.Anything, "john.smith@company.com").Return(manager, nil)
+			},
+			expectNotify: true,
+		},
+		{
+			name:   "only the author can edit",
+			userID: 1,
+			body:   "changed",
+			setupMocks: func(ur *MockUserRepository, cr *MockTaskCommentRepository) {
+				ur.On("GetByID", mock.Anything, int64(1)).Return(manager, nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(existing, nil)
+			},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:   "comment not found",
+			userID: 2,
+			body:   "changed",
+			setupMocks: func(ur *MockUserRepository, cr *MockTaskCommentRepository) {
+				ur.On("GetByID", mock.Anything, int64(2)).Return(owner, nil)
+				cr.On("GetByID", mock.Anything, int64(1), int64(10)).Return(nil, nil).Once()
+			},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockCommentRepo := new(MockTaskCommentRepository)
+			broker := messaging.NewMockBroker()
+
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2}, nil)
+			tt.setupMocks(mockUserRepo, mockCommentRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: broker})
+			service := NewTaskCommentService(taskService, mockUserRepo, mockCommentRepo, broker)
+			_, err := service.UpdateComment(context.Background(), 1, 10, tt.body, tt.userID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectNotify {
+				assert.Equal(t, []messaging.CommentMentionedMessage{
+					{TaskID: 1, CommentID: 10, AuthorID: 2, RecipientIDs: []int64{1}},
+				}, broker.GetMentionMessages())
+			} else {
+				assert.Empty(t, broker.GetMentionMessages())
+			}
+			mockUserRepo.AssertExpectations(t)
+			mockCommentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskCommentService_DeleteComment(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *models.User
+		authorID      int64
+		expectDelete  bool
+		expectedError error
+	}{
+		{name: "author deletes their comment", user: &models.User{ID: 2, Role: models.RoleTechnician}, authorID: 2, expectDelete: true},
+		{name: "manager deletes any comment", user: &models.User{ID: 1, Role: models.RoleManager}, authorID: 2, expectDelete: true},
+		{name: "technician cannot delete a manager's comment", user: &models.User{ID: 2, Role: models.RoleTechnician}, authorID: 1, expectedError: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockCommentRepo := new(MockTaskCommentRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2}, nil)
+			mockCommentRepo.On("GetByID", mock.Anything, int64(1), int64(10)).Return(&models.TaskComment{ID: 10, TaskID: 1, AuthorID: tt.authorID}, nil)
+			if tt.expectDelete {
+				mockCommentRepo.On("Delete", mock.Anything, int64(10)).Return(nil)
+			}
+
+			broker := messaging.NewMockBroker()
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: broker})
+			service := NewTaskCommentService(taskService, mockUserRepo, mockCommentRepo, broker)
+			err := service.DeleteComment(context.Background(), 1, 10, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockCommentRepo.AssertExpectations(t)
+		})
+	}
+}
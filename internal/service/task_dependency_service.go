@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+)
+
+var (
+	ErrDependencyCycle  = errors.New("the prerequisite already depends on the task")
+	ErrDependencyExists = errors.New("the task already depends on the prerequisite")
+)
+
+// TaskDependencyService manages the dependencies between tasks. Anyone who
+// can see both tasks (their technician, managers) can link them.
+type TaskDependencyService struct {
+	taskService    *TaskService
+	dependencyRepo repository.TaskDependencyRepository
+	userRepo       repository.UserRepository
+	messageBroker  messaging.MessageBroker
+}
+
+func NewTaskDependencyService(
+	taskService *TaskService,
+	dependencyRepo repository.TaskDependencyRepository,
+	userRepo repository.UserRepository,
+	messageBroker messaging.MessageBroker,
+) *TaskDependencyService {
+	return &TaskDependencyService{
+		taskService:    taskService,
+		dependencyRepo: dependencyRepo,
+		userRepo:       userRepo,
+		messageBroker:  messageBroker,
+	}
+}
+
+// GetGraph returns the tasks the task depends on, directly or not, and those
+// depending on it. Technicians get no titles for other technicians' tasks.
+func (s *TaskDependencyService) GetGraph(ctx context.Context, taskID int64, userID int64) (*models.TaskDependencyGraph, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	graph, err := s.dependencyRepo.GetGraph(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	graph.RedactFor(user)
+	return graph, nil
+}
+
+// AddDependency makes the task wait for dependsOnID and returns the updated
+// graph
+func (s *TaskDependencyService) AddDependency(ctx context.Context, taskID int64, dependsOnID int64, userID int64) (*models.TaskDependencyGraph, error) {
+	dependency := &models.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID}
+
+	// Validate input
+	if err := dependency.Validate(); err != nil {
+		return nil, ErrDependencyCycle
+	}
+
+	// Same visibility rules as the tasks themselves
+	task, err := s.taskService.GetTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	prerequisite, err := s.taskService.GetTask(ctx, dependsOnID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A completed task cannot wait for a task that is not; reopen it first
+	if task.Status == models.TaskStatusCompleted && prerequisite.Status != models.TaskStatusCompleted {
+		return nil, ErrTaskBlocked
+	}
+
+	if err := s.dependencyRepo.Create(ctx, dependency, userID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCycle):
+			return nil, ErrDependencyCycle
+		case errors.Is(err, repository.ErrDuplicate):
+			return nil, ErrDependencyExists
+		default:
+			return nil, err
+		}
+	}
+
+	return s.GetGraph(ctx, taskID, userID)
+}
+
+// RemoveDependency unlinks the task from dependsOnID. When that was the last
+// prerequisite the open task was waiting for, its technician is notified.
+func (s *TaskDependencyService) RemoveDependency(ctx context.Context, taskID int64, dependsOnID int64, userID int64) error {
+	// Same visibility rules as the task itself
+	task, err := s.taskService.GetTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.dependencyRepo.Delete(ctx, &models.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID})
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotFound
+	}
+
+	if task.Status == models.TaskStatusOpen && len(task.BlockedBy) == 1 && task.BlockedBy[0] == dependsOnID {
+		go s.messageBroker.PublishTaskUnblocked(ctx, task.ID, task.TechnicianID, task.Title)
+	}
+	return nil
+}
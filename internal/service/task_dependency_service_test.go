@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskDependencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskDependencyRepository) Create(ctx context.Context, dependency *models.TaskDependency, createdBy int64) error {
+	args := m.Called(ctx, dependency, createdBy)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyRepository) Delete(ctx context.Context, dependency *models.TaskDependency) (bool, error) {
+	args := m.Called(ctx, dependency)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskDependencyRepository) GetGraph(ctx context.Context, taskID int64) (*models.TaskDependencyGraph, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskDependencyGraph), args.Error(1)
+}
+
+func TestTaskDependencyService_AddDependency(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	tests := []struct {
+		name               string
+		taskID             int64
+		dependsOnID        int64
+		taskStatus         string
+		prerequisiteStatus string
+		setupMocks         func(*MockTaskDependencyRepository)
+		expectedError      error
+	}{
+		{
+			name:               "open task waits for an open task",
+			taskID:             2,
+			dependsOnID:        1,
+			taskStatus:         models.TaskStatusOpen,
+			prerequisiteStatus: models.TaskStatusOpen,
+			setupMocks: func(dr *MockTaskDependencyRepository) {
+				dr.On("Create", mock.Anything, &models.TaskDependency{TaskID: 2, DependsOnID: 1}, manager.ID).Return(nil)
+				dr.On("GetGraph", mock.Anything, int64(2)).Return(&models.TaskDependencyGraph{TaskID: 2}, nil)
+			},
+		},
+		{
+			name:          "task cannot depend on itself",
+			taskID:        2,
+			dependsOnID:   2,
+			setupMocks:    func(dr *MockTaskDependencyRepository) {},
+			expectedError: ErrDependencyCycle,
+		},
+		{
+			name:               "prerequisite already depends on the task",
+			taskID:             2,
+			dependsOnID:        1,
+			taskStatus:         models.TaskStatusOpen,
+			prerequisiteStatus: models.TaskStatusOpen,
+			setupMocks: func(dr *MockTaskDependencyRepository) {
+				dr.On("Create", mock.Anything, mock.Anything, manager.ID).Return(repository.ErrCycle)
+			},
+			expectedError: ErrDependencyCycle,
+		},
+		{
+			name:               "dependency already exists",
+			taskID:             2,
+			dependsOnID:        1,
+			taskStatus:         models.TaskStatusOpen,
+			prerequisiteStatus: models.TaskStatusCompleted,
+			setupMocks: func(dr *MockTaskDependencyRepository) {
+				dr.On("Create", mock.Anything, mock.Anything, manager.ID).Return(repository.ErrDuplicate)
+			},
+			expectedError: ErrDependencyExists,
+		},
+		{
+			name:               "completed task cannot wait for an open task",
+			taskID:             2,
+			dependsOnID:        1,
+			taskStatus:         models.TaskStatusCompleted,
+			prerequisiteStatus: models.TaskStatusOpen,
+			setupMocks:         func(dr *MockTaskDependencyRepository) {},
+			expectedError:      ErrTaskBlocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockDependencyRepo := new(MockTaskDependencyRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, tt.taskID).Return(&models.Task{ID: tt.taskID, TechnicianID: 2, Status: tt.taskStatus}, nil).Maybe()
+			mockTaskRepo.On("GetByID", mock.Anything, tt.dependsOnID).Return(&models.Task{ID: tt.dependsOnID, TechnicianID: 3, Status: tt.prerequisiteStatus}, nil).Maybe()
+			tt.setupMocks(mockDependencyRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskDependencyService(taskService, mockDependencyRepo, mockUserRepo, messaging.NewMockBroker())
+			graph, err := service.AddDependency(context.Background(), tt.taskID, tt.dependsOnID, manager.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.taskID, graph.TaskID)
+			}
+			mockDependencyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskDependencyService_RemoveDependency(t *testing.T) {
+	owner := &models.User{ID: 2, Role: models.RoleTechnician}
+
+	tests := []struct {
+		name            string
+		blockedBy       []int64
+		removed         bool
+		expectUnblocked bool
+		expectedError   error
+	}{
+		{
+			name:            "removing the last blocking prerequisite unblocks the task",
+			blockedBy:       []int64{1},
+			removed:         true,
+			expectUnblocked: true,
+		},
+		{
+			name:      "task still waits for another prerequisite",
+			blockedBy: []int64{1, 3},
+			removed:   true,
+		},
+		{
+			name:          "unknown dependency",
+			removed:       false,
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockDependencyRepo := new(MockTaskDependencyRepository)
+			mockBroker := messaging.NewMockBroker()
+
+			mockUserRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(2)).Return(&models.Task{
+				ID: 2, TechnicianID: 2, Title: "Configure network", Status: models.TaskStatusOpen, BlockedBy: tt.blockedBy,
+			}, nil)
+			mockDependencyRepo.On("Delete", mock.Anything, &models.TaskDependency{TaskID: 2, DependsOnID: 1}).Return(tt.removed, nil)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskDependencyService(taskService, mockDependencyRepo, mockUserRepo, mockBroker)
+			err := service.RemoveDependency(context.Background(), 2, 1, owner.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectUnblocked {
+				assert.Eventually(t, func() bool { return len(mockBroker.GetUnblockedMessages()) == 1 }, time.Second, 10*time.Millisecond)
+			} else {
+				assert.Empty(t, mockBroker.GetUnblockedMessages())
+			}
+			mockDependencyRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+)
+
+// TaskMaterialService records the parts used on tasks. The technician of a
+// task and managers can record and remove its materials; the stock follows.
+type TaskMaterialService struct {
+	taskService   *TaskService
+	partRepo      repository.PartRepository
+	materialRepo  repository.TaskMaterialRepository
+	messageBroker messaging.MessageBroker
+}
+
+func NewTaskMaterialService(
+	taskService *TaskService,
+	partRepo repository.PartRepository,
+	materialRepo repository.TaskMaterialRepository,
+	messageBroker messaging.MessageBroker,
+) *TaskMaterialService {
+	return &TaskMaterialService{
+		taskService:   taskService,
+		partRepo:      partRepo,
+		materialRepo:  materialRepo,
+		messageBroker: messageBroker,
+	}
+}
+
+func (s *TaskMaterialService) GetMaterials(ctx context.Context, taskID int64, userID int64) ([]*models.TaskMaterial, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.materialRepo.GetByTaskID(ctx, taskID)
+}
+
+// AddMaterial records parts used on the task and takes them out of stock.
+// When that brings the part down to its low stock threshold, the managers are
+// alerted.
+func (s *TaskMaterialService) AddMaterial(ctx context.Context, taskID int64, material *models.TaskMaterial, userID int64) (*models.TaskMaterial, error) {
+	task, err := s.taskService.getEditableTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	material.Sanitize()
+	material.ID = 0
+	material.TaskID = task.ID
+	material.CreatedBy = &userID
+
+	// Validate input
+	if err := material.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+	part, err := s.partRepo.GetByID(ctx, material.PartID)
+	if err != nil {
+		return nil, err
+	}
+	if part == nil {
+		return nil, ErrUnknownPart
+	}
+
+	part, err = s.materialRepo.Create(ctx, material)
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, err
+	}
+
+	if part.BecameLowStock(-material.Quantity) {
+		go s.messageBroker.PublishPartLowStock(ctx, task.ID, part.ID, part.SKU, part.Name, part.Unit, part.Stock)
+	}
+	return s.materialRepo.GetByID(ctx, task.ID, material.ID)
+}
+
+// RemoveMaterial deletes a material recorded by mistake and puts its quantity
+// back in stock
+func (s *TaskMaterialService) RemoveMaterial(ctx context.Context, taskID int64, materialID int64, userID int64) error {
+	if _, err := s.taskService.getEditableTask(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	material, err := s.materialRepo.GetByID(ctx, taskID, materialID)
+	if err != nil {
+		return err
+	}
+	if material == nil {
+		return ErrNotFound
+	}
+
+	removed, err := s.materialRepo.Delete(ctx, material, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/messaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskMaterialRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskMaterialRepository) Create(ctx context.Context, material *models.TaskMaterial) (*models.Part, error) {
+	args := m.Called(ctx, material)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Part), args.Error(1)
+}
+
+func (m *MockTaskMaterialRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskMaterial, error) {
+	args := m.Called(ctx, taskID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskMaterial), args.Error(1)
+}
+
+func (m *MockTaskMaterialRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskMaterial, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]*models.TaskMaterial), args.Error(1)
+}
+
+func (m *MockTaskMaterialRepository) Delete(ctx context.Context, material *models.TaskMaterial, deletedBy int64) (bool, error) {
+	args := m.Called(ctx, material, deletedBy)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestTaskMaterialService_AddMaterial(t *testing.T) {
+	owner := &models.User{ID: 2, Role: models.RoleTechnician}
+	otherTechnician := &models.User{ID: 3, Role: models.RoleTechnician}
+	filter := &models.Part{ID: 1, SKU: "FLT-MERV13", Name: "MERV 13 air filter", Unit: "pcs", Stock: 8, LowStockThreshold: 6}
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		material       *models.TaskMaterial
+		setupMocks     func(*MockPartRepository, *MockTaskMaterialRepository)
+		expectLowStock bool
+		expectedError  error
+	}{
+		{
+			name:     "stock stays above the threshold",
+			user:     owner,
+			material: &models.TaskMaterial{PartID: 1, Quantity: 1},
+			setupMocks: func(pr *MockPartRepository, mr *MockTaskMaterialRepository) {
+				pr.On("GetByID", mock.Anything, int64(1)).Return(filter, nil)
+				mr.On("Create", mock.Anything, mock.MatchedBy(func(m *models.TaskMaterial) bool {
+					return m.TaskID == 1 && *m.CreatedBy == owner.ID
+				})).Run(func(args mock.Arguments) { args.Get(1).(*models.TaskMaterial).ID = 5 }).
+					Return(&models.Part{ID: 1, Stock: 7, LowStockThreshold: 6}, nil)
+				mr.On("GetByID", mock.Anything, int64(1), int64(5)).Return(&models.TaskMaterial{ID: 5}, nil)
+			},
+		},
+		{
+			name:     "stock reaches the threshold",
+			user:     owner,
+			material: &models.TaskMaterial{PartID: 1, Quantity: 2},
+			setupMocks: func(pr *MockPartRepository, mr *MockTaskMaterialRepository) {
+				pr.On("GetByID", mock.Anything, int64(1)).Return(filter, nil)
+				mr.On("Create", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) { args.Get(1).(*models.TaskMaterial).ID = 5 }).
+					Return(&models.Part{ID: 1, SKU: "FLT-MERV13", Stock: 6, LowStockThreshold: 6}, nil)
+				mr.On("GetByID", mock.Anything, int64(1), int64(5)).Return(&models.TaskMaterial{ID: 5}, nil)
+			},
+			expectLowStock: true,
+		},
+		{
+			name:     "not enough in stock",
+			user:     owner,
+			material: &models.TaskMaterial{PartID: 1, Quantity: 20},
+			setupMocks: func(pr *MockPartRepository, mr *MockTaskMaterialRepository) {
+				pr.On("GetByID", mock.Anything, int64(1)).Return(filter, nil)
+				mr.On("Create", mock.Anything, mock.Anything).Return(nil, repository.ErrInsufficientStock)
+			},
+			expectedError: ErrInsufficientStock,
+		},
+		{
+			name:     "part not in the catalog",
+			user:     owner,
+			material: &models.TaskMaterial{PartID: 9, Quantity: 1},
+			setupMocks: func(pr *MockPartRepository, mr *MockTaskMaterialRepository) {
+				pr.On("GetByID", mock.Anything, int64(9)).Return(nil, nil)
+			},
+			expectedError: ErrUnknownPart,
+		},
+		{
+			name:          "zero quantity",
+			user:          owner,
+			material:      &models.TaskMaterial{PartID: 1},
+			setupMocks:    func(pr *MockPartRepository, mr *MockTaskMaterialRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "another technician's task",
+			user:          otherTechnician,
+			material:      &models.TaskMaterial{PartID: 1, Quantity: 1},
+			setupMocks:    func(pr *MockPartRepository, mr *MockTaskMaterialRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockPartRepo := new(MockPartRepository)
+			mockMaterialRepo := new(MockTaskMaterialRepository)
+			mockBroker := messaging.NewMockBroker()
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: owner.ID}, nil)
+			tt.setupMocks(mockPartRepo, mockMaterialRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskMaterialService(taskService, mockPartRepo, mockMaterialRepo, mockBroker)
+			material, err := service.AddMaterial(context.Background(), 1, tt.material, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, int64(5), material.ID)
+			}
+			if tt.expectLowStock {
+				assert.Eventually(t, func() bool { return len(mockBroker.GetLowStockMessages()) == 1 }, time.Second, 10*time.Millisecond)
+				assert.Equal(t, 6, mockBroker.GetLowStockMessages()[0].Stock)
+			} else {
+				assert.Empty(t, mockBroker.GetLowStockMessages())
+			}
+			mockPartRepo.AssertExpectations(t)
+			mockMaterialRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskMaterialService_RemoveMaterial(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+	material := &models.TaskMaterial{ID: 5, TaskID: 1, PartID: 1, Quantity: 2}
+
+	tests := []struct {
+		name          string
+		found         bool
+		removed       bool
+		expectedError error
+	}{
+		{name: "material goes back to stock", found: true, removed: true},
+		{name: "removed concurrently", found: true, removed: false, expectedError: ErrNotFound},
+		{name: "unknown material", expectedError: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockMaterialRepo := new(MockTaskMaterialRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, manager.ID).Return(manager, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2}, nil)
+			if tt.found {
+				mockMaterialRepo.On("GetByID", mock.Anything, int64(1), int64(5)).Return(material, nil)
+				mockMaterialRepo.On("Delete", mock.Anything, material, manager.ID).Return(tt.removed, nil)
+			} else {
+				mockMaterialRepo.On("GetByID", mock.Anything, int64(1), int64(5)).Return(nil, nil)
+			}
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskMaterialService(taskService, new(MockPartRepository), mockMaterialRepo, messaging.NewMockBroker())
+			err := service.RemoveMaterial(context.Background(), 1, 5, manager.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockMaterialRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sword-challenge/config"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/storage"
+	"time"
+)
+
+// tasksPurged counts trashed tasks permanently removed by the retention job,
+// exposed on /debug/vars
+var tasksPurged = expvar.NewInt("tasks_purged_total")
+
+type TaskRetentionService struct {
+	taskRepo  repository.TaskRepository
+	blobStore storage.BlobStore
+	retention config.TaskRetention
+}
+
+func NewTaskRetentionService(
+	taskRepo repository.TaskRepository,
+	blobStore storage.BlobStore,
+	retention config.TaskRetention,
+) *TaskRetentionService {
+	return &TaskRetentionService{
+		taskRepo:  taskRepo,
+		blobStore: blobStore,
+		retention: retention,
+	}
+}
+
+// Purge permanently removes tasks that have been in the trash longer than the
+// retention window, one batch at a time, and returns how many were removed
+func (s *TaskRetentionService) Purge(ctx context.Context) (int64, error) {
+	if s.retention.MaxAge <= 0 || s.retention.BatchSize <= 0 {
+		return 0, ErrInvalidInput
+	}
+
+	before := time.Now().Add(-s.retention.MaxAge)
+	var total int64
+	for {
+		purged, blobKeys, err := s.taskRepo.PurgeDeleted(ctx, before, s.retention.BatchSize)
+		total += purged
+		tasksPurged.Add(purged)
+		if err != nil {
+			return total, err
+		}
+
+		// The rows are gone; a file that fails to delete is only an orphan
+		for _, storageKey := range blobKeys {
+			for _, key := range models.AttachmentBlobKeys(storageKey) {
+				if err := s.blobStore.Delete(ctx, key); err != nil {
+					log.Printf("Error deleting attachment blob %s: %v", key, err)
+				}
+			}
+		}
+
+		if purged < int64(s.retention.BatchSize) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+
+	log.Printf("Purged %d tasks deleted before %s", total, before.Format(time.RFC3339))
+	return total, nil
+}
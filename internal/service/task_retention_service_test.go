@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sword-challenge/config"
+	"sword-challenge/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTaskRetentionService_Purge(t *testing.T) {
+	retention := config.TaskRetention{MaxAge: 24 * time.Hour, BatchSize: 2}
+	beforeCutoff := mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-23 * time.Hour))
+	})
+
+	tests := []struct {
+		name          string
+		retention     config.TaskRetention
+		setupMocks    func(*MockTaskRepository)
+		expectedTotal int64
+		expectedKeys  []string
+		expectedErr   error
+	}{
+		{
+			name:      "success - runs batches until a short one",
+			retention: retention,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("PurgeDeleted", mock.Anything, beforeCutoff, 2).Return(int64(2), []string{"tasks/1/a"}, nil).Once()
+				tr.On("PurgeDeleted", mock.Anything, beforeCutoff, 2).Return(int64(0), nil, nil).Once()
+			},
+			expectedTotal: 2,
+			expectedKeys:  []string{"tasks/2/b"},
+		},
+		{
+			name:      "error - repository error",
+			retention: retention,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("PurgeDeleted", mock.Anything, beforeCutoff, 2).Return(int64(0), nil, errors.New("repository error")).Once()
+			},
+			expectedErr: errors.New("repository error"),
+		},
+		{
+			name:        "error - invalid retention",
+			retention:   config.TaskRetention{MaxAge: 24 * time.Hour},
+			setupMocks:  func(tr *MockTaskRepository) {},
+			expectedErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			tt.setupMocks(mockTaskRepo)
+
+			// tasks/2/b belongs to a task that is not purged
+			blobStore := storage.NewMemoryStore()
+			for _, key := range []string{"tasks/1/a", "tasks/1/a.small", "tasks/2/b"} {
+				blobStore.Put(context.Background(), key, strings.NewReader("x"), 1, "", "")
+			}
+
+			service := NewTaskRetentionService(mockTaskRepo, blobStore, tt.retention)
+			total, err := service.Purge(context.Background())
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedTotal, total)
+			if tt.expectedKeys != nil {
+				assert.ElementsMatch(t, tt.expectedKeys, blobStore.Keys())
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+type TaskRevisionService struct {
+	taskService  *TaskService
+	revisionRepo repository.TaskRevisionRepository
+}
+
+func NewTaskRevisionService(
+	taskService *TaskService,
+	revisionRepo repository.TaskRevisionRepository,
+) *TaskRevisionService {
+	return &TaskRevisionService{
+		taskService:  taskService,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (s *TaskRevisionService) GetRevisions(ctx context.Context, taskID int64, userID int64) ([]*models.TaskRevision, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.revisionRepo.GetByTaskID(ctx, taskID)
+}
+
+func (s *TaskRevisionService) DiffRevisions(ctx context.Context, taskID int64, from, to int, userID int64) (*models.TaskRevisionDiff, error) {
+	if from < 1 || to < 1 {
+		return nil, ErrInvalidInput
+	}
+
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.revisionRepo.GetByRevision(ctx, taskID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.revisionRepo.GetByRevision(ctx, taskID, to)
+	if err != nil {
+		return nil, err
+	}
+	if fromRevision == nil || toRevision == nil {
+		return nil, ErrNotFound
+	}
+
+	return models.DiffRevisions(fromRevision, toRevision)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskRevisionRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskRevision, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TaskRevision), args.Error(1)
+}
+
+func (m *MockTaskRevisionRepository) GetByRevision(ctx context.Context, taskID int64, revision int) (*models.TaskRevision, error) {
+	args := m.Called(ctx, taskID, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRevision), args.Error(1)
+}
+
+func TestTaskRevisionService_GetRevisions(t *testing.T) {
+	tests := []struct {
+		name              string
+		user              *models.User
+		expectedRevisions []*models.TaskRevision
+		expectedError     error
+	}{
+		{
+			name:              "manager sees revisions of any task",
+			user:              &models.User{ID: 1, Role: models.RoleManager},
+			expectedRevisions: []*models.TaskRevision{{TaskID: 1, Revision: 1}, {TaskID: 1, Revision: 2}},
+		},
+		{
+			name:              "technician sees revisions of own task",
+			user:              &models.User{ID: 2, Role: models.RoleTechnician},
+			expectedRevisions: []*models.TaskRevision{{TaskID: 1, Revision: 1}, {TaskID: 1, Revision: 2}},
+		},
+		{
+			name:          "unauthorized - technician accessing other's task",
+			user:          &models.User{ID: 3, Role: models.RoleTechnician},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockRevisionRepo := new(MockTaskRevisionRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2}, nil)
+			if tt.expectedError == nil {
+				mockRevisionRepo.On("GetByTaskID", mock.Anything, int64(1)).Return(tt.expectedRevisions, nil)
+			}
+
+			service := NewTaskRevisionService(newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo}), mockRevisionRepo)
+			revisions, err := service.GetRevisions(context.Background(), 1, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedRevisions, revisions)
+			mockRevisionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskRevisionService_DiffRevisions(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	first := &models.TaskRevision{TaskID: 1, Revision: 1, Title: "Fix AC", Summary: "Replaced filters", PerformedAt: performedAt}
+	second := &models.TaskRevision{TaskID: 1, Revision: 2, Title: "Fix AC", Summary: "Checked filters", PerformedAt: performedAt}
+
+	tests := []struct {
+		name            string
+		from, to        int
+		setupMocks      func(*MockTaskRevisionRepository)
+		expectedChanges []models.FieldChange
+		expectedError   error
+	}{
+		{
+			name: "summary rewrite is reported",
+			from: 1,
+			to:   2,
+			setupMocks: func(rr *MockTaskRevisionRepository) {
+				rr.On("GetByRevision", mock.Anything, int64(1), 1).Return(first, nil)
+				rr.On("GetByRevision", mock.Anything, int64(1), 2).Return(second, nil)
+			},
+			expectedChanges: []models.FieldChange{{Field: "summary", Before: "Replaced filters", After: "Checked filters"}},
+		},
+		{
+			name: "unknown revision",
+			from: 1,
+			to:   9,
+			setupMocks: func(rr *MockTaskRevisionRepository) {
+				rr.On("GetByRevision", mock.Anything, int64(1), 1).Return(first, nil)
+				rr.On("GetByRevision", mock.Anything, int64(1), 9).Return(nil, nil)
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "invalid revision number",
+			from:          0,
+			to:            2,
+			setupMocks:    func(rr *MockTaskRevisionRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockRevisionRepo := new(MockTaskRevisionRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleManager}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 2}, nil)
+			tt.setupMocks(mockRevisionRepo)
+
+			service := NewTaskRevisionService(newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo}), mockRevisionRepo)
+			diff, err := service.DiffRevisions(context.Background(), 1, tt.from, tt.to, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.expectedChanges, diff.Changes)
+			} else {
+				assert.Nil(t, diff)
+			}
+			mockRevisionRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+type TaskSearchService struct {
+	searchRepo repository.TaskSearchRepository
+	userRepo   repository.UserRepository
+}
+
+func NewTaskSearchService(
+	searchRepo repository.TaskSearchRepository,
+	userRepo repository.UserRepository,
+) *TaskSearchService {
+	return &TaskSearchService{
+		searchRepo: searchRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (s *TaskSearchService) Search(ctx context.Context, query models.TaskSearchQuery, userID int64) ([]*models.TaskSearchResult, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	// Same visibility rules as GetTasks: technicians only see their own tasks
+	query.TechnicianID = 0
+	if user.IsTechnician() {
+		query.TechnicianID = userID
+	}
+
+	hits, err := s.searchRepo.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := models.SearchTerms(query.Query)
+	results := make([]*models.TaskSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, &models.TaskSearchResult{
+			Task:    hit.Task,
+			Score:   hit.Score,
+			Snippet: models.HighlightSnippet(hit.Task.Summary, terms, models.SnippetLength),
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskSearchRepository) Search(ctx context.Context, query models.TaskSearchQuery) ([]*models.TaskSearchHit, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TaskSearchHit), args.Error(1)
+}
+
+func TestTaskSearchService_Search(t *testing.T) {
+	task := &models.Task{ID: 1, TechnicianID: 1, Title: "Fix AC", Summary: "Replaced the compressor relay"}
+
+	tests := []struct {
+		name            string
+		user            *models.User
+		query           models.TaskSearchQuery
+		setupMocks      func(*MockTaskSearchRepository)
+		expectedResults []*models.TaskSearchResult
+		expectedError   error
+	}{
+		{
+			name:  "technician searches only their own tasks",
+			user:  &models.User{ID: 1, Role: models.RoleTechnician},
+			query: models.TaskSearchQuery{Query: "compressor"},
+			setupMocks: func(sr *MockTaskSearchRepository) {
+				sr.On("Search", mock.Anything, models.TaskSearchQuery{Query: "compressor", TechnicianID: 1, Limit: models.DefaultSearchLimit}).
+					Return([]*models.TaskSearchHit{{Task: task, Score: 1.5}}, nil)
+			},
+			expectedResults: []*models.TaskSearchResult{
+				{Task: task, Score: 1.5, Snippet: "Replaced the <mark>compressor</mark> relay"},
+			},
+		},
+		{
+			name:  "manager searches every task",
+			user:  &models.User{ID: 2, Role: models.RoleManager},
+			query: models.TaskSearchQuery{Query: "compressor", TechnicianID: 1, Limit: 5},
+			setupMocks: func(sr *MockTaskSearchRepository) {
+				sr.On("Search", mock.Anything, models.TaskSearchQuery{Query: "compressor", Limit: 5}).
+					Return([]*models.TaskSearchHit{}, nil)
+			},
+			expectedResults: []*models.TaskSearchResult{},
+		},
+		{
+			name:          "empty query",
+			user:          &models.User{ID: 2, Role: models.RoleManager},
+			query:         models.TaskSearchQuery{Query: "  "},
+			setupMocks:    func(sr *MockTaskSearchRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "user not found",
+			user:          nil,
+			query:         models.TaskSearchQuery{Query: "compressor"},
+			setupMocks:    func(sr *MockTaskSearchRepository) {},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSearchRepo := new(MockTaskSearchRepository)
+			mockUserRepo := new(MockUserRepository)
+
+			userID := int64(1)
+			if tt.user != nil {
+				userID = tt.user.ID
+			}
+			mockUserRepo.On("GetByID", mock.Anything, userID).Return(tt.user, nil)
+			tt.setupMocks(mockSearchRepo)
+
+			service := NewTaskSearchService(mockSearchRepo, mockUserRepo)
+			results, err := service.Search(context.Background(), tt.query, userID)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResults, results)
+			mockSearchRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,31 +3,53 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
 	"sword-challenge/internal/models"
 	"sword-challenge/internal/repository"
 	"sword-challenge/pkg/messaging"
+	"time"
 )
 
 var (
-	ErrUnauthorized = errors.New("unauthorized access")
-	ErrNotFound     = errors.New("resource not found")
-	ErrInvalidInput = errors.New("invalid input")
+	ErrUnauthorized       = errors.New("unauthorized access")
+	ErrNotFound           = errors.New("resource not found")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrPreconditionFailed = errors.New("resource was modified by another request")
+	ErrUnknownTags        = errors.New("tags are not in the managed vocabulary")
+	ErrChecklistOpen      = errors.New("task has required checklist items that are not done")
+	ErrTaskBlocked        = errors.New("task depends on tasks that are not completed")
+	ErrUnknownSite        = errors.New("site does not exist")
+	ErrUnknownAsset       = errors.New("asset does not exist")
+	ErrAssetNotOnSite     = errors.New("asset is not installed on the site")
+	ErrLocationNotFlagged = errors.New("task location is not flagged for review")
 )
 
 type TaskService struct {
 	taskRepo      repository.TaskRepository
 	userRepo      repository.UserRepository
+	tagRepo       repository.TagRepository
+	slaRepo       repository.SLARepository
+	siteRepo      repository.SiteRepository
+	assetRepo     repository.AssetRepository
 	messageBroker messaging.MessageBroker
 }
 
 func NewTaskService(
 	taskRepo repository.TaskRepository,
 	userRepo repository.UserRepository,
+	tagRepo repository.TagRepository,
+	slaRepo repository.SLARepository,
+	siteRepo repository.SiteRepository,
+	assetRepo repository.AssetRepository,
 	messageBroker messaging.MessageBroker,
 ) *TaskService {
 	return &TaskService{
 		taskRepo:      taskRepo,
 		userRepo:      userRepo,
+		tagRepo:       tagRepo,
+		slaRepo:       slaRepo,
+		siteRepo:      siteRepo,
+		assetRepo:     assetRepo,
 		messageBroker: messageBroker,
 	}
 }
@@ -47,31 +69,102 @@ func (s *TaskService) CreateTask(ctx context.Context, task *models.Task, userID
 	// Sanitize input
 	task.Sanitize()
 
+	// Tasks are recorded once performed unless they are created open
+	if task.Status == "" {
+		task.Status = models.TaskStatusCompleted
+	}
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityNormal
+	}
+
 	// Validate input
 	if err := task.Validate(); err != nil {
 		return nil, ErrInvalidInput
 	}
+	if task.Location != nil {
+		if err := task.Location.Validate(); err != nil {
+			return nil, ErrInvalidInput
+		}
+		task.Location.RecordedAt = time.Now()
+	}
+	tags, err := s.checkTags(ctx, task.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLocation(ctx, task, nil); err != nil {
+		return nil, err
+	}
+	site, err := s.checkGeofence(ctx, task, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// A task created with its checklist cannot be completed before it is done
+	if task.Status == models.TaskStatusCompleted {
+		for _, item := range task.ChecklistItems {
+			if item.BlocksCompletion() {
+				return nil, ErrChecklistOpen
+			}
+		}
+	}
 
-	task.TechnicianID = userID
-	if err := s.taskRepo.Create(ctx, &models.Task{
-		TechnicianID: userID,
-		Title:        task.Title,
-		Summary:      task.Summary,
-		PerformedAt:  task.PerformedAt,
-	}); err != nil {
+	created := &models.Task{
+		TechnicianID:   userID,
+		Title:          task.Title,
+		Summary:        task.Summary,
+		PerformedAt:    task.PerformedAt,
+		Status:         task.Status,
+		Priority:       task.Priority,
+		Tags:           tags,
+		Template:       task.Template,
+		DueAt:          task.DueAt,
+		AtRiskAt:       task.AtRiskAt,
+		SLAPolicyID:    task.SLAPolicyID,
+		SiteID:         task.SiteID,
+		AssetID:        task.AssetID,
+		Location:       task.Location,
+		ChecklistItems: task.ChecklistItems,
+	}
+	if err := s.createTask(ctx, created, time.Now()); err != nil {
 		return nil, err
 	}
 
-	task, err = s.taskRepo.GetLastInsertTask(ctx)
+	// Reload the task with the defaults and details set by the database
+	task, err = s.taskRepo.GetByID(ctx, created.ID)
 	if err != nil {
 		return nil, err
 	}
+	if task == nil {
+		return nil, ErrNotFound
+	}
 
-	// Publish task created event
-	go s.messageBroker.PublishTaskCreated(ctx, task.ID, userID, task.Title)
+	s.publishOffSite(ctx, task, site)
 	return task, nil
 }
 
+// createTask saves a checked task and announces it. An open task without a due
+// date gets the one of the strictest SLA policy of its tags, counted from
+// start. Tasks of technicians and of recurring definitions are both created
+// here.
+func (s *TaskService) createTask(ctx context.Context, task *models.Task, start time.Time) error {
+	// An explicit due date wins over the SLA policies of the tags
+	if task.DueAt != nil {
+		task.SetDueAt(task.DueAt, models.DefaultAtRiskWindow)
+	} else if task.Status == models.TaskStatusOpen && len(task.Tags) > 0 {
+		if err := s.applySLAPolicy(ctx, task, task.Tags, start); err != nil {
+			return err
+		}
+	}
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		return err
+	}
+
+	// Publish task created event
+	go s.messageBroker.PublishTaskCreated(ctx, task.ID, task.TechnicianID, task.Title, task.PerformedAt)
+	return nil
+}
+
 func (s *TaskService) GetTask(ctx context.Context, taskID int64, userID int64) (*models.Task, error) {
 	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
 	if err != nil {
@@ -95,15 +188,35 @@ func (s *TaskService) GetTask(ctx context.Context, taskID int64, userID int64) (
 	}
 
 	return &models.Task{
-		ID:           task.ID,
-		TechnicianID: task.TechnicianID,
-		Title:        task.Title,
-		Summary:      task.Summary,
-		PerformedAt:  task.PerformedAt,
+		ID:              task.ID,
+		TechnicianID:    task.TechnicianID,
+		Title:           task.Title,
+		Summary:         task.Summary,
+		SummaryHTML:     task.SummaryHTML,
+		PerformedAt:     task.PerformedAt,
+		Status:          task.Status,
+		Priority:        task.Priority,
+		Checklist:       task.Checklist,
+		Tags:            task.Tags,
+		Template:        task.Template,
+		RecurringTaskID: task.RecurringTaskID,
+		ScheduledFor:    task.ScheduledFor,
+		DueAt:           task.DueAt,
+		AtRiskAt:        task.AtRiskAt,
+		SLAPolicyID:     task.SLAPolicyID,
+		SLAStatus:       task.SLAStatus,
+		CompletedAt:     task.CompletedAt,
+		ClaimedBy:       task.ClaimedBy,
+		ClaimedAt:       task.ClaimedAt,
+		SiteID:          task.SiteID,
+		AssetID:         task.AssetID,
+		Location:        task.Location,
+		BlockedBy:       task.BlockedBy,
+		Version:         task.Version,
 	}, nil
 }
 
-func (s *TaskService) GetTasks(ctx context.Context, userID int64) ([]*models.Task, error) {
+func (s *TaskService) GetTasks(ctx context.Context, userID int64, filter models.TaskFilter) ([]*models.Task, error) {
 	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
 	if err != nil {
 		return nil, err
@@ -112,45 +225,398 @@ func (s *TaskService) GetTasks(ctx context.Context, userID int64) ([]*models.Tas
 		return nil, ErrNotFound
 	}
 
+	if err := filter.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	// Technicians can only see their own tasks
+	var technicianID int64
 	if user.IsTechnician() {
-		return s.taskRepo.GetByTechnicianID(ctx, userID)
+		technicianID = userID
+	}
+
+	var tasks []*models.Task
+	switch {
+	case len(filter.Tags) > 0:
+		tasks, err = s.taskRepo.GetByTags(ctx, technicianID, filter.Tags, filter.MatchAll)
+	case user.IsTechnician():
+		tasks, err = s.taskRepo.GetByTechnicianID(ctx, userID)
+	default:
+		tasks, err = s.taskRepo.GetAll(ctx)
+	}
+	if err != nil || filter.SLA == "" && filter.Geofence == "" {
+		return tasks, err
+	}
+
+	// The SLA status depends on the current time and the location is stored
+	// apart from the task, so both are filtered here
+	matching := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if filter.SLA != "" && task.SLAStatus != filter.SLA {
+			continue
+		}
+		if filter.Geofence != "" && !task.Location.MatchesGeofence(filter.Geofence) {
+			continue
+		}
+		matching = append(matching, task)
 	}
-	return s.taskRepo.GetAll(ctx)
+	return matching, nil
 }
 
 func (s *TaskService) UpdateTask(ctx context.Context, task *models.Task, userID int64) error {
-	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	existingTask, err := s.getEditableTask(ctx, task.ID, userID)
 	if err != nil {
 		return err
 	}
-	if user == nil {
-		return ErrNotFound
+
+	// Sanitize input
+	task.Sanitize()
+
+	// The status and priority are kept when the request has none
+	if task.Status == "" {
+		task.Status = existingTask.Status
+	}
+	if task.Priority == "" {
+		task.Priority = existingTask.Priority
+	}
+
+	// The due date is kept when the request has none; a new one keeps the
+	// task's at-risk window
+	if task.DueAt == nil {
+		task.DueAt, task.AtRiskAt = existingTask.DueAt, existingTask.AtRiskAt
+	} else {
+		task.SetDueAt(task.DueAt, existingTask.AtRiskWindow())
+	}
+
+	// The site and asset are kept when the request has neither; the asset is
+	// also kept when the request has the current site
+	switch {
+	case task.SiteID == nil && task.AssetID == nil:
+		task.SiteID, task.AssetID = existingTask.SiteID, existingTask.AssetID
+	case task.AssetID == nil && sameID(task.SiteID, existingTask.SiteID):
+		task.AssetID = existingTask.AssetID
+	}
+
+	// Validate input
+	if err := task.Validate(); err != nil {
+		return ErrInvalidInput
+	}
+	task.RenderSummary()
+
+	// Only the technician of the task records where it was performed; the
+	// location is kept when the request has none
+	if task.Location != nil {
+		if existingTask.TechnicianID != userID {
+			return ErrUnauthorized
+		}
+		if err := task.Location.Validate(); err != nil {
+			return ErrInvalidInput
+		}
+		task.Location.RecordedAt = time.Now()
+	}
+
+	// Tags are kept when the request has none
+	if task.Tags != nil {
+		if task.Tags, err = s.checkTags(ctx, task.Tags); err != nil {
+			return err
+		}
+	}
+	if err := s.checkLocation(ctx, task, existingTask); err != nil {
+		return err
+	}
+	site, err := s.checkGeofence(ctx, task, existingTask)
+	if err != nil {
+		return err
+	}
+
+	if err := s.saveTask(ctx, existingTask, task, userID); err != nil {
+		return err
+	}
+	s.publishOffSite(ctx, task, site)
+	if task.Tags == nil {
+		task.Tags = existingTask.Tags
+	}
+	if task.Location == nil {
+		task.Location = existingTask.Location
+	}
+	task.Checklist = existingTask.Checklist
+	task.Template = existingTask.Template
+	task.RecurringTaskID = existingTask.RecurringTaskID
+	task.ScheduledFor = existingTask.ScheduledFor
+	task.ClaimedBy = existingTask.ClaimedBy
+	task.ClaimedAt = existingTask.ClaimedAt
+	task.BlockedBy = existingTask.BlockedBy
+	return nil
+}
+
+// PatchTask applies a merge patch to the task; fields absent from the patch
+// keep their current value
+func (s *TaskService) PatchTask(ctx context.Context, taskID int64, patch *models.TaskPatch, version int, userID int64) (*models.Task, error) {
+	existingTask, err := s.getEditableTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	patch.Sanitize()
+
+	task := patch.Apply(existingTask)
+	task.Version = version
+	// Patches have no location; the current one is only checked again when
+	// the task moves to another site
+	task.Location = nil
+	// Likewise tags absent from the patch are left alone, so the repository
+	// does not rewrite them
+	if patch.Tags == nil {
+		task.Tags = nil
+	}
+
+	// Validate the resulting task
+	if err := task.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+	task.RenderSummary()
+
+	if patch.Tags != nil {
+		if task.Tags, err = s.checkTags(ctx, task.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkLocation(ctx, task, existingTask); err != nil {
+		return nil, err
+	}
+	site, err := s.checkGeofence(ctx, task, existingTask)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.saveTask(ctx, existingTask, task, userID); err != nil {
+		return nil, err
+	}
+	s.publishOffSite(ctx, task, site)
+	if task.Location == nil {
+		task.Location = existingTask.Location
 	}
+	if task.Tags == nil {
+		task.Tags = existingTask.Tags
+	}
+	return task, nil
+}
 
-	existingTask, err := s.taskRepo.GetByID(ctx, task.ID)
+// applySLAPolicy makes the task due after the resolution time of the
+// strictest SLA policy of its tags, counted from from. Tasks whose tags have
+// no policy get no due date.
+func (s *TaskService) applySLAPolicy(ctx context.Context, task *models.Task, tags []string, from time.Time) error {
+	policies, err := s.slaRepo.GetPoliciesByTags(ctx, tags)
 	if err != nil {
 		return err
 	}
+	if policy := models.StrictestSLAPolicy(policies); policy != nil {
+		task.ApplySLAPolicy(policy, from)
+	}
+	return nil
+}
+
+// checkTags normalizes tag names and makes sure they all belong to the
+// managed vocabulary
+func (s *TaskService) checkTags(ctx context.Context, names []string) ([]string, error) {
+	tags := models.NormalizeTags(names)
+	if err := models.ValidateTaskTags(tags); err != nil {
+		return nil, ErrInvalidInput
+	}
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	known, err := s.tagRepo.GetByNames(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(known) != len(tags) {
+		return nil, ErrUnknownTags
+	}
+	return tags, nil
+}
+
+// checkLocation makes sure the task's site and asset exist and the asset is
+// installed on the site; a task with an asset and no site gets the asset's
+// site. The links of existingTask are not checked again, so tasks keep their
+// links to sites and assets deleted since.
+func (s *TaskService) checkLocation(ctx context.Context, task *models.Task, existingTask *models.Task) error {
+	var keptSite, keptAsset bool
+	if existingTask != nil {
+		keptSite = sameID(task.SiteID, existingTask.SiteID)
+		keptAsset = sameID(task.AssetID, existingTask.AssetID)
+	}
+	if keptSite && keptAsset {
+		return nil
+	}
+
+	if task.AssetID != nil {
+		asset, err := s.assetRepo.GetByID(ctx, *task.AssetID)
+		if err != nil {
+			return err
+		}
+		if asset == nil {
+			return ErrUnknownAsset
+		}
+		if task.SiteID == nil {
+			task.SiteID = &asset.SiteID
+		} else if *task.SiteID != asset.SiteID {
+			return ErrAssetNotOnSite
+		}
+		// Sites with assets cannot be deleted, so the asset's site exists
+		return nil
+	}
+
+	if task.SiteID == nil || keptSite {
+		return nil
+	}
+	site, err := s.siteRepo.GetByID(ctx, *task.SiteID)
+	if err != nil {
+		return err
+	}
+	if site == nil {
+		return ErrUnknownSite
+	}
+	return nil
+}
+
+// checkGeofence checks a new location of the task against the geofence of
+// its site, and the current location again when the task moves to another
+// site. A manager's review is kept as long as the coordinates are. It returns
+// the site, nil when the task has none, and leaves task.Location nil when the
+// location does not change.
+func (s *TaskService) checkGeofence(ctx context.Context, task *models.Task, existingTask *models.Task) (*models.Site, error) {
+	if task.Location == nil {
+		if existingTask == nil || existingTask.Location == nil || sameID(task.SiteID, existingTask.SiteID) {
+			return nil, nil
+		}
+		location := *existingTask.Location
+		task.Location = &location
+	} else {
+		var previous *models.TaskLocation
+		if existingTask != nil {
+			previous = existingTask.Location
+		}
+		task.Location.KeepReview(previous)
+	}
+
+	// A deleted site no longer has a geofence to check against
+	var site *models.Site
+	if task.SiteID != nil {
+		var err error
+		if site, err = s.siteRepo.GetByID(ctx, *task.SiteID); err != nil {
+			return nil, err
+		}
+	}
+	task.Location.CheckGeofence(site)
+	return site, nil
+}
+
+// publishOffSite announces a task whose location was just found outside the
+// geofence of site and not reviewed yet
+func (s *TaskService) publishOffSite(ctx context.Context, task *models.Task, site *models.Site) {
+	if site == nil || !task.Location.Flagged() {
+		return
+	}
+	go s.messageBroker.PublishTaskOffSite(ctx, task.ID, task.TechnicianID, task.Title, site.ID, site.Name, *task.Location.Distance)
+}
+
+// sameID reports whether two optional references point to the same row
+func sameID(a, b *int64) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+// getEditableTask loads a task the user is allowed to modify
+func (s *TaskService) getEditableTask(ctx context.Context, taskID int64, userID int64) (*models.Task, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	existingTask, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
 	if existingTask == nil {
-		return ErrNotFound
+		return nil, ErrNotFound
 	}
 
 	// Only technicians can update their own tasks
 	if user.IsTechnician() {
 		if existingTask.TechnicianID != userID {
-			return ErrUnauthorized
+			return nil, ErrUnauthorized
 		}
 	}
 
-	// Sanitize input
-	task.Sanitize()
+	return existingTask, nil
+}
 
-	// Validate input
-	if err := task.Validate(); err != nil {
-		return err
+// saveTask writes a validated task over existingTask, enforcing the version
+// the client last read and that only tasks with their required checklist
+// items and prerequisites done are completed
+func (s *TaskService) saveTask(ctx context.Context, existingTask *models.Task, task *models.Task, userID int64) error {
+	// The client must have seen the current version; 0 means any version
+	if task.Version == 0 {
+		task.Version = existingTask.Version
+	}
+	if task.Version != existingTask.Version {
+		return ErrPreconditionFailed
+	}
+
+	completing := task.Status == models.TaskStatusCompleted && existingTask.Status != models.TaskStatusCompleted
+
+	task.TechnicianID = existingTask.TechnicianID
+	// The repository checks the checklist and the prerequisites in its
+	// transaction, so an item or a task reopened meanwhile cannot be missed
+	if err := s.taskRepo.Update(ctx, task, userID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrVersionConflict):
+			return ErrPreconditionFailed
+		case errors.Is(err, repository.ErrChecklistOpen):
+			return ErrChecklistOpen
+		case errors.Is(err, repository.ErrBlocked):
+			return ErrTaskBlocked
+		default:
+			return err
+		}
 	}
 
-	return s.taskRepo.Update(ctx, task)
+	// The repository keeps the first completion time until the task reopens
+	now := time.Now()
+	switch {
+	case task.Status != models.TaskStatusCompleted:
+		task.CompletedAt = nil
+	case existingTask.CompletedAt != nil:
+		task.CompletedAt = existingTask.CompletedAt
+	default:
+		task.CompletedAt = &now
+	}
+	task.SLAPolicyID = existingTask.SLAPolicyID
+	task.UpdateSLAStatus(now)
+
+	if completing {
+		s.publishUnblocked(ctx, task.ID)
+	}
+	return nil
+}
+
+// publishUnblocked announces the open tasks waiting only on the task, which
+// was just completed or deleted. The change is already saved, so a failed
+// lookup is logged rather than returned.
+func (s *TaskService) publishUnblocked(ctx context.Context, taskID int64) {
+	dependents, err := s.taskRepo.GetUnblockedDependents(ctx, taskID)
+	if err != nil {
+		log.Printf("Error getting the tasks unblocked by task %d: %v", taskID, err)
+		return
+	}
+	for _, dependent := range dependents {
+		go s.messageBroker.PublishTaskUnblocked(ctx, dependent.ID, dependent.TechnicianID, dependent.Title)
+	}
 }
 
 func (s *TaskService) DeleteTask(ctx context.Context, taskID int64, userID int64) error {
@@ -175,5 +641,98 @@ func (s *TaskService) DeleteTask(ctx context.Context, taskID int64, userID int64
 		return ErrNotFound
 	}
 
-	return s.taskRepo.Delete(ctx, taskID)
+	if err := s.taskRepo.Delete(ctx, taskID); err != nil {
+		return err
+	}
+
+	// Tasks in the trash no longer block the tasks depending on them
+	if task.Status != models.TaskStatusCompleted {
+		s.publishUnblocked(ctx, taskID)
+	}
+	return nil
+}
+
+func (s *TaskService) RestoreTask(ctx context.Context, taskID int64, userID int64) (*models.Task, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	// Only managers can restore tasks
+	if !user.IsManager() {
+		return nil, ErrUnauthorized
+	}
+
+	task, err := s.taskRepo.GetDeletedByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrNotFound
+	}
+
+	if err := s.taskRepo.Restore(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	task.DeletedAt = nil
+	return task, nil
+}
+
+func (s *TaskService) GetDeletedTasks(ctx context.Context, userID int64) ([]*models.Task, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	// Only managers can see the trash
+	if !user.IsManager() {
+		return nil, ErrUnauthorized
+	}
+
+	return s.taskRepo.GetDeleted(ctx)
+}
+
+// ReviewLocation records that a manager reviewed a task recorded outside the
+// geofence of its site, which takes it off the flagged tasks
+func (s *TaskService) ReviewLocation(ctx context.Context, taskID int64, userID int64) (*models.Task, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+	if !user.IsManager() {
+		return nil, ErrUnauthorized
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrNotFound
+	}
+	if !task.Location.Flagged() {
+		return nil, ErrLocationNotFlagged
+	}
+
+	if err := s.taskRepo.ReviewLocation(ctx, taskID, userID); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, ErrLocationNotFlagged
+		}
+		return nil, err
+	}
+	now := time.Now()
+	task.Location.ReviewedBy = &userID
+	task.Location.ReviewedAt = &now
+	task.Version++
+	return task, nil
 }
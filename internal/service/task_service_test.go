@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
 	"sword-challenge/pkg/messaging"
 
 	"github.com/stretchr/testify/assert"
@@ -34,29 +35,78 @@ func (m *MockTaskRepository) GetByTechnicianID(ctx context.Context, technicianID
 	return args.Get(0).([]*models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetLastInsertTask(ctx context.Context) (*models.Task, error) {
+func (m *MockTaskRepository) GetByAssetID(ctx context.Context, assetID int64, technicianID int64) ([]*models.Task, error) {
+	args := m.Called(ctx, assetID, technicianID)
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetAll(ctx context.Context) ([]*models.Task, error) {
 	args := m.Called(ctx)
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetByTags(ctx context.Context, technicianID int64, tags []string, matchAll bool) ([]*models.Task, error) {
+	args := m.Called(ctx, technicianID, tags, matchAll)
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) Update(ctx context.Context, task *models.Task, editorID int64) error {
+	args := m.Called(ctx, task, editorID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetDeletedByID(ctx context.Context, id int64) (*models.Task, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetAll(ctx context.Context) ([]*models.Task, error) {
+func (m *MockTaskRepository) GetDeleted(ctx context.Context) ([]*models.Task, error) {
 	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]*models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) Update(ctx context.Context, task *models.Task) error {
-	args := m.Called(ctx, task)
+func (m *MockTaskRepository) Restore(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) Delete(ctx context.Context, id int64) error {
+func (m *MockTaskRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, []string, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil, args.Error(2)
+	}
+	return args.Get(0).(int64), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockTaskRepository) GetUnblockedDependents(ctx context.Context, id int64) ([]*models.Task, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ReviewLocation(ctx context.Context, taskID int64, reviewerID int64) error {
+	args := m.Called(ctx, taskID, reviewerID)
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) PurgeLocations(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockUserRepository struct {
 	mock.Mock
 }
@@ -92,6 +142,51 @@ func (m *MockUserRepository) Delete(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
+// taskServiceDeps are the dependencies of a TaskService built for a test.
+// Those left nil get an empty mock, so a test only names what it sets up.
+type taskServiceDeps struct {
+	taskRepo  repository.TaskRepository
+	userRepo  repository.UserRepository
+	tagRepo   repository.TagRepository
+	slaRepo   repository.SLARepository
+	siteRepo  repository.SiteRepository
+	assetRepo repository.AssetRepository
+	broker    messaging.MessageBroker
+}
+
+func newTestTaskService(deps taskServiceDeps) *TaskService {
+	if deps.taskRepo == nil {
+		deps.taskRepo = new(MockTaskRepository)
+	}
+	if deps.userRepo == nil {
+		deps.userRepo = new(MockUserRepository)
+	}
+	if deps.tagRepo == nil {
+		deps.tagRepo = new(MockTagRepository)
+	}
+	if deps.slaRepo == nil {
+		deps.slaRepo = new(MockSLARepository)
+	}
+	if deps.siteRepo == nil {
+		deps.siteRepo = new(MockSiteRepository)
+	}
+	if deps.assetRepo == nil {
+		deps.assetRepo = new(MockAssetRepository)
+	}
+	if deps.broker == nil {
+		deps.broker = messaging.NewMockBroker()
+	}
+	return NewTaskService(deps.taskRepo, deps.userRepo, deps.tagRepo, deps.slaRepo, deps.siteRepo, deps.assetRepo, deps.broker)
+}
+
+// setCreatedID gives the task passed to a mocked Create the ID the database
+// would assign
+func setCreatedID(id int64) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		args.Get(1).(*models.Task).ID = id
+	}
+}
+
 func TestTaskService_CreateTask(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -106,7 +201,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 			task: &models.Task{
 				Title:       "Test task",
 				Summary:     "Test task summary",
-				PerformedAt: time.Now(),
+				PerformedAt: time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC),
 			},
 			userID: 1,
 			setupMocks: func(tr *MockTaskRepository, ur *MockUserRepository, mb *messaging.MockBroker) {
@@ -115,15 +210,26 @@ func TestTaskService_CreateTask(t *testing.T) {
 					Name: "Test Tech",
 					Role: models.RoleTechnician,
 				}, nil)
-				tr.On("Create", mock.Anything, mock.Anything).Return(nil)
+				tr.On("Create", mock.Anything, mock.Anything).Run(setCreatedID(7)).Return(nil)
+				tr.On("GetByID", mock.Anything, int64(7)).Return(&models.Task{
+					ID:           7,
+					TechnicianID: 1,
+					Title:        "Test task",
+					Summary:      "Test task summary",
+				}, nil)
 			},
 			expectedError: nil,
 			verifyMessages: func(t *testing.T, mb *messaging.MockBroker) {
+				// The event is published asynchronously
+				assert.Eventually(t, func() bool { return len(mb.GetMessages()) == 1 }, time.Second, 10*time.Millisecond)
 				messages := mb.GetMessages()
 				assert.Len(t, messages, 1)
-				assert.Equal(t, int64(0), messages[0].TaskID)
+				assert.Equal(t, int64(7), messages[0].TaskID)
 				assert.Equal(t, int64(1), messages[0].TechnicianID)
 				assert.Equal(t, "Test task", messages[0].Title)
+				// Notifications show when the task was performed, not when
+				// the message is handled
+				assert.Equal(t, time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC), messages[0].PerformedAt)
 			},
 		},
 		{
@@ -174,7 +280,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 
 			tt.setupMocks(mockTaskRepo, mockUserRepo, mockBroker)
 
-			service := NewTaskService(mockTaskRepo, mockUserRepo, mockBroker)
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: mockBroker})
 			_, err := service.CreateTask(context.Background(), tt.task, tt.userID)
 
 			assert.Equal(t, tt.expectedError, err)
@@ -279,7 +385,7 @@ func TestTaskService_GetTask(t *testing.T) {
 
 			tt.setupMocks(mockTaskRepo, mockUserRepo, mockBroker)
 
-			service := NewTaskService(mockTaskRepo, mockUserRepo, mockBroker)
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: mockBroker})
 			task, err := service.GetTask(context.Background(), tt.taskID, tt.userID)
 
 			assert.Equal(t, tt.expectedError, err)
@@ -296,3 +402,765 @@ func TestTaskService_GetTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_RestoreTask(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 21, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		setupMocks    func(*MockTaskRepository, *MockUserRepository)
+		expectedTask  *models.Task
+		expectedError error
+	}{
+		{
+			name: "successful restore by manager",
+			setupMocks: func(tr *MockTaskRepository, ur *MockUserRepository) {
+				ur.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleManager}, nil)
+				tr.On("GetDeletedByID", mock.Anything, int64(5)).Return(&models.Task{ID: 5, TechnicianID: 2, Title: "Test task", DeletedAt: &deletedAt}, nil)
+				tr.On("Restore", mock.Anything, int64(5)).Return(nil)
+			},
+			expectedTask: &models.Task{ID: 5, TechnicianID: 2, Title: "Test task"},
+		},
+		{
+			name: "task not in trash",
+			setupMocks: func(tr *MockTaskRepository, ur *MockUserRepository) {
+				ur.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleManager}, nil)
+				tr.On("GetDeletedByID", mock.Anything, int64(5)).Return(nil, nil)
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name: "unauthorized - technician cannot restore",
+			setupMocks: func(tr *MockTaskRepository, ur *MockUserRepository) {
+				ur.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			tt.setupMocks(mockTaskRepo, mockUserRepo)
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			task, err := service.RestoreTask(context.Background(), 5, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedTask, task)
+			mockTaskRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_GetDeletedTasks(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 21, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		role          models.UserRole
+		setupMocks    func(*MockTaskRepository)
+		expectedTasks []*models.Task
+		expectedError error
+	}{
+		{
+			name: "manager lists the trash",
+			role: models.RoleManager,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("GetDeleted", mock.Anything).Return([]*models.Task{{ID: 5, DeletedAt: &deletedAt}}, nil)
+			},
+			expectedTasks: []*models.Task{{ID: 5, DeletedAt: &deletedAt}},
+		},
+		{
+			name:          "unauthorized - technician cannot list the trash",
+			role:          models.RoleTechnician,
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: tt.role}, nil)
+			tt.setupMocks(mockTaskRepo)
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			tasks, err := service.GetDeletedTasks(context.Background(), 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedTasks, tasks)
+			mockTaskRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_UpdateTask(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	existing := &models.Task{ID: 1, TechnicianID: 1, Title: "Test task", Summary: "Test task summary", PerformedAt: performedAt, Status: models.TaskStatusCompleted, Priority: models.TaskPriorityNormal, Version: 3}
+
+	tests := []struct {
+		name          string
+		version       int
+		setupMocks    func(*MockTaskRepository)
+		expectedError error
+	}{
+		{
+			name:    "successful update with current version",
+			version: 3,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool { return task.Version == 3 }), int64(1)).Return(nil)
+			},
+		},
+		{
+			name:    "wildcard version updates the current version",
+			version: 0,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool { return task.Version == 3 }), int64(1)).Return(nil)
+			},
+		},
+		{
+			name:          "stale version is rejected",
+			version:       2,
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrPreconditionFailed,
+		},
+		{
+			name:    "concurrent update between read and write",
+			version: 3,
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("Update", mock.Anything, mock.Anything, int64(1)).Return(repository.ErrVersionConflict)
+			},
+			expectedError: ErrPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil)
+			tt.setupMocks(mockTaskRepo)
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			err := service.UpdateTask(context.Background(), &models.Task{
+				ID:          1,
+				Title:       "Updated task",
+				Summary:     "Updated summary",
+				PerformedAt: performedAt,
+				Version:     tt.version,
+			}, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTaskRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_PatchTask(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	summary := "Replaced filters"
+	emptyTitle := ""
+
+	tests := []struct {
+		name          string
+		patch         *models.TaskPatch
+		version       int
+		userID        int64
+		setupMocks    func(*MockTaskRepository)
+		expectedTask  *models.Task
+		expectedError error
+	}{
+		{
+			name:    "patch keeps omitted fields",
+			patch:   &models.TaskPatch{Summary: &summary},
+			version: 3,
+			userID:  1,
+			setupMocks: func(tr *MockTaskRepository) {
+				// Tags absent from the patch are not rewritten
+				tr.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool { return task.Tags == nil }), int64(1)).Return(nil)
+			},
+			expectedTask: &models.Task{ID: 1, TechnicianID: 1, Title: "Test task", Summary: "Replaced filters", SummaryHTML: "<p>Replaced filters</p>", PerformedAt: performedAt, Status: models.TaskStatusCompleted, Priority: models.TaskPriorityNormal, Tags: []string{"hvac"}, CompletedAt: &performedAt, Version: 3},
+		},
+		{
+			name:          "patched task must still be valid",
+			patch:         &models.TaskPatch{Title: &emptyTitle},
+			version:       3,
+			userID:        1,
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "stale version is rejected",
+			patch:         &models.TaskPatch{Summary: &summary},
+			version:       2,
+			userID:        1,
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrPreconditionFailed,
+		},
+		{
+			name:          "technician cannot patch another technician's task",
+			patch:         &models.TaskPatch{Summary: &summary},
+			version:       3,
+			userID:        2,
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+
+			existing := &models.Task{ID: 1, TechnicianID: 1, Title: "Test task", Summary: "Test task summary", PerformedAt: performedAt, Status: models.TaskStatusCompleted, Priority: models.TaskPriorityNormal, Tags: []string{"hvac"}, CompletedAt: &performedAt, Version: 3}
+			mockUserRepo.On("GetByID", mock.Anything, tt.userID).Return(&models.User{ID: tt.userID, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil)
+			tt.setupMocks(mockTaskRepo)
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			task, err := service.PatchTask(context.Background(), 1, tt.patch, tt.version, tt.userID)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedTask, task)
+			mockTaskRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_PatchTaskLocation(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	title := "Chiller inspection"
+	siteID, assetID := int64(1), int64(2)
+	otherSiteID, otherAssetID, unknownID := int64(4), int64(3), int64(9)
+
+	tests := []struct {
+		name          string
+		patch         *models.TaskPatch
+		expectedSite  int64
+		expectedError error
+	}{
+		{
+			// Asset 2 was deleted since; the task keeps it without a lookup
+			name:         "kept links are not checked",
+			patch:        &models.TaskPatch{Title: &title},
+			expectedSite: siteID,
+		},
+		{
+			name:         "new asset moves the task to its site",
+			patch:        &models.TaskPatch{AssetID: &otherAssetID},
+			expectedSite: otherSiteID,
+		},
+		{
+			name:          "asset on another site",
+			patch:         &models.TaskPatch{SiteID: &siteID, AssetID: &otherAssetID},
+			expectedError: ErrAssetNotOnSite,
+		},
+		{
+			name:          "unknown asset",
+			patch:         &models.TaskPatch{AssetID: &unknownID},
+			expectedError: ErrUnknownAsset,
+		},
+		{
+			name:          "unknown site",
+			patch:         &models.TaskPatch{SiteID: &unknownID, ClearAsset: true},
+			expectedError: ErrUnknownSite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockSiteRepo := new(MockSiteRepository)
+			mockAssetRepo := new(MockAssetRepository)
+
+			existing := &models.Task{ID: 1, TechnicianID: 1, Title: "Test task", Summary: "Test task summary", PerformedAt: performedAt, Status: models.TaskStatusCompleted, Priority: models.TaskPriorityNormal, SiteID: &siteID, AssetID: &assetID, Version: 3}
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil)
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, int64(1)).Return(nil).Maybe()
+			mockAssetRepo.On("GetByID", mock.Anything, otherAssetID).Return(&models.Asset{ID: otherAssetID, SiteID: otherSiteID}, nil).Maybe()
+			mockAssetRepo.On("GetByID", mock.Anything, unknownID).Return(nil, nil).Maybe()
+			mockSiteRepo.On("GetByID", mock.Anything, unknownID).Return(nil, nil).Maybe()
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, siteRepo: mockSiteRepo, assetRepo: mockAssetRepo})
+			task, err := service.PatchTask(context.Background(), 1, tt.patch, 3, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.expectedSite, *task.SiteID)
+			}
+			mockAssetRepo.AssertNotCalled(t, "GetByID", mock.Anything, assetID)
+		})
+	}
+}
+
+func TestTaskService_CompleteTask(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		expectUpdate  bool
+		updateErr     error
+		expectedError error
+	}{
+		{
+			name:         "all required items done",
+			expectUpdate: true,
+		},
+		{
+			name:          "required items still open",
+			expectUpdate:  true,
+			updateErr:     repository.ErrChecklistOpen,
+			expectedError: ErrChecklistOpen,
+		},
+		{
+			name:          "prerequisites not completed",
+			expectUpdate:  true,
+			updateErr:     repository.ErrBlocked,
+			expectedError: ErrTaskBlocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockBroker := messaging.NewMockBroker()
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{
+				ID: 1, TechnicianID: 1, Title: "Test task", Summary: "Test task summary", PerformedAt: performedAt,
+				Status: models.TaskStatusOpen, Priority: models.TaskPriorityNormal, Version: 3,
+			}, nil)
+			if tt.expectUpdate {
+				mockTaskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Status == models.TaskStatusCompleted
+				}), int64(1)).Return(tt.updateErr)
+			}
+			if tt.expectedError == nil {
+				mockTaskRepo.On("GetUnblockedDependents", mock.Anything, int64(1)).Return([]*models.Task{
+					{ID: 2, TechnicianID: 3, Title: "Configure network", Status: models.TaskStatusOpen},
+				}, nil)
+			}
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, broker: mockBroker})
+			err := service.UpdateTask(context.Background(), &models.Task{
+				ID:          1,
+				Title:       "Test task",
+				Summary:     "Test task summary",
+				PerformedAt: performedAt,
+				Status:      models.TaskStatusCompleted,
+			}, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTaskRepo.AssertExpectations(t)
+			if tt.expectedError == nil {
+				assert.Eventually(t, func() bool { return len(mockBroker.GetUnblockedMessages()) == 1 }, time.Second, 10*time.Millisecond)
+				assert.Equal(t, messaging.TaskUnblockedMessage{TaskID: 2, TechnicianID: 3, Title: "Configure network"}, mockBroker.GetUnblockedMessages()[0])
+			} else {
+				assert.Empty(t, mockBroker.GetUnblockedMessages())
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTaskTags(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		tags          []string
+		setupMocks    func(*MockTaskRepository, *MockTagRepository)
+		expectedError error
+	}{
+		{
+			name: "tags are normalized and stored",
+			tags: []string{"Preventive", "hvac", " HVAC "},
+			setupMocks: func(tr *MockTaskRepository, gr *MockTagRepository) {
+				gr.On("GetByNames", mock.Anything, []string{"hvac", "preventive"}).Return([]*models.Tag{{ID: 1, Name: "hvac"}, {ID: 5, Name: "preventive"}}, nil)
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return assert.ObjectsAreEqual([]string{"hvac", "preventive"}, task.Tags)
+				})).Run(setCreatedID(1)).Return(nil)
+				tr.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 1, Tags: []string{"hvac", "preventive"}}, nil)
+			},
+		},
+		{
+			name: "tags outside the vocabulary are rejected",
+			tags: []string{"hvac", "gardening"},
+			setupMocks: func(tr *MockTaskRepository, gr *MockTagRepository) {
+				gr.On("GetByNames", mock.Anything, []string{"gardening", "hvac"}).Return([]*models.Tag{{ID: 1, Name: "hvac"}}, nil)
+			},
+			expectedError: ErrUnknownTags,
+		},
+		{
+			name:          "malformed tags are invalid input",
+			tags:          []string{"air conditioning"},
+			setupMocks:    func(tr *MockTaskRepository, gr *MockTagRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTagRepo := new(MockTagRepository)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			tt.setupMocks(mockTaskRepo, mockTagRepo)
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, tagRepo: mockTagRepo})
+			_, err := service.CreateTask(context.Background(), &models.Task{
+				Title:       "Test task",
+				Summary:     "Test task summary",
+				PerformedAt: performedAt,
+				Tags:        tt.tags,
+			}, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTaskRepo.AssertExpectations(t)
+			mockTagRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_GetTasksByTags(t *testing.T) {
+	tagged := []*models.Task{{ID: 1, TechnicianID: 2, Tags: []string{"hvac", "preventive"}}}
+
+	tests := []struct {
+		name                 string
+		user                 *models.User
+		filter               models.TaskFilter
+		expectedTechnicianID int64
+		expectedTags         []string
+	}{
+		{
+			name:                 "manager filters every task by any tag",
+			user:                 &models.User{ID: 1, Role: models.RoleManager},
+			filter:               models.TaskFilter{Tags: []string{"Preventive", "hvac"}},
+			expectedTechnicianID: 0,
+			expectedTags:         []string{"hvac", "preventive"},
+		},
+		{
+			name:                 "technician filters only their tasks",
+			user:                 &models.User{ID: 2, Role: models.RoleTechnician},
+			filter:               models.TaskFilter{Tags: []string{"hvac"}, MatchAll: true},
+			expectedTechnicianID: 2,
+			expectedTags:         []string{"hvac"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByTags", mock.Anything, tt.expectedTechnicianID, tt.expectedTags, tt.filter.MatchAll).Return(tagged, nil)
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			tasks, err := service.GetTasks(context.Background(), tt.user.ID, tt.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tagged, tasks)
+			mockTaskRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_CreateTaskSLA(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	dueAt := time.Date(2024, 3, 22, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		task       *models.Task
+		setupMocks func(*MockTaskRepository, *MockSLARepository)
+	}{
+		{
+			name: "open task follows the strictest policy of its tags",
+			task: &models.Task{Status: models.TaskStatusOpen, Tags: []string{"corrective", "hvac"}},
+			setupMocks: func(tr *MockTaskRepository, sr *MockSLARepository) {
+				sr.On("GetPoliciesByTags", mock.Anything, []string{"corrective", "hvac"}).Return([]*models.SLAPolicy{
+					{ID: 1, ResolutionMinutes: 1440, AtRiskMinutes: 240},
+					{ID: 2, ResolutionMinutes: 480, AtRiskMinutes: 60},
+				}, nil)
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return *task.SLAPolicyID == 2 &&
+						task.DueAt.Sub(*task.AtRiskAt) == time.Hour &&
+						time.Until(*task.DueAt) > 7*time.Hour
+				})).Run(setCreatedID(1)).Return(nil)
+			},
+		},
+		{
+			name: "explicit due date wins over the policies",
+			task: &models.Task{Status: models.TaskStatusOpen, Tags: []string{"corrective"}, DueAt: &dueAt},
+			setupMocks: func(tr *MockTaskRepository, sr *MockSLARepository) {
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.SLAPolicyID == nil &&
+						task.DueAt.Equal(dueAt) &&
+						task.AtRiskAt.Equal(dueAt.Add(-models.DefaultAtRiskWindow))
+				})).Run(setCreatedID(1)).Return(nil)
+			},
+		},
+		{
+			name: "completed task gets no due date",
+			task: &models.Task{Tags: []string{"corrective"}},
+			setupMocks: func(tr *MockTaskRepository, sr *MockSLARepository) {
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.DueAt == nil && task.SLAPolicyID == nil
+				})).Run(setCreatedID(1)).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTagRepo := new(MockTagRepository)
+			mockSLARepo := new(MockSLARepository)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			var tags []*models.Tag
+			for i, name := range tt.task.Tags {
+				tags = append(tags, &models.Tag{ID: int64(i + 1), Name: name})
+			}
+			mockTagRepo.On("GetByNames", mock.Anything, tt.task.Tags).Return(tags, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: 1}, nil)
+			tt.setupMocks(mockTaskRepo, mockSLARepo)
+
+			tt.task.Title = "Test task"
+			tt.task.Summary = "Test task summary"
+			tt.task.PerformedAt = performedAt
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, tagRepo: mockTagRepo, slaRepo: mockSLARepo})
+			_, err := service.CreateTask(context.Background(), tt.task, 1)
+
+			assert.NoError(t, err)
+			mockTaskRepo.AssertExpectations(t)
+			mockSLARepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskService_CreateTaskGeofence(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	siteID := int64(1)
+	siteLat, siteLng := 38.7223, -9.1393
+	// About 111 and 556 meters north of the site
+	nearLat, farLat := 38.7233, 38.7273
+
+	tests := []struct {
+		name             string
+		siteID           *int64
+		latitude         float64
+		expectedGeofence string
+		expectOffSite    bool
+	}{
+		{name: "inside the geofence", siteID: &siteID, latitude: nearLat, expectedGeofence: models.GeofenceInside},
+		{name: "outside the geofence", siteID: &siteID, latitude: farLat, expectedGeofence: models.GeofenceOutside, expectOffSite: true},
+		{name: "task without a site", latitude: farLat, expectedGeofence: models.GeofenceUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockSiteRepo := new(MockSiteRepository)
+			mockBroker := messaging.NewMockBroker()
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			mockSiteRepo.On("GetByID", mock.Anything, siteID).Return(&models.Site{
+				ID: siteID, Name: "Lisbon headquarters", Latitude: &siteLat, Longitude: &siteLng, GeofenceRadius: models.DefaultGeofenceRadius,
+			}, nil).Maybe()
+			// The repository stores the location checked by the service
+			inserted := &models.Task{ID: 1, TechnicianID: 1, Title: "Test task", SiteID: tt.siteID}
+			mockTaskRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+				return task.Location != nil && !task.Location.RecordedAt.IsZero()
+			})).Run(func(args mock.Arguments) {
+				task := args.Get(1).(*models.Task)
+				task.ID = inserted.ID
+				inserted.Location = task.Location
+			}).Return(nil)
+			mockTaskRepo.On("GetByID", mock.Anything, inserted.ID).Return(inserted, nil)
+
+			latitude, longitude := tt.latitude, siteLng
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, siteRepo: mockSiteRepo, broker: mockBroker})
+			task, err := service.CreateTask(context.Background(), &models.Task{
+				Title:       "Test task",
+				Summary:     "Test task summary",
+				PerformedAt: performedAt,
+				SiteID:      tt.siteID,
+				Location:    &models.TaskLocation{Latitude: &latitude, Longitude: &longitude},
+			}, 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedGeofence, task.Location.Geofence)
+			if tt.expectOffSite {
+				assert.Eventually(t, func() bool { return len(mockBroker.GetOffSiteMessages()) == 1 }, time.Second, 10*time.Millisecond)
+				message := mockBroker.GetOffSiteMessages()[0]
+				assert.Equal(t, siteID, message.SiteID)
+				assert.InDelta(t, 556, message.Distance, 5)
+			} else {
+				assert.Never(t, func() bool { return len(mockBroker.GetOffSiteMessages()) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestTaskService_UpdateTaskGeofenceReview(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	siteID, otherSiteID := int64(1), int64(2)
+	siteLat, siteLng := 38.7223, -9.1393
+	// About 556 and 667 meters north of the first site
+	farLat, fartherLat := 38.7273, 38.7283
+	manager, reviewedAt := int64(5), time.Date(2024, 3, 21, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		siteID        int64
+		latitude      *float64
+		expectReview  bool
+		expectOffSite bool
+	}{
+		{name: "moved to another site", siteID: otherSiteID, expectReview: true},
+		{name: "same coordinates sent again", siteID: siteID, latitude: &farLat, expectReview: true},
+		{name: "new coordinates", siteID: siteID, latitude: &fartherLat, expectOffSite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockSiteRepo := new(MockSiteRepository)
+			mockBroker := messaging.NewMockBroker()
+
+			latitude, longitude, distance := farLat, siteLng, 556
+			existing := &models.Task{
+				ID: 1, TechnicianID: 1, Title: "Test task", Summary: "Test task summary", PerformedAt: performedAt,
+				Status: models.TaskStatusCompleted, Priority: models.TaskPriorityNormal, SiteID: &siteID, Version: 3,
+				Location: &models.TaskLocation{
+					Latitude: &latitude, Longitude: &longitude, Geofence: models.GeofenceOutside, Distance: &distance,
+					ReviewedBy: &manager, ReviewedAt: &reviewedAt,
+				},
+			}
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: models.RoleTechnician}, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(existing, nil)
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, int64(1)).Return(nil)
+			for _, id := range []int64{siteID, otherSiteID} {
+				mockSiteRepo.On("GetByID", mock.Anything, id).Return(&models.Site{
+					ID: id, Name: "Lisbon headquarters", Latitude: &siteLat, Longitude: &siteLng, GeofenceRadius: models.DefaultGeofenceRadius,
+				}, nil).Maybe()
+			}
+
+			update := &models.Task{
+				ID: 1, Title: "Test task", Summary: "Test task summary", PerformedAt: performedAt,
+				Status: models.TaskStatusCompleted, SiteID: &tt.siteID,
+			}
+			if tt.latitude != nil {
+				// A client cannot review its own location
+				update.Location = &models.TaskLocation{Latitude: tt.latitude, Longitude: &longitude, ReviewedBy: &existing.TechnicianID}
+			}
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo, siteRepo: mockSiteRepo, broker: mockBroker})
+			err := service.UpdateTask(context.Background(), update, 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.GeofenceOutside, update.Location.Geofence)
+			if tt.expectReview {
+				assert.Equal(t, &manager, update.Location.ReviewedBy)
+				assert.Equal(t, &reviewedAt, update.Location.ReviewedAt)
+			} else {
+				assert.Nil(t, update.Location.ReviewedBy)
+				assert.Nil(t, update.Location.ReviewedAt)
+			}
+			if tt.expectOffSite {
+				assert.Eventually(t, func() bool { return len(mockBroker.GetOffSiteMessages()) == 1 }, time.Second, 10*time.Millisecond)
+			} else {
+				assert.Never(t, func() bool { return len(mockBroker.GetOffSiteMessages()) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestTaskService_ReviewLocation(t *testing.T) {
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+	distance := 556
+	reviewedAt := time.Date(2024, 3, 21, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		location      *models.TaskLocation
+		reviewErr     error
+		expectReview  bool
+		expectedError error
+	}{
+		{
+			name:         "flagged location",
+			user:         manager,
+			location:     &models.TaskLocation{Geofence: models.GeofenceOutside, Distance: &distance},
+			expectReview: true,
+		},
+		{
+			name:          "already reviewed",
+			user:          manager,
+			location:      &models.TaskLocation{Geofence: models.GeofenceOutside, Distance: &distance, ReviewedBy: &manager.ID, ReviewedAt: &reviewedAt},
+			expectedError: ErrLocationNotFlagged,
+		},
+		{
+			name:          "inside the geofence",
+			user:          manager,
+			location:      &models.TaskLocation{Geofence: models.GeofenceInside},
+			expectedError: ErrLocationNotFlagged,
+		},
+		{
+			name:          "task without a location",
+			user:          manager,
+			expectedError: ErrLocationNotFlagged,
+		},
+		{
+			name:          "reviewed concurrently",
+			user:          manager,
+			location:      &models.TaskLocation{Geofence: models.GeofenceOutside, Distance: &distance},
+			reviewErr:     repository.ErrVersionConflict,
+			expectReview:  true,
+			expectedError: ErrLocationNotFlagged,
+		},
+		{
+			name:          "technicians do not review",
+			user:          technician,
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: technician.ID, Location: tt.location, Version: 3}, nil).Maybe()
+			if tt.expectReview {
+				mockTaskRepo.On("ReviewLocation", mock.Anything, int64(1), manager.ID).Return(tt.reviewErr)
+			}
+
+			service := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			task, err := service.ReviewLocation(context.Background(), 1, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, manager.ID, *task.Location.ReviewedBy)
+				assert.False(t, task.Location.Flagged())
+				assert.Equal(t, 4, task.Version)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+// TaskTemplateService manages the task templates. Everyone can read them and
+// technicians create tasks from them; only managers can change them.
+type TaskTemplateService struct {
+	taskService  *TaskService
+	userRepo     repository.UserRepository
+	templateRepo repository.TaskTemplateRepository
+}
+
+func NewTaskTemplateService(
+	taskService *TaskService,
+	userRepo repository.UserRepository,
+	templateRepo repository.TaskTemplateRepository,
+) *TaskTemplateService {
+	return &TaskTemplateService{
+		taskService:  taskService,
+		userRepo:     userRepo,
+		templateRepo: templateRepo,
+	}
+}
+
+func (s *TaskTemplateService) GetTemplates(ctx context.Context, userID int64) ([]*models.TaskTemplate, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.templateRepo.GetAll(ctx)
+}
+
+// GetTemplate returns the current revision of a template
+func (s *TaskTemplateService) GetTemplate(ctx context.Context, templateID int64, userID int64) (*models.TaskTemplate, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return s.getTemplate(ctx, templateID)
+}
+
+// GetTemplateRevision returns a past or current revision, e.g. the one a task
+// was created from; revisions of deleted templates are still available
+func (s *TaskTemplateService) GetTemplateRevision(ctx context.Context, templateID int64, revision int, userID int64) (*models.TaskTemplate, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	template, err := s.templateRepo.GetRevision(ctx, templateID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrNotFound
+	}
+	return template, nil
+}
+
+func (s *TaskTemplateService) CreateTemplate(ctx context.Context, template *models.TaskTemplate, userID int64) (*models.TaskTemplate, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	template.EditorID = userID
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.getTemplate(ctx, template.ID)
+}
+
+// UpdateTemplate saves the template as a new revision. Tasks created earlier
+// keep pointing at the revision they were created from.
+func (s *TaskTemplateService) UpdateTemplate(ctx context.Context, template *models.TaskTemplate, userID int64) (*models.TaskTemplate, error) {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getTemplate(ctx, template.ID); err != nil {
+		return nil, err
+	}
+	if err := s.checkTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	template.EditorID = userID
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.getTemplate(ctx, template.ID)
+}
+
+// DeleteTemplate removes a template from the list; its revisions are kept
+func (s *TaskTemplateService) DeleteTemplate(ctx context.Context, templateID int64, userID int64) error {
+	if err := requireManager(ctx, s.userRepo, userID); err != nil {
+		return err
+	}
+	if _, err := s.getTemplate(ctx, templateID); err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(ctx, templateID)
+}
+
+// CreateTask creates a task from the current revision of a template. Fields
+// set in fields override the template; the title is rendered from the title
+// pattern otherwise. The task starts open unless fields.Status is set.
+func (s *TaskTemplateService) CreateTask(ctx context.Context, templateID int64, fields *models.TaskFromTemplate, userID int64) (*models.Task, error) {
+	if _, err := getUser(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &models.Task{
+		Title:          fields.Title,
+		Summary:        fields.Summary,
+		PerformedAt:    fields.PerformedAt,
+		Status:         fields.Status,
+		Priority:       fields.Priority,
+		Tags:           fields.Tags,
+		Template:       &models.TaskTemplateRef{ID: template.ID, Revision: template.Revision},
+		ChecklistItems: template.ChecklistItems(),
+	}
+	if task.Title == "" {
+		if task.Title, err = template.RenderTitle(fields.Variables, fields.PerformedAt); err != nil {
+			return nil, ErrInvalidInput
+		}
+	}
+	if task.Summary == "" {
+		task.Summary = template.Summary
+	}
+	if task.Status == "" {
+		task.Status = models.TaskStatusOpen
+	}
+	if task.Tags == nil {
+		task.Tags = template.Tags
+	}
+
+	return s.taskService.CreateTask(ctx, task, userID)
+}
+
+// checkTemplate sanitizes and validates a template and makes sure its tags
+// belong to the managed vocabulary
+func (s *TaskTemplateService) checkTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	// Sanitize input
+	template.Sanitize()
+
+	// Validate input
+	if err := template.Validate(); err != nil {
+		return ErrInvalidInput
+	}
+	tags, err := s.taskService.checkTags(ctx, template.Tags)
+	if err != nil {
+		return err
+	}
+	template.Tags = tags
+	return nil
+}
+
+func (s *TaskTemplateService) getTemplate(ctx context.Context, templateID int64) (*models.TaskTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrNotFound
+	}
+	return template, nil
+}
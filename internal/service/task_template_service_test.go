@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) GetByID(ctx context.Context, id int64) (*models.TaskTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) GetAll(ctx context.Context) ([]*models.TaskTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) GetRevision(ctx context.Context, id int64, revision int) (*models.TaskTemplate, error) {
+	args := m.Called(ctx, id, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestTaskTemplateService_CreateTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          models.UserRole
+		template      *models.TaskTemplate
+		setupMocks    func(*MockTaskTemplateRepository, *MockTagRepository)
+		expectedError error
+	}{
+		{
+			name:     "manager creates a template with known tags",
+			role:     models.RoleManager,
+			template: &models.TaskTemplate{Name: "Filter replacement", TitlePattern: "Replace filters - {{site}}", Tags: []string{"HVAC"}},
+			setupMocks: func(tr *MockTaskTemplateRepository, gr *MockTagRepository) {
+				gr.On("GetByNames", mock.Anything, []string{"hvac"}).Return([]*models.Tag{{ID: 1, Name: "hvac"}}, nil)
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(template *models.TaskTemplate) bool {
+					return template.EditorID == 1 && template.Tags[0] == "hvac"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TaskTemplate).ID = 5
+				}).Return(nil)
+				tr.On("GetByID", mock.Anything, int64(5)).Return(&models.TaskTemplate{ID: 5, Revision: 1}, nil)
+			},
+		},
+		{
+			name:     "unknown tags are rejected",
+			role:     models.RoleManager,
+			template: &models.TaskTemplate{Name: "Filter replacement", TitlePattern: "Replace filters", Tags: []string{"roofing"}},
+			setupMocks: func(tr *MockTaskTemplateRepository, gr *MockTagRepository) {
+				gr.On("GetByNames", mock.Anything, []string{"roofing"}).Return([]*models.Tag{}, nil)
+			},
+			expectedError: ErrUnknownTags,
+		},
+		{
+			name:          "invalid template",
+			role:          models.RoleManager,
+			template:      &models.TaskTemplate{Name: "Filter replacement"},
+			setupMocks:    func(tr *MockTaskTemplateRepository, gr *MockTagRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "technicians cannot create templates",
+			role:          models.RoleTechnician,
+			template:      &models.TaskTemplate{Name: "Filter replacement", TitlePattern: "Replace filters"},
+			setupMocks:    func(tr *MockTaskTemplateRepository, gr *MockTagRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockTemplateRepo := new(MockTaskTemplateRepository)
+			mockTagRepo := new(MockTagRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.User{ID: 1, Role: tt.role}, nil)
+			tt.setupMocks(mockTemplateRepo, mockTagRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{userRepo: mockUserRepo, tagRepo: mockTagRepo})
+			service := NewTaskTemplateService(taskService, mockUserRepo, mockTemplateRepo)
+			_, err := service.CreateTemplate(context.Background(), tt.template, 1)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTemplateRepo.AssertExpectations(t)
+			mockTagRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskTemplateService_CreateTask(t *testing.T) {
+	performedAt := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	template := &models.TaskTemplate{
+		ID:           5,
+		Revision:     3,
+		Name:         "Filter replacement",
+		TitlePattern: "Replace filters - {{site}}",
+		Summary:      "Replaced the air filters.",
+		Checklist: []models.TaskTemplateChecklistItem{
+			{Text: "Switch off the unit", Required: true},
+			{Text: "Check airflow"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		fields        *models.TaskFromTemplate
+		setupMocks    func(*MockTaskRepository)
+		expectedError error
+	}{
+		{
+			name:   "task gets the rendered title, checklist and template revision",
+			fields: &models.TaskFromTemplate{PerformedAt: performedAt, Variables: map[string]string{"site": "Lisbon office"}},
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Title == "Replace filters - Lisbon office" &&
+						task.Summary == "Replaced the air filters." &&
+						task.Status == models.TaskStatusOpen &&
+						*task.Template == models.TaskTemplateRef{ID: 5, Revision: 3} &&
+						len(task.ChecklistItems) == 2 && task.ChecklistItems[0].Required
+				})).Run(setCreatedID(10)).Return(nil)
+				tr.On("GetByID", mock.Anything, int64(10)).Return(&models.Task{ID: 10}, nil)
+			},
+		},
+		{
+			name:   "fields override the template",
+			fields: &models.TaskFromTemplate{PerformedAt: performedAt, Title: "Custom title", Summary: "Custom summary"},
+			setupMocks: func(tr *MockTaskRepository) {
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Title == "Custom title" && task.Summary == "Custom summary"
+				})).Run(setCreatedID(10)).Return(nil)
+				tr.On("GetByID", mock.Anything, int64(10)).Return(&models.Task{ID: 10}, nil)
+			},
+		},
+		{
+			name:          "missing title variable",
+			fields:        &models.TaskFromTemplate{PerformedAt: performedAt},
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:          "task cannot be completed with required items open",
+			fields:        &models.TaskFromTemplate{PerformedAt: performedAt, Title: "Custom title", Status: models.TaskStatusCompleted},
+			setupMocks:    func(tr *MockTaskRepository) {},
+			expectedError: ErrChecklistOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTemplateRepo := new(MockTaskTemplateRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, int64(2)).Return(&models.User{ID: 2, Role: models.RoleTechnician}, nil)
+			mockTemplateRepo.On("GetByID", mock.Anything, int64(5)).Return(template, nil)
+			tt.setupMocks(mockTaskRepo)
+
+			taskService := newTestTaskService(taskServiceDeps{taskRepo: mockTaskRepo, userRepo: mockUserRepo})
+			service := NewTaskTemplateService(taskService, mockUserRepo, mockTemplateRepo)
+			_, err := service.CreateTask(context.Background(), 5, tt.fields, 2)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
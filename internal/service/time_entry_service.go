@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"time"
+)
+
+var ErrTimeEntryOverlap = errors.New("the time entry overlaps another entry of the technician")
+
+// TimeEntryService tracks the time technicians spend on their tasks, either
+// with a start/stop timer or by recording a finished entry afterwards. Only
+// the technician of a task records time on it; managers can see and delete
+// every entry.
+type TimeEntryService struct {
+	taskService   *TaskService
+	timeEntryRepo repository.TimeEntryRepository
+	userRepo      repository.UserRepository
+}
+
+func NewTimeEntryService(
+	taskService *TaskService,
+	timeEntryRepo repository.TimeEntryRepository,
+	userRepo repository.UserRepository,
+) *TimeEntryService {
+	return &TimeEntryService{
+		taskService:   taskService,
+		timeEntryRepo: timeEntryRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// GetEntries returns the entries of a task and the total time spent on it,
+// running timers counting up to now
+func (s *TimeEntryService) GetEntries(ctx context.Context, taskID int64, userID int64) (*models.TaskTimeEntries, error) {
+	// Same visibility rules as the task itself
+	if _, err := s.taskService.GetTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepo.GetByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewTaskTimeEntries(taskID, entries, time.Now()), nil
+}
+
+// CreateEntry records time already spent on a task. Leaving EndedAt empty
+// starts a timer at StartedAt.
+func (s *TimeEntryService) CreateEntry(ctx context.Context, taskID int64, entry *models.TimeEntry, userID int64) (*models.TimeEntry, error) {
+	if _, err := s.getTrackableTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	entry.Sanitize()
+	entry.StartedAt = entry.StartedAt.Truncate(time.Second)
+	if entry.EndedAt != nil {
+		endedAt := entry.EndedAt.Truncate(time.Second)
+		entry.EndedAt = &endedAt
+	}
+
+	// Validate input
+	now := time.Now()
+	if err := entry.Validate(now); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.create(ctx, taskID, entry, userID, now)
+}
+
+// StartTimer starts a timer on the task now. A technician runs one timer at a
+// time.
+func (s *TimeEntryService) StartTimer(ctx context.Context, taskID int64, note string, userID int64) (*models.TimeEntry, error) {
+	if _, err := s.getTrackableTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	entry := &models.TimeEntry{Note: note}
+
+	// Sanitize input
+	entry.Sanitize()
+	now := time.Now().Truncate(time.Second)
+	entry.StartedAt = now
+
+	// Validate input
+	if err := entry.Validate(now); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.create(ctx, taskID, entry, userID, now)
+}
+
+// StopTimer stops the running timer of the user on the task; ErrNotFound
+// means there is none
+func (s *TimeEntryService) StopTimer(ctx context.Context, taskID int64, userID int64) (*models.TimeEntry, error) {
+	if _, err := s.getTrackableTask(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.timeEntryRepo.GetRunning(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrNotFound
+	}
+
+	// A timer stopped within the second it started ends when it started; it
+	// still counts as one second
+	now := time.Now().Truncate(time.Second)
+
+	stopped, err := s.timeEntryRepo.Stop(ctx, entry.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !stopped {
+		// Stopped by a concurrent request
+		return nil, ErrNotFound
+	}
+
+	entry.EndedAt = &now
+	entry.UpdateDuration(now)
+	return entry, nil
+}
+
+// DeleteEntry removes an entry. Technicians can only delete their own entries
+// on their own tasks.
+func (s *TimeEntryService) DeleteEntry(ctx context.Context, taskID int64, entryID int64, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrNotFound
+	}
+
+	if _, err := s.taskService.getEditableTask(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	entry, err := s.timeEntryRepo.GetByID(ctx, taskID, entryID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return ErrNotFound
+	}
+	if user.IsTechnician() && entry.TechnicianID != userID {
+		return ErrUnauthorized
+	}
+
+	return s.timeEntryRepo.Delete(ctx, entryID)
+}
+
+// GetReport returns the time spent in a period per technician and per task.
+// Technicians only get their own figures.
+func (s *TimeEntryService) GetReport(ctx context.Context, query models.TimeReportQuery, userID int64) (*models.TimeReport, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	// Validate input
+	if err := query.Validate(); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	// Technicians can only see their own time
+	if user.IsTechnician() {
+		query.TechnicianID = userID
+	}
+
+	rows, err := s.timeEntryRepo.GetReport(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return models.NewTimeReport(query, rows), nil
+}
+
+// getTrackableTask loads a task the user can record time on: a technician and
+// their own task
+func (s *TimeEntryService) getTrackableTask(ctx context.Context, taskID int64, userID int64) (*models.Task, error) {
+	user, err := s.userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+	if !user.IsTechnician() {
+		return nil, ErrUnauthorized
+	}
+
+	return s.taskService.getEditableTask(ctx, taskID, userID)
+}
+
+func (s *TimeEntryService) create(ctx context.Context, taskID int64, entry *models.TimeEntry, userID int64, now time.Time) (*models.TimeEntry, error) {
+	entry.ID = 0
+	entry.TaskID = taskID
+	entry.TechnicianID = userID
+
+	if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+		if errors.Is(err, repository.ErrOverlap) {
+			return nil, ErrTimeEntryOverlap
+		}
+		return nil, err
+	}
+
+	entry.CreatedAt = now
+	entry.UpdateDuration(now)
+	return entry, nil
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTimeEntryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimeEntryRepository) Create(ctx context.Context, entry *models.TimeEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TimeEntry, error) {
+	args := m.Called(ctx, taskID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TimeEntry, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]*models.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) GetRunning(ctx context.Context, taskID int64, technicianID int64) (*models.TimeEntry, error) {
+	args := m.Called(ctx, taskID, technicianID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Stop(ctx context.Context, id int64, endedAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, endedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepository) GetReport(ctx context.Context, query models.TimeReportQuery, now time.Time) ([]*models.TaskTime, error) {
+	args := m.Called(ctx, query, now)
+	return args.Get(0).([]*models.TaskTime), args.Error(1)
+}
+
+func newTimeEntryService(taskRepo *MockTaskRepository, userRepo *MockUserRepository, timeEntryRepo *MockTimeEntryRepository) *TimeEntryService {
+	taskService := newTestTaskService(taskServiceDeps{taskRepo: taskRepo, userRepo: userRepo})
+	return NewTimeEntryService(taskService, timeEntryRepo, userRepo)
+}
+
+func TestTimeEntryService_CreateEntry(t *testing.T) {
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+	otherTechnician := &models.User{ID: 3, Role: models.RoleTechnician}
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+	startedAt := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	endedAt := startedAt.Add(90 * time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		entry         *models.TimeEntry
+		setupMocks    func(*MockTimeEntryRepository)
+		expectedError error
+	}{
+		{
+			name:  "technician records time on their task",
+			user:  technician,
+			entry: &models.TimeEntry{StartedAt: startedAt, EndedAt: &endedAt, Note: "  Replaced the compressor "},
+			setupMocks: func(tr *MockTimeEntryRepository) {
+				tr.On("Create", mock.Anything, mock.MatchedBy(func(e *models.TimeEntry) bool {
+					return e.TaskID == 1 && e.TechnicianID == technician.ID && e.Note == "Replaced the compressor"
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "another technician's task",
+			user:          otherTechnician,
+			entry:         &models.TimeEntry{StartedAt: startedAt, EndedAt: &endedAt},
+			setupMocks:    func(tr *MockTimeEntryRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "managers do not record time",
+			user:          manager,
+			entry:         &models.TimeEntry{StartedAt: startedAt, EndedAt: &endedAt},
+			setupMocks:    func(tr *MockTimeEntryRepository) {},
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "entry ending in the future",
+			user:          technician,
+			entry:         &models.TimeEntry{StartedAt: startedAt, EndedAt: &future},
+			setupMocks:    func(tr *MockTimeEntryRepository) {},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:  "entry overlapping another one",
+			user:  technician,
+			entry: &models.TimeEntry{StartedAt: startedAt, EndedAt: &endedAt},
+			setupMocks: func(tr *MockTimeEntryRepository) {
+				tr.On("Create", mock.Anything, mock.Anything).Return(repository.ErrOverlap)
+			},
+			expectedError: ErrTimeEntryOverlap,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTimeEntryRepo := new(MockTimeEntryRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: technician.ID}, nil).Maybe()
+			tt.setupMocks(mockTimeEntryRepo)
+
+			service := newTimeEntryService(mockTaskRepo, mockUserRepo, mockTimeEntryRepo)
+			entry, err := service.CreateEntry(context.Background(), 1, tt.entry, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, int64(5400), entry.DurationSeconds)
+				assert.False(t, entry.Running)
+			}
+			mockTimeEntryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeEntryService_StartTimer(t *testing.T) {
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+
+	tests := []struct {
+		name          string
+		createErr     error
+		expectedError error
+	}{
+		{name: "timer starts"},
+		{name: "another timer is running", createErr: repository.ErrOverlap, expectedError: ErrTimeEntryOverlap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTimeEntryRepo := new(MockTimeEntryRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, technician.ID).Return(technician, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: technician.ID}, nil)
+			mockTimeEntryRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.TimeEntry) bool {
+				return e.EndedAt == nil && !e.StartedAt.IsZero()
+			})).Return(tt.createErr)
+
+			service := newTimeEntryService(mockTaskRepo, mockUserRepo, mockTimeEntryRepo)
+			entry, err := service.StartTimer(context.Background(), 1, "On site", technician.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.True(t, entry.Running)
+				assert.Equal(t, "On site", entry.Note)
+			}
+		})
+	}
+}
+
+func TestTimeEntryService_StopTimer(t *testing.T) {
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		running       *models.TimeEntry
+		stopped       bool
+		expectedError error
+	}{
+		{
+			name:    "running timer stops",
+			running: &models.TimeEntry{ID: 7, TaskID: 1, TechnicianID: technician.ID, StartedAt: startedAt},
+			stopped: true,
+		},
+		{
+			name:          "no running timer",
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "stopped concurrently",
+			running:       &models.TimeEntry{ID: 7, TaskID: 1, TechnicianID: technician.ID, StartedAt: startedAt},
+			stopped:       false,
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTimeEntryRepo := new(MockTimeEntryRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, technician.ID).Return(technician, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: technician.ID}, nil)
+			if tt.running != nil {
+				mockTimeEntryRepo.On("GetRunning", mock.Anything, int64(1), technician.ID).Return(tt.running, nil)
+				mockTimeEntryRepo.On("Stop", mock.Anything, int64(7), mock.AnythingOfType("time.Time")).Return(tt.stopped, nil)
+			} else {
+				mockTimeEntryRepo.On("GetRunning", mock.Anything, int64(1), technician.ID).Return(nil, nil)
+			}
+
+			service := newTimeEntryService(mockTaskRepo, mockUserRepo, mockTimeEntryRepo)
+			entry, err := service.StopTimer(context.Background(), 1, technician.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.False(t, entry.Running)
+				assert.NotNil(t, entry.EndedAt)
+				assert.GreaterOrEqual(t, entry.DurationSeconds, int64(3600))
+			}
+			mockTimeEntryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeEntryService_DeleteEntry(t *testing.T) {
+	technician := &models.User{ID: 2, Role: models.RoleTechnician}
+	manager := &models.User{ID: 1, Role: models.RoleManager}
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		entry         *models.TimeEntry
+		expectDelete  bool
+		expectedError error
+	}{
+		{name: "technician deletes their entry", user: technician, entry: &models.TimeEntry{ID: 7, TaskID: 1, TechnicianID: 2}, expectDelete: true},
+		{name: "technician cannot delete someone else's entry", user: technician, entry: &models.TimeEntry{ID: 7, TaskID: 1, TechnicianID: 3}, expectedError: ErrUnauthorized},
+		{name: "manager deletes any entry", user: manager, entry: &models.TimeEntry{ID: 7, TaskID: 1, TechnicianID: 3}, expectDelete: true},
+		{name: "unknown entry", user: manager, expectedError: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTimeEntryRepo := new(MockTimeEntryRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTaskRepo.On("GetByID", mock.Anything, int64(1)).Return(&models.Task{ID: 1, TechnicianID: technician.ID}, nil)
+			if tt.entry != nil {
+				mockTimeEntryRepo.On("GetByID", mock.Anything, int64(1), int64(7)).Return(tt.entry, nil)
+			} else {
+				mockTimeEntryRepo.On("GetByID", mock.Anything, int64(1), int64(7)).Return(nil, nil)
+			}
+			if tt.expectDelete {
+				mockTimeEntryRepo.On("Delete", mock.Anything, int64(7)).Return(nil)
+			}
+
+			service := newTimeEntryService(mockTaskRepo, mockUserRepo, mockTimeEntryRepo)
+			err := service.DeleteEntry(context.Background(), 1, 7, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockTimeEntryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeEntryService_GetReport(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		user               *models.User
+		query              models.TimeReportQuery
+		expectedTechnician int64
+		expectedError      error
+	}{
+		{name: "manager reports on a technician", user: &models.User{ID: 1, Role: models.RoleManager}, query: models.TimeReportQuery{From: from, To: to, TechnicianID: 3}, expectedTechnician: 3},
+		{name: "manager reports on everyone", user: &models.User{ID: 1, Role: models.RoleManager}, query: models.TimeReportQuery{From: from, To: to}, expectedTechnician: 0},
+		{name: "technician only sees their own time", user: &models.User{ID: 2, Role: models.RoleTechnician}, query: models.TimeReportQuery{From: from, To: to, TechnicianID: 3}, expectedTechnician: 2},
+		{name: "invalid period", user: &models.User{ID: 1, Role: models.RoleManager}, query: models.TimeReportQuery{From: to, To: from}, expectedError: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockTimeEntryRepo := new(MockTimeEntryRepository)
+
+			mockUserRepo.On("GetByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+			mockTimeEntryRepo.On("GetReport", mock.Anything, mock.MatchedBy(func(q models.TimeReportQuery) bool {
+				return q.TechnicianID == tt.expectedTechnician
+			}), mock.Anything).Return([]*models.TaskTime{
+				{TaskID: 1, TechnicianID: tt.expectedTechnician, Seconds: 3600},
+			}, nil).Maybe()
+
+			service := newTimeEntryService(new(MockTaskRepository), mockUserRepo, mockTimeEntryRepo)
+			report, err := service.GetReport(context.Background(), tt.query, tt.user.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, int64(3600), report.TotalSeconds)
+			}
+			mockTimeEntryRepo.AssertExpectations(t)
+		})
+	}
+}
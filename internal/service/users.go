@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+)
+
+// getUser loads the user making the request; ErrNotFound if there is none
+func getUser(ctx context.Context, userRepo repository.UserRepository, userID int64) (*models.User, error) {
+	user, err := userRepo.GetByID(ctx, userID) // don't trust in user input
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+	return user, nil
+}
+
+// requireManager returns ErrUnauthorized unless the user is a manager
+func requireManager(ctx context.Context, userRepo repository.UserRepository, userID int64) error {
+	user, err := getUser(ctx, userRepo, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsManager() {
+		return ErrUnauthorized
+	}
+	return nil
+}
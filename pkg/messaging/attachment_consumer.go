@@ -0,0 +1,167 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"sword-challenge/internal/imaging"
+	"sword-challenge/internal/models"
+	"sword-challenge/internal/repository"
+	"sword-challenge/pkg/storage"
+)
+
+// AttachmentConsumer processes stored image attachments: it writes their
+// thumbnails and switches them to a copy without metadata
+type AttachmentConsumer struct {
+	broker         MessageBroker
+	attachmentRepo repository.TaskAttachmentRepository
+	blobStore      storage.BlobStore
+}
+
+func NewAttachmentConsumer(
+	broker MessageBroker,
+	attachmentRepo repository.TaskAttachmentRepository,
+	blobStore storage.BlobStore,
+) *AttachmentConsumer {
+	return &AttachmentConsumer{
+		broker:         broker,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+	}
+}
+
+func (c *AttachmentConsumer) Start(ctx context.Context) error {
+	// Type assert to get the RabbitMQ implementation for consuming messages
+	rabbitmq, ok := c.broker.(*RabbitMQ)
+	if !ok {
+		return fmt.Errorf("broker is not a RabbitMQ implementation")
+	}
+
+	msgs, err := rabbitmq.channel.Consume(
+		AttachmentStoredQueue, // queue
+		"",                    // consumer
+		false,                 // auto-ack
+		false,                 // exclusive
+		false,                 // no-local
+		false,                 // no-wait
+		nil,                   // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register a consumer: %v", err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			ctx := context.Background()
+			var attachmentMsg struct {
+				TaskID       int64 `json:"task_id"`
+				AttachmentID int64 `json:"attachment_id"`
+			}
+
+			if err := json.Unmarshal(msg.Body, &attachmentMsg); err != nil {
+				log.Printf("Error unmarshaling message: %v", err)
+				msg.Nack(false, false)
+				continue
+			}
+
+			// Until processed the upload keeps its metadata, GPS included,
+			// so failures are retried rather than dropped
+			if err := c.Process(ctx, attachmentMsg.TaskID, attachmentMsg.AttachmentID); err != nil {
+				log.Printf("Error processing attachment %d: %v", attachmentMsg.AttachmentID, err)
+				rabbitmq.reject(ctx, msg, AttachmentStoredQueue)
+				continue
+			}
+
+			msg.Ack(false)
+		}
+	}()
+
+	return nil
+}
+
+// Process makes the thumbnails of an image attachment and a copy of it without
+// metadata, then switches the attachment to that copy and deletes the upload.
+// The upload is never overwritten, so a failure at any step leaves the row
+// consistent with its blob and the message can simply be retried. Attachments
+// that are gone or not images are skipped.
+func (c *AttachmentConsumer) Process(ctx context.Context, taskID int64, attachmentID int64) error {
+	attachment, err := c.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment == nil || !attachment.IsImage() {
+		return nil
+	}
+	if attachment.ProcessedAt != nil {
+		// A previous attempt may have failed to delete the upload
+		return c.deleteUpload(ctx, attachment.StorageKey)
+	}
+
+	content, err := c.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(content)
+	content.Close()
+	if err != nil {
+		return err
+	}
+
+	result, err := imaging.Process(data, models.ThumbnailSizes)
+	if err != nil {
+		return err
+	}
+
+	// Every blob first: once the row is marked processed they must exist
+	strippedKey := models.StrippedKey(attachment.StorageKey)
+	for size, thumbnail := range result.Thumbnails {
+		if err := c.put(ctx, models.ThumbnailKey(strippedKey, size), thumbnail, "image/jpeg"); err != nil {
+			return err
+		}
+	}
+	checksum, err := c.putChecksum(ctx, strippedKey, result.Original, attachment.ContentType)
+	if err != nil {
+		return err
+	}
+
+	attachment.StorageKey = strippedKey
+	attachment.Size = int64(len(result.Original))
+	attachment.Checksum = checksum
+	attachment.Width = result.Width
+	attachment.Height = result.Height
+	attachment.CapturedAt = result.CapturedAt
+	if err := c.attachmentRepo.MarkProcessed(ctx, attachment); err != nil {
+		return err
+	}
+	return c.deleteUpload(ctx, strippedKey)
+}
+
+// deleteUpload deletes the blob a processed attachment was stripped from
+func (c *AttachmentConsumer) deleteUpload(ctx context.Context, storageKey string) error {
+	upload := models.UploadKey(storageKey)
+	if upload == storageKey {
+		return nil
+	}
+	return c.blobStore.Delete(ctx, upload)
+}
+
+func (c *AttachmentConsumer) put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := c.putChecksum(ctx, key, data, contentType)
+	return err
+}
+
+// putChecksum stores data under key and returns its hex-encoded SHA-256
+func (c *AttachmentConsumer) putChecksum(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if err := c.blobStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType, checksum); err != nil {
+		return "", err
+	}
+	return checksum, nil
+}
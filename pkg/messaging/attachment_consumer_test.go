@@ -0,0 +1,104 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"sort"
+	"testing"
+	"time"
+
+	"sword-challenge/internal/models"
+	"sword-challenge/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAttachmentRepository keeps one attachment and can fail MarkProcessed
+type fakeAttachmentRepository struct {
+	attachment *models.TaskAttachment
+	markErr    error
+}
+
+func (r *fakeAttachmentRepository) Create(ctx context.Context, attachment *models.TaskAttachment) error {
+	return nil
+}
+
+func (r *fakeAttachmentRepository) GetByID(ctx context.Context, taskID int64, id int64) (*models.TaskAttachment, error) {
+	stored := *r.attachment
+	return &stored, nil
+}
+
+func (r *fakeAttachmentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskAttachment, error) {
+	return nil, nil
+}
+
+func (r *fakeAttachmentRepository) MarkProcessed(ctx context.Context, attachment *models.TaskAttachment) error {
+	if r.markErr != nil {
+		return r.markErr
+	}
+	now := time.Now()
+	stored := *attachment
+	stored.ProcessedAt = &now
+	r.attachment = &stored
+	return nil
+}
+
+func (r *fakeAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func storeTestImage(t *testing.T, store *storage.MemoryStore, key string) []byte {
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 40, 20)), nil))
+	data := encoded.Bytes()
+	require.NoError(t, store.Put(context.Background(), key, bytes.NewReader(data), int64(len(data)), "image/jpeg", ""))
+	return data
+}
+
+func sortedKeys(store *storage.MemoryStore) []string {
+	keys := store.Keys()
+	sort.Strings(keys)
+	return keys
+}
+
+func TestAttachmentConsumer_Process(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStore()
+	upload := storeTestImage(t, store, "tasks/1/a")
+	repo := &fakeAttachmentRepository{attachment: &models.TaskAttachment{
+		ID: 2, TaskID: 1, StorageKey: "tasks/1/a", ContentType: "image/jpeg", Size: int64(len(upload)),
+	}}
+	consumer := NewAttachmentConsumer(NewMockBroker(), repo, store)
+
+	// A failed switch leaves the row on the untouched upload, so it is retried
+	repo.markErr = errors.New("connection reset")
+	require.Error(t, consumer.Process(ctx, 1, 2))
+	assert.Equal(t, "tasks/1/a", repo.attachment.StorageKey)
+	content, err := store.Get(ctx, "tasks/1/a")
+	require.NoError(t, err)
+	var stored bytes.Buffer
+	_, err = stored.ReadFrom(content)
+	require.NoError(t, err)
+	assert.Equal(t, upload, stored.Bytes())
+
+	// The retry switches the row to the stripped copy and deletes the upload
+	repo.markErr = nil
+	require.NoError(t, consumer.Process(ctx, 1, 2))
+	stripped := models.StrippedKey("tasks/1/a")
+	assert.Equal(t, stripped, repo.attachment.StorageKey)
+	assert.NotEmpty(t, repo.attachment.Checksum)
+	want := []string{stripped}
+	for _, size := range models.ThumbnailSizeNames() {
+		want = append(want, models.ThumbnailKey(stripped, size))
+	}
+	sort.Strings(want)
+	assert.Equal(t, want, sortedKeys(store))
+
+	// A redelivered message changes nothing
+	require.NoError(t, consumer.Process(ctx, 1, 2))
+	assert.Equal(t, want, sortedKeys(store))
+}
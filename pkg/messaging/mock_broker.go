@@ -3,18 +3,70 @@ package messaging
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // MockBroker is a simple in-memory message broker for testing
 type MockBroker struct {
-	mu       sync.RWMutex
-	messages []TaskCreatedMessage
+	mu                 sync.RWMutex
+	messages           []TaskCreatedMessage
+	attachmentMessages []AttachmentStoredMessage
+	mentionMessages    []CommentMentionedMessage
+	escalationMessages []TaskEscalatedMessage
+	unblockedMessages  []TaskUnblockedMessage
+	lowStockMessages   []PartLowStockMessage
+	offSiteMessages    []TaskOffSiteMessage
 }
 
 type TaskCreatedMessage struct {
 	TaskID       int64
 	TechnicianID int64
 	Title        string
+	PerformedAt  time.Time
+}
+
+type AttachmentStoredMessage struct {
+	TaskID       int64
+	AttachmentID int64
+}
+
+type CommentMentionedMessage struct {
+	TaskID       int64
+	CommentID    int64
+	AuthorID     int64
+	RecipientIDs []int64
+}
+
+type TaskEscalatedMessage struct {
+	TaskID       int64
+	TechnicianID int64
+	Level        string
+	Title        string
+	DueAt        time.Time
+}
+
+type TaskUnblockedMessage struct {
+	TaskID       int64
+	TechnicianID int64
+	Title        string
+}
+
+type PartLowStockMessage struct {
+	TaskID int64
+	PartID int64
+	SKU    string
+	Name   string
+	Unit   string
+	Stock  int
+}
+
+type TaskOffSiteMessage struct {
+	TaskID       int64
+	TechnicianID int64
+	Title        string
+	SiteID       int64
+	SiteName     string
+	Distance     int
 }
 
 // NewMockBroker creates a new mock message broker
@@ -25,7 +77,7 @@ func NewMockBroker() *MockBroker {
 }
 
 // PublishTaskCreated implements MessageBroker interface
-func (m *MockBroker) PublishTaskCreated(ctx context.Context, taskID int64, technicianID int64, title string) error {
+func (m *MockBroker) PublishTaskCreated(ctx context.Context, taskID int64, technicianID int64, title string, performedAt time.Time) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -33,6 +85,93 @@ func (m *MockBroker) PublishTaskCreated(ctx context.Context, taskID int64, techn
 		TaskID:       taskID,
 		TechnicianID: technicianID,
 		Title:        title,
+		PerformedAt:  performedAt,
+	})
+	return nil
+}
+
+// PublishAttachmentStored implements MessageBroker interface
+func (m *MockBroker) PublishAttachmentStored(ctx context.Context, taskID int64, attachmentID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.attachmentMessages = append(m.attachmentMessages, AttachmentStoredMessage{
+		TaskID:       taskID,
+		AttachmentID: attachmentID,
+	})
+	return nil
+}
+
+// PublishCommentMentioned implements MessageBroker interface
+func (m *MockBroker) PublishCommentMentioned(ctx context.Context, taskID int64, commentID int64, authorID int64, recipientIDs []int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mentionMessages = append(m.mentionMessages, CommentMentionedMessage{
+		TaskID:       taskID,
+		CommentID:    commentID,
+		AuthorID:     authorID,
+		RecipientIDs: recipientIDs,
+	})
+	return nil
+}
+
+// PublishTaskEscalated implements MessageBroker interface
+func (m *MockBroker) PublishTaskEscalated(ctx context.Context, taskID int64, technicianID int64, level string, title string, dueAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.escalationMessages = append(m.escalationMessages, TaskEscalatedMessage{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+		Level:        level,
+		Title:        title,
+		DueAt:        dueAt,
+	})
+	return nil
+}
+
+// PublishTaskUnblocked implements MessageBroker interface
+func (m *MockBroker) PublishTaskUnblocked(ctx context.Context, taskID int64, technicianID int64, title string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unblockedMessages = append(m.unblockedMessages, TaskUnblockedMessage{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+		Title:        title,
+	})
+	return nil
+}
+
+// PublishPartLowStock implements MessageBroker interface
+func (m *MockBroker) PublishPartLowStock(ctx context.Context, taskID int64, partID int64, sku string, name string, unit string, stock int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lowStockMessages = append(m.lowStockMessages, PartLowStockMessage{
+		TaskID: taskID,
+		PartID: partID,
+		SKU:    sku,
+		Name:   name,
+		Unit:   unit,
+		Stock:  stock,
+	})
+	return nil
+}
+
+// PublishTaskOffSite implements MessageBroker interface
+func (m *MockBroker) PublishTaskOffSite(ctx context.Context, taskID int64, technicianID int64, title string, siteID int64, siteName string, distance int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.offSiteMessages = append(m.offSiteMessages, TaskOffSiteMessage{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+		Title:        title,
+		SiteID:       siteID,
+		SiteName:     siteName,
+		Distance:     distance,
 	})
 	return nil
 }
@@ -52,10 +191,76 @@ func (m *MockBroker) GetMessages() []TaskCreatedMessage {
 	return messages
 }
 
+// GetAttachmentMessages returns all published attachment messages
+func (m *MockBroker) GetAttachmentMessages() []AttachmentStoredMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]AttachmentStoredMessage, len(m.attachmentMessages))
+	copy(messages, m.attachmentMessages)
+	return messages
+}
+
+// GetMentionMessages returns all published comment mention messages
+func (m *MockBroker) GetMentionMessages() []CommentMentionedMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]CommentMentionedMessage, len(m.mentionMessages))
+	copy(messages, m.mentionMessages)
+	return messages
+}
+
+// GetEscalationMessages returns all published task escalation messages
+func (m *MockBroker) GetEscalationMessages() []TaskEscalatedMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]TaskEscalatedMessage, len(m.escalationMessages))
+	copy(messages, m.escalationMessages)
+	return messages
+}
+
+// GetUnblockedMessages returns all published task unblocked messages
+func (m *MockBroker) GetUnblockedMessages() []TaskUnblockedMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]TaskUnblockedMessage, len(m.unblockedMessages))
+	copy(messages, m.unblockedMessages)
+	return messages
+}
+
+// GetLowStockMessages returns all published part low stock messages
+func (m *MockBroker) GetLowStockMessages() []PartLowStockMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]PartLowStockMessage, len(m.lowStockMessages))
+	copy(messages, m.lowStockMessages)
+	return messages
+}
+
+// GetOffSiteMessages returns all published task off site messages
+func (m *MockBroker) GetOffSiteMessages() []TaskOffSiteMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]TaskOffSiteMessage, len(m.offSiteMessages))
+	copy(messages, m.offSiteMessages)
+	return messages
+}
+
 // ClearMessages clears all published messages
 func (m *MockBroker) ClearMessages() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.messages = make([]TaskCreatedMessage, 0)
+	m.attachmentMessages = nil
+	m.mentionMessages = nil
+	m.escalationMessages = nil
+	m.unblockedMessages = nil
+	m.lowStockMessages = nil
+	m.offSiteMessages = nil
 }
@@ -36,65 +36,234 @@ func (c *NotificationConsumer) Start(ctx context.Context) error {
 		return fmt.Errorf("broker is not a RabbitMQ implementation")
 	}
 
-	msgs, err := rabbitmq.channel.Consume(
-		TaskCreatedQueue, // queue
-		"",               // consumer
-		false,            // auto-ack
-		false,            // exclusive
-		false,            // no-local
-		false,            // no-wait
-		nil,              // args
-	)
-	if err != nil {
-		return fmt.Errorf("failed to register a consumer: %v", err)
+	handlers := map[string]func(ctx context.Context, body []byte) error{
+		TaskCreatedQueue:      c.handleTaskCreated,
+		CommentMentionedQueue: c.handleCommentMentioned,
+		TaskEscalatedQueue:    c.handleTaskEscalated,
+		TaskUnblockedQueue:    c.handleTaskUnblocked,
+		PartLowStockQueue:     c.handlePartLowStock,
+		TaskOffSiteQueue:      c.handleTaskOffSite,
 	}
+	for queue, handle := range handlers {
+		msgs, err := rabbitmq.channel.Consume(
+			queue, // queue
+			"",    // consumer
+			false, // auto-ack
+			false, // exclusive
+			false, // no-local
+			false, // no-wait
+			nil,   // args
+		)
+		if err != nil {
+			return fmt.Errorf("failed to register a consumer: %v", err)
+		}
 
-	go func() {
-		for msg := range msgs {
-			ctx := context.Background()
-			var taskMsg struct {
-				TaskID       int64  `json:"task_id"`
-				TechnicianID int64  `json:"technician_id"`
-				Title        string `json:"title"`
+		go func() {
+			for msg := range msgs {
+				if err := handle(context.Background(), msg.Body); err != nil {
+					log.Printf("Error handling %s message: %v", queue, err)
+					rabbitmq.reject(context.Background(), msg, queue)
+					continue
+				}
+				msg.Ack(false)
 			}
+		}()
+	}
 
-			if err := json.Unmarshal(msg.Body, &taskMsg); err != nil {
-				log.Printf("Error unmarshaling message: %v", err)
-				msg.Nack(false, false)
-				continue
-			}
+	return nil
+}
 
-			// Get technician details
-			technician, err := c.userRepo.GetByID(ctx, taskMsg.TechnicianID)
-			if err != nil {
-				log.Printf("Error getting technician: %v", err)
-				msg.Nack(false, false)
-				continue
-			}
+// handleTaskCreated notifies managers that a technician performed a task
+func (c *NotificationConsumer) handleTaskCreated(ctx context.Context, body []byte) error {
+	var taskMsg struct {
+		TaskID       int64     `json:"task_id"`
+		TechnicianID int64     `json:"technician_id"`
+		Title        string    `json:"title"`
+		PerformedAt  time.Time `json:"performed_at"`
+	}
+	if err := json.Unmarshal(body, &taskMsg); err != nil {
+		return fmt.Errorf("unmarshaling message: %v", err)
+	}
 
-			// Create notification
-			task := &models.Task{
-				ID:           taskMsg.TaskID,
-				TechnicianID: taskMsg.TechnicianID,
-				Title:        taskMsg.Title,
-				PerformedAt:  time.Now(),
-			}
-			notification, err := models.NewTaskNotification(task, technician)
-			if err != nil {
-				log.Printf("Error creating notification: %v", err)
-				msg.Nack(false, false)
-				continue
-			}
+	// Get technician details
+	technician, err := c.userRepo.GetByID(ctx, taskMsg.TechnicianID)
+	if err != nil {
+		return fmt.Errorf("getting technician: %v", err)
+	}
 
-			if err := c.notificationRepo.Create(ctx, notification); err != nil {
-				log.Printf("Error creating notification: %v", err)
-				msg.Nack(false, false)
-				continue
-			}
+	// Messages published before performed_at was added fall back to the
+	// time they are handled
+	if taskMsg.PerformedAt.IsZero() {
+		taskMsg.PerformedAt = time.Now()
+	}
 
-			msg.Ack(false)
-		}
-	}()
+	// Create notification
+	task := &models.Task{
+		ID:           taskMsg.TaskID,
+		TechnicianID: taskMsg.TechnicianID,
+		Title:        taskMsg.Title,
+		PerformedAt:  taskMsg.PerformedAt,
+	}
+	notification, err := models.NewTaskNotification(task, technician)
+	if err != nil {
+		return fmt.Errorf("creating notification: %v", err)
+	}
+
+	return c.notificationRepo.Create(ctx, notification)
+}
+
+// handleCommentMentioned notifies each user mentioned in a comment. The
+// notifications are created all or nothing, so a failed message can be retried
+// without notifying anyone twice.
+func (c *NotificationConsumer) handleCommentMentioned(ctx context.Context, body []byte) error {
+	var mentionMsg struct {
+		TaskID       int64   `json:"task_id"`
+		CommentID    int64   `json:"comment_id"`
+		AuthorID     int64   `json:"author_id"`
+		RecipientIDs []int64 `json:"recipient_ids"`
+	}
+	if err := json.Unmarshal(body, &mentionMsg); err != nil {
+		return fmt.Errorf("unmarshaling message: %v", err)
+	}
+
+	author, err := c.userRepo.GetByID(ctx, mentionMsg.AuthorID)
+	if err != nil {
+		return fmt.Errorf("getting author: %v", err)
+	}
 
+	notifications := make([]*models.Notification, 0, len(mentionMsg.RecipientIDs))
+	for _, recipientID := range mentionMsg.RecipientIDs {
+		notification, err := models.NewCommentMentionNotification(mentionMsg.TaskID, mentionMsg.CommentID, author, recipientID)
+		if err != nil {
+			return fmt.Errorf("creating notification: %v", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := c.notificationRepo.CreateAll(ctx, notifications); err != nil {
+		return fmt.Errorf("creating notifications: %v", err)
+	}
 	return nil
 }
+
+// handleTaskEscalated notifies the technician's manager, or every manager when
+// the technician has none, that a task is at risk or breached its SLA
+func (c *NotificationConsumer) handleTaskEscalated(ctx context.Context, body []byte) error {
+	var escalationMsg struct {
+		TaskID       int64     `json:"task_id"`
+		TechnicianID int64     `json:"technician_id"`
+		Level        string    `json:"level"`
+		Title        string    `json:"title"`
+		DueAt        time.Time `json:"due_at"`
+	}
+	if err := json.Unmarshal(body, &escalationMsg); err != nil {
+		return fmt.Errorf("unmarshaling message: %v", err)
+	}
+
+	technician, err := c.userRepo.GetByID(ctx, escalationMsg.TechnicianID)
+	if err != nil {
+		return fmt.Errorf("getting technician: %v", err)
+	}
+
+	task := &models.Task{
+		ID:           escalationMsg.TaskID,
+		TechnicianID: escalationMsg.TechnicianID,
+		Title:        escalationMsg.Title,
+		DueAt:        &escalationMsg.DueAt,
+	}
+	notification, err := models.NewTaskEscalationNotification(task, technician, escalationMsg.Level)
+	if err != nil {
+		return fmt.Errorf("creating notification: %v", err)
+	}
+
+	return c.notificationRepo.Create(ctx, notification)
+}
+
+// handleTaskUnblocked tells the technician of a task that its prerequisites
+// are done and it can be completed
+func (c *NotificationConsumer) handleTaskUnblocked(ctx context.Context, body []byte) error {
+	var unblockedMsg struct {
+		TaskID       int64  `json:"task_id"`
+		TechnicianID int64  `json:"technician_id"`
+		Title        string `json:"title"`
+	}
+	if err := json.Unmarshal(body, &unblockedMsg); err != nil {
+		return fmt.Errorf("unmarshaling message: %v", err)
+	}
+
+	task := &models.Task{
+		ID:           unblockedMsg.TaskID,
+		TechnicianID: unblockedMsg.TechnicianID,
+		Title:        unblockedMsg.Title,
+	}
+	notification, err := models.NewTaskUnblockedNotification(task)
+	if err != nil {
+		return fmt.Errorf("creating notification: %v", err)
+	}
+
+	return c.notificationRepo.Create(ctx, notification)
+}
+
+// handlePartLowStock tells the managers that the parts used on a task brought
+// a part down to its low stock threshold
+func (c *NotificationConsumer) handlePartLowStock(ctx context.Context, body []byte) error {
+	var lowStockMsg struct {
+		TaskID int64  `json:"task_id"`
+		PartID int64  `json:"part_id"`
+		SKU    string `json:"sku"`
+		Name   string `json:"name"`
+		Unit   string `json:"unit"`
+		Stock  int    `json:"stock"`
+	}
+	if err := json.Unmarshal(body, &lowStockMsg); err != nil {
+		return fmt.Errorf("unmarshaling message: %v", err)
+	}
+
+	part := &models.Part{
+		ID:    lowStockMsg.PartID,
+		SKU:   lowStockMsg.SKU,
+		Name:  lowStockMsg.Name,
+		Unit:  lowStockMsg.Unit,
+		Stock: lowStockMsg.Stock,
+	}
+	notification, err := models.NewLowStockNotification(part, lowStockMsg.TaskID)
+	if err != nil {
+		return fmt.Errorf("creating notification: %v", err)
+	}
+
+	return c.notificationRepo.Create(ctx, notification)
+}
+
+// handleTaskOffSite notifies the technician's manager, or every manager when
+// the technician has none, that a task was recorded outside the geofence of
+// its site
+func (c *NotificationConsumer) handleTaskOffSite(ctx context.Context, body []byte) error {
+	var offSiteMsg struct {
+		TaskID       int64  `json:"task_id"`
+		TechnicianID int64  `json:"technician_id"`
+		Title        string `json:"title"`
+		SiteID       int64  `json:"site_id"`
+		SiteName     string `json:"site_name"`
+		Distance     int    `json:"distance"`
+	}
+	if err := json.Unmarshal(body, &offSiteMsg); err != nil {
+		return fmt.Errorf("unmarshaling message: %v", err)
+	}
+
+	technician, err := c.userRepo.GetByID(ctx, offSiteMsg.TechnicianID)
+	if err != nil {
+		return fmt.Errorf("getting technician: %v", err)
+	}
+
+	task := &models.Task{
+		ID:           offSiteMsg.TaskID,
+		TechnicianID: offSiteMsg.TechnicianID,
+		Title:        offSiteMsg.Title,
+	}
+	site := &models.Site{ID: offSiteMsg.SiteID, Name: offSiteMsg.SiteName}
+	notification, err := models.NewTaskOffSiteNotification(task, technician, site, offSiteMsg.Distance)
+	if err != nil {
+		return fmt.Errorf("creating notification: %v", err)
+	}
+
+	return c.notificationRepo.Create(ctx, notification)
+}
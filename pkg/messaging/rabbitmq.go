@@ -4,17 +4,62 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"strconv"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 const (
-	TaskCreatedQueue = "task_created"
-	TaskExchange     = "task_exchange"
+	TaskCreatedQueue      = "task_created"
+	AttachmentStoredQueue = "attachment_stored"
+	CommentMentionedQueue = "comment_mentioned"
+	TaskEscalatedQueue    = "task_escalated"
+	TaskUnblockedQueue    = "task_unblocked"
+	PartLowStockQueue     = "part_low_stock"
+	TaskOffSiteQueue      = "task_off_site"
+	TaskExchange          = "task_exchange"
+
+	// Retry queues hold messages that failed to process until RetryDelay
+	// passes, then dead-letter them back to their queue. After MaxAttempts
+	// messages are parked in the failed queue instead.
+	AttachmentRetryQueue        = "attachment_stored_retry"
+	AttachmentFailedQueue       = "attachment_stored_failed"
+	CommentMentionedRetryQueue  = "comment_mentioned_retry"
+	CommentMentionedFailedQueue = "comment_mentioned_failed"
+)
+
+const (
+	// MaxAttempts is how many times a message is processed before it is parked
+	MaxAttempts = 5
+	// RetryDelay is how long a failed message waits before it is redelivered
+	RetryDelay = 30 * time.Second
+
+	attemptsHeader = "x-attempts"
 )
 
+// retryRoute names the queues a failed message goes through
+type retryRoute struct {
+	retry  string
+	failed string
+}
+
+// retryRoutes lists the queues whose failed messages are retried; the others
+// drop them
+var retryRoutes = map[string]retryRoute{
+	AttachmentStoredQueue: {retry: AttachmentRetryQueue, failed: AttachmentFailedQueue},
+	CommentMentionedQueue: {retry: CommentMentionedRetryQueue, failed: CommentMentionedFailedQueue},
+}
+
 type MessageBroker interface {
-	PublishTaskCreated(ctx context.Context, taskID int64, technicianID int64, title string) error
+	PublishTaskCreated(ctx context.Context, taskID int64, technicianID int64, title string, performedAt time.Time) error
+	PublishAttachmentStored(ctx context.Context, taskID int64, attachmentID int64) error
+	PublishCommentMentioned(ctx context.Context, taskID int64, commentID int64, authorID int64, recipientIDs []int64) error
+	PublishTaskEscalated(ctx context.Context, taskID int64, technicianID int64, level string, title string, dueAt time.Time) error
+	PublishTaskUnblocked(ctx context.Context, taskID int64, technicianID int64, title string) error
+	PublishPartLowStock(ctx context.Context, taskID int64, partID int64, sku string, name string, unit string, stock int) error
+	PublishTaskOffSite(ctx context.Context, taskID int64, technicianID int64, title string, siteID int64, siteName string, distance int) error
 	Close() error
 }
 
@@ -51,33 +96,70 @@ func NewRabbitMQ(url string) (*RabbitMQ, error) {
 		return nil, fmt.Errorf("failed to declare exchange: %v", err)
 	}
 
-	// Declare queue
-	_, err = ch.QueueDeclare(
-		TaskCreatedQueue, // name
-		true,             // durable
-		false,            // delete when unused
-		false,            // exclusive
-		false,            // no-wait
-		nil,              // arguments
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to declare queue: %v", err)
+	for _, queue := range []string{TaskCreatedQueue, AttachmentStoredQueue, CommentMentionedQueue, TaskEscalatedQueue, TaskUnblockedQueue, PartLowStockQueue, TaskOffSiteQueue} {
+		// Declare queue
+		_, err = ch.QueueDeclare(
+			queue, // name
+			true,  // durable
+			false, // delete when unused
+			false, // exclusive
+			false, // no-wait
+			nil,   // arguments
+		)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, fmt.Errorf("failed to declare queue: %v", err)
+		}
+
+		// Bind queue to exchange
+		err = ch.QueueBind(
+			queue,        // queue name
+			queue,        // routing key
+			TaskExchange, // exchange
+			false,
+			nil,
+		)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, fmt.Errorf("failed to bind queue: %v", err)
+		}
 	}
 
-	// Bind queue to exchange
-	err = ch.QueueBind(
-		TaskCreatedQueue, // queue name
-		TaskCreatedQueue, // routing key
-		TaskExchange,     // exchange
-		false,
-		nil,
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to bind queue: %v", err)
+	// Retry and failed queues have no consumer: retried messages expire back
+	// to the queue they came from, failed ones wait for an operator
+	for queue, route := range retryRoutes {
+		_, err = ch.QueueDeclare(
+			route.retry, // name
+			true,        // durable
+			false,       // delete when unused
+			false,       // exclusive
+			false,       // no-wait
+			amqp.Table{
+				"x-dead-letter-exchange":    TaskExchange,
+				"x-dead-letter-routing-key": queue,
+			},
+		)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, fmt.Errorf("failed to declare queue: %v", err)
+		}
+
+		_, err = ch.QueueDeclare(
+			route.failed, // name
+			true,         // durable
+			false,        // delete when unused
+			false,        // exclusive
+			false,        // no-wait
+			nil,          // arguments
+		)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return nil, fmt.Errorf("failed to declare queue: %v", err)
+		}
 	}
 
 	return &RabbitMQ{
@@ -86,15 +168,17 @@ func NewRabbitMQ(url string) (*RabbitMQ, error) {
 	}, nil
 }
 
-func (r *RabbitMQ) PublishTaskCreated(ctx context.Context, taskID int64, technicianID int64, title string) error {
+func (r *RabbitMQ) PublishTaskCreated(ctx context.Context, taskID int64, technicianID int64, title string, performedAt time.Time) error {
 	message := struct {
-		TaskID       int64  `json:"task_id"`
-		TechnicianID int64  `json:"technician_id"`
-		Title        string `json:"title"`
+		TaskID       int64     `json:"task_id"`
+		TechnicianID int64     `json:"technician_id"`
+		Title        string    `json:"title"`
+		PerformedAt  time.Time `json:"performed_at"`
 	}{
 		TaskID:       taskID,
 		TechnicianID: technicianID,
 		Title:        title,
+		PerformedAt:  performedAt,
 	}
 
 	body, err := json.Marshal(message)
@@ -114,6 +198,238 @@ func (r *RabbitMQ) PublishTaskCreated(ctx context.Context, taskID int64, technic
 	)
 }
 
+func (r *RabbitMQ) PublishAttachmentStored(ctx context.Context, taskID int64, attachmentID int64) error {
+	message := struct {
+		TaskID       int64 `json:"task_id"`
+		AttachmentID int64 `json:"attachment_id"`
+	}{
+		TaskID:       taskID,
+		AttachmentID: attachmentID,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %v", err)
+	}
+
+	return r.channel.PublishWithContext(ctx,
+		TaskExchange,          // exchange
+		AttachmentStoredQueue, // routing key
+		false,                 // mandatory
+		false,                 // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+func (r *RabbitMQ) PublishCommentMentioned(ctx context.Context, taskID int64, commentID int64, authorID int64, recipientIDs []int64) error {
+	message := struct {
+		TaskID       int64   `json:"task_id"`
+		CommentID    int64   `json:"comment_id"`
+		AuthorID     int64   `json:"author_id"`
+		RecipientIDs []int64 `json:"recipient_ids"`
+	}{
+		TaskID:       taskID,
+		CommentID:    commentID,
+		AuthorID:     authorID,
+		RecipientIDs: recipientIDs,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %v", err)
+	}
+
+	return r.channel.PublishWithContext(ctx,
+		TaskExchange,          // exchange
+		CommentMentionedQueue, // routing key
+		false,                 // mandatory
+		false,                 // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+func (r *RabbitMQ) PublishTaskEscalated(ctx context.Context, taskID int64, technicianID int64, level string, title string, dueAt time.Time) error {
+	message := struct {
+		TaskID       int64     `json:"task_id"`
+		TechnicianID int64     `json:"technician_id"`
+		Level        string    `json:"level"`
+		Title        string    `json:"title"`
+		DueAt        time.Time `json:"due_at"`
+	}{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+		Level:        level,
+		Title:        title,
+		DueAt:        dueAt,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %v", err)
+	}
+
+	return r.channel.PublishWithContext(ctx,
+		TaskExchange,       // exchange
+		TaskEscalatedQueue, // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+func (r *RabbitMQ) PublishTaskUnblocked(ctx context.Context, taskID int64, technicianID int64, title string) error {
+	message := struct {
+		TaskID       int64  `json:"task_id"`
+		TechnicianID int64  `json:"technician_id"`
+		Title        string `json:"title"`
+	}{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+		Title:        title,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %v", err)
+	}
+
+	return r.channel.PublishWithContext(ctx,
+		TaskExchange,       // exchange
+		TaskUnblockedQueue, // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+func (r *RabbitMQ) PublishPartLowStock(ctx context.Context, taskID int64, partID int64, sku string, name string, unit string, stock int) error {
+	message := struct {
+		TaskID int64  `json:"task_id"`
+		PartID int64  `json:"part_id"`
+		SKU    string `json:"sku"`
+		Name   string `json:"name"`
+		Unit   string `json:"unit"`
+		Stock  int    `json:"stock"`
+	}{
+		TaskID: taskID,
+		PartID: partID,
+		SKU:    sku,
+		Name:   name,
+		Unit:   unit,
+		Stock:  stock,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %v", err)
+	}
+
+	return r.channel.PublishWithContext(ctx,
+		TaskExchange,      // exchange
+		PartLowStockQueue, // routing key
+		false,             // mandatory
+		false,             // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+func (r *RabbitMQ) PublishTaskOffSite(ctx context.Context, taskID int64, technicianID int64, title string, siteID int64, siteName string, distance int) error {
+	message := struct {
+		TaskID       int64  `json:"task_id"`
+		TechnicianID int64  `json:"technician_id"`
+		Title        string `json:"title"`
+		SiteID       int64  `json:"site_id"`
+		SiteName     string `json:"site_name"`
+		Distance     int    `json:"distance"`
+	}{
+		TaskID:       taskID,
+		TechnicianID: technicianID,
+		Title:        title,
+		SiteID:       siteID,
+		SiteName:     siteName,
+		Distance:     distance,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %v", err)
+	}
+
+	return r.channel.PublishWithContext(ctx,
+		TaskExchange,     // exchange
+		TaskOffSiteQueue, // routing key
+		false,            // mandatory
+		false,            // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+// reject handles a message of queue that failed to process. Messages of
+// queues in retryRoutes are republished to the retry queue, or to the failed
+// queue once tried MaxAttempts times, then acked; if republishing fails the
+// original is requeued, so the message is never lost. Others are dropped.
+func (r *RabbitMQ) reject(ctx context.Context, msg amqp.Delivery, queue string) {
+	route, ok := retryRoutes[queue]
+	if !ok {
+		msg.Nack(false, false)
+		return
+	}
+
+	attempts := int32(1)
+	if previous, ok := msg.Headers[attemptsHeader].(int32); ok {
+		attempts = previous + 1
+	}
+
+	queue, expiration := route.retry, strconv.FormatInt(RetryDelay.Milliseconds(), 10)
+	if attempts >= MaxAttempts {
+		queue, expiration = route.failed, ""
+	}
+
+	err := r.channel.PublishWithContext(ctx,
+		"",    // default exchange: routing key is the queue name
+		queue, // routing key
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Expiration:   expiration,
+			Headers:      amqp.Table{attemptsHeader: attempts},
+			Body:         msg.Body,
+		},
+	)
+	if err != nil {
+		log.Printf("Error republishing message to %s: %v", queue, err)
+		msg.Nack(false, true)
+		return
+	}
+	msg.Ack(false)
+}
+
 func (r *RabbitMQ) Close() error {
 	if err := r.channel.Close(); err != nil {
 		return err
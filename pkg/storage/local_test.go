@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "tasks/1/abc", strings.NewReader("signed form"), 11, "application/pdf", ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	r, err := store.Get(ctx, "tasks/1/abc")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, _ := io.ReadAll(r)
+	r.Close()
+	if string(got) != "signed form" {
+		t.Errorf("Get() = %q", got)
+	}
+
+	if err := store.Delete(ctx, "tasks/1/abc"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "tasks/1/abc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotFound)
+	}
+	if err := store.Delete(ctx, "tasks/1/abc"); err != nil {
+		t.Errorf("Delete() of a missing object error = %v", err)
+	}
+
+	for _, key := range []string{"../escape", "/etc/passwd", "tasks//1", "tasks/./1", ""} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "", ""); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+	}
+}
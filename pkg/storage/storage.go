@@ -0,0 +1,40 @@
+// Package storage keeps binary objects (attachments, thumbnails) outside the
+// database behind a small key/value interface.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore stores objects by key. Keys are slash-separated relative paths
+// such as "tasks/42/3f9a..."; they never come from user input.
+type BlobStore interface {
+	// Put stores the content read from r. checksum is the hex SHA-256 of the
+	// content, used by backends that verify uploads.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string, checksum string) error
+	// Get opens a stored object; the caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// validKey rejects keys that could escape the store's root
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}